@@ -17,6 +17,8 @@ import (
 	yaml "gopkg.in/yaml.v3"
 
 	"github.com/redpanda-data/benthos/v4/internal/component/metrics"
+	"github.com/redpanda-data/benthos/v4/internal/filepath/ifs"
+	"github.com/redpanda-data/benthos/v4/internal/httpserver"
 	"github.com/redpanda-data/benthos/v4/internal/log"
 )
 
@@ -51,6 +53,8 @@ type Type struct {
 	handlers    map[string]http.HandlerFunc
 	handlersMut sync.RWMutex
 
+	jwtAuth *httpserver.JWTAuthenticator
+
 	log    log.Modular
 	mux    *mux.Router
 	server *http.Server
@@ -80,9 +84,26 @@ func New(
 		}
 	}
 
+	if conf.ClientCAFile != "" {
+		if conf.CertFile == "" {
+			return nil, errors.New("client_ca_file requires cert_file and key_file to be specified")
+		}
+		if server.TLSConfig, err = httpserver.ClientAuthTLSConfig(ifs.OS(), conf.ClientCAFile); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := conf.BasicAuth.Validate(); err != nil {
 		return nil, err
 	}
+	if conf.BasicAuth.Enabled && conf.JWTAuth.Enabled {
+		return nil, errors.New("basic_auth and jwt_auth cannot both be enabled")
+	}
+
+	jwtAuth, err := httpserver.NewJWTAuthenticator(conf.JWTAuth, ifs.OS())
+	if err != nil {
+		return nil, err
+	}
 
 	t := &Type{
 		conf:      conf,
@@ -91,6 +112,7 @@ func New(
 		mux:       gMux,
 		server:    server,
 		log:       log,
+		jwtAuth:   jwtAuth,
 	}
 	t.ctx, t.cancel = context.WithCancel(context.Background())
 
@@ -240,12 +262,12 @@ func (t *Type) RegisterEndpoint(path, desc string, handlerFunc http.HandlerFunc)
 	defer t.handlersMut.Unlock()
 
 	if _, exists := t.handlers[path]; !exists {
-		wrapHandler := t.conf.BasicAuth.WrapHandler(func(w http.ResponseWriter, r *http.Request) {
+		wrapHandler := t.conf.BasicAuth.WrapHandler(t.jwtAuth.WrapHandler(func(w http.ResponseWriter, r *http.Request) {
 			t.handlersMut.RLock()
 			h := t.handlers[path]
 			t.handlersMut.RUnlock()
 			h(w, r)
-		})
+		}))
 
 		GetMuxRoute(t.mux, path).Handler(wrapHandler)
 		GetMuxRoute(t.mux, t.conf.RootPath+path).Handler(wrapHandler)
@@ -263,12 +285,12 @@ func (t *Type) ListenAndServe() error {
 		"Listening for HTTP requests at: %v\n",
 		"http://"+t.conf.Address,
 	)
-	if t.server.TLSConfig != nil {
-		return t.server.ListenAndServeTLS("", "")
-	}
 	if t.conf.CertFile != "" {
 		return t.server.ListenAndServeTLS(t.conf.CertFile, t.conf.KeyFile)
 	}
+	if t.server.TLSConfig != nil {
+		return t.server.ListenAndServeTLS("", "")
+	}
 	return t.server.ListenAndServe()
 }
 
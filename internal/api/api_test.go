@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -185,3 +187,61 @@ func TestAPIBasicAuth(t *testing.T) {
 		}(tc))
 	}
 }
+
+func TestAPIJWTAuth(t *testing.T) {
+	conf := api.NewConfig()
+	conf.JWTAuth.Enabled = true
+	conf.JWTAuth.HMACSecret = "shh"
+
+	s, err := api.New("", "", conf, nil, log.Noop(), metrics.Noop())
+	require.NoError(t, err)
+
+	handler := s.Handler()
+
+	claims := jwt.MapClaims{"sub": "foo", "exp": time.Now().Add(time.Hour).Unix()}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("shh"))
+	require.NoError(t, err)
+
+	noExpToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "foo"}).SignedString([]byte("shh"))
+	require.NoError(t, err)
+
+	badToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("nope"))
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		token string
+		code  int
+	}{
+		{token: "", code: http.StatusUnauthorized},
+		{token: badToken, code: http.StatusUnauthorized},
+		{token: noExpToken, code: http.StatusUnauthorized},
+		{token: token, code: http.StatusOK},
+	} {
+		request, _ := http.NewRequest("GET", "/version", http.NoBody)
+		if test.token != "" {
+			request.Header.Set("Authorization", "Bearer "+test.token)
+		}
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, request)
+
+		assert.Equal(t, test.code, response.Code)
+	}
+}
+
+func TestAPIAuthConfigErrors(t *testing.T) {
+	conf := api.NewConfig()
+	conf.BasicAuth.Enabled = true
+	conf.BasicAuth.Username = "foo"
+	conf.JWTAuth.Enabled = true
+	conf.JWTAuth.HMACSecret = "shh"
+
+	_, err := api.New("", "", conf, nil, log.Noop(), metrics.Noop())
+	require.Error(t, err)
+
+	conf = api.NewConfig()
+	conf.ClientCAFile = "/does/not/exist.pem"
+
+	_, err = api.New("", "", conf, nil, log.Noop(), metrics.Noop())
+	require.Error(t, err)
+}
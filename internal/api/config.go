@@ -14,6 +14,7 @@ const (
 	fieldDebugEndpoints = "debug_endpoints"
 	fieldCertFile       = "cert_file"
 	fieldKeyFile        = "key_file"
+	fieldClientCAFile   = "client_ca_file"
 	fieldCORS           = "cors"
 	fieldBasicAuth      = "basic_auth"
 )
@@ -26,8 +27,10 @@ type Config struct {
 	DebugEndpoints bool                       `json:"debug_endpoints" yaml:"debug_endpoints"`
 	CertFile       string                     `json:"cert_file" yaml:"cert_file"`
 	KeyFile        string                     `json:"key_file" yaml:"key_file"`
+	ClientCAFile   string                     `json:"client_ca_file" yaml:"client_ca_file"`
 	CORS           httpserver.CORSConfig      `json:"cors" yaml:"cors"`
 	BasicAuth      httpserver.BasicAuthConfig `json:"basic_auth" yaml:"basic_auth"`
+	JWTAuth        httpserver.JWTAuthConfig   `json:"jwt_auth" yaml:"jwt_auth"`
 }
 
 // NewConfig creates an API configuration struct fully populated with default values.
@@ -39,8 +42,10 @@ func NewConfig() Config {
 		DebugEndpoints: false,
 		CertFile:       "",
 		KeyFile:        "",
+		ClientCAFile:   "",
 		CORS:           httpserver.NewServerCORSConfig(),
 		BasicAuth:      httpserver.NewBasicAuthConfig(),
+		JWTAuth:        httpserver.NewJWTAuthConfig(),
 	}
 }
 
@@ -64,11 +69,17 @@ func FromParsed(pConf *docs.ParsedConfig) (conf Config, err error) {
 	if conf.KeyFile, err = pConf.FieldString(fieldKeyFile); err != nil {
 		return
 	}
+	if conf.ClientCAFile, err = pConf.FieldString(fieldClientCAFile); err != nil {
+		return
+	}
 	if conf.CORS, err = httpserver.CORSConfigFromParsed(pConf); err != nil {
 		return
 	}
 	if conf.BasicAuth, err = httpserver.BasicAuthConfigFromParsed(pConf); err != nil {
 		return
 	}
+	if conf.JWTAuth, err = httpserver.JWTAuthConfigFromParsed(pConf); err != nil {
+		return
+	}
 	return
 }
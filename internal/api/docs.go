@@ -20,8 +20,10 @@ func Spec() docs.FieldSpecs {
 		).HasDefault(false),
 		docs.FieldString(fieldCertFile, "An optional certificate file for enabling TLS.").Advanced().HasDefault(""),
 		docs.FieldString(fieldKeyFile, "An optional key file for enabling TLS.").Advanced().HasDefault(""),
+		docs.FieldString(fieldClientCAFile, "An optional file containing certificate authorities, which when specified along with `cert_file` and `key_file` requires clients to present a certificate signed by one of them.").Advanced().HasDefault(""),
 		httpserver.ServerCORSFieldSpec(),
 		httpserver.BasicAuthFieldSpec(),
+		httpserver.JWTAuthFieldSpec(),
 	}
 }
 
@@ -0,0 +1,75 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package bloblang
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/internal/message"
+)
+
+func BenchmarkMappingConstantFolding(b *testing.B) {
+	benchmarkMapping(b, `
+root.a = this.value + (60 * 60 * 24)
+root.b = this.name + "-" + "suffix".uppercase()
+root.c = "  padded  ".trim().length() * 2
+`, `{"value":10,"name":"foo"}`)
+}
+
+func BenchmarkMappingInlinedLets(b *testing.B) {
+	benchmarkMapping(b, `
+let seconds_per_day = 60 * 60 * 24
+let prefix = "tenant".uppercase()
+root.a = this.value * $seconds_per_day
+root.b = $prefix + "-" + this.name
+root.c = $seconds_per_day * 7
+`, `{"value":10,"name":"foo"}`)
+}
+
+func BenchmarkMappingSingleUseLets(b *testing.B) {
+	benchmarkMapping(b, `
+let name = this.name.uppercase()
+root.a = $name.trim()
+let value = this.value
+root.b = $value
+`, `{"value":10,"name":"foo"}`)
+}
+
+func BenchmarkMappingHoistedPaths(b *testing.B) {
+	benchmarkMapping(b, `
+root.id = this.event.payload.user.id
+root.name = this.event.payload.user.name
+root.email = this.event.payload.user.email
+root.country = this.event.payload.user.address.country
+root.city = this.event.payload.user.address.city
+`, `{"event":{"payload":{"user":{"id":"a","name":"b","email":"c","address":{"country":"d","city":"e"}}}}}`)
+}
+
+func BenchmarkMappingContentParseJSON(b *testing.B) {
+	benchmarkMapping(b, `
+root.doc = content().parse_json()
+root.id = this.id
+`, `{"id":"foo","values":[1,2,3],"nested":{"a":"b","c":[{"d":"e"}]}}`)
+}
+
+func benchmarkMapping(b *testing.B, mapping, input string) {
+	exec, err := GlobalEnvironment().NewMapping(mapping)
+	require.NoError(b, err)
+
+	part := message.NewPart([]byte(input))
+	_, err = part.AsStructured()
+	require.NoError(b, err)
+
+	batch := message.Batch{part}
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		res, err := exec.MapPart(0, batch)
+		require.NoError(b, err)
+		require.NotNil(b, res)
+	}
+}
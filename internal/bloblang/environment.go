@@ -14,6 +14,7 @@ import (
 type Environment struct {
 	pCtx            parser.Context
 	maxMapRecursion int
+	budget          query.Budget
 }
 
 // GlobalEnvironment returns the global default environment. Modifying this
@@ -76,6 +77,7 @@ func (e *Environment) NewMapping(blobl string) (*mapping.Executor, error) {
 	if e.maxMapRecursion > 0 {
 		exec.SetMaxMapRecursion(e.maxMapRecursion)
 	}
+	exec.SetBudget(e.budget)
 	return exec, nil
 }
 
@@ -180,6 +182,15 @@ func (e *Environment) WithMaxMapRecursion(n int) *Environment {
 	return &env
 }
 
+// WithExecutionBudget returns a copy of the environment where each execution of
+// mappings parsed from it is limited by a budget. Executions that exceed the
+// budget fail with an error that matches query.ErrBudgetExceeded.
+func (e *Environment) WithExecutionBudget(b query.Budget) *Environment {
+	env := *e
+	env.budget = b
+	return &env
+}
+
 // WalkFunctions executes a provided function argument for every function that
 // has been registered to the environment.
 func (e *Environment) WalkFunctions(fn func(name string, spec query.FunctionSpec)) {
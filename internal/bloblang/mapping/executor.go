@@ -46,8 +46,10 @@ type Executor struct {
 	input      []rune
 	maps       map[string]query.Function
 	statements []Statement
+	hoisted    *query.HoistedPaths
 
 	maxMapStacks int
+	budget       query.Budget
 }
 
 const defaultMaxMapStacks = 5000
@@ -57,11 +59,19 @@ const defaultMaxMapStacks = 5000
 // is an optional slice pointing to the parsed expression that created the
 // executor.
 func NewExecutor(annotation string, input []rune, maps map[string]query.Function, statements ...Statement) *Executor {
+	// Maps are omitted from the targets context as they have isolated contexts
+	// and might not be fully defined yet.
+	var targets []query.TargetPath
+	for _, stmt := range statements {
+		_, tmpPaths := stmt.QueryTargets(query.TargetsContext{})
+		targets = append(targets, tmpPaths...)
+	}
 	return &Executor{
 		annotation:   annotation,
 		input:        input,
 		maps:         maps,
 		statements:   statements,
+		hoisted:      query.NewHoistedPaths(targets),
 		maxMapStacks: defaultMaxMapStacks,
 	}
 }
@@ -73,11 +83,29 @@ func (e *Executor) SetMaxMapRecursion(m int) {
 	e.maxMapStacks = m
 }
 
+// SetBudget configures limits on the resources consumed by each execution of
+// this mapping. Executions that exceed the budget fail with an error that
+// matches query.ErrBudgetExceeded.
+func (e *Executor) SetBudget(b query.Budget) {
+	e.budget = b
+}
+
 // Annotation returns a string annotation that describes the mapping executor.
 func (e *Executor) Annotation() string {
 	return e.annotation
 }
 
+// Input returns the slice of the parsed expression that created the executor,
+// which may be empty.
+func (e *Executor) Input() []rune {
+	return e.input
+}
+
+// Statements returns the statements executed by the mapping.
+func (e *Executor) Statements() []Statement {
+	return e.statements
+}
+
 // Maps returns any map definitions contained within the mapping.
 func (e *Executor) Maps() map[string]query.Function {
 	return e.maps
@@ -146,8 +174,13 @@ func (e *Executor) mapPart(appendTo *message.Part, index int, reference Message)
 	var newPart *message.Part
 	var newValue any = value.Nothing(nil)
 
+	// Lookups of paths referenced multiple times can be cached as long as the
+	// reference message is not also the target of our assignments.
+	var pathCache *query.PathCache
+
 	if appendTo == nil {
 		newPart = reference.Get(index).ShallowCopy()
+		pathCache = e.hoisted.NewCache()
 	} else {
 		newPart = appendTo
 		if appendObj, err := appendTo.AsStructuredMut(); err == nil {
@@ -156,6 +189,7 @@ func (e *Executor) mapPart(appendTo *message.Part, index int, reference Message)
 	}
 
 	vars := map[string]any{}
+	budget := query.NewBudgetTracker(e.budget)
 
 	for _, stmt := range e.statements {
 		err := stmt.Execute(query.FunctionContext{
@@ -165,7 +199,7 @@ func (e *Executor) mapPart(appendTo *message.Part, index int, reference Message)
 			MsgBatch: reference,
 			NewMeta:  newPart,
 			NewValue: &newValue,
-		}.WithValueFunc(lazyValue),
+		}.WithValueFunc(lazyValue).WithPathCache(pathCache).WithBudget(budget),
 			AssignmentContext{
 				Vars:  vars,
 				Meta:  newPart,
@@ -174,8 +208,10 @@ func (e *Executor) mapPart(appendTo *message.Part, index int, reference Message)
 		)
 		if err != nil {
 			var line int
-			if len(e.input) > 0 && len(stmt.Input()) > 0 {
-				line, _ = LineAndColOf(e.input, stmt.Input())
+			stmtInput := stmt.Input()
+			stmtInput, err = unwrapInlinedErr(stmtInput, err)
+			if len(e.input) > 0 && len(stmtInput) > 0 {
+				line, _ = LineAndColOf(e.input, stmtInput)
 			}
 			var ctxErr query.ErrNoContext
 			if parseErr != nil && errors.As(err, &ctxErr) {
@@ -189,6 +225,12 @@ func (e *Executor) mapPart(appendTo *message.Part, index int, reference Message)
 		}
 	}
 
+	// An exceeded budget must fail the mapping even when the error was
+	// caught within it.
+	if err := budget.Err(); err != nil {
+		return nil, err
+	}
+
 	switch newValue.(type) {
 	case value.Delete:
 		// Return nil (filter the message part)
@@ -241,6 +283,11 @@ func (e *Executor) Exec(ctx query.FunctionContext) (any, error) {
 		return nil, &errStacks{annotation: e.annotation, maxStacks: e.maxMapStacks}
 	}
 
+	ctx = e.withBudget(ctx)
+	if err := ctx.Budget().CheckRecursionDepth(stackCount); err != nil {
+		return nil, err
+	}
+
 	var newObj any = value.Nothing(nil)
 	ctx.NewValue = &newObj
 
@@ -254,17 +301,30 @@ func (e *Executor) Exec(ctx query.FunctionContext) (any, error) {
 		}
 	}
 
+	if err := ctx.Budget().Err(); err != nil {
+		return nil, err
+	}
 	return newObj, nil
 }
 
 // ExecOnto a provided assignment context.
 func (e *Executor) ExecOnto(ctx query.FunctionContext, onto AssignmentContext) error {
+	ctx = e.withBudget(ctx)
 	for _, stmt := range e.statements {
 		if err := stmt.Execute(ctx, onto); err != nil {
 			return formatExecErr(err, e.input, stmt.Input())
 		}
 	}
-	return nil
+	return ctx.Budget().Err()
+}
+
+// withBudget adds a tracker of the budget of this mapping to a context, unless
+// the context is already tracking the budget of a parent execution.
+func (e *Executor) withBudget(ctx query.FunctionContext) query.FunctionContext {
+	if ctx.Budget() != nil {
+		return ctx
+	}
+	return ctx.WithBudget(query.NewBudgetTracker(e.budget))
 }
 
 // ToBytes executes this function for a message of a batch and returns the
@@ -317,6 +377,8 @@ func formatExecErr(err error, input, stmtInput []rune) error {
 		return u
 	}
 
+	stmtInput, err = unwrapInlinedErr(stmtInput, err)
+
 	var line int
 	if len(input) > 0 && len(stmtInput) > 0 {
 		line, _ = LineAndColOf(input, stmtInput)
@@ -332,3 +394,14 @@ func formatExecErr(err error, input, stmtInput []rune) error {
 		err:  err,
 	}
 }
+
+// unwrapInlinedErr extracts an error returned by a variable assignment that was
+// inlined into a statement, along with the input of the assignment, so that it
+// can be reported as if the assignment had not been inlined.
+func unwrapInlinedErr(stmtInput []rune, err error) ([]rune, error) {
+	var iErr *query.ErrInlinedVar
+	if errors.As(err, &iErr) {
+		return iErr.Input, iErr.Err
+	}
+	return stmtInput, err
+}
@@ -54,6 +54,11 @@ func (s *SingleStatement) Input() []rune {
 	return s.input
 }
 
+// Query returns the query function of this statement.
+func (s *SingleStatement) Query() query.Function {
+	return s.query
+}
+
 // Execute executes this statement and applies the result onto the assigned
 // destination.
 func (s *SingleStatement) Execute(fnContext query.FunctionContext, asContext AssignmentContext) error {
@@ -18,6 +18,10 @@ type Context struct {
 	Methods      *query.MethodSet
 	namedContext *namedContext
 	importer     Importer
+
+	// Tracks the variables of a mapping being parsed that can be inlined.
+	lets            *letTracker
+	conditionalLets bool
 }
 
 // EmptyContext returns a parser context with no functions, methods or import
@@ -64,9 +68,30 @@ func (pCtx Context) HasNamedContext(name string) bool {
 	return false
 }
 
+// withLetTracker returns a Context where variables are tracked for inlining by
+// a provided scope, which can be nil in order to disable tracking (for example
+// within map definitions as they have isolated variables).
+func (pCtx Context) withLetTracker(l *letTracker) Context {
+	pCtx.lets = l
+	pCtx.conditionalLets = false
+	return pCtx
+}
+
+// withConditionalLets returns a Context where variable assignments are
+// conditional, and therefore cannot be treated as constant from that point in
+// the mapping onwards.
+func (pCtx Context) withConditionalLets() Context {
+	pCtx.conditionalLets = true
+	return pCtx
+}
+
 // InitFunction attempts to initialise a function from the available
 // constructors of the parser context.
 func (pCtx Context) InitFunction(name string, args *query.ParsedParams) (query.Function, error) {
+	if name == "var" {
+		// Variables referenced by name cannot be inlined.
+		pCtx.lets.spoilAll()
+	}
 	return pCtx.Functions.Init(name, args)
 }
 
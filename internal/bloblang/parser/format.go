@@ -0,0 +1,522 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package parser
+
+import (
+	"errors"
+	"strings"
+)
+
+// FormatMaxLineWidth is the width beyond which the formatter attempts to break
+// long method chains across multiple lines.
+const FormatMaxLineWidth = 100
+
+// FormatMapping returns a canonically formatted version of a Bloblang mapping.
+//
+// The parsed form of a mapping discards comments and layout, and therefore the
+// formatter works from the lexical structure of the mapping instead, where
+// comments and the line breaks chosen by the author are preserved. Horizontal
+// whitespace between tokens is normalised, lines are indented by two spaces
+// per level of nested brackets and blocks (such as `match` and `if` bodies),
+// consecutive blank lines are collapsed, and method chains that exceed
+// FormatMaxLineWidth are broken into one method per line.
+//
+// The tokens of the result are checked against those of the input, and an
+// error is returned if the formatting would change anything other than
+// whitespace.
+func FormatMapping(expr string) (string, *Error) {
+	input := []rune(expr)
+
+	tokens, err := fmtTokenize(input)
+	if err != nil {
+		return "", err
+	}
+
+	f := &formatter{}
+	f.format(tokens)
+	result := f.buf.String()
+
+	resTokens, err := fmtTokenize([]rune(result))
+	if err != nil || !fmtTokensEquivalent(tokens, resTokens) {
+		return "", NewFatalError(input, errors.New("unable to format mapping without changing its meaning"))
+	}
+	return result, nil
+}
+
+//------------------------------------------------------------------------------
+
+type fmtTokenType int
+
+const (
+	fmtTokenWord fmtTokenType = iota
+	fmtTokenString
+	fmtTokenComment
+	fmtTokenNewline
+	fmtTokenPunct
+	fmtTokenOperator
+)
+
+type fmtToken struct {
+	kind  fmtTokenType
+	value string
+}
+
+func (t fmtToken) is(kind fmtTokenType, values ...string) bool {
+	if t.kind != kind {
+		return false
+	}
+	if len(values) == 0 {
+		return true
+	}
+	for _, v := range values {
+		if t.value == v {
+			return true
+		}
+	}
+	return false
+}
+
+func fmtIsWordChar(r rune) bool {
+	return (r >= 'a' && r <= 'z') ||
+		(r >= 'A' && r <= 'Z') ||
+		(r >= '0' && r <= '9') ||
+		r == '_'
+}
+
+func fmtIsDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+var fmtOperators = []string{
+	"==", "!=", ">=", "<=", "&&", "||", "=>", "->",
+	"=", "+", "-", "*", "/", "%", ">", "<", "|", "!",
+}
+
+func fmtConsumeQuoted(input []rune, i int) (int, *Error) {
+	escaped := false
+	for j := i + 1; j < len(input); j++ {
+		switch {
+		case input[j] == '\n':
+			return 0, NewFatalError(input[i:], errors.New("required"), "end quote")
+		case input[j] == '"' && !escaped:
+			return j + 1, nil
+		case input[j] == '\\':
+			escaped = !escaped
+		default:
+			escaped = false
+		}
+	}
+	return 0, NewFatalError(input[len(input):], errors.New("required"), "end quote")
+}
+
+func fmtTokenize(input []rune) ([]fmtToken, *Error) {
+	var tokens []fmtToken
+
+	lastSignificant := func() (fmtToken, bool) {
+		for i := len(tokens) - 1; i >= 0; i-- {
+			if tokens[i].kind != fmtTokenComment && tokens[i].kind != fmtTokenNewline {
+				return tokens[i], true
+			}
+		}
+		return fmtToken{}, false
+	}
+
+	i := 0
+tokenLoop:
+	for i < len(input) {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '\n':
+			tokens = append(tokens, fmtToken{kind: fmtTokenNewline, value: "\n"})
+			i++
+		case c == '#':
+			j := i
+			for j < len(input) && input[j] != '\n' {
+				j++
+			}
+			tokens = append(tokens, fmtToken{
+				kind:  fmtTokenComment,
+				value: strings.TrimRight(string(input[i:j]), " \t\r"),
+			})
+			i = j
+		case c == '"':
+			if i+2 < len(input) && input[i+1] == '"' && input[i+2] == '"' {
+				end := -1
+				for j := i + 3; j+2 < len(input); j++ {
+					if input[j] == '"' && input[j+1] == '"' && input[j+2] == '"' {
+						end = j + 3
+						break
+					}
+				}
+				if end == -1 {
+					return nil, NewFatalError(input[len(input):], errors.New("required"), "end triple-quote")
+				}
+				tokens = append(tokens, fmtToken{kind: fmtTokenString, value: string(input[i:end])})
+				i = end
+				continue
+			}
+			end, err := fmtConsumeQuoted(input, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, fmtToken{kind: fmtTokenString, value: string(input[i:end])})
+			i = end
+		case c == '@' || c == '$':
+			j := i + 1
+			if c == '@' && j < len(input) && input[j] == '"' {
+				end, err := fmtConsumeQuoted(input, j)
+				if err != nil {
+					return nil, err
+				}
+				j = end
+			} else {
+				for j < len(input) && fmtIsWordChar(input[j]) {
+					j++
+				}
+			}
+			tokens = append(tokens, fmtToken{kind: fmtTokenWord, value: string(input[i:j])})
+			i = j
+		case fmtIsWordChar(c):
+			j := i
+			for j < len(input) && fmtIsWordChar(input[j]) {
+				j++
+			}
+			// Numbers with a fractional part are a single token, unless they
+			// are a segment of a path (i.e. `this.foo.0.1`).
+			prev, hasPrev := lastSignificant()
+			if fmtIsDigits(string(input[i:j])) && !(hasPrev && prev.is(fmtTokenPunct, ".")) &&
+				j+1 < len(input) && input[j] == '.' && input[j+1] >= '0' && input[j+1] <= '9' {
+				j++
+				for j < len(input) && input[j] >= '0' && input[j] <= '9' {
+					j++
+				}
+			}
+			tokens = append(tokens, fmtToken{kind: fmtTokenWord, value: string(input[i:j])})
+			i = j
+		case c == '.' && i+2 < len(input) && input[i+1] == '.' && input[i+2] == '.':
+			// The rest element of an array pattern.
+			tokens = append(tokens, fmtToken{kind: fmtTokenPunct, value: "..."})
+			i += 3
+		case strings.ContainsRune("()[]{},.:", c):
+			tokens = append(tokens, fmtToken{kind: fmtTokenPunct, value: string(c)})
+			i++
+		default:
+			for _, op := range fmtOperators {
+				if strings.HasPrefix(string(input[i:min(i+2, len(input))]), op) {
+					tokens = append(tokens, fmtToken{kind: fmtTokenOperator, value: op})
+					i += len(op)
+					continue tokenLoop
+				}
+			}
+			return nil, NewFatalError(input[i:], errors.New("unexpected character"))
+		}
+	}
+	return tokens, nil
+}
+
+func fmtTokensEquivalent(a, b []fmtToken) bool {
+	filter := func(t []fmtToken) []fmtToken {
+		out := make([]fmtToken, 0, len(t))
+		for _, v := range t {
+			if v.kind != fmtTokenNewline {
+				out = append(out, v)
+			}
+		}
+		return out
+	}
+	a, b = filter(a), filter(b)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+//------------------------------------------------------------------------------
+
+type fmtBracket struct {
+	value      string
+	block      bool
+	lineIndent int
+}
+
+type formatter struct {
+	buf strings.Builder
+
+	brackets []fmtBracket
+
+	// The last token that wasn't a comment or line break.
+	prevSig *fmtToken
+
+	continuation    bool
+	pendingBlank    bool
+	lastLineOpened  bool
+	writtenAnything bool
+}
+
+func fmtIsKeyword(t fmtToken, prevSig *fmtToken) bool {
+	if t.kind != fmtTokenWord {
+		return false
+	}
+	if prevSig != nil && prevSig.is(fmtTokenPunct, ".") {
+		return false
+	}
+	switch t.value {
+	case "if", "else", "match":
+		return true
+	}
+	return false
+}
+
+// Whether the token ends a value, which determines whether a following `{`
+// opens a block or an object literal, and whether a following `-` is unary.
+func fmtEndsValue(t *fmtToken) bool {
+	if t == nil {
+		return false
+	}
+	switch t.kind {
+	case fmtTokenWord, fmtTokenString:
+		return true
+	case fmtTokenPunct:
+		return t.value == ")" || t.value == "]" || t.value == "}"
+	}
+	return false
+}
+
+// Whether the `{` at index i of a line opens a block. A `{` at the start of a
+// line, or following a `let` at the start of a line, never opens a block as it
+// is either an object literal or a match case pattern.
+func fmtOpensBlock(line []fmtToken, i int, prevSig *fmtToken) bool {
+	if i == 0 || (i == 1 && line[0].is(fmtTokenWord, "let")) {
+		return false
+	}
+	return fmtEndsValue(prevSig)
+}
+
+func fmtIsUnary(t fmtToken, prevSig *fmtToken) bool {
+	if t.is(fmtTokenOperator, "!") {
+		return true
+	}
+	if !t.is(fmtTokenOperator, "-") {
+		return false
+	}
+	return !fmtEndsValue(prevSig) || fmtIsKeyword(*prevSig, nil)
+}
+
+func fmtNeedsSpace(prev *fmtToken, prevUnary, prevBlockOpen bool, next fmtToken, nextBlockClose bool) bool {
+	if next.kind == fmtTokenComment {
+		return true
+	}
+	if prevUnary {
+		return false
+	}
+	if prev.is(fmtTokenPunct, "(", "[", ".", "...") {
+		return false
+	}
+	if prev.is(fmtTokenPunct, "{") {
+		return prevBlockOpen && !next.is(fmtTokenPunct, "}")
+	}
+	if next.is(fmtTokenPunct, "}") {
+		return nextBlockClose
+	}
+	if next.is(fmtTokenPunct, ",", ")", "]", ".", ":") {
+		return false
+	}
+	if next.is(fmtTokenPunct, "(") {
+		return !(prev.kind == fmtTokenWord && !fmtIsKeyword(*prev, nil))
+	}
+	return true
+}
+
+func fmtLineEndsContinuation(line []fmtToken) bool {
+	for i := len(line) - 1; i >= 0; i-- {
+		if line[i].kind == fmtTokenComment {
+			continue
+		}
+		return line[i].kind == fmtTokenOperator || line[i].is(fmtTokenPunct, ".", ":")
+	}
+	return false
+}
+
+// fmtSplitChain attempts to break a line containing a long method chain after
+// each dot that precedes a method call at the top level of the line.
+func fmtSplitChain(line []fmtToken) [][]fmtToken {
+	var splits []int
+	depth := 0
+	for i, t := range line {
+		switch {
+		case t.is(fmtTokenPunct, "(", "[", "{"):
+			depth++
+		case t.is(fmtTokenPunct, ")", "]", "}"):
+			depth--
+		case depth == 0 && t.is(fmtTokenPunct, ".") &&
+			i+2 < len(line) && line[i+1].kind == fmtTokenWord && line[i+2].is(fmtTokenPunct, "("):
+			splits = append(splits, i)
+		}
+		if depth < 0 {
+			return nil
+		}
+	}
+	if len(splits) < 2 {
+		return nil
+	}
+	var lines [][]fmtToken
+	last := 0
+	for _, s := range splits {
+		lines = append(lines, line[last:s+1])
+		last = s + 1
+	}
+	return append(lines, line[last:])
+}
+
+func (f *formatter) renderLine(line []fmtToken, indent int) string {
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat("  ", indent))
+
+	prevSig := f.prevSig
+	brackets := append([]fmtBracket(nil), f.brackets...)
+
+	var prev *fmtToken
+	var prevUnary, prevBlockOpen bool
+	for i := range line {
+		t := line[i]
+
+		var unary, blockOpen, blockClose bool
+		switch {
+		case t.kind == fmtTokenOperator:
+			unary = fmtIsUnary(t, prevSig)
+		case t.is(fmtTokenPunct, "(", "["):
+			brackets = append(brackets, fmtBracket{value: t.value})
+		case t.is(fmtTokenPunct, "{"):
+			blockOpen = fmtOpensBlock(line, i, prevSig)
+			brackets = append(brackets, fmtBracket{value: t.value, block: blockOpen})
+		case t.is(fmtTokenPunct, ")", "]", "}"):
+			if len(brackets) > 0 {
+				blockClose = brackets[len(brackets)-1].block
+				brackets = brackets[:len(brackets)-1]
+			}
+		}
+
+		if prev != nil && fmtNeedsSpace(prev, prevUnary, prevBlockOpen, t, blockClose) {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(t.value)
+
+		prev, prevUnary, prevBlockOpen = &line[i], unary, blockOpen
+		if t.kind != fmtTokenComment {
+			prevSig = &line[i]
+		}
+	}
+	return sb.String()
+}
+
+// commitLine updates the formatter state to reflect a line having been written.
+func (f *formatter) commitLine(line []fmtToken, indent int) {
+	for i := range line {
+		t := line[i]
+		if t.kind == fmtTokenComment {
+			continue
+		}
+		switch {
+		case t.is(fmtTokenPunct, "(", "["):
+			f.brackets = append(f.brackets, fmtBracket{value: t.value, lineIndent: indent})
+		case t.is(fmtTokenPunct, "{"):
+			block := fmtOpensBlock(line, i, f.prevSig)
+			f.brackets = append(f.brackets, fmtBracket{value: t.value, block: block, lineIndent: indent})
+		case t.is(fmtTokenPunct, ")", "]", "}"):
+			if len(f.brackets) > 0 {
+				f.brackets = f.brackets[:len(f.brackets)-1]
+			}
+		}
+		f.prevSig = &line[i]
+	}
+}
+
+func (f *formatter) lineIndent(line []fmtToken) int {
+	if len(line) > 0 && line[0].is(fmtTokenPunct, ")", "]", "}") && len(f.brackets) > 0 {
+		return f.brackets[len(f.brackets)-1].lineIndent
+	}
+	indent := 0
+	if len(f.brackets) > 0 {
+		indent = f.brackets[len(f.brackets)-1].lineIndent + 1
+	}
+	if f.continuation {
+		indent++
+	}
+	return indent
+}
+
+func (f *formatter) format(tokens []fmtToken) {
+	var lines [][]fmtToken
+	var current []fmtToken
+	for _, t := range tokens {
+		if t.kind == fmtTokenNewline {
+			lines = append(lines, current)
+			current = nil
+			continue
+		}
+		current = append(current, t)
+	}
+	lines = append(lines, current)
+
+	for len(lines) > 0 {
+		line := lines[0]
+		lines = lines[1:]
+
+		if len(line) == 0 {
+			if f.writtenAnything && !f.lastLineOpened {
+				f.pendingBlank = true
+			}
+			continue
+		}
+
+		indent := f.lineIndent(line)
+		rendered := f.renderLine(line, indent)
+		if len(rendered) > FormatMaxLineWidth {
+			if split := fmtSplitChain(line); split != nil {
+				lines = append(split, lines...)
+				continue
+			}
+		}
+
+		if f.pendingBlank && !line[0].is(fmtTokenPunct, ")", "]", "}") {
+			f.buf.WriteByte('\n')
+		}
+		f.pendingBlank = false
+
+		f.buf.WriteString(rendered)
+		f.buf.WriteByte('\n')
+		f.writtenAnything = true
+
+		if line[0].kind == fmtTokenComment {
+			f.lastLineOpened = false
+			continue
+		}
+
+		f.commitLine(line, indent)
+		f.continuation = fmtLineEndsContinuation(line)
+
+		f.lastLineOpened = false
+		for i := len(line) - 1; i >= 0; i-- {
+			if line[i].kind == fmtTokenComment {
+				continue
+			}
+			f.lastLineOpened = line[i].is(fmtTokenPunct, "(", "[", "{")
+			break
+		}
+	}
+}
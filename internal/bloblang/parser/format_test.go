@@ -0,0 +1,243 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatMapping(t *testing.T) {
+	tests := map[string]struct {
+		input  string
+		output string
+	}{
+		"already formatted": {
+			input:  "root = this\n",
+			output: "root = this\n",
+		},
+		"whitespace around operators": {
+			input:  `root.foo = this.a+this.b*  2`,
+			output: "root.foo = this.a + this.b * 2\n",
+		},
+		"unary operators": {
+			input: `let x = -5
+root.y = $x+ -3
+root.z = ! this.enabled`,
+			output: `let x = -5
+root.y = $x + -3
+root.z = !this.enabled
+`,
+		},
+		"comments and blank lines": {
+			input: `
+
+# first comment
+root = this   # trailing comment
+
+
+
+  # another comment
+root.foo = "bar"
+
+`,
+			output: `# first comment
+root = this # trailing comment
+
+# another comment
+root.foo = "bar"
+`,
+		},
+		"function and method arguments": {
+			input:  `root.id = uuid_v4( ).string( ).replace_all( "-" , "" )`,
+			output: "root.id = uuid_v4().string().replace_all(\"-\", \"\")\n",
+		},
+		"named arguments": {
+			input:  `root.nums = range(start:0,stop:  10,step:2)`,
+			output: "root.nums = range(start: 0, stop: 10, step: 2)\n",
+		},
+		"object and array literals": {
+			input:  `root = { "a":[1,2,-3 ],"b" :@meta , "c": $v}`,
+			output: "root = {\"a\": [1, 2, -3], \"b\": @meta, \"c\": $v}\n",
+		},
+		"match block indentation": {
+			input: `root.b = match this.b {
+"x"=>1
+      _   => {"a":this.a}
+}`,
+			output: `root.b = match this.b {
+  "x" => 1
+  _ => {"a": this.a}
+}
+`,
+		},
+		"match without context": {
+			input: `root = match {
+this.a>5  =>"big"
+}`,
+			output: `root = match {
+  this.a > 5 => "big"
+}
+`,
+		},
+		"single line if expression": {
+			input:  `root = if this.x>5 {"big"} else if !this.y {  "no"} else {"small"}`,
+			output: "root = if this.x > 5 { \"big\" } else if !this.y { \"no\" } else { \"small\" }\n",
+		},
+		"root level if statement": {
+			input: `if this.a {
+      root.a = 1
+
+} else if this.b {
+root.b = 2
+}
+else {
+  # nothing to see here
+    root.c = 3
+}`,
+			output: `if this.a {
+  root.a = 1
+} else if this.b {
+  root.b = 2
+}
+else {
+  # nothing to see here
+  root.c = 3
+}
+`,
+		},
+		"nested maps": {
+			input: `map foo {
+root.a = this.a.map_each(ele -> {
+"v": ele
+})
+}
+root = this.apply("foo")`,
+			output: `map foo {
+  root.a = this.a.map_each(ele -> {
+    "v": ele
+  })
+}
+root = this.apply("foo")
+`,
+		},
+		"existing method chain line breaks": {
+			input: `root = this.foo.
+uppercase().
+      trim()`,
+			output: `root = this.foo.
+  uppercase().
+  trim()
+`,
+		},
+		"long method chain": {
+			input: `root.out = this.items.filter(i -> i.x > 5).map_each(i -> i.name.uppercase()).sort().join(",").catch("")`,
+			output: `root.out = this.items.
+  filter(i -> i.x > 5).
+  map_each(i -> i.name.uppercase()).
+  sort().
+  join(",").
+  catch("")
+`,
+		},
+		"multiple line arrays": {
+			input: `root = [
+1,
+  2,
+    [
+3
+]
+]`,
+			output: `root = [
+  1,
+  2,
+  [
+    3
+  ]
+]
+`,
+		},
+		"paths and numbers": {
+			input:  `root."foo.bar" = this.0.1+1.5`,
+			output: "root.\"foo.bar\" = this.0.1 + 1.5\n",
+		},
+		"triple quoted strings": {
+			input: `meta foo = """
+hello
+  world"""
+meta = deleted()`,
+			output: `meta foo = """
+hello
+  world"""
+meta = deleted()
+`,
+		},
+		"match patterns": {
+			input: `root = match this {
+  let {"type":"click","user":u}=>u.name
+  let {"type" : "view",..._} => "view"
+  let [ first,...rest ] => rest
+  let n : number => n
+}`,
+			output: `root = match this {
+  let {"type": "click", "user": u} => u.name
+  let {"type": "view", ..._} => "view"
+  let [first, ...rest] => rest
+  let n: number => n
+}
+`,
+		},
+		"map expressions": {
+			input:  `root = this.( a|b ).c`,
+			output: "root = this.(a | b).c\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, pErr := ParseMapping(GlobalContext(), test.input)
+			require.Nil(t, pErr, "input mapping is invalid")
+
+			out, err := FormatMapping(test.input)
+			require.Nil(t, err)
+			assert.Equal(t, test.output, out)
+
+			_, pErr = ParseMapping(GlobalContext(), out)
+			require.Nil(t, pErr, "formatted mapping is invalid")
+
+			again, err := FormatMapping(out)
+			require.Nil(t, err)
+			assert.Equal(t, out, again, "formatting is not idempotent")
+		})
+	}
+}
+
+func TestFormatMappingErrors(t *testing.T) {
+	tests := map[string]struct {
+		input  string
+		errStr string
+	}{
+		"unterminated quote": {
+			input:  `root = "foo`,
+			errStr: "line 1 char 12: required: expected end quote",
+		},
+		"unterminated triple quote": {
+			input:  `root = """foo`,
+			errStr: "line 1 char 14: required: expected end triple-quote",
+		},
+		"unexpected character": {
+			input:  `root = this ^ 2`,
+			errStr: "line 1 char 13: unexpected character",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := FormatMapping(test.input)
+			require.NotNil(t, err)
+			assert.Equal(t, test.errStr, err.ErrorAtPosition([]rune(test.input)))
+		})
+	}
+}
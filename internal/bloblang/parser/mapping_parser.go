@@ -11,6 +11,7 @@ import (
 
 	"github.com/redpanda-data/benthos/v4/internal/bloblang/mapping"
 	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
+	"github.com/redpanda-data/benthos/v4/internal/value"
 )
 
 // ParseMapping parses a bloblang mapping and returns an executor to run it, or
@@ -69,7 +70,8 @@ func parseExecutor(pCtx Context) Func[*mapping.Executor] {
 		maps := map[string]query.Function{}
 		statements := []mapping.Statement{}
 
-		statementPattern := mappingStatement(pCtx, true, maps)
+		lets := &letTracker{}
+		statementPattern := mappingStatement(pCtx.withLetTracker(lets), true, maps)
 
 		res := statementPattern(DiscardedWhitespaceNewlineComments(input).Remaining)
 		if res.Err != nil {
@@ -79,6 +81,7 @@ func parseExecutor(pCtx Context) Func[*mapping.Executor] {
 		if res.Payload != nil {
 			statements = append(statements, res.Payload)
 		}
+		lets.endStatement(res.Payload)
 
 		for {
 			if res.Remaining = Discard(SpacesAndTabs)(res.Remaining).Remaining; len(res.Remaining) == 0 {
@@ -101,7 +104,9 @@ func parseExecutor(pCtx Context) Func[*mapping.Executor] {
 			if res.Payload != nil {
 				statements = append(statements, res.Payload)
 			}
+			lets.endStatement(res.Payload)
 		}
+		statements = lets.inline(statements)
 		return Success(mapping.NewExecutor("", input, maps, statements...), res.Remaining)
 	}
 }
@@ -268,7 +273,7 @@ func mapParser(pCtx Context, maps map[string]query.Function) Func[string] {
 				DiscardedWhitespaceNewlineComments,
 			),
 			// Prevent imports, maps and metadata assignments.
-			mappingStatement(pCtx, false, nil),
+			mappingStatement(pCtx.withLetTracker(nil), false, nil),
 			Sequence(
 				Discard(SpacesAndTabs),
 				NewlineAllowComment,
@@ -332,12 +337,165 @@ func letStatementParser(pCtx Context) Func[mapping.Statement] {
 		if res.Err != nil {
 			return Fail[mapping.Statement](res.Err, input)
 		}
-		return Success[mapping.Statement](mapping.NewSingleStatement(
-			input,
-			mapping.NewVarAssignment(res.Payload[2].(string)),
-			res.Payload[6].(query.Function),
-		), res.Remaining)
+		name, fn := res.Payload[2].(string), res.Payload[6].(query.Function)
+		stmt := mapping.NewSingleStatement(input, mapping.NewVarAssignment(name), fn)
+		pCtx.lets.assign(name, input, fn, stmt, pCtx.conditionalLets)
+		return Success[mapping.Statement](stmt, res.Remaining)
+	}
+}
+
+// letTracker tracks the variables of a mapping in order to determine which of
+// them can be inlined. Variables that, at the current position of the parser,
+// are known to hold a constant value have their references replaced with the
+// value itself, which allows expressions they're used within to be folded
+// further.
+//
+// Variables that are assigned non-constant values are candidates for inlining
+// into the statement immediately following their assignment, which can only be
+// determined once the whole mapping has been parsed.
+type letTracker struct {
+	values map[string]any
+
+	// The latest candidate assigned to each variable, the candidate assigned by
+	// the statement preceding the one currently being parsed, and the candidate
+	// assigned by the statement currently being parsed.
+	candidates map[string]*letCandidate
+	window     *letCandidate
+	next       *letCandidate
+	all        []*letCandidate
+}
+
+type letCandidate struct {
+	*query.VarCandidate
+
+	stmt      mapping.Statement
+	following mapping.Statement
+
+	// The candidate previously assigned to the same variable, which is still
+	// observed when this candidate resolves to nothing.
+	prev *letCandidate
+
+	// Set when the variable is referenced anywhere other than the statement
+	// following its assignment.
+	spoiled bool
+}
+
+func (l *letTracker) get(name string) (any, bool) {
+	if l == nil {
+		return nil, false
+	}
+	v, exists := l.values[name]
+	return v, exists
+}
+
+// reference returns a reference to a variable that is a candidate for inlining,
+// or false if the variable should be referenced as normal.
+func (l *letTracker) reference(name string) (query.Function, bool) {
+	if l == nil {
+		return nil, false
+	}
+	c, exists := l.candidates[name]
+	if !exists {
+		return nil, false
+	}
+	if c != l.window {
+		c.spoiled = true
+		return nil, false
+	}
+	return c.Reference(), true
+}
+
+// spoilAll prevents all variables assigned so far from being inlined, which is
+// necessary when they might be referenced dynamically.
+func (l *letTracker) spoilAll() {
+	if l == nil {
+		return
+	}
+	for _, c := range l.candidates {
+		c.spoiled = true
+	}
+}
+
+func (l *letTracker) assign(name string, input []rune, fn query.Function, stmt mapping.Statement, conditional bool) {
+	if l == nil {
+		return
+	}
+	delete(l.values, name)
+	if conditional {
+		// Previous candidates remain, as references after this point might
+		// still observe them.
+		return
+	}
+	prev := l.candidates[name]
+	delete(l.candidates, name)
+	if lit, isLit := fn.(*query.Literal); isLit {
+		switch lit.Value.(type) {
+		case value.Delete, value.Nothing:
+			// Deletes remove the variable and nothing leaves the previous
+			// value intact, neither can be inlined.
+			return
+		}
+		if l.values == nil {
+			l.values = map[string]any{}
+		}
+		l.values[name] = lit.Value
+		return
+	}
+	c := &letCandidate{
+		VarCandidate: query.NewVarCandidate(name, input, fn),
+		stmt:         stmt,
+		prev:         prev,
+	}
+	if l.candidates == nil {
+		l.candidates = map[string]*letCandidate{}
+	}
+	l.candidates[name] = c
+	l.all = append(l.all, c)
+	l.next = c
+}
+
+// endStatement must be called after each top level statement of a mapping has
+// been parsed, with the resulting statement or nil if the statement does not
+// execute (such as a map definition).
+func (l *letTracker) endStatement(stmt mapping.Statement) {
+	if l.window != nil {
+		l.window.following = stmt
+	}
+	l.window, l.next = l.next, nil
+}
+
+// inline attempts to inline each candidate into the statement following it,
+// and returns the statements of the mapping without the assignments of
+// candidates that were inlined.
+func (l *letTracker) inline(statements []mapping.Statement) []mapping.Statement {
+	removed := map[mapping.Statement]struct{}{}
+
+	// Candidates are visited in reverse so that inlining one can prevent the
+	// inlining of the candidate it reassigned.
+	for i := len(l.all) - 1; i >= 0; i-- {
+		c := l.all[i]
+		if c.spoiled {
+			continue
+		}
+		following, isSingle := c.following.(*mapping.SingleStatement)
+		if !isSingle || !c.Inline(following.Query()) {
+			continue
+		}
+		removed[c.stmt] = struct{}{}
+		if c.prev != nil {
+			c.prev.spoiled = true
+		}
+	}
+	if len(removed) == 0 {
+		return statements
+	}
+	kept := make([]mapping.Statement, 0, len(statements)-len(removed))
+	for _, stmt := range statements {
+		if _, isRemoved := removed[stmt]; !isRemoved {
+			kept = append(kept, stmt)
+		}
 	}
+	return kept
 }
 
 var nameLiteralParser = JoinStringPayloads(
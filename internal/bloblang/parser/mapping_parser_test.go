@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/mapping"
 	"github.com/redpanda-data/benthos/v4/internal/message"
 )
 
@@ -589,6 +590,264 @@ from "%v"`, directMapFile),
 	}
 }
 
+func TestMappingOptimisations(t *testing.T) {
+	tests := map[string]struct {
+		mapping string
+		input   string
+		output  string
+	}{
+		"reassigned let constants": {
+			mapping: `let x = 1
+root.a = $x
+let x = this.value
+root.b = $x
+let x = 3
+root.c = $x + 1`,
+			input:  `{"value":"foo"}`,
+			output: `{"a":1,"b":"foo","c":4}`,
+		},
+		"conditionally assigned lets": {
+			mapping: `let x = 1
+if this.flip {
+  let x = 2
+  root.a = $x
+}
+root.b = $x`,
+			input:  `{"flip":true}`,
+			output: `{"a":2,"b":2}`,
+		},
+		"deleted lets": {
+			mapping: `let x = 1
+let x = deleted()
+root.a = $x.catch("deleted")`,
+			input:  `{}`,
+			output: `{"a":"deleted"}`,
+		},
+		"map variables are isolated": {
+			mapping: `map foo {
+  root = $x.catch("isolated")
+}
+let x = 1
+root.a = null.apply("foo")
+root.b = $x`,
+			input:  `{}`,
+			output: `{"a":"isolated","b":1}`,
+		},
+		"inlined lets as match cases remain queries": {
+			mapping: `let x = true
+root = match this.value {
+  $x => "yes"
+  _ => "no"
+}`,
+			input:  `{"value":5}`,
+			output: `yes`,
+		},
+		"failed folding of inlined lets happens at runtime": {
+			mapping: `let x = "a"
+root.a = ($x - 1).catch("caught")
+root.b = "foo".slice($x).catch("caught")
+root.c = $x.number().catch("caught")`,
+			input:  `{}`,
+			output: `{"a":"caught","b":"caught","c":"caught"}`,
+		},
+		"folded methods and arithmetic": {
+			mapping: `let suffix = "bar".uppercase()
+root.a = "foo" + $suffix.lowercase() + this.value
+root.b = 60 * 60 * 24 * this.days`,
+			input:  `{"value":"baz","days":2}`,
+			output: `{"a":"foobarbaz","b":172800}`,
+		},
+		"hoisted paths": {
+			mapping: `root.a = this.a.b.c
+root.b = this.a.b.d
+root.c = this.a.b.e.f
+root.d = this.a.b.e.g.h`,
+			input:  `{"a":{"b":{"c":1,"d":2,"e":{"f":3}}}}`,
+			output: `{"a":1,"b":2,"c":3,"d":null}`,
+		},
+		"hoisted paths through arrays": {
+			mapping: `root.a = this.a.b.0.c
+root.b = this.a.b.1.c
+root.c = this.a.b.c`,
+			input:  `{"a":{"b":[{"c":1},{"c":2}]}}`,
+			output: `{"a":1,"b":2,"c":null}`,
+		},
+		"hoisted paths with changing contexts": {
+			mapping: `root.a = this.a.b.c
+root.b = this.a.map_each(ele -> this.a.b.c + ele.value.c)
+root.c = this.a.b.(this.c + this.c)`,
+			input:  `{"a":{"b":{"c":1,"value":{"c":2}}}}`,
+			output: `{"a":1,"b":{"b":2},"c":2}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			exec, perr := ParseMapping(GlobalContext(), test.mapping)
+			require.Nil(t, perr)
+
+			resPart, err := exec.MapPart(0, message.QuickBatch([][]byte{[]byte(test.input)}))
+			require.NoError(t, err)
+			assert.Equal(t, test.output, string(resPart.AsBytes()))
+		})
+	}
+}
+
+func TestMappingHoistedPathsMapOnto(t *testing.T) {
+	exec, perr := ParseMapping(GlobalContext(), `
+root.a.b.c = "new"
+root.x = this.a.b.c
+root.y = this.a.b.d
+`)
+	require.Nil(t, perr)
+
+	part := message.NewPart([]byte(`{"a":{"b":{"c":"old","d":"unchanged"}}}`))
+	resPart, err := exec.MapOnto(part, 0, message.Batch{part})
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":{"b":{"c":"new","d":"unchanged"}},"x":"new","y":"unchanged"}`, string(resPart.AsBytes()))
+}
+
+func TestMappingInlinedLets(t *testing.T) {
+	tests := map[string]struct {
+		mapping string
+		input   string
+		output  string
+		err     string
+
+		// Variables that are still assigned after inlining
+		assigned []string
+	}{
+		"used once": {
+			mapping: `let x = this.a.b
+let y = $x.uppercase()
+root.c = $y`,
+			input:  `{"a":{"b":"foo"}}`,
+			output: `{"c":"FOO"}`,
+		},
+		"used once as a path": {
+			mapping: `let x = this.a
+root.c = $x.b.uppercase()`,
+			input:  `{"a":{"b":"foo"}}`,
+			output: `{"c":"FOO"}`,
+		},
+		"used more than once": {
+			mapping: `let x = this.a.uppercase()
+root.b = $x + $x`,
+			input:    `{"a":"foo"}`,
+			output:   `{"b":"FOOFOO"}`,
+			assigned: []string{"x"},
+		},
+		"used in later statements": {
+			mapping: `let x = this.a.uppercase()
+root.b = $x
+root.c = $x`,
+			input:    `{"a":"foo"}`,
+			output:   `{"b":"FOO","c":"FOO"}`,
+			assigned: []string{"x"},
+		},
+		"used after other queries": {
+			mapping: `let x = this.a.uppercase()
+root.b = this.c + $x`,
+			input:    `{"a":"foo","c":"bar"}`,
+			output:   `{"b":"barFOO"}`,
+			assigned: []string{"x"},
+		},
+		"used within a lambda": {
+			mapping: `let x = this.a
+root.b = this.c.map_each(ele -> ele + $x)`,
+			input:    `{"a":1,"c":[1,2]}`,
+			output:   `{"b":[2,3]}`,
+			assigned: []string{"x"},
+		},
+		"used by name": {
+			mapping: `let x = this.a
+root.b = $x
+root.c = var("x")`,
+			input:    `{"a":"foo"}`,
+			output:   `{"b":"foo","c":"foo"}`,
+			assigned: []string{"x"},
+		},
+		"used conditionally": {
+			mapping: `let x = this.a
+if this.flip {
+  root.b = $x
+}`,
+			input:    `{"a":"foo","flip":true}`,
+			output:   `{"b":"foo"}`,
+			assigned: []string{"x"},
+		},
+		"impure functions execute in order": {
+			mapping: `let x = count("inlined_lets_test")
+root.a = $x
+root.b = count("inlined_lets_test")`,
+			input:  `{}`,
+			output: `{"a":1,"b":2}`,
+		},
+		"reassigned with nothing": {
+			mapping: `let x = this.a
+root.a = $x
+let x = if this.flip { "flipped" }
+root.b = $x`,
+			input:    `{"a":"foo","flip":false}`,
+			output:   `{"a":"foo","b":"foo"}`,
+			assigned: []string{"x"},
+		},
+		"reassigned with deleted": {
+			mapping: `let x = this.a
+root.a = $x
+let x = this.b.or(deleted())
+root.b = $x`,
+			input:    `{"a":"foo"}`,
+			assigned: []string{"x"},
+			err:      "failed assignment (line 4): variable 'x' undefined",
+		},
+		"errors from their assignment": {
+			mapping: `root.a = "foo"
+let x = this.a.number()
+root.b = $x.string()`,
+			input: `{"a":"nope"}`,
+			err:   "failed assignment (line 2): ",
+		},
+		"errors from the following statement": {
+			mapping: `let x = this.a
+root.b = $x.uppercase()`,
+			input: `{"a":5}`,
+			err:   "failed assignment (line 2): ",
+		},
+		"errors within catch": {
+			mapping: `let x = this.a.number()
+root.b = $x.catch(0)`,
+			input:    `{"a":"nope"}`,
+			err:      "failed assignment (line 1): ",
+			assigned: []string{"x"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			exec, perr := ParseMapping(GlobalContext(), test.mapping)
+			require.Nil(t, perr)
+
+			var assigned []string
+			for _, target := range exec.AssignmentTargets() {
+				if target.Type == mapping.TargetVariable {
+					assigned = append(assigned, target.Path[0])
+				}
+			}
+			assert.Equal(t, test.assigned, assigned)
+
+			resPart, err := exec.MapPart(0, message.QuickBatch([][]byte{[]byte(test.input)}))
+			if test.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.output, string(resPart.AsBytes()))
+		})
+	}
+}
+
 func BenchmarkMappingParser(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, err := ParseMapping(GlobalContext(), `
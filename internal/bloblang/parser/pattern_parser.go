@@ -0,0 +1,317 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
+	"github.com/redpanda-data/benthos/v4/internal/value"
+)
+
+var patternTypes = map[string]value.Type{
+	string(value.TString):    value.TString,
+	string(value.TBytes):     value.TBytes,
+	string(value.TNumber):    value.TNumber,
+	string(value.TBool):      value.TBool,
+	string(value.TTimestamp): value.TTimestamp,
+	string(value.TArray):     value.TArray,
+	string(value.TObject):    value.TObject,
+	string(value.TNull):      value.TNull,
+}
+
+// parsedPattern is the result of parsing a match case pattern along with the
+// names it binds.
+type parsedPattern struct {
+	pattern query.Pattern
+	names   []string
+}
+
+func (p *parsedPattern) absorb(child parsedPattern) {
+	p.names = append(p.names, child.names...)
+}
+
+func (p *parsedPattern) bindRest(name string) {
+	if name != "_" {
+		p.names = append(p.names, name)
+	}
+}
+
+var patternArrayDelim = Sequence(
+	Discard(SpacesAndTabs),
+	charComma,
+	DiscardedWhitespaceNewlineComments,
+)
+
+// matchPatternParser parses the pattern of a match case following the `let`
+// keyword, which at the top level is either an object pattern, an array
+// pattern or a named pattern. Number literals are excluded at the top level as
+// `let -1` is also a valid arithmetic query.
+func matchPatternParser(input []rune) Result[parsedPattern] {
+	switch {
+	case len(input) > 0 && input[0] == '{':
+		return objectPatternParser(input)
+	case len(input) > 0 && input[0] == '[':
+		return arrayPatternParser(input)
+	}
+	return namedPatternParser(input)
+}
+
+func elementPatternParser(input []rune) Result[parsedPattern] {
+	if len(input) > 0 {
+		switch input[0] {
+		case '{':
+			return objectPatternParser(input)
+		case '[':
+			return arrayPatternParser(input)
+		case '"':
+			res := QuotedString(input)
+			if res.Err != nil {
+				return Fail[parsedPattern](res.Err, input)
+			}
+			return Success(parsedPattern{pattern: query.NewLiteralPattern(res.Payload)}, res.Remaining)
+		}
+	}
+	if res := Number(input); res.Err == nil {
+		return Success(parsedPattern{pattern: query.NewLiteralPattern(res.Payload)}, res.Remaining)
+	}
+	return namedPatternParser(input)
+}
+
+// namedPatternParser parses a binding, a wildcard, a typed binding or a
+// boolean/null literal.
+func namedPatternParser(input []rune) Result[parsedPattern] {
+	res := contextNameParser(input)
+	if res.Err != nil {
+		return Fail[parsedPattern](res.Err, input)
+	}
+	name, remaining := res.Payload, res.Remaining
+
+	typeRes := Sequence(
+		Discard(SpacesAndTabs),
+		charColon,
+		Discard(SpacesAndTabs),
+		contextNameParser,
+	)(remaining)
+	if typeRes.Err != nil {
+		switch name {
+		case "true", "false":
+			return Success(parsedPattern{pattern: query.NewLiteralPattern(name == "true")}, remaining)
+		case "null":
+			return Success(parsedPattern{pattern: query.NewLiteralPattern(nil)}, remaining)
+		}
+		p := parsedPattern{pattern: query.NewBindingPattern(name, "")}
+		if name != "_" {
+			p.names = []string{name}
+		}
+		return Success(p, remaining)
+	}
+
+	typeName := typeRes.Payload[3]
+	t, exists := patternTypes[typeName]
+	if !exists {
+		var names []string
+		for k := range patternTypes {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		err := fmt.Errorf("unrecognised pattern type `%v`, expected one of: %v", typeName, strings.Join(names, ", "))
+		typeInput := remaining[len(remaining)-len(typeRes.Remaining)-len([]rune(typeName)):]
+		return Fail[parsedPattern](NewFatalError(typeInput, err), input)
+	}
+
+	p := parsedPattern{pattern: query.NewBindingPattern(name, t)}
+	if name != "_" {
+		p.names = []string{name}
+	}
+	return Success(p, typeRes.Remaining)
+}
+
+type patternElement struct {
+	parsedPattern
+	key      string
+	isRest   bool
+	restName string
+}
+
+func restPatternParser(input []rune) (Result[patternElement], bool) {
+	restRes := Term("...")(input)
+	if restRes.Err != nil {
+		return Result[patternElement]{}, false
+	}
+	nameRes := contextNameParser(restRes.Remaining)
+	if nameRes.Err != nil {
+		return Fail[patternElement](nameRes.Err, input), true
+	}
+	return Success(patternElement{isRest: true, restName: nameRes.Payload}, nameRes.Remaining), true
+}
+
+func objectPatternEntryParser(input []rune) Result[patternElement] {
+	if res, isRest := restPatternParser(input); isRest {
+		return res
+	}
+	res := Sequence(
+		FuncAsAny(QuotedString),
+		FuncAsAny(Discard(SpacesAndTabs)),
+		FuncAsAny(charColon),
+		FuncAsAny(DiscardedWhitespaceNewlineComments),
+		FuncAsAny(elementPatternParser),
+	)(input)
+	if res.Err != nil {
+		return Fail[patternElement](res.Err, input)
+	}
+	return Success(patternElement{
+		parsedPattern: res.Payload[4].(parsedPattern),
+		key:           res.Payload[0].(string),
+	}, res.Remaining)
+}
+
+func objectPatternParser(input []rune) Result[parsedPattern] {
+	res := DelimitedPattern(
+		Sequence(
+			charSquigOpen,
+			DiscardedWhitespaceNewlineComments,
+		),
+		objectPatternEntryParser,
+		patternArrayDelim,
+		Sequence(
+			DiscardedWhitespaceNewlineComments,
+			charSquigClose,
+		),
+	)(input)
+	if res.Err != nil {
+		return Fail[parsedPattern](res.Err, input)
+	}
+
+	var p parsedPattern
+	var hasRest bool
+	var restName string
+	keys := make([]string, 0, len(res.Payload))
+	values := make([]query.Pattern, 0, len(res.Payload))
+	for i, e := range res.Payload {
+		if e.isRest {
+			if i != len(res.Payload)-1 {
+				return Fail[parsedPattern](NewFatalError(input, fmt.Errorf("rest pattern `...%v` must be the last entry of an object pattern", e.restName)), input)
+			}
+			hasRest, restName = true, e.restName
+			p.bindRest(restName)
+			continue
+		}
+		keys = append(keys, e.key)
+		values = append(values, e.pattern)
+		p.absorb(e.parsedPattern)
+	}
+	p.pattern = query.NewObjectPattern(keys, values, hasRest, restName)
+	return Success(p, res.Remaining)
+}
+
+func arrayPatternElementParser(input []rune) Result[patternElement] {
+	if res, isRest := restPatternParser(input); isRest {
+		return res
+	}
+	res := elementPatternParser(input)
+	if res.Err != nil {
+		return Fail[patternElement](res.Err, input)
+	}
+	return Success(patternElement{parsedPattern: res.Payload}, res.Remaining)
+}
+
+func arrayPatternParser(input []rune) Result[parsedPattern] {
+	res := DelimitedPattern(
+		Sequence(
+			charSquareOpen,
+			DiscardedWhitespaceNewlineComments,
+		),
+		arrayPatternElementParser,
+		patternArrayDelim,
+		Sequence(
+			DiscardedWhitespaceNewlineComments,
+			charSquareClose,
+		),
+	)(input)
+	if res.Err != nil {
+		return Fail[parsedPattern](res.Err, input)
+	}
+
+	var p parsedPattern
+	var hasRest bool
+	var restName string
+	elements := make([]query.Pattern, 0, len(res.Payload))
+	for i, e := range res.Payload {
+		if e.isRest {
+			if i != len(res.Payload)-1 {
+				return Fail[parsedPattern](NewFatalError(input, fmt.Errorf("rest pattern `...%v` must be the last element of an array pattern", e.restName)), input)
+			}
+			hasRest, restName = true, e.restName
+			p.bindRest(restName)
+			continue
+		}
+		elements = append(elements, e.pattern)
+		p.absorb(e.parsedPattern)
+	}
+	p.pattern = query.NewArrayPattern(elements, hasRest, restName)
+	return Success(p, res.Remaining)
+}
+
+// patternMatchCaseParser attempts to parse a match case where the case is a
+// destructuring pattern of the form `let <pattern> => <query>`. The returned
+// boolean indicates whether the input was committed to as a pattern case, when
+// false the input should instead be parsed as a regular match case, which
+// preserves the meaning of cases that query a field named `let`.
+//
+// The prefix is required because bare patterns are ambiguous with existing
+// cases, where `{"type": "click"}` and `[first, rest]` are literals compared
+// against the context and names within them are queries of the context.
+func patternMatchCaseParser(pCtx Context, input []rune) (Result[query.MatchCase], bool) {
+	letRes := Sequence(
+		Term("let"),
+		SpacesAndTabs,
+	)(input)
+	if letRes.Err != nil {
+		return Result[query.MatchCase]{}, false
+	}
+
+	res := matchPatternParser(letRes.Remaining)
+	if res.Err != nil {
+		if res.Err.IsFatal() {
+			return Fail[query.MatchCase](res.Err, input), true
+		}
+		return Result[query.MatchCase]{}, false
+	}
+
+	arrowRes := Sequence(
+		Discard(SpacesAndTabs),
+		Term("=>"),
+		Discard(SpacesAndTabs),
+	)(res.Remaining)
+	if arrowRes.Err != nil {
+		return Result[query.MatchCase]{}, false
+	}
+
+	seen := map[string]struct{}{}
+	for _, name := range res.Payload.names {
+		if _, exists := seen[name]; exists {
+			return Fail[query.MatchCase](NewFatalError(input, fmt.Errorf("pattern binds the name `%v` more than once", name)), input), true
+		}
+		seen[name] = struct{}{}
+		if pCtx.HasNamedContext(name) {
+			return Fail[query.MatchCase](NewFatalError(input, fmt.Errorf("context label `%v` would shadow a parent context", name)), input), true
+		}
+		if name == "root" || name == "this" {
+			return Fail[query.MatchCase](NewFatalError(input, fmt.Errorf("context label `%v` is not allowed", name)), input), true
+		}
+	}
+
+	bodyCtx := pCtx
+	for _, name := range res.Payload.names {
+		bodyCtx = bodyCtx.WithNamedContext(name)
+	}
+
+	bodyRes := queryParser(bodyCtx)(arrowRes.Remaining)
+	if bodyRes.Err != nil {
+		return Fail[query.MatchCase](bodyRes.Err, input), true
+	}
+	return Success(query.NewPatternMatchCase(res.Payload.pattern, bodyRes.Payload), bodyRes.Remaining), true
+}
@@ -0,0 +1,200 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/internal/message"
+)
+
+func TestMatchPatterns(t *testing.T) {
+	tests := map[string]struct {
+		mapping string
+		input   string
+		output  string
+	}{
+		"object patterns": {
+			mapping: `root = this.events.map_each(e -> match e {
+  let {"type": "click", "user": u} => "click by " + u.name
+  let {"type": "view", "page": {"path": p}} => "view of " + p
+  _ => "other"
+})`,
+			input:  `{"events":[{"type":"click","user":{"name":"ash"}},{"type":"view","page":{"path":"/a"}},{"type":"click"},{"type":"view","page":"/b"},"nope"]}`,
+			output: `["click by ash","view of /a","other","other","other"]`,
+		},
+		"object patterns reject extra keys": {
+			mapping: `root = match {
+  let {"a": a, "b": _} => a
+  _ => "none"
+}`,
+			input:  `{"a":1,"b":null,"c":3}`,
+			output: `none`,
+		},
+		"object rest patterns": {
+			mapping: `root = this.values.map_each(v -> match v {
+  let {"a": a, ...others} => [a, others]
+  let {"b": b, ..._} => b
+  _ => "none"
+})`,
+			input:  `{"values":[{"a":1},{"a":2,"c":3,"d":4},{"b":5,"c":6},{"c":7}]}`,
+			output: `[[1,{}],[2,{"c":3,"d":4}],5,"none"]`,
+		},
+		"array patterns": {
+			mapping: `root = this.lists.map_each(l -> match l {
+  let [] => "empty"
+  let [only] => "one: " + only.string()
+  let [first, second] => "two: " + (first + second).string()
+  let [first, ...rest] => "many: " + first.string() + " then " + rest.length().string()
+})`,
+			input:  `{"lists":[[],[1],[1,2],[1,2,3,4]]}`,
+			output: `["empty","one: 1","two: 3","many: 1 then 3"]`,
+		},
+		"discarded rest": {
+			mapping: `root = match this {
+  let [{"type": t}, ..._] => t
+  _ => "none"
+}`,
+			input:  `[{"type":"a"},{"type":"b"}]`,
+			output: `a`,
+		},
+		"type patterns": {
+			mapping: `root = this.values.map_each(v -> match v {
+  let n: number => n * 2
+  let s: string => s.uppercase()
+  let [_: number, ...ns] => ns
+  let _: null => "null"
+  _ => "other"
+})`,
+			input:  `{"values":[2,"foo",[1,2,3],null,true,["a"]]}`,
+			output: `[4,"FOO",[2,3],"null","other","other"]`,
+		},
+		"literal values in patterns": {
+			mapping: `root = this.values.map_each(v -> match v {
+  let [true, x] => "true " + x
+  let [null, x] => "null " + x
+  let [5, x] => "five " + x
+  let [-1.5, x] => "neg " + x
+  _ => "other"
+})`,
+			input:  `{"values":[[true,"a"],[null,"b"],[5,"c"],[-1.5,"d"],[false,"e"]]}`,
+			output: `["true a","null b","five c","neg d","other"]`,
+		},
+		"literal object cases remain exact": {
+			mapping: `root = match this {
+  let {"a": 1} => "exact"
+  _ => "not exact"
+}`,
+			input:  `{"a":1,"b":2}`,
+			output: `not exact`,
+		},
+		"literal collections remain comparisons": {
+			mapping: `root = match this {
+  [a, b].contains(3) => "array"
+  {"kind": kind}.kind == "foo" => "object"
+  _ => "none"
+}`,
+			input:  `{"a":1,"b":2,"kind":"foo"}`,
+			output: `object`,
+		},
+		"fields named let remain field queries": {
+			mapping: `root = match this {
+  let => "let"
+  let == false => "not let"
+  _ => "none"
+}`,
+			input:  `{"let":false}`,
+			output: `not let`,
+		},
+		"bare names remain field queries": {
+			mapping: `root = match this {
+  enabled => "enabled"
+  _ => "disabled"
+}`,
+			input:  `{"enabled":true}`,
+			output: `enabled`,
+		},
+		"bindings do not leak between cases": {
+			mapping: `root = match this {
+  let {"a": x: string, ..._} => x
+  let {"b": x, ..._} => x
+}`,
+			input:  `{"a":1,"b":"two"}`,
+			output: `two`,
+		},
+		"bindings within lambdas": {
+			mapping: `root = this.items.map_each(item -> match item {
+  let {"tags": [tag, ..._], ..._} => tag + item.suffix
+  _ => deleted()
+})`,
+			input:  `{"items":[{"tags":["a","b"],"suffix":"!"},{"tags":[]},{"tags":["c"],"suffix":"!"}]}`,
+			output: `["a!","c!"]`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			exec, perr := ParseMapping(GlobalContext(), test.mapping)
+			require.Nil(t, perr)
+
+			resPart, err := exec.MapPart(0, message.QuickBatch([][]byte{[]byte(test.input)}))
+			require.NoError(t, err)
+			assert.Equal(t, test.output, string(resPart.AsBytes()))
+		})
+	}
+}
+
+func TestMatchPatternErrors(t *testing.T) {
+	tests := map[string]struct {
+		mapping string
+		err     string
+	}{
+		"unknown type": {
+			mapping: `root = match this {
+  let n: integer => n
+}`,
+			err: "line 2 char 10: unrecognised pattern type `integer`, expected one of: array, bool, bytes, null, number, object, string, timestamp",
+		},
+		"duplicate names": {
+			mapping: `root = match this {
+  let [x, x] => x
+}`,
+			err: "line 2 char 3: pattern binds the name `x` more than once",
+		},
+		"shadowed names": {
+			mapping: `root = this.map_each(x -> match x {
+  let [x] => x
+})`,
+			err: "line 2 char 3: context label `x` would shadow a parent context",
+		},
+		"reserved names": {
+			mapping: `root = match this {
+  let {"a": this} => this
+}`,
+			err: "line 2 char 3: context label `this` is not allowed",
+		},
+		"rest not last": {
+			mapping: `root = match this {
+  let [...xs, x] => x
+}`,
+			err: "line 2 char 7: rest pattern `...xs` must be the last element of an array pattern",
+		},
+		"object rest not last": {
+			mapping: `root = match this {
+  let {...xs, "a": x} => x
+}`,
+			err: "line 2 char 7: rest pattern `...xs` must be the last entry of an object pattern",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, perr := ParseMapping(GlobalContext(), test.mapping)
+			require.NotNil(t, perr)
+			assert.Equal(t, test.err, perr.ErrorAtPosition([]rune(test.mapping)))
+		})
+	}
+}
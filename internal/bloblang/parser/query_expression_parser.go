@@ -36,6 +36,10 @@ func matchCaseParser(pCtx Context) Func[query.MatchCase] {
 	)
 
 	return func(input []rune) Result[query.MatchCase] {
+		if patternRes, committed := patternMatchCaseParser(pCtx, input); committed {
+			return patternRes
+		}
+
 		res := p(input)
 		if res.Err != nil {
 			return Fail[query.MatchCase](res.Err, input)
@@ -199,12 +199,21 @@ var variableReferencePattern = Expect(
 	"variable path",
 )
 
-func variableReferenceParser(input []rune) Result[query.Function] {
-	res := variableReferencePattern(input)
-	if res.Err != nil {
-		return Fail[query.Function](res.Err, input)
+func variableReferenceParser(pCtx Context) Func[query.Function] {
+	return func(input []rune) Result[query.Function] {
+		res := variableReferencePattern(input)
+		if res.Err != nil {
+			return Fail[query.Function](res.Err, input)
+		}
+		name := res.Payload[1]
+		if v, isConst := pCtx.lets.get(name); isConst {
+			return Success(query.NewInlinedVarFunction(name, v), res.Remaining)
+		}
+		if ref, isCandidate := pCtx.lets.reference(name); isCandidate {
+			return Success(ref, res.Remaining)
+		}
+		return Success(query.NewVarFunction(name), res.Remaining)
 	}
-	return Success(query.NewVarFunction(res.Payload[1]), res.Remaining)
 }
 
 var metadataReferencePattern = Expect(
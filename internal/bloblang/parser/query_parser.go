@@ -16,7 +16,7 @@ func queryParser(pCtx Context) Func[query.Function] {
 			literalValueParser(pCtx),
 			functionParser(pCtx),
 			metadataReferenceParser,
-			variableReferenceParser,
+			variableReferenceParser(pCtx),
 			fieldReferenceRootParser(pCtx),
 		),
 		"query",
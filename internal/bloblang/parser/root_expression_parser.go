@@ -8,6 +8,7 @@ import (
 )
 
 func rootLevelIfExpressionParser(pCtx Context) Func[mapping.Statement] {
+	bodyCtx := pCtx.withConditionalLets()
 	return func(input []rune) Result[mapping.Statement] {
 		ifParser := Sequence(
 			FuncAsAny(Expect(Term("if"), "assignment")),
@@ -19,7 +20,7 @@ func rootLevelIfExpressionParser(pCtx Context) Func[mapping.Statement] {
 					charSquigOpen,
 					DiscardedWhitespaceNewlineComments,
 				),
-				mappingStatement(pCtx, true, nil),
+				mappingStatement(bodyCtx, true, nil),
 				Sequence(
 					Discard(SpacesAndTabs),
 					NewlineAllowComment,
@@ -43,7 +44,7 @@ func rootLevelIfExpressionParser(pCtx Context) Func[mapping.Statement] {
 					charSquigOpen,
 					DiscardedWhitespaceNewlineComments,
 				),
-				mappingStatement(pCtx, true, nil),
+				mappingStatement(bodyCtx, true, nil),
 				Sequence(
 					Discard(SpacesAndTabs),
 					NewlineAllowComment,
@@ -65,7 +66,7 @@ func rootLevelIfExpressionParser(pCtx Context) Func[mapping.Statement] {
 					charSquigOpen,
 					DiscardedWhitespaceNewlineComments,
 				),
-				mappingStatement(pCtx, true, nil),
+				mappingStatement(bodyCtx, true, nil),
 				Sequence(
 					Discard(SpacesAndTabs),
 					NewlineAllowComment,
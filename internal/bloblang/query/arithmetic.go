@@ -74,6 +74,9 @@ func arithmeticFunc[T any](lhs, rhs Function, op arithmeticOpFunc[T]) (Function,
 			return NewLiteralFunction(annotation, res), nil
 		}
 	}
+	if folded, ok := foldArithmetic(annotation, lhs, rhs, op); ok {
+		return folded, nil
+	}
 
 	return ClosureFunction(annotation, func(ctx FunctionContext) (any, error) {
 		var err error
@@ -95,24 +98,32 @@ func arithmeticFunc[T any](lhs, rhs Function, op arithmeticOpFunc[T]) (Function,
 var ErrDivideByZero = errors.New("attempted to divide by zero")
 
 type (
-	intArithmeticFunc[T any]   func(left, right int64) (T, error)
-	uintArithmeticFunc[T any]  func(left, right uint64) (T, error)
-	floatArithmeticFunc[T any] func(left, right float64) (T, error)
+	intArithmeticFunc[T any]     func(left, right int64) (T, error)
+	uintArithmeticFunc[T any]    func(left, right uint64) (T, error)
+	floatArithmeticFunc[T any]   func(left, right float64) (T, error)
+	decimalArithmeticFunc[T any] func(left, right value.Decimal) (T, error)
 )
 
-// Takes two arithmetic funcs, one for integer values and one for float values
-// and returns a generic arithmetic func. If both values can be represented as
-// integers the integer func is called, otherwise the float func is called.
+// Takes arithmetic funcs for unsigned integer, integer, float and decimal
+// values and returns a generic arithmetic func. If either value is a decimal
+// the decimal func is called, if both values can be represented as integers
+// an integer func is called, otherwise the float func is called.
 func numberDegradationFunc[T any](
 	op ArithmeticOperator,
 	uiFn uintArithmeticFunc[T],
 	iFn intArithmeticFunc[T],
 	fFn floatArithmeticFunc[T],
+	dFn decimalArithmeticFunc[T],
 ) arithmeticOpFunc[T] {
 	return func(lhs, rhs Function, left, right any) (t T, err error) {
 		left = value.ISanitize(left)
 		right = value.ISanitize(right)
 
+		// Decimals take precedence so that calculations remain exact.
+		if leftDec, rightDec, isDec := value.DecimalOperands(left, right); isDec {
+			return dFn(leftDec, rightDec)
+		}
+
 		// If either value is a float then we degrade into a float calculation.
 		if leftFloat, leftIsFloat := left.(float64); leftIsFloat {
 			rightFloat, err := value.IGetNumber(right)
@@ -173,10 +184,19 @@ func prodOp(op ArithmeticOperator) (arithmeticOpFunc[any], bool) {
 			func(lhs, rhs float64) (any, error) {
 				return lhs * rhs, nil
 			},
+			func(lhs, rhs value.Decimal) (any, error) {
+				return lhs.Mul(rhs)
+			},
 		), true
 	case ArithmeticDiv:
-		// Only executes on float values.
+		// Only executes on float or decimal values.
 		return func(lFn, rFn Function, left, right any) (any, error) {
+			if lhs, rhs, isDec := value.DecimalOperands(left, right); isDec {
+				if rhs.Sign() == 0 {
+					return nil, fmt.Errorf("decimal division: %w", ErrDivideByZero)
+				}
+				return lhs.Quo(rhs)
+			}
 			lhs, err := value.IGetNumber(left)
 			if err != nil {
 				return nil, NewTypeMismatch(op.String(), lFn, rFn, left, right)
@@ -191,8 +211,14 @@ func prodOp(op ArithmeticOperator) (arithmeticOpFunc[any], bool) {
 			return lhs / rhs, nil
 		}, true
 	case ArithmeticMod:
-		// Only executes on integer values.
+		// Only executes on integer or decimal values.
 		return func(lFn, rFn Function, left, right any) (any, error) {
+			if lhs, rhs, isDec := value.DecimalOperands(left, right); isDec {
+				if rhs.Sign() == 0 {
+					return nil, fmt.Errorf("decimal modulo: %w", ErrDivideByZero)
+				}
+				return lhs.Rem(rhs)
+			}
 			lhs, err := value.IGetInt(left)
 			if err != nil {
 				return nil, NewTypeMismatch(op.String(), lFn, rFn, left, right)
@@ -223,10 +249,13 @@ func sumOp(op ArithmeticOperator) (arithmeticOpFunc[any], bool) {
 			func(left, right float64) (any, error) {
 				return left + right, nil
 			},
+			func(left, right value.Decimal) (any, error) {
+				return left.Add(right)
+			},
 		)
 		return func(lFn, rFn Function, left, right any) (any, error) {
 			switch left.(type) {
-			case float64, int, int64, uint64, json.Number:
+			case float64, int, int64, uint64, json.Number, value.Decimal:
 				return numberAdd(lFn, rFn, left, right)
 			case string, []byte:
 				lhs, err := value.IGetString(left)
@@ -252,6 +281,9 @@ func sumOp(op ArithmeticOperator) (arithmeticOpFunc[any], bool) {
 			func(lhs, rhs float64) (any, error) {
 				return lhs - rhs, nil
 			},
+			func(lhs, rhs value.Decimal) (any, error) {
+				return lhs.Sub(rhs)
+			},
 		), true
 	}
 	return nil, false
@@ -338,6 +370,8 @@ func compareOp(op ArithmeticOperator) (arithmeticOpFunc[bool], bool) {
 		return intCompareFn(left, right), nil
 	}, func(left, right float64) (bool, error) {
 		return floatCompareFn(left, right), nil
+	}, func(left, right value.Decimal) (bool, error) {
+		return intCompareFn(int64(left.Cmp(right)), 0), nil
 	})
 
 	boolOpFn := compareBoolFn(op)
@@ -25,6 +25,9 @@ func TestArithmeticNumberDegradation(t *testing.T) {
 		func(left, right float64) (any, error) {
 			return left / right, nil
 		},
+		func(left, right value.Decimal) (any, error) {
+			return left.Quo(right)
+		},
 	)
 
 	testCases := []struct {
@@ -82,6 +85,24 @@ func TestArithmeticNumberDegradation(t *testing.T) {
 			right:  json.Number("3"),
 			result: 4.0,
 		},
+		{
+			name:   "left is decimal",
+			left:   mustDecimal(t, "12.50"),
+			right:  int64(5),
+			result: mustDecimal(t, "2.50"),
+		},
+		{
+			name:   "right is decimal",
+			left:   0.1,
+			right:  mustDecimal(t, "0.02"),
+			result: mustDecimal(t, "5"),
+		},
+		{
+			name:  "decimal and string",
+			left:  mustDecimal(t, "1"),
+			right: "not a number",
+			err:   "cannot add types number (from left) and string (from right)",
+		},
 		{
 			name:  "left is invalid int",
 			left:  "not a number",
@@ -122,6 +143,13 @@ func TestArithmeticNumberDegradation(t *testing.T) {
 	}
 }
 
+func mustDecimal(t testing.TB, s string) value.Decimal {
+	t.Helper()
+	d, err := value.NewDecimalFromString(s)
+	require.NoError(t, err)
+	return d
+}
+
 func TestArithmeticComparisons(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -131,6 +159,34 @@ func TestArithmeticComparisons(t *testing.T) {
 		result      any
 		errContains string
 	}{
+		{
+			name:   "decimals beyond float precision",
+			left:   mustDecimal(t, "0.10000000000000000001"),
+			right:  mustDecimal(t, "0.1"),
+			op:     ArithmeticGt,
+			result: true,
+		},
+		{
+			name:   "decimal equal to int",
+			left:   mustDecimal(t, "2.00"),
+			right:  int64(2),
+			op:     ArithmeticEq,
+			result: true,
+		},
+		{
+			name:   "float less than decimal",
+			left:   1.5,
+			right:  mustDecimal(t, "1.50000000000000000001"),
+			op:     ArithmeticLt,
+			result: true,
+		},
+		{
+			name:   "decimal not equal to string",
+			left:   mustDecimal(t, "1"),
+			right:  "1",
+			op:     ArithmeticNeq,
+			result: true,
+		},
 		{
 			name:   "left int64 to right int64",
 			left:   int64(1780921717355446273),
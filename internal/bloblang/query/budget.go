@@ -0,0 +1,207 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package query
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Budget describes limits on the resources that a single execution of a
+// mapping is allowed to consume. A zero value for any field means that the
+// resource is not limited.
+type Budget struct {
+	// MaxOperations is the maximum number of functions, methods, operators and
+	// iterations executed.
+	MaxOperations int64
+
+	// MaxAllocatedBytes is the maximum number of bytes that values created
+	// during the execution are estimated to occupy.
+	MaxAllocatedBytes int64
+
+	// MaxRecursionDepth is the maximum number of nested map executions.
+	MaxRecursionDepth int
+
+	// Timeout is the maximum wall-clock time of the execution.
+	Timeout time.Duration
+}
+
+// IsZero returns true if the budget does not limit any resources.
+func (b Budget) IsZero() bool {
+	return b == Budget{}
+}
+
+// BudgetResource identifies a resource limited by a Budget.
+type BudgetResource string
+
+// Resources limited by a Budget.
+const (
+	BudgetResourceOperations     BudgetResource = "operations"
+	BudgetResourceAllocatedBytes BudgetResource = "allocated bytes"
+	BudgetResourceRecursionDepth BudgetResource = "recursion depth"
+	BudgetResourceTimeout        BudgetResource = "timeout"
+)
+
+// ErrBudgetExceeded is matched by all errors caused by the execution of a
+// mapping exceeding its budget.
+var ErrBudgetExceeded = errors.New("execution budget exceeded")
+
+// BudgetExceededError is returned when the execution of a mapping exceeds a
+// limit of its budget.
+type BudgetExceededError struct {
+	Resource BudgetResource
+	Limit    string
+}
+
+// Error implements the standard error interface.
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("%v: %v limit of %v reached", ErrBudgetExceeded, e.Resource, e.Limit)
+}
+
+// Is allows the error to be matched against ErrBudgetExceeded.
+func (e *BudgetExceededError) Is(target error) bool {
+	return target == ErrBudgetExceeded
+}
+
+//------------------------------------------------------------------------------
+
+// The deadline of a budget is only checked periodically in order to avoid
+// reading the clock for every operation.
+const budgetDeadlineCheckInterval = 64
+
+// BudgetTracker tracks the resources consumed by a single execution of a
+// mapping against a Budget. Once a limit has been exceeded all subsequent
+// operations fail, which ensures that an exceeded budget cannot be recovered
+// from within a mapping by catching the error.
+//
+// A BudgetTracker must not be used by multiple goroutines.
+type BudgetTracker struct {
+	budget   Budget
+	deadline time.Time
+
+	ops   int64
+	bytes int64
+	err   error
+}
+
+// NewBudgetTracker creates a tracker for a single execution of a mapping,
+// returns nil if the budget does not limit any resources.
+func NewBudgetTracker(b Budget) *BudgetTracker {
+	if b.IsZero() {
+		return nil
+	}
+	t := &BudgetTracker{budget: b}
+	if b.Timeout > 0 {
+		t.deadline = time.Now().Add(b.Timeout)
+	}
+	return t
+}
+
+// Err returns the error that caused the budget to be exceeded, or nil if it has
+// not been exceeded.
+func (t *BudgetTracker) Err() error {
+	if t == nil {
+		return nil
+	}
+	return t.err
+}
+
+func (t *BudgetTracker) fail(resource BudgetResource, limit any) error {
+	t.err = &BudgetExceededError{Resource: resource, Limit: fmt.Sprintf("%v", limit)}
+	return t.err
+}
+
+// operation records a single operation.
+func (t *BudgetTracker) operation() error {
+	if t == nil {
+		return nil
+	}
+	if t.err != nil {
+		return t.err
+	}
+	t.ops++
+	if limit := t.budget.MaxOperations; limit > 0 && t.ops > limit {
+		return t.fail(BudgetResourceOperations, limit)
+	}
+	if !t.deadline.IsZero() && t.ops%budgetDeadlineCheckInterval == 0 && time.Now().After(t.deadline) {
+		return t.fail(BudgetResourceTimeout, t.budget.Timeout)
+	}
+	return nil
+}
+
+// allocate records an estimated number of bytes being allocated.
+func (t *BudgetTracker) allocate(n int64) error {
+	if t == nil {
+		return nil
+	}
+	if t.err != nil {
+		return t.err
+	}
+	t.bytes += n
+	if limit := t.budget.MaxAllocatedBytes; limit > 0 && t.bytes > limit {
+		return t.fail(BudgetResourceAllocatedBytes, limit)
+	}
+	return nil
+}
+
+// reserve returns an error if allocating a number of bytes would exceed the
+// budget, without recording the allocation. This allows functions to fail
+// before performing large allocations.
+func (t *BudgetTracker) reserve(n int64) error {
+	if t == nil {
+		return nil
+	}
+	if t.err != nil {
+		return t.err
+	}
+	if limit := t.budget.MaxAllocatedBytes; limit > 0 && t.bytes+n > limit {
+		return t.fail(BudgetResourceAllocatedBytes, limit)
+	}
+	return nil
+}
+
+// CheckRecursionDepth returns an error if a given depth of nested map
+// executions exceeds the budget.
+func (t *BudgetTracker) CheckRecursionDepth(depth int) error {
+	if t == nil {
+		return nil
+	}
+	if t.err != nil {
+		return t.err
+	}
+	if limit := t.budget.MaxRecursionDepth; limit > 0 && depth > limit {
+		return t.fail(BudgetResourceRecursionDepth, limit)
+	}
+	return nil
+}
+
+// Size estimates for values created during execution, these do not need to be
+// precise and only need to scale with the size of the value.
+const (
+	budgetArrayElementSize  = 16
+	budgetObjectElementSize = 48
+)
+
+// allocatedSize estimates the shallow size of a value.
+func allocatedSize(v any) int64 {
+	switch t := v.(type) {
+	case string:
+		return int64(len(t))
+	case []byte:
+		return int64(len(t))
+	case []any:
+		return int64(len(t)) * budgetArrayElementSize
+	case map[string]any:
+		return int64(len(t)) * budgetObjectElementSize
+	}
+	return 0
+}
+
+// accountResult records an operation that produced a value.
+func (t *BudgetTracker) accountResult(v any) error {
+	if err := t.operation(); err != nil {
+		return err
+	}
+	return t.allocate(allocatedSize(v))
+}
@@ -95,6 +95,29 @@ func NewTypeMismatch(operation string, lfn, rfn Function, left, right any) *Type
 
 //------------------------------------------------------------------------------
 
+// ErrInlinedVar is an error returned by the query of a variable assignment
+// that has been inlined into the statement referencing the variable. The input
+// of the original assignment is retained so that the error can be reported
+// from the assignment rather than the statement it was inlined into.
+type ErrInlinedVar struct {
+	Input []rune
+	Err   error
+}
+
+var _ error = &ErrInlinedVar{}
+
+// Error returns the underlying error message.
+func (e *ErrInlinedVar) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error value.
+func (e *ErrInlinedVar) Unwrap() error {
+	return e.Err
+}
+
+//------------------------------------------------------------------------------
+
 // ComponentError is an error that could be returned by a component annotated by
 // its label and path.
 type ComponentError struct {
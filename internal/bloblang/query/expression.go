@@ -12,6 +12,7 @@ import (
 // query is checked and, if true, the underlying query is executed and returned.
 type MatchCase struct {
 	caseFn  Function
+	pattern Pattern
 	queryFn Function
 }
 
@@ -24,6 +25,16 @@ func NewMatchCase(caseFn, queryFn Function) MatchCase {
 	}
 }
 
+// NewPatternMatchCase creates a single match case of a match expression, where
+// the context value is checked against a pattern and, if it matches, the
+// underlying query is executed with the names bound by the pattern.
+func NewPatternMatchCase(pattern Pattern, queryFn Function) MatchCase {
+	return MatchCase{
+		pattern: pattern,
+		queryFn: queryFn,
+	}
+}
+
 // NewMatchFunction takes a contextual mapping and a list of MatchCases, when
 // the function is executed.
 func NewMatchFunction(contextFn Function, cases ...MatchCase) Function {
@@ -43,6 +54,12 @@ func NewMatchFunction(contextFn Function, cases ...MatchCase) Function {
 		}
 		for i, c := range cases {
 			caseCtx := ctx.WithValue(ctxVal)
+			if c.pattern != nil {
+				if boundCtx, matched := c.pattern.Match(ctxVal, caseCtx); matched {
+					return c.queryFn.Exec(boundCtx)
+				}
+				continue
+			}
 			var caseVal any
 			if caseVal, err = c.caseFn.Exec(caseCtx); err != nil {
 				return nil, fmt.Errorf("failed to check match case %v: %w", i, err)
@@ -58,11 +75,16 @@ func NewMatchFunction(contextFn Function, cases ...MatchCase) Function {
 
 		var targets []TargetPath
 		for _, c := range cases {
-			_, caseTargets := c.caseFn.QueryTargets(contextCtx)
-			targets = append(targets, caseTargets...)
+			queryCtx := contextCtx
+			if c.pattern != nil {
+				queryCtx = patternTargetsContext(queryCtx, contextTargets, c.pattern)
+			} else {
+				_, caseTargets := c.caseFn.QueryTargets(contextCtx)
+				targets = append(targets, caseTargets...)
+			}
 
 			// TODO: Include new current targets in returned context
-			_, queryTargets := c.queryFn.QueryTargets(contextCtx)
+			_, queryTargets := c.queryFn.QueryTargets(queryCtx)
 			targets = append(targets, queryTargets...)
 		}
 
@@ -71,6 +93,17 @@ func NewMatchFunction(contextFn Function, cases ...MatchCase) Function {
 	})
 }
 
+func patternTargetsContext(ctx TargetsContext, contextTargets []TargetPath, pattern Pattern) TargetsContext {
+	for _, b := range pattern.Bindings() {
+		paths := make([]TargetPath, len(contextTargets))
+		for i, t := range contextTargets {
+			paths[i] = NewTargetPath(t.Type, append(append([]string{}, t.Path...), b.Path...)...)
+		}
+		ctx = ctx.withNamedPaths(b.Name, paths)
+	}
+	return ctx
+}
+
 // ElseIf represents an else-if block in an if expression.
 type ElseIf struct {
 	QueryFn Function
@@ -338,6 +338,25 @@ func TestExpressionTargets(t *testing.T) {
 				NewTargetPath(TargetValue, "baz"),
 			},
 		},
+		"match pattern bindings": {
+			input: NewMatchFunction(
+				NewFieldFunction("foo"),
+				NewPatternMatchCase(
+					NewObjectPattern([]string{"bar"}, []Pattern{
+						NewArrayPattern([]Pattern{NewBindingPattern("first", "")}, true, "rest"),
+					}, false, ""),
+					NewArrayLiteral(
+						NewNamedContextFieldFunction("first", "baz"),
+						NewNamedContextFieldFunction("rest", ""),
+					).(Function),
+				),
+			),
+			output: []TargetPath{
+				NewTargetPath(TargetValue, "foo", "bar", "0", "baz"),
+				NewTargetPath(TargetValue, "foo", "bar"),
+				NewTargetPath(TargetValue, "foo"),
+			},
+		},
 		"if query path": {
 			input: NewIfFunction(
 				mustFunc(InitFunctionHelper("json", "foo.bar")),
@@ -55,7 +55,20 @@ func (f closureFunction) Annotation() string {
 
 // Exec the underlying closure.
 func (f closureFunction) Exec(ctx FunctionContext) (any, error) {
-	return f.exec(ctx)
+	if ctx.budget == nil {
+		return f.exec(ctx)
+	}
+	if err := ctx.budget.operation(); err != nil {
+		return nil, err
+	}
+	v, err := f.exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.budget.allocate(allocatedSize(v)); err != nil {
+		return nil, err
+	}
+	return v, nil
 }
 
 // QueryTargets returns nothing.
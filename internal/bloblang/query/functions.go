@@ -24,6 +24,9 @@ type fieldFunction struct {
 	namedContext string
 	fromRoot     bool
 	path         []string
+
+	// Dot path keys of each prefix of the path, used for hoisted lookups.
+	prefixKeys []string
 }
 
 func (f *fieldFunction) expand(path ...string) *fieldFunction {
@@ -32,6 +35,9 @@ func (f *fieldFunction) expand(path ...string) *fieldFunction {
 	newPath = append(newPath, f.path...)
 	newPath = append(newPath, path...)
 	newFn.path = newPath
+	if !newFn.fromRoot && newFn.namedContext == "" {
+		newFn.prefixKeys = dotPathPrefixKeys(newPath)
+	}
 	return &newFn
 }
 
@@ -67,6 +73,11 @@ func (f *fieldFunction) Exec(ctx FunctionContext) (any, error) {
 			}
 		}
 		target = *v
+		if ctx.value == nil && ctx.pathCache != nil && len(f.prefixKeys) > 0 {
+			if res, ok := ctx.pathCache.lookup(target, f.path, f.prefixKeys); ok {
+				return res, nil
+			}
+		}
 	} else {
 		var ok bool
 		if target, ok = ctx.NamedValue(f.namedContext); !ok {
@@ -121,7 +132,8 @@ func NewFieldFunction(pathStr string) Function {
 		path = gabs.DotPathToSlice(pathStr)
 	}
 	return &fieldFunction{
-		path: path,
+		path:       path,
+		prefixKeys: dotPathPrefixKeys(path),
 	}
 }
 
@@ -213,7 +225,7 @@ var _ = registerSimpleFunction(
 
 //------------------------------------------------------------------------------
 
-var _ = registerSimpleFunction(
+var _ = registerFunction(
 	NewFunctionSpec(
 		FunctionCategoryMessage, "content",
 		"Returns the full raw contents of the mapping target message as a byte array. When mapping to a JSON field the value should be encoded using the method xref:guides:bloblang/methods.adoc#encode[`encode`], or cast to a string directly using the method xref:guides:bloblang/methods.adoc#string[`string`], otherwise it will be base64 encoded by default.",
@@ -223,11 +235,27 @@ var _ = registerSimpleFunction(
 			`{"doc":"{\"foo\":\"bar\"}"}`,
 		),
 	),
-	func(ctx FunctionContext) (any, error) {
-		return ctx.MsgBatch.Get(ctx.Index).AsBytes(), nil
+	func(*ParsedParams) (Function, error) {
+		return contentFunction{}, nil
 	},
 )
 
+// contentFunction is a distinct type so that methods are able to identify it as
+// their target and access the structured form of the message when cached.
+type contentFunction struct{}
+
+func (contentFunction) Annotation() string {
+	return "function content"
+}
+
+func (contentFunction) Exec(ctx FunctionContext) (any, error) {
+	return ctx.MsgBatch.Get(ctx.Index).AsBytes(), nil
+}
+
+func (contentFunction) QueryTargets(ctx TargetsContext) (TargetsContext, []TargetPath) {
+	return ctx, nil
+}
+
 //------------------------------------------------------------------------------
 
 var _ = registerSimpleFunction(
@@ -463,11 +491,16 @@ func rangeFunction(args *ParsedParams) (Function, error) {
 	} else if start >= stop {
 		return nil, fmt.Errorf("with positive step arg start (%v) must be < stop (%v)", start, stop)
 	}
-	r := make([]any, (stop-start)/step)
-	for i := 0; i < len(r); i++ {
-		r[i] = start + step*int64(i)
-	}
+	n := (stop - start) / step
 	return ClosureFunction("function range", func(ctx FunctionContext) (any, error) {
+		// Ranges can be huge and so the budget is checked before allocating.
+		if err := ctx.budget.reserve(n * budgetArrayElementSize); err != nil {
+			return nil, err
+		}
+		r := make([]any, n)
+		for i := 0; i < len(r); i++ {
+			r[i] = start + step*int64(i)
+		}
 		return r, nil
 	}, nil), nil
 }
@@ -81,6 +81,19 @@ func drainIter(iter Iterator) ([]any, error) {
 	}
 }
 
+// drainIterBudgeted drains an iterator and accounts for the resulting array
+// against the budget of the execution.
+func drainIterBudgeted(ctx FunctionContext, iter Iterator) (any, error) {
+	arr, err := drainIter(iter)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.budget.allocate(allocatedSize(arr)); err != nil {
+		return nil, err
+	}
+	return arr, nil
+}
+
 type closureIterator struct {
 	next func() (any, error)
 	len  func() (int, bool)
@@ -141,6 +154,9 @@ func (f *filterMethod) TryIterate(ctx FunctionContext) (Iterator, any, error) {
 					}
 					return nil, err
 				}
+				if err := ctx.budget.operation(); err != nil {
+					return nil, err
+				}
 				f, err := f.mapFn.Exec(ctx.WithValue(v))
 				if err != nil {
 					return nil, err
@@ -162,6 +178,9 @@ func (f *filterMethod) execFallback(ctx FunctionContext, res any) (any, error) {
 	}
 	newMap := make(map[string]any, len(m))
 	for k, v := range m {
+		if err := ctx.budget.operation(); err != nil {
+			return nil, err
+		}
 		var ctxMap any = map[string]any{
 			"key":   k,
 			"value": v,
@@ -182,7 +201,7 @@ func (f *filterMethod) Exec(ctx FunctionContext) (any, error) {
 	if err != nil || res != nil {
 		return res, err
 	}
-	return drainIter(iter)
+	return drainIterBudgeted(ctx, iter)
 }
 
 func (f *filterMethod) QueryTargets(ctx TargetsContext) (TargetsContext, []TargetPath) {
@@ -234,6 +253,9 @@ func (m *mapEachMethod) TryIterate(ctx FunctionContext) (Iterator, any, error) {
 					return nil, err
 				}
 
+				if err := ctx.budget.operation(); err != nil {
+					return nil, err
+				}
 				newV, err := m.mapFn.Exec(ctx.WithValue(v))
 				if err != nil {
 					return nil, ErrFrom(err, m.mapFn)
@@ -257,6 +279,9 @@ func (m *mapEachMethod) execFallback(ctx FunctionContext, res any) (any, error)
 	}
 	newMap := make(map[string]any, len(resMap))
 	for k, v := range resMap {
+		if err := ctx.budget.operation(); err != nil {
+			return nil, err
+		}
 		var ctxMap any = map[string]any{
 			"key":   k,
 			"value": v,
@@ -281,7 +306,7 @@ func (m *mapEachMethod) Exec(ctx FunctionContext) (any, error) {
 	if err != nil || res != nil {
 		return res, err
 	}
-	return drainIter(iter)
+	return drainIterBudgeted(ctx, iter)
 }
 
 func (m *mapEachMethod) QueryTargets(ctx TargetsContext) (TargetsContext, []TargetPath) {
@@ -109,6 +109,9 @@ func (m *mapLiteral) Exec(ctx FunctionContext) (any, error) {
 			dynMap[key] = val
 		}
 	}
+	if err := ctx.budget.accountResult(dynMap); err != nil {
+		return nil, err
+	}
 	return dynMap, nil
 }
 
@@ -182,6 +185,9 @@ func (a *arrayLiteral) Exec(ctx FunctionContext) (any, error) {
 			dynArray = append(dynArray, v)
 		}
 	}
+	if err := ctx.budget.accountResult(dynArray); err != nil {
+		return nil, err
+	}
 	return dynArray, nil
 }
 
@@ -20,20 +20,41 @@ func registerSimpleMethod(spec MethodSpec, ctor simpleMethodConstructor) struct{
 		if err != nil {
 			return nil, err
 		}
-		return ClosureFunction("method "+spec.Name, func(ctx FunctionContext) (any, error) {
-			v, err := target.Exec(ctx)
-			if err != nil {
-				return nil, err
-			}
-			res, err := fn(v, ctx)
-			if err != nil {
-				return nil, ErrFrom(err, target)
-			}
-			return res, nil
-		}, target.QueryTargets), nil
+		return simpleMethodFunction(spec, target, fn), nil
 	})
 }
 
+func simpleMethodFunction(spec MethodSpec, target Function, fn simpleMethod) Function {
+	if folded, ok := foldSimpleMethod(spec, target, fn); ok {
+		return folded
+	}
+	return &simpleMethodFn{
+		closureFunction: closureFunction{
+			annotation: "method " + spec.Name,
+			exec: func(ctx FunctionContext) (any, error) {
+				v, err := target.Exec(ctx)
+				if err != nil {
+					return nil, err
+				}
+				res, err := fn(v, ctx)
+				if err != nil {
+					return nil, ErrFrom(err, target)
+				}
+				return res, nil
+			},
+			queryTargets: target.QueryTargets,
+		},
+		target: target,
+	}
+}
+
+// simpleMethodFn is a simple method applied to a target function, where the
+// target is always executed first and its errors are returned unchanged.
+type simpleMethodFn struct {
+	closureFunction
+	target Function
+}
+
 type simpleMethod func(v any, ctx FunctionContext) (any, error)
 
 func stringMethod(fn func(v string) (any, error)) simpleMethod {
@@ -66,9 +87,27 @@ func numberMethod(fn func(f *float64, i *int64, ui *uint64) (any, error)) simple
 			} else {
 				return nil, fmt.Errorf("failed to parse number: %v", err)
 			}
+		case value.Decimal:
+			df, err := t.Float64()
+			if err != nil {
+				return nil, err
+			}
+			f = &df
 		default:
 			return nil, value.NewTypeError(v, value.TNumber)
 		}
 		return fn(f, i, ui)
 	}
 }
+
+// integerRoundingMethod is a numberMethod that rounds decimals exactly using a
+// decimal rounding mode rather than converting them to floats.
+func integerRoundingMethod(mode string, fn func(f *float64, i *int64, ui *uint64) (any, error)) simpleMethod {
+	numFn := numberMethod(fn)
+	return func(v any, ctx FunctionContext) (any, error) {
+		if d, ok := v.(value.Decimal); ok {
+			return d.Round(0, mode)
+		}
+		return numFn(v, ctx)
+	}
+}
@@ -22,7 +22,7 @@ var _ = registerSimpleMethod(
 		),
 	),
 	func(*ParsedParams) (simpleMethod, error) {
-		return numberMethod(func(f *float64, i *int64, ui *uint64) (any, error) {
+		return integerRoundingMethod("ceiling", func(f *float64, i *int64, ui *uint64) (any, error) {
 			if f != nil {
 				ceiled := math.Ceil(*f)
 				if i, err := value.IToInt(ceiled); err == nil {
@@ -51,7 +51,7 @@ var _ = registerSimpleMethod(
 		),
 	),
 	func(*ParsedParams) (simpleMethod, error) {
-		return numberMethod(func(f *float64, i *int64, ui *uint64) (any, error) {
+		return integerRoundingMethod("floor", func(f *float64, i *int64, ui *uint64) (any, error) {
 			if f != nil {
 				floored := math.Floor(*f)
 				if i, err := value.IToInt(floored); err == nil {
@@ -220,7 +220,7 @@ var _ = registerSimpleMethod(
 		),
 	),
 	func(*ParsedParams) (simpleMethod, error) {
-		return numberMethod(func(f *float64, i *int64, ui *uint64) (any, error) {
+		return integerRoundingMethod("half_up", func(f *float64, i *int64, ui *uint64) (any, error) {
 			if f != nil {
 				rounded := math.Round(*f)
 				if i, err := value.IToInt(rounded); err == nil {
@@ -0,0 +1,545 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/redpanda-data/benthos/v4/internal/value"
+)
+
+// lazyArrayMethod is a method that transforms the elements of an array target
+// into a new array, where the elements of the target are consumed and yielded
+// lazily when possible.
+type lazyArrayMethod struct {
+	name       string
+	target     Function
+	iterTarget Iterable
+	targets    func(ctx TargetsContext) (TargetsContext, []TargetPath)
+	iterFn     func(ctx FunctionContext, iter Iterator) (Iterator, error)
+}
+
+func newLazyArrayMethod(
+	name string,
+	target Function,
+	iterFn func(ctx FunctionContext, iter Iterator) (Iterator, error),
+	argFns ...Function,
+) *lazyArrayMethod {
+	iterTarget, _ := target.(Iterable)
+	return &lazyArrayMethod{
+		name:       name,
+		target:     target,
+		iterTarget: iterTarget,
+		targets:    aggregateTargetPaths(append([]Function{target}, argFns...)...),
+		iterFn:     iterFn,
+	}
+}
+
+func (l *lazyArrayMethod) Annotation() string {
+	return "method " + l.name
+}
+
+func (l *lazyArrayMethod) TryIterate(ctx FunctionContext) (Iterator, any, error) {
+	iter, err := iterateArrayTarget(ctx, l.iterTarget, l.target)
+	if err != nil {
+		return nil, nil, err
+	}
+	if iter, err = l.iterFn(ctx, iter); err != nil {
+		return nil, nil, err
+	}
+	return iter, nil, nil
+}
+
+func (l *lazyArrayMethod) Exec(ctx FunctionContext) (any, error) {
+	iter, _, err := l.TryIterate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return drainIterBudgeted(ctx, iter)
+}
+
+func (l *lazyArrayMethod) QueryTargets(ctx TargetsContext) (TargetsContext, []TargetPath) {
+	return l.targets(ctx)
+}
+
+// iterateArrayTarget returns an iterator over the elements of an array target,
+// where errors are annotated with the target and each element is accounted
+// for as an operation against the budget of the execution.
+func iterateArrayTarget(ctx FunctionContext, iFn Iterable, fn Function) (Iterator, error) {
+	iter, res, err := execTryIter(iFn, fn, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if iter == nil {
+		return nil, ErrFrom(value.NewTypeError(res, value.TArray), fn)
+	}
+	return closureIterator{
+		next: func() (any, error) {
+			v, err := iter.Next()
+			if err != nil {
+				if err != errEndOfIter {
+					err = ErrFrom(err, fn)
+				}
+				return nil, err
+			}
+			if err := ctx.budget.operation(); err != nil {
+				return nil, err
+			}
+			return v, nil
+		},
+		len: iter.Len,
+	}, nil
+}
+
+// forEachArrayTarget calls a closure for each element of an array target
+// without materialising the target when it is able to provide an iterator.
+func forEachArrayTarget(ctx FunctionContext, fn Function, each func(i int, v any) error) error {
+	iterTarget, _ := fn.(Iterable)
+	iter, err := iterateArrayTarget(ctx, iterTarget, fn)
+	if err != nil {
+		return err
+	}
+	for i := 0; ; i++ {
+		v, err := iter.Next()
+		if err != nil {
+			if errors.Is(err, errEndOfIter) {
+				return nil
+			}
+			return err
+		}
+		if err := each(i, v); err != nil {
+			return err
+		}
+	}
+}
+
+// groupKey converts the result of a grouping query into an object key.
+func groupKey(v any) (string, error) {
+	switch t := value.ISanitize(v).(type) {
+	case string:
+		return t, nil
+	case []byte, int64, uint64, float64, json.Number, value.Decimal, bool:
+		return value.IToString(t), nil
+	}
+	return "", value.NewTypeError(v, value.TString, value.TNumber, value.TBool)
+}
+
+// uniqueSet tracks whether values have been seen before, where numbers and
+// strings are checked separately. Decimals that cannot be represented exactly
+// as a float are checked by their canonical form.
+type uniqueSet struct {
+	strs map[string]struct{}
+	nums map[float64]struct{}
+	decs map[string]struct{}
+}
+
+func uniqueSetCheck[T comparable](m *map[T]struct{}, v T) bool {
+	if *m == nil {
+		*m = map[T]struct{}{}
+	}
+	_, exists := (*m)[v]
+	if !exists {
+		(*m)[v] = struct{}{}
+	}
+	return !exists
+}
+
+// add adds a value to the set and returns true if the value was not already
+// present.
+func (u *uniqueSet) add(v any) (bool, error) {
+	switch t := value.ISanitize(v).(type) {
+	case string:
+		return uniqueSetCheck(&u.strs, t), nil
+	case []byte:
+		return uniqueSetCheck(&u.strs, string(t)), nil
+	case json.Number:
+		f, err := t.Float64()
+		if err != nil {
+			var i int64
+			if i, err = t.Int64(); err == nil {
+				f = float64(i)
+			}
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to parse number: %w", err)
+		}
+		return uniqueSetCheck(&u.nums, f), nil
+	case int64:
+		return uniqueSetCheck(&u.nums, float64(t)), nil
+	case uint64:
+		return uniqueSetCheck(&u.nums, float64(t)), nil
+	case float64:
+		return uniqueSetCheck(&u.nums, t), nil
+	case value.Decimal:
+		if f, err := t.Float64(); err == nil {
+			if fd, err := value.NewDecimalFromFloat64(f); err == nil && fd.Cmp(t) == 0 {
+				return uniqueSetCheck(&u.nums, f), nil
+			}
+		}
+		return uniqueSetCheck(&u.decs, t.Canonical()), nil
+	}
+	return false, value.NewTypeError(v, value.TString, value.TNumber)
+}
+
+//------------------------------------------------------------------------------
+
+var _ = registerMethod(
+	NewMethodSpec(
+		"group_by", "",
+	).InCategory(
+		MethodCategoryObjectAndArray,
+		"Executes a query for each element of an array and groups the elements into an object of arrays keyed by the results. The query must resolve to a string, number or boolean, and the elements of each group retain their original order.",
+		NewExampleSpec("",
+			`root = this.users.group_by(user -> user.team)`,
+			`{"users":[{"name":"ash","team":"red"},{"name":"bo","team":"blue"},{"name":"cy","team":"red"}]}`,
+			`{"blue":[{"name":"bo","team":"blue"}],"red":[{"name":"ash","team":"red"},{"name":"cy","team":"red"}]}`,
+		),
+	).Param(ParamQuery("key", "A query to execute for each element, the result of which is used as the key of the group the element belongs to.", false)),
+	func(target Function, args *ParsedParams) (Function, error) {
+		keyFn, err := args.FieldQuery("key")
+		if err != nil {
+			return nil, err
+		}
+		return ClosureFunction("method group_by", func(ctx FunctionContext) (any, error) {
+			groups := map[string]any{}
+			if err := forEachArrayTarget(ctx, target, func(i int, v any) error {
+				k, err := execGroupKey(ctx, keyFn, v)
+				if err != nil {
+					return fmt.Errorf("index %v: %w", i, err)
+				}
+				group, _ := groups[k].([]any)
+				groups[k] = append(group, v)
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+			if err := ctx.budget.allocate(allocatedSize(groups)); err != nil {
+				return nil, err
+			}
+			return groups, nil
+		}, target.QueryTargets), nil
+	},
+)
+
+var _ = registerMethod(
+	NewMethodSpec(
+		"count_by", "",
+	).InCategory(
+		MethodCategoryObjectAndArray,
+		"Executes a query for each element of an array and counts the number of elements that resolve to each result, returning an object of counts keyed by the results. The query must resolve to a string, number or boolean.",
+		NewExampleSpec("",
+			`root = this.events.count_by(event -> event.level)`,
+			`{"events":[{"level":"info"},{"level":"error"},{"level":"info"}]}`,
+			`{"error":1,"info":2}`,
+		),
+	).Param(ParamQuery("key", "A query to execute for each element, the result of which is used as the key of the count the element contributes to.", false)),
+	func(target Function, args *ParsedParams) (Function, error) {
+		keyFn, err := args.FieldQuery("key")
+		if err != nil {
+			return nil, err
+		}
+		return ClosureFunction("method count_by", func(ctx FunctionContext) (any, error) {
+			counts := map[string]any{}
+			if err := forEachArrayTarget(ctx, target, func(i int, v any) error {
+				k, err := execGroupKey(ctx, keyFn, v)
+				if err != nil {
+					return fmt.Errorf("index %v: %w", i, err)
+				}
+				c, _ := counts[k].(int64)
+				counts[k] = c + 1
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+			return counts, nil
+		}, target.QueryTargets), nil
+	},
+)
+
+func execGroupKey(ctx FunctionContext, keyFn Function, v any) (string, error) {
+	k, err := keyFn.Exec(ctx.WithValue(v))
+	if err != nil {
+		return "", err
+	}
+	return groupKey(k)
+}
+
+var _ = registerMethod(
+	NewMethodSpec(
+		"partition", "",
+	).InCategory(
+		MethodCategoryObjectAndArray,
+		"Executes a query for each element of an array and splits the elements into two arrays, the first containing the elements for which the query resolved to `true` and the second containing the remaining elements. Elements for which the query resolves to any non-boolean value are placed in the second array.",
+		NewExampleSpec("",
+			`root.passed = this.scores.partition(score -> score >= 50).index(0)
+root.failed = this.scores.partition(score -> score >= 50).index(1)`,
+			`{"scores":[72,31,50,49]}`,
+			`{"failed":[31,49],"passed":[72,50]}`,
+		),
+	).Param(ParamQuery("test", "A query to apply to each element, if this query resolves to a boolean `true` the element is added to the first array, otherwise it is added to the second.", false)),
+	func(target Function, args *ParsedParams) (Function, error) {
+		testFn, err := args.FieldQuery("test")
+		if err != nil {
+			return nil, err
+		}
+		return ClosureFunction("method partition", func(ctx FunctionContext) (any, error) {
+			matched, unmatched := []any{}, []any{}
+			if err := forEachArrayTarget(ctx, target, func(i int, v any) error {
+				res, err := testFn.Exec(ctx.WithValue(v))
+				if err != nil {
+					return fmt.Errorf("index %v: %w", i, err)
+				}
+				if b, _ := res.(bool); b {
+					matched = append(matched, v)
+				} else {
+					unmatched = append(unmatched, v)
+				}
+				return nil
+			}); err != nil {
+				return nil, err
+			}
+			res := []any{matched, unmatched}
+			if err := ctx.budget.allocate(allocatedSize(res)); err != nil {
+				return nil, err
+			}
+			return res, nil
+		}, target.QueryTargets), nil
+	},
+)
+
+//------------------------------------------------------------------------------
+
+var _ = registerMethod(
+	NewMethodSpec(
+		"chunk", "",
+	).InCategory(
+		MethodCategoryObjectAndArray,
+		"Splits an array into an array of arrays each containing a number of elements. The final array contains the remaining elements and may therefore be smaller.",
+		NewExampleSpec("",
+			`root.batches = this.ids.chunk(2)`,
+			`{"ids":["a","b","c","d","e"]}`,
+			`{"batches":[["a","b"],["c","d"],["e"]]}`,
+		),
+	).Param(ParamInt64("size", "The number of elements of each array.")),
+	func(target Function, args *ParsedParams) (Function, error) {
+		size, err := args.FieldInt64("size")
+		if err != nil {
+			return nil, err
+		}
+		if size <= 0 {
+			return nil, fmt.Errorf("size must be greater than zero, got %v", size)
+		}
+		return newLazyArrayMethod("chunk", target, func(ctx FunctionContext, iter Iterator) (Iterator, error) {
+			return closureIterator{
+				next: func() (any, error) {
+					var chunk []any
+					for int64(len(chunk)) < size {
+						v, err := iter.Next()
+						if err != nil {
+							if err == errEndOfIter && len(chunk) > 0 {
+								break
+							}
+							return nil, err
+						}
+						if chunk == nil {
+							chunk = make([]any, 0, size)
+						}
+						chunk = append(chunk, v)
+					}
+					return chunk, nil
+				},
+				len: func() (int, bool) {
+					l, ok := iter.Len()
+					return (l + int(size) - 1) / int(size), ok
+				},
+			}, nil
+		}), nil
+	},
+)
+
+var _ = registerMethod(
+	NewMethodSpec(
+		"sliding", "",
+	).InCategory(
+		MethodCategoryObjectAndArray,
+		"Returns an array of overlapping windows of an array, where each window is an array of consecutive elements. Windows start every `step` elements and only complete windows are returned, therefore an array smaller than the window size results in an empty array.",
+		NewExampleSpec("",
+			`root.pairs = this.readings.sliding(2)`,
+			`{"readings":[3,5,4,8]}`,
+			`{"pairs":[[3,5],[5,4],[4,8]]}`,
+		),
+		NewExampleSpec("",
+			`root.windows = this.readings.sliding(size: 3, step: 2)`,
+			`{"readings":[1,2,3,4,5,6,7]}`,
+			`{"windows":[[1,2,3],[3,4,5],[5,6,7]]}`,
+		),
+	).
+		Param(ParamInt64("size", "The number of elements of each window.")).
+		Param(ParamInt64("step", "The number of elements between the start of each window.").Default(1)),
+	func(target Function, args *ParsedParams) (Function, error) {
+		size, err := args.FieldInt64("size")
+		if err != nil {
+			return nil, err
+		}
+		if size <= 0 {
+			return nil, fmt.Errorf("size must be greater than zero, got %v", size)
+		}
+		step, err := args.FieldInt64("step")
+		if err != nil {
+			return nil, err
+		}
+		if step <= 0 {
+			return nil, fmt.Errorf("step must be greater than zero, got %v", step)
+		}
+		return newLazyArrayMethod("sliding", target, func(ctx FunctionContext, iter Iterator) (Iterator, error) {
+			var window []any
+			var skip int64
+			return closureIterator{
+				next: func() (any, error) {
+					for ; skip > 0; skip-- {
+						if _, err := iter.Next(); err != nil {
+							return nil, err
+						}
+					}
+					for int64(len(window)) < size {
+						v, err := iter.Next()
+						if err != nil {
+							return nil, err
+						}
+						window = append(window, v)
+					}
+					res := window
+					if step < size {
+						window = append(make([]any, 0, size), window[step:]...)
+					} else {
+						window = nil
+						skip = step - size
+					}
+					return res, nil
+				},
+			}, nil
+		}), nil
+	},
+)
+
+var _ = registerMethod(
+	NewMethodSpec(
+		"distinct_by", "",
+	).InCategory(
+		MethodCategoryObjectAndArray,
+		"Executes a query for each element of an array and removes any elements that resolve to a result already seen in a previous element, retaining the first occurrence. The query must resolve to a string or number, and numbers and strings are checked separately (`\"5\"` is a different result to `5`).",
+		NewExampleSpec("",
+			`root.users = this.users.distinct_by(user -> user.email.lowercase())`,
+			`{"users":[{"id":1,"email":"ash@example.com"},{"id":2,"email":"bo@example.com"},{"id":3,"email":"Ash@Example.com"}]}`,
+			`{"users":[{"email":"ash@example.com","id":1},{"email":"bo@example.com","id":2}]}`,
+		),
+	).Param(ParamQuery("key", "A query to execute for each element, the result of which determines whether the element is distinct.", false)),
+	func(target Function, args *ParsedParams) (Function, error) {
+		keyFn, err := args.FieldQuery("key")
+		if err != nil {
+			return nil, err
+		}
+		return newLazyArrayMethod("distinct_by", target, func(ctx FunctionContext, iter Iterator) (Iterator, error) {
+			var seen uniqueSet
+			i := -1
+			return closureIterator{
+				next: func() (any, error) {
+					for {
+						v, err := iter.Next()
+						if err != nil {
+							return nil, err
+						}
+						i++
+						k, err := keyFn.Exec(ctx.WithValue(v))
+						if err != nil {
+							return nil, fmt.Errorf("index %v: %w", i, err)
+						}
+						unique, err := seen.add(k)
+						if err != nil {
+							return nil, fmt.Errorf("index %v: %w", i, err)
+						}
+						if unique {
+							return v, nil
+						}
+					}
+				},
+			}, nil
+		}), nil
+	},
+)
+
+var _ = registerMethod(
+	NewMethodSpec(
+		"zip_with", "",
+	).InCategory(
+		MethodCategoryObjectAndArray,
+		"Combines the elements of an array with the elements of another array of the same length at matching indexes. When a query is provided it is executed for each pair of elements with a context containing a field `left` with the element of the target array and a field `right` with the element of the argument array, and the result is used as the element of the resulting array. Otherwise each element of the resulting array is an array of the pair of elements.",
+		NewExampleSpec("",
+			`root.pairs = this.names.zip_with(this.ages)`,
+			`{"names":["ash","bo"],"ages":[31,27]}`,
+			`{"pairs":[["ash",31],["bo",27]]}`,
+		),
+		NewExampleSpec("",
+			`root.totals = this.prices.zip_with(this.quantities, item -> item.left * item.right)`,
+			`{"prices":[2.5,10,4],"quantities":[4,1,3]}`,
+			`{"totals":[10,10,12]}`,
+		),
+	).
+		Param(ParamQuery("other", "An array to combine with the target array.", true)).
+		Param(ParamQuery("combine", "An optional query that combines each pair of elements into a single value.", false).Optional()),
+	func(target Function, args *ParsedParams) (Function, error) {
+		otherFn, err := args.FieldQuery("other")
+		if err != nil {
+			return nil, err
+		}
+		combineFn, err := args.FieldOptionalQuery("combine")
+		if err != nil {
+			return nil, err
+		}
+		otherIterFn, _ := otherFn.(Iterable)
+		return newLazyArrayMethod("zip_with", target, func(ctx FunctionContext, iter Iterator) (Iterator, error) {
+			otherIter, res, err := execTryIter(otherIterFn, otherFn, ctx)
+			if err != nil {
+				return nil, err
+			}
+			if otherIter == nil {
+				return nil, ErrFrom(value.NewTypeError(res, value.TArray), otherFn)
+			}
+			i := -1
+			return closureIterator{
+				next: func() (any, error) {
+					left, lErr := iter.Next()
+					if lErr != nil && lErr != errEndOfIter {
+						return nil, lErr
+					}
+					right, rErr := otherIter.Next()
+					if rErr != nil && rErr != errEndOfIter {
+						return nil, ErrFrom(rErr, otherFn)
+					}
+					if lErr != nil || rErr != nil {
+						if lErr == nil || rErr == nil {
+							return nil, errors.New("can't zip different length array values")
+						}
+						return nil, errEndOfIter
+					}
+					i++
+					if combineFn == nil {
+						return []any{left, right}, nil
+					}
+					var pair any = map[string]any{
+						"left":  left,
+						"right": right,
+					}
+					v, err := combineFn.Exec(ctx.WithValue(pair))
+					if err != nil {
+						return nil, fmt.Errorf("index %v: %w", i, err)
+					}
+					return v, nil
+				},
+				len: iter.Len,
+			}, nil
+		}, otherFn), nil
+	},
+)
@@ -0,0 +1,252 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package query
+
+import (
+	"testing"
+
+	"github.com/Jeffail/gabs/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReshapeMethods(t *testing.T) {
+	jsonFn := func(json string) Function {
+		t.Helper()
+		gObj, err := gabs.ParseJSON([]byte(json))
+		require.NoError(t, err)
+		return NewLiteralFunction("", gObj.Data())
+	}
+	arithmetic := func(left, right Function, op ArithmeticOperator) Function {
+		t.Helper()
+		fn, err := NewArithmeticExpression(
+			[]Function{left, right},
+			[]ArithmeticOperator{op},
+		)
+		require.NoError(t, err)
+		return fn
+	}
+	type easyMethod struct {
+		name string
+		args []any
+	}
+	methods := func(fn Function, methods ...easyMethod) Function {
+		t.Helper()
+		for _, m := range methods {
+			var err error
+			fn, err = InitMethodHelper(m.name, fn, m.args...)
+			require.NoError(t, err)
+		}
+		return fn
+	}
+	method := func(name string, args ...any) easyMethod {
+		return easyMethod{name: name, args: args}
+	}
+
+	tests := map[string]struct {
+		input  Function
+		output any
+		err    string
+	}{
+		"group by": {
+			input: methods(
+				jsonFn(`[{"k":"a","v":1},{"k":2,"v":2},{"k":"a","v":3},{"k":true,"v":4}]`),
+				method("group_by", NewFieldFunction("k")),
+			),
+			output: map[string]any{
+				"a":    []any{map[string]any{"k": "a", "v": 1.0}, map[string]any{"k": "a", "v": 3.0}},
+				"2":    []any{map[string]any{"k": 2.0, "v": 2.0}},
+				"true": []any{map[string]any{"k": true, "v": 4.0}},
+			},
+		},
+		"group by empty": {
+			input: methods(
+				jsonFn(`[]`),
+				method("group_by", NewFieldFunction("k")),
+			),
+			output: map[string]any{},
+		},
+		"group by bad key": {
+			input: methods(
+				jsonFn(`[{"k":"a"},{"k":{"nested":true}}]`),
+				method("group_by", NewFieldFunction("k")),
+			),
+			err: "index 1: expected string, number or bool value, got object",
+		},
+		"group by not array": {
+			input: methods(
+				jsonFn(`{"k":"a"}`),
+				method("group_by", NewFieldFunction("k")),
+			),
+			err: "expected array value, got object",
+		},
+		"count by": {
+			input: methods(
+				jsonFn(`["a","bb","cc","d","eee"]`),
+				method("count_by", methods(NewFieldFunction(""), method("length"))),
+			),
+			output: map[string]any{"1": int64(2), "2": int64(2), "3": int64(1)},
+		},
+		"partition": {
+			input: methods(
+				jsonFn(`[5,12,20,1]`),
+				method("partition", arithmetic(NewFieldFunction(""), NewLiteralFunction("", 10.0), ArithmeticGt)),
+			),
+			output: []any{[]any{12.0, 20.0}, []any{5.0, 1.0}},
+		},
+		"partition non boolean": {
+			input: methods(
+				jsonFn(`[{"ok":true},{"ok":"yes"},{}]`),
+				method("partition", NewFieldFunction("ok")),
+			),
+			output: []any{[]any{map[string]any{"ok": true}}, []any{map[string]any{"ok": "yes"}, map[string]any{}}},
+		},
+		"chunk": {
+			input: methods(
+				jsonFn(`[1,2,3,4,5,6]`),
+				method("chunk", int64(3)),
+			),
+			output: []any{[]any{1.0, 2.0, 3.0}, []any{4.0, 5.0, 6.0}},
+		},
+		"chunk remainder": {
+			input: methods(
+				jsonFn(`[1,2,3]`),
+				method("chunk", int64(2)),
+			),
+			output: []any{[]any{1.0, 2.0}, []any{3.0}},
+		},
+		"chunk empty": {
+			input: methods(
+				jsonFn(`[]`),
+				method("chunk", int64(2)),
+			),
+			output: []any{},
+		},
+		"sliding": {
+			input: methods(
+				jsonFn(`[1,2,3,4]`),
+				method("sliding", int64(3)),
+			),
+			output: []any{[]any{1.0, 2.0, 3.0}, []any{2.0, 3.0, 4.0}},
+		},
+		"sliding step larger than size": {
+			input: methods(
+				jsonFn(`[1,2,3,4,5,6,7,8]`),
+				method("sliding", int64(2), int64(3)),
+			),
+			output: []any{[]any{1.0, 2.0}, []any{4.0, 5.0}, []any{7.0, 8.0}},
+		},
+		"sliding too small": {
+			input: methods(
+				jsonFn(`[1,2]`),
+				method("sliding", int64(3)),
+			),
+			output: []any(nil),
+		},
+		"distinct by": {
+			input: methods(
+				jsonFn(`[{"id":1,"n":"a"},{"id":"1","n":"b"},{"id":1,"n":"c"},{"id":2,"n":"d"}]`),
+				method("distinct_by", NewFieldFunction("id")),
+			),
+			output: []any{
+				map[string]any{"id": 1.0, "n": "a"},
+				map[string]any{"id": "1", "n": "b"},
+				map[string]any{"id": 2.0, "n": "d"},
+			},
+		},
+		"distinct by bad key": {
+			input: methods(
+				jsonFn(`[{"id":1},{"id":null}]`),
+				method("distinct_by", NewFieldFunction("id")),
+			),
+			err: "index 1: expected string or number value, got null",
+		},
+		"zip with pairs": {
+			input: methods(
+				jsonFn(`["a","b"]`),
+				method("zip_with", jsonFn(`[1,2]`)),
+			),
+			output: []any{[]any{"a", 1.0}, []any{"b", 2.0}},
+		},
+		"zip with query": {
+			input: methods(
+				jsonFn(`[1,2,3]`),
+				method("zip_with", jsonFn(`[10,20,30]`), arithmetic(NewFieldFunction("left"), NewFieldFunction("right"), ArithmeticAdd)),
+			),
+			output: []any{11.0, 22.0, 33.0},
+		},
+		"zip with different lengths": {
+			input: methods(
+				jsonFn(`[1,2,3]`),
+				method("zip_with", jsonFn(`[10,20]`)),
+			),
+			err: "can't zip different length array values",
+		},
+		"zip with not array": {
+			input: methods(
+				jsonFn(`[1,2,3]`),
+				method("zip_with", jsonFn(`{"a":1}`)),
+			),
+			err: "expected array value, got object",
+		},
+		"chained lazily": {
+			input: methods(
+				jsonFn(`[1,2,3,4,5,6,7,8,9]`),
+				method("sliding", int64(2)),
+				method("distinct_by", methods(NewFieldFunction(""), method("sum"))),
+				method("chunk", int64(3)),
+			),
+			output: []any{
+				[]any{[]any{1.0, 2.0}, []any{2.0, 3.0}, []any{3.0, 4.0}},
+				[]any{[]any{4.0, 5.0}, []any{5.0, 6.0}, []any{6.0, 7.0}},
+				[]any{[]any{7.0, 8.0}, []any{8.0, 9.0}},
+			},
+		},
+		"chained group by": {
+			input: methods(
+				jsonFn(`[1,2,3,4,5]`),
+				method("chunk", int64(2)),
+				method("group_by", methods(NewFieldFunction(""), method("length"))),
+			),
+			output: map[string]any{
+				"2": []any{[]any{1.0, 2.0}, []any{3.0, 4.0}},
+				"1": []any{[]any{5.0}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := test.input.Exec(FunctionContext{
+				Maps: map[string]Function{},
+			}.WithValueFunc(func() *any { return nil }))
+			if test.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.output, res)
+		})
+	}
+
+	for _, args := range [][]any{{"chunk", int64(0)}, {"sliding", int64(-1)}, {"sliding", int64(2), int64(0)}} {
+		_, err := InitMethodHelper(args[0].(string), NewLiteralFunction("", []any{}), args[1:]...)
+		require.Error(t, err, args)
+	}
+}
+
+func TestReshapeMethodsBudget(t *testing.T) {
+	arr := make([]any, 100)
+	for i := range arr {
+		arr[i] = int64(i)
+	}
+	fn, err := InitMethodHelper("chunk", NewLiteralFunction("", arr), int64(10))
+	require.NoError(t, err)
+
+	_, err = fn.Exec(FunctionContext{}.WithValue(nil).WithBudget(NewBudgetTracker(Budget{MaxOperations: 50})))
+	require.ErrorIs(t, err, ErrBudgetExceeded)
+}
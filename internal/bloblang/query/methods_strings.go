@@ -1178,52 +1178,116 @@ func parseCSVMethod(args *ParsedParams) (simpleMethod, error) {
 
 //------------------------------------------------------------------------------
 
-var _ = registerSimpleMethod(
-	NewMethodSpec(
-		"parse_json", "",
-	).Param(
-		ParamBool("use_number", "An optional flag that when set makes parsing numbers as json.Number instead of the default float64.").Optional(),
-	).InCategory(
-		MethodCategoryParsing,
-		"Attempts to parse a string as a JSON document and returns the result.",
-		NewExampleSpec("",
-			`root.doc = this.doc.parse_json()`,
-			`{"doc":"{\"foo\":\"bar\"}"}`,
-			`{"doc":{"foo":"bar"}}`,
-		),
-		NewExampleSpec("",
-			`root.doc = this.doc.parse_json(use_number: true)`,
-			`{"doc":"{\"foo\":\"11380878173205700000000000000000000000000000000\"}"}`,
-			`{"doc":{"foo":"11380878173205700000000000000000000000000000000"}}`,
-		),
+var parseJSONSpec = NewMethodSpec(
+	"parse_json", "",
+).Param(
+	ParamBool("use_number", "An optional flag that when set makes parsing numbers as json.Number instead of the default float64.").Optional(),
+).Param(
+	ParamBool("use_decimal", "An optional flag that when set parses numbers as decimals, which preserve every digit of the original number through arithmetic and when serialised back into JSON.").Optional(),
+).InCategory(
+	MethodCategoryParsing,
+	"Attempts to parse a string as a JSON document and returns the result.",
+	NewExampleSpec("",
+		`root.doc = this.doc.parse_json()`,
+		`{"doc":"{\"foo\":\"bar\"}"}`,
+		`{"doc":{"foo":"bar"}}`,
 	),
-	func(args *ParsedParams) (simpleMethod, error) {
-		useNumber, err := args.FieldOptionalBool("use_number")
-		if err != nil {
-			return nil, err
+	NewExampleSpec("",
+		`root.doc = this.doc.parse_json(use_number: true)`,
+		`{"doc":"{\"foo\":\"11380878173205700000000000000000000000000000000\"}"}`,
+		`{"doc":{"foo":"11380878173205700000000000000000000000000000000"}}`,
+	),
+	NewExampleSpec("",
+		`root.total = this.doc.parse_json(use_decimal: true).values().sum()`,
+		`{"doc":"{\"a\":0.1,\"b\":0.2,\"c\":12345678901234567890.01}"}`,
+		`{"total":12345678901234567890.31}`,
+	),
+)
+
+var _ = registerMethod(parseJSONSpec, func(target Function, args *ParsedParams) (Function, error) {
+	useNumber, err := args.FieldOptionalBool("use_number")
+	if err != nil {
+		return nil, err
+	}
+	useDecimal, err := args.FieldOptionalBool("use_decimal")
+	if err != nil {
+		return nil, err
+	}
+	preserveNumbers := (useNumber != nil && *useNumber) || (useDecimal != nil && *useDecimal)
+	parseFn := func(v any, ctx FunctionContext) (any, error) {
+		var jsonBytes []byte
+		switch t := v.(type) {
+		case string:
+			jsonBytes = []byte(t)
+		case []byte:
+			jsonBytes = t
+		default:
+			return nil, value.NewTypeError(v, value.TString)
 		}
-		return func(v any, ctx FunctionContext) (any, error) {
-			var jsonBytes []byte
-			switch t := v.(type) {
-			case string:
-				jsonBytes = []byte(t)
-			case []byte:
-				jsonBytes = t
-			default:
-				return nil, value.NewTypeError(v, value.TString)
+		var jObj any
+		decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+		if preserveNumbers {
+			decoder.UseNumber()
+		}
+		if err := decoder.Decode(&jObj); err != nil {
+			return nil, fmt.Errorf("failed to parse value as JSON: %w", err)
+		}
+		if useDecimal != nil && *useDecimal {
+			return jsonNumbersToDecimals(jObj)
+		}
+		return jObj, nil
+	}
+	if _, isContent := target.(contentFunction); isContent && !preserveNumbers {
+		// Parsing the raw contents of a message is a common pattern, and when
+		// the message already has a structured form cached we can skip both
+		// serialising and parsing it.
+		return ClosureFunction("method parse_json", func(ctx FunctionContext) (any, error) {
+			if part := ctx.MsgBatch.Get(ctx.Index); part.HasStructured() {
+				if structured, err := part.AsStructured(); err == nil {
+					if v, ok := jsonNormalised(structured); ok {
+						return v, nil
+					}
+				}
+			}
+			v, err := target.Exec(ctx)
+			if err != nil {
+				return nil, err
 			}
-			var jObj any
-			decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
-			if useNumber != nil && *useNumber {
-				decoder.UseNumber()
+			res, err := parseFn(v, ctx)
+			if err != nil {
+				return nil, ErrFrom(err, target)
 			}
-			if err := decoder.Decode(&jObj); err != nil {
-				return nil, fmt.Errorf("failed to parse value as JSON: %w", err)
+			return res, nil
+		}, target.QueryTargets), nil
+	}
+	return simpleMethodFunction(parseJSONSpec, target, parseFn), nil
+})
+
+// jsonNumbersToDecimals walks a document decoded with json.Number values and
+// replaces each of them with a decimal in place.
+func jsonNumbersToDecimals(v any) (any, error) {
+	switch t := v.(type) {
+	case json.Number:
+		return value.NewDecimalFromString(t.String())
+	case map[string]any:
+		for k, e := range t {
+			d, err := jsonNumbersToDecimals(e)
+			if err != nil {
+				return nil, err
 			}
-			return jObj, nil
-		}, nil
-	},
-)
+			t[k] = d
+		}
+	case []any:
+		for i, e := range t {
+			d, err := jsonNumbersToDecimals(e)
+			if err != nil {
+				return nil, err
+			}
+			t[i] = d
+		}
+	}
+	return v, nil
+}
 
 var _ = registerSimpleMethod(
 	NewMethodSpec(
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -1520,9 +1521,12 @@ func sumMethod(target Function, _ *ParsedParams) (Function, error) {
 			return nil, err
 		}
 		switch t := value.ISanitize(v).(type) {
-		case float64, int64, uint64, json.Number:
+		case float64, int64, uint64, json.Number, value.Decimal:
 			return v, nil
 		case []any:
+			if slices.ContainsFunc(t, isDecimal) {
+				return sumDecimals(t)
+			}
 			var total float64
 			for i, v := range t {
 				n, nErr := value.IGetNumber(v)
@@ -1541,6 +1545,27 @@ func sumMethod(target Function, _ *ParsedParams) (Function, error) {
 	}, target.QueryTargets), nil
 }
 
+func isDecimal(v any) bool {
+	_, ok := v.(value.Decimal)
+	return ok
+}
+
+// sumDecimals adds the elements of an array exactly, which is used when any of
+// them are decimals.
+func sumDecimals(values []any) (any, error) {
+	var total value.Decimal
+	for i, v := range values {
+		d, err := value.IGetDecimal(v)
+		if err == nil {
+			total, err = total.Add(d)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("index %v: %w", i, err)
+		}
+	}
+	return total, nil
+}
+
 //------------------------------------------------------------------------------
 
 var _ = registerSimpleMethod(
@@ -1574,31 +1599,7 @@ func uniqueMethod(args *ParsedParams) (simpleMethod, error) {
 			return nil, value.NewTypeError(v, value.TArray)
 		}
 
-		var strCompares map[string]struct{}
-		var numCompares map[float64]struct{}
-
-		checkStr := func(str string) bool {
-			if strCompares == nil {
-				strCompares = make(map[string]struct{}, len(slice))
-			}
-			_, exists := strCompares[str]
-			if !exists {
-				strCompares[str] = struct{}{}
-			}
-			return !exists
-		}
-
-		checkNum := func(num float64) bool {
-			if numCompares == nil {
-				numCompares = make(map[float64]struct{}, len(slice))
-			}
-			_, exists := numCompares[num]
-			if !exists {
-				numCompares[num] = struct{}{}
-			}
-			return !exists
-		}
-
+		var seen uniqueSet
 		uniqueSlice := make([]any, 0, len(slice))
 		for i, v := range slice {
 			check := v
@@ -1608,32 +1609,9 @@ func uniqueMethod(args *ParsedParams) (simpleMethod, error) {
 					return nil, fmt.Errorf("index %v: %w", i, err)
 				}
 			}
-			var unique bool
-			switch t := value.ISanitize(check).(type) {
-			case string:
-				unique = checkStr(t)
-			case []byte:
-				unique = checkStr(string(t))
-			case json.Number:
-				f, err := t.Float64()
-				if err != nil {
-					var i int64
-					if i, err = t.Int64(); err == nil {
-						f = float64(i)
-					}
-				}
-				if err != nil {
-					return nil, fmt.Errorf("index %v: failed to parse number: %w", i, err)
-				}
-				unique = checkNum(f)
-			case int64:
-				unique = checkNum(float64(t))
-			case uint64:
-				unique = checkNum(float64(t))
-			case float64:
-				unique = checkNum(t)
-			default:
-				return nil, fmt.Errorf("index %v: %w", i, value.NewTypeError(check, value.TString, value.TNumber))
+			unique, err := seen.add(check)
+			if err != nil {
+				return nil, fmt.Errorf("index %v: %w", i, err)
 			}
 			if unique {
 				uniqueSlice = append(uniqueSlice, v)
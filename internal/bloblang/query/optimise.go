@@ -0,0 +1,416 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+	"unicode/utf8"
+
+	"github.com/redpanda-data/benthos/v4/internal/value"
+)
+
+// This file contains optimisations that are applied to query functions as they
+// are constructed. None of these optimisations are allowed to change the
+// observable behaviour of a mapping, including the errors it yields, they
+// simply reduce the amount of work performed for each execution.
+
+// constant is the result of a query that has been resolved to a static value
+// at parse time. Unlike a Literal a constant is not treated as a literal value
+// when constructing other functions (such as match cases and static arguments)
+// as that could change the semantics and errors of the mapping, but constants
+// can still be folded into other constants.
+type constant struct {
+	annotation string
+	value      any
+	targets    []TargetPath
+}
+
+// NewInlinedVarFunction creates a function that returns the value of a
+// variable that is known to be constant at parse time, without the overhead of
+// looking it up.
+func NewInlinedVarFunction(name string, v any) Function {
+	return &constant{
+		annotation: "variable " + name,
+		value:      v,
+		targets:    []TargetPath{NewTargetPath(TargetVariable, name)},
+	}
+}
+
+func (c *constant) Annotation() string {
+	return c.annotation
+}
+
+func (c *constant) Exec(ctx FunctionContext) (any, error) {
+	return c.value, nil
+}
+
+func (c *constant) QueryTargets(ctx TargetsContext) (TargetsContext, []TargetPath) {
+	if len(c.targets) == 0 {
+		return ctx, nil
+	}
+	ctx = ctx.WithValues(c.targets)
+	return ctx, c.targets
+}
+
+// constantValue returns the static value of a function if it is either a
+// literal or a constant.
+func constantValue(fn Function) (any, bool) {
+	switch t := fn.(type) {
+	case *Literal:
+		return t.Value, true
+	case *constant:
+		return t.value, true
+	}
+	return nil, false
+}
+
+func isFoldableValue(v any) bool {
+	switch v.(type) {
+	case value.Delete, value.Nothing:
+		return false
+	}
+	return true
+}
+
+// isFoldableResult returns true for values that are safe to share between
+// executions. Some methods modify byte slices in place and structured values
+// are expected to be fresh, therefore only immutable scalars are folded.
+func isFoldableResult(v any) bool {
+	switch v.(type) {
+	case nil, string, bool, float64, int64, uint64, json.Number, time.Time:
+		return true
+	}
+	return false
+}
+
+// foldArithmetic attempts to resolve an arithmetic operation where at least one
+// side is a constant. Operations that fail are not folded in order to preserve
+// the behaviour of the mapping at runtime.
+func foldArithmetic[T any](annotation string, lhs, rhs Function, op arithmeticOpFunc[T]) (Function, bool) {
+	l, lIsConst := constantValue(lhs)
+	r, rIsConst := constantValue(rhs)
+	if !lIsConst || !rIsConst || !isFoldableValue(l) || !isFoldableValue(r) {
+		return nil, false
+	}
+	res, err := op(lhs, rhs, l, r)
+	if err != nil || !isFoldableResult(res) {
+		return nil, false
+	}
+	return &constant{annotation: annotation, value: res}, true
+}
+
+// methodIsFoldable returns true if a method can be safely evaluated at parse
+// time when its target is a static value. Impure methods are excluded, as are
+// methods that accept query parameters since those are executed with access to
+// the wider context of the mapping.
+func methodIsFoldable(spec MethodSpec) bool {
+	if spec.Impure {
+		return false
+	}
+	for _, def := range spec.Params.Definitions {
+		if def.ValueType == value.TQuery {
+			return false
+		}
+	}
+	return true
+}
+
+// foldSimpleMethod attempts to execute a simple method against a static target
+// at parse time, and if successful returns a constant containing the result.
+// Methods that fail are not folded in order to preserve the error context that
+// would otherwise be provided at runtime.
+func foldSimpleMethod(spec MethodSpec, target Function, fn simpleMethod) (Function, bool) {
+	v, isConst := constantValue(target)
+	if !isConst || !isFoldableValue(v) || !methodIsFoldable(spec) {
+		return nil, false
+	}
+	res, err := fn(v, FunctionContext{})
+	if err != nil || !isFoldableResult(res) {
+		return nil, false
+	}
+	return &constant{annotation: "method " + spec.Name, value: res}, true
+}
+
+//------------------------------------------------------------------------------
+
+// VarCandidate is the query of a variable assignment that might be inlined into
+// the statement immediately following it, removing the need to store and look
+// up the variable.
+//
+// The variable must be referenced exactly once within the following statement
+// and nowhere else. The reference must also be executed before anything else
+// within the statement and have its errors returned unchanged, which means the
+// query is executed exactly once, at the same point relative to every other
+// query of the mapping, and with the same result and side effects.
+type VarCandidate struct {
+	name  string
+	input []rune
+	fn    Function
+
+	refs    int
+	inlined bool
+}
+
+// NewVarCandidate creates a candidate for inlining from a variable assignment,
+// where input is the parsed expression of the assignment and fn is the query
+// being assigned.
+func NewVarCandidate(name string, input []rune, fn Function) *VarCandidate {
+	return &VarCandidate{name: name, input: input, fn: fn}
+}
+
+// Reference returns a function that references the variable, which looks up the
+// variable as normal unless the candidate is inlined.
+func (c *VarCandidate) Reference() Function {
+	c.refs++
+	return &varReference{
+		candidate: c,
+		lookup:    NewVarFunction(c.name),
+	}
+}
+
+// Inline attempts to inline the candidate into the query of the statement that
+// follows the variable assignment, and returns true if successful, in which
+// case the assignment must be removed from the mapping. This must be called
+// before the mapping is executed.
+func (c *VarCandidate) Inline(fn Function) bool {
+	if c.refs != 1 {
+		return false
+	}
+	ref, isRef := leadingFunction(fn).(*varReference)
+	if !isRef || ref.candidate != c {
+		return false
+	}
+	c.inlined = true
+	return true
+}
+
+// leadingFunction returns the function that is executed before anything else
+// when fn is executed, and whose errors are returned by fn unchanged.
+func leadingFunction(fn Function) Function {
+	for {
+		switch t := fn.(type) {
+		case *simpleMethodFn:
+			fn = t.target
+		case *getMethod:
+			fn = t.fn
+		default:
+			return fn
+		}
+	}
+}
+
+type varReference struct {
+	candidate *VarCandidate
+	lookup    Function
+}
+
+func (r *varReference) Annotation() string {
+	return r.lookup.Annotation()
+}
+
+func (r *varReference) Exec(ctx FunctionContext) (any, error) {
+	if !r.candidate.inlined {
+		return r.lookup.Exec(ctx)
+	}
+	v, err := r.candidate.fn.Exec(ctx)
+	if err != nil {
+		// Errors from a candidate inlined into this one already carry the
+		// input of their original assignment.
+		if _, isInlined := err.(*ErrInlinedVar); !isInlined {
+			err = &ErrInlinedVar{Input: r.candidate.input, Err: err}
+		}
+		return nil, err
+	}
+	switch v.(type) {
+	case value.Nothing:
+		// The assignment would have been skipped, leaving any previous value
+		// of the variable intact.
+		return r.lookup.Exec(ctx)
+	case value.Delete:
+		return nil, fmt.Errorf("variable '%v' undefined", r.candidate.name)
+	}
+	return v, nil
+}
+
+func (r *varReference) QueryTargets(ctx TargetsContext) (TargetsContext, []TargetPath) {
+	if r.candidate.inlined {
+		return r.candidate.fn.QueryTargets(ctx)
+	}
+	return r.lookup.QueryTargets(ctx)
+}
+
+//------------------------------------------------------------------------------
+
+// HoistedPaths describes a set of path prefixes that are referenced from the
+// main context of a mapping multiple times, and are therefore worth caching for
+// the duration of an execution.
+type HoistedPaths struct {
+	prefixes map[string]struct{}
+}
+
+// NewHoistedPaths walks the targets of a mapping and determines which path
+// prefixes of the main context are referenced more than once. Returns nil if
+// there are no prefixes worth hoisting.
+func NewHoistedPaths(targets []TargetPath) *HoistedPaths {
+	counts := map[string]int{}
+	for _, t := range targets {
+		if t.Type != TargetValue {
+			continue
+		}
+		// Single segment paths are a single map lookup and so we gain nothing
+		// by caching them.
+		for i := 2; i <= len(t.Path); i++ {
+			counts[SliceToDotPath(t.Path[:i]...)]++
+		}
+	}
+
+	var h *HoistedPaths
+	for k, v := range counts {
+		if v < 2 {
+			continue
+		}
+		if h == nil {
+			h = &HoistedPaths{prefixes: map[string]struct{}{}}
+		}
+		h.prefixes[k] = struct{}{}
+	}
+	return h
+}
+
+// NewCache creates a cache of hoisted path lookups to be used for a single
+// execution of a mapping.
+func (h *HoistedPaths) NewCache() *PathCache {
+	if h == nil {
+		return nil
+	}
+	return &PathCache{hoisted: h}
+}
+
+// PathCache stores the results of hoisted path lookups from the main context of
+// a mapping for the duration of a single execution. The main context must not
+// be mutated for the lifetime of the cache.
+type PathCache struct {
+	hoisted *HoistedPaths
+	values  map[string]any
+}
+
+// lookup attempts to resolve a path from a target by using the longest hoisted
+// prefix of the path. The provided keys must be the dot path representations of
+// each prefix of the path, starting from the prefix of length two. Returns
+// false if the path has no hoisted prefixes.
+func (c *PathCache) lookup(target any, path, keys []string) (any, bool) {
+	for i := len(path); i >= 2; i-- {
+		key := keys[i-2]
+		if _, exists := c.hoisted.prefixes[key]; !exists {
+			continue
+		}
+		base, cached := c.values[key]
+		if !cached {
+			var isObj bool
+			if base, isObj = objectPath(target, path[:i]); !isObj {
+				return nil, false
+			}
+			if c.values == nil {
+				c.values = make(map[string]any, len(c.hoisted.prefixes))
+			}
+			c.values[key] = base
+		}
+		if i == len(path) {
+			return base, true
+		}
+		if res, isObj := objectPath(base, path[i:]); isObj {
+			return res, true
+		}
+		return nil, false
+	}
+	return nil, false
+}
+
+// objectPath walks a path through nested objects, returning false if any
+// non-object value is encountered along the way. Paths that traverse arrays
+// should be resolved with gabs in order to retain array expansion behaviour.
+func objectPath(v any, path []string) (any, bool) {
+	for _, seg := range path {
+		obj, isObj := v.(map[string]any)
+		if !isObj {
+			return nil, false
+		}
+		v = obj[seg]
+	}
+	return v, true
+}
+
+func dotPathPrefixKeys(path []string) []string {
+	if len(path) < 2 {
+		return nil
+	}
+	keys := make([]string, 0, len(path)-1)
+	for i := 2; i <= len(path); i++ {
+		keys = append(keys, SliceToDotPath(path[:i]...))
+	}
+	return keys
+}
+
+//------------------------------------------------------------------------------
+
+// jsonNormalised attempts to produce a copy of a structured message value that
+// is identical to the result of serialising the value as JSON and parsing it
+// back without number preservation. This allows us to skip the round trip
+// entirely when a message already has a cached structured value. Returns false
+// if the value contains types where the round trip cannot be reproduced
+// precisely.
+func jsonNormalised(v any) (any, bool) {
+	switch t := v.(type) {
+	case nil, bool:
+		return t, true
+	case string:
+		// Invalid UTF-8 is replaced during serialisation.
+		if !utf8.ValidString(t) {
+			return nil, false
+		}
+		return t, true
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return nil, false
+		}
+		return t, true
+	case json.Number:
+		f, err := t.Float64()
+		if err != nil {
+			return nil, false
+		}
+		return f, true
+	case int64:
+		return float64(t), true
+	case uint64:
+		return float64(t), true
+	case int:
+		return float64(t), true
+	case []any:
+		res := make([]any, len(t))
+		for i, e := range t {
+			var ok bool
+			if res[i], ok = jsonNormalised(e); !ok {
+				return nil, false
+			}
+		}
+		return res, true
+	case map[string]any:
+		res := make(map[string]any, len(t))
+		for k, e := range t {
+			if !utf8.ValidString(k) {
+				return nil, false
+			}
+			ne, ok := jsonNormalised(e)
+			if !ok {
+				return nil, false
+			}
+			res[k] = ne
+		}
+		return res, true
+	}
+	return nil, false
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package query
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/internal/message"
+)
+
+func TestConstantFolding(t *testing.T) {
+	upper, err := InitMethodHelper("uppercase", NewLiteralFunction("", "foo"))
+	require.NoError(t, err)
+	require.IsType(t, &constant{}, upper)
+	assert.Equal(t, "method uppercase", upper.Annotation())
+
+	concat, err := NewArithmeticExpression(
+		[]Function{upper, NewLiteralFunction("", "bar")},
+		[]ArithmeticOperator{ArithmeticAdd},
+	)
+	require.NoError(t, err)
+	require.IsType(t, &constant{}, concat)
+
+	v, err := concat.Exec(FunctionContext{})
+	require.NoError(t, err)
+	assert.Equal(t, "FOObar", v)
+
+	// Failed operations are deferred until runtime
+	failedSub, err := NewArithmeticExpression(
+		[]Function{upper, NewLiteralFunction("", int64(5))},
+		[]ArithmeticOperator{ArithmeticSub},
+	)
+	require.NoError(t, err)
+	assert.IsType(t, closureFunction{}, failedSub)
+
+	_, err = failedSub.Exec(FunctionContext{})
+	require.Error(t, err)
+
+	failedNum, err := InitMethodHelper("number", NewLiteralFunction("", "nope"))
+	require.NoError(t, err)
+	assert.IsType(t, closureFunction{}, failedNum)
+
+	// Mutable results are not shared between executions
+	decoded, err := InitMethodHelper("decode", NewLiteralFunction("", "Zm9v"), "base64")
+	require.NoError(t, err)
+	assert.IsType(t, &simpleMethodFn{}, decoded)
+
+	// Methods with query parameters are not folded
+	caught, err := InitMethodHelper("catch", NewLiteralFunction("", "foo"), NewFieldFunction("bar"))
+	require.NoError(t, err)
+	assert.IsType(t, closureFunction{}, caught)
+}
+
+func TestInlinedVarFunction(t *testing.T) {
+	fn := NewInlinedVarFunction("foo", "bar")
+	assert.Equal(t, "variable foo", fn.Annotation())
+
+	_, targets := fn.QueryTargets(TargetsContext{})
+	assert.Equal(t, []TargetPath{NewTargetPath(TargetVariable, "foo")}, targets)
+
+	v, err := fn.Exec(FunctionContext{})
+	require.NoError(t, err)
+	assert.Equal(t, "bar", v)
+}
+
+func TestVarCandidate(t *testing.T) {
+	once := NewVarCandidate("foo", nil, NewFieldFunction("bar"))
+	onceRef, err := InitMethodHelper("uppercase", once.Reference())
+	require.NoError(t, err)
+	require.True(t, once.Inline(onceRef))
+
+	v, err := onceRef.Exec(FunctionContext{}.WithValue(map[string]any{"bar": "baz"}))
+	require.NoError(t, err)
+	assert.Equal(t, "BAZ", v)
+
+	_, targets := onceRef.QueryTargets(TargetsContext{})
+	assert.Equal(t, []TargetPath{NewTargetPath(TargetValue, "bar")}, targets)
+
+	twice := NewVarCandidate("foo", nil, NewFieldFunction("bar"))
+	twiceRef, err := NewArithmeticExpression(
+		[]Function{twice.Reference(), twice.Reference()},
+		[]ArithmeticOperator{ArithmeticAdd},
+	)
+	require.NoError(t, err)
+	assert.False(t, twice.Inline(twiceRef))
+
+	// References that are not executed first cannot be inlined
+	trailing := NewVarCandidate("foo", nil, NewFieldFunction("bar"))
+	trailingRef, err := NewArithmeticExpression(
+		[]Function{NewFieldFunction("baz"), trailing.Reference()},
+		[]ArithmeticOperator{ArithmeticAdd},
+	)
+	require.NoError(t, err)
+	assert.False(t, trailing.Inline(trailingRef))
+
+	v, err = trailingRef.Exec(FunctionContext{
+		Vars: map[string]any{"foo": "buz"},
+	}.WithValue(map[string]any{"baz": "qux"}))
+	require.NoError(t, err)
+	assert.Equal(t, "quxbuz", v)
+}
+
+func TestPathCache(t *testing.T) {
+	doc := map[string]any{
+		"a": map[string]any{
+			"b": map[string]any{
+				"c": "first",
+				"d": []any{"second"},
+			},
+		},
+	}
+
+	hoisted := NewHoistedPaths([]TargetPath{
+		NewTargetPath(TargetValue, "a", "b", "c"),
+		NewTargetPath(TargetValue, "a", "b", "d", "0"),
+		NewTargetPath(TargetValue, "x"),
+		NewTargetPath(TargetValue, "x"),
+		NewTargetPath(TargetVariable, "a", "b"),
+	})
+	require.NotNil(t, hoisted)
+	assert.Equal(t, map[string]struct{}{
+		"a.b": {},
+	}, hoisted.prefixes)
+
+	ctx := FunctionContext{}.WithValueFunc(func() *any {
+		var v any = doc
+		return &v
+	}).WithPathCache(hoisted.NewCache())
+
+	for _, test := range []struct {
+		path   string
+		output any
+	}{
+		{path: "a.b.c", output: "first"},
+		{path: "a.b.d.0", output: "second"},
+		{path: "a.b.e", output: nil},
+		{path: "a.b", output: doc["a"].(map[string]any)["b"]},
+	} {
+		v, err := NewFieldFunction(test.path).Exec(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, test.output, v, test.path)
+	}
+	assert.Len(t, ctx.pathCache.values, 1)
+
+	assert.Nil(t, NewHoistedPaths([]TargetPath{
+		NewTargetPath(TargetValue, "a", "b"),
+		NewTargetPath(TargetValue, "a", "c"),
+	}))
+}
+
+func TestContentParseJSONStructured(t *testing.T) {
+	fn, err := InitFunctionHelper("content")
+	require.NoError(t, err)
+
+	parseJSON, err := InitMethodHelper("parse_json", fn)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		structured any
+		output     any
+	}{
+		"json numbers": {
+			structured: map[string]any{"a": json.Number("5"), "b": []any{json.Number("1.5"), "c"}},
+			output:     map[string]any{"a": 5.0, "b": []any{1.5, "c"}},
+		},
+		"go integers": {
+			structured: map[string]any{"a": int64(5), "b": uint64(6), "c": 7},
+			output:     map[string]any{"a": 5.0, "b": 6.0, "c": 7.0},
+		},
+		"byte values": {
+			structured: map[string]any{"a": []byte("foo")},
+			output:     map[string]any{"a": "Zm9v"},
+		},
+		"scalar": {
+			structured: "foo",
+			output:     "foo",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			part := message.NewPart(nil)
+			part.SetStructured(test.structured)
+
+			v, err := parseJSON.Exec(FunctionContext{
+				MsgBatch: message.Batch{part},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, test.output, v)
+
+			// Compare with the result of parsing the serialised message
+			rawPart := message.NewPart(part.AsBytes())
+			v, err = parseJSON.Exec(FunctionContext{
+				MsgBatch: message.Batch{rawPart},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, test.output, v)
+		})
+	}
+}
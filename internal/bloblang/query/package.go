@@ -59,6 +59,8 @@ type FunctionContext struct {
 	value      *any
 	nextValue  *any
 	namedValue *namedContextValue
+	pathCache  *PathCache
+	budget     *BudgetTracker
 
 	// Used to track how many maps we've entered.
 	stackCount int
@@ -77,6 +79,18 @@ func (ctx FunctionContext) IncrStackCount() (FunctionContext, int) { //nolint: g
 	return ctx, ctx.stackCount
 }
 
+// WithBudget returns a FunctionContext where the resources consumed by the
+// execution are tracked against a budget.
+func (ctx FunctionContext) WithBudget(t *BudgetTracker) FunctionContext {
+	ctx.budget = t
+	return ctx
+}
+
+// Budget returns the tracker of the execution budget if one has been set.
+func (ctx FunctionContext) Budget() *BudgetTracker {
+	return ctx.budget
+}
+
 // NamedValue returns the value of a named context if it exists.
 func (ctx FunctionContext) NamedValue(name string) (any, bool) {
 	current := ctx.namedValue
@@ -115,6 +129,15 @@ func (ctx FunctionContext) Value() *any {
 // WithValueFunc returns a function context with a new value func.
 func (ctx FunctionContext) WithValueFunc(fn func() *any) FunctionContext {
 	ctx.valueFn = fn
+	ctx.pathCache = nil
+	return ctx
+}
+
+// WithPathCache returns a function context where lookups of hoisted paths from
+// the value func are cached. The value returned by the value func must not be
+// mutated for the lifetime of the cache.
+func (ctx FunctionContext) WithPathCache(c *PathCache) FunctionContext {
+	ctx.pathCache = c
 	return ctx
 }
 
@@ -0,0 +1,206 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package query
+
+import (
+	"strconv"
+
+	"github.com/redpanda-data/benthos/v4/internal/value"
+)
+
+// PatternBinding describes a name that is bound by a pattern when it matches,
+// along with the path of the bound value relative to the matched value.
+type PatternBinding struct {
+	Name string
+	Path []string
+}
+
+// Pattern describes the structure of a value that a match case expects, and
+// may bind parts of that value to names that can be referenced from within the
+// body of the case.
+type Pattern interface {
+	// Match checks whether a value matches the pattern and, if so, returns a
+	// context with all bound names added as named values.
+	Match(v any, ctx FunctionContext) (FunctionContext, bool)
+
+	// Bindings returns the names bound by the pattern.
+	Bindings() []PatternBinding
+}
+
+//------------------------------------------------------------------------------
+
+type bindingPattern struct {
+	name string
+	t    value.Type
+}
+
+// NewBindingPattern returns a pattern that binds the matched value to a name.
+// If the type is not empty then the pattern only matches values of that type.
+// An empty name or `_` matches without binding the value, and therefore an
+// untyped pattern with no name is a wildcard.
+func NewBindingPattern(name string, t value.Type) Pattern {
+	if name == "_" {
+		name = ""
+	}
+	return bindingPattern{name: name, t: t}
+}
+
+func (b bindingPattern) Match(v any, ctx FunctionContext) (FunctionContext, bool) {
+	if b.t != "" && value.ITypeOf(v) != b.t {
+		return ctx, false
+	}
+	if b.name != "" {
+		ctx = ctx.WithNamedValue(b.name, v)
+	}
+	return ctx, true
+}
+
+func (b bindingPattern) Bindings() []PatternBinding {
+	if b.name == "" {
+		return nil
+	}
+	return []PatternBinding{{Name: b.name}}
+}
+
+//------------------------------------------------------------------------------
+
+type literalPattern struct {
+	value any
+}
+
+// NewLiteralPattern returns a pattern that matches values equal to a literal.
+// Unlike the comparison of literal match cases the type of the value must also
+// match, and therefore a pattern `true` does not match the number `5`.
+func NewLiteralPattern(v any) Pattern {
+	return literalPattern{value: v}
+}
+
+func (l literalPattern) Match(v any, ctx FunctionContext) (FunctionContext, bool) {
+	return ctx, value.ITypeOf(l.value) == value.ITypeOf(v) && value.ICompare(l.value, v)
+}
+
+func (literalPattern) Bindings() []PatternBinding {
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+type objectPattern struct {
+	keys     []string
+	values   []Pattern
+	hasRest  bool
+	restName string
+}
+
+// NewObjectPattern returns a pattern that matches objects containing each of
+// the provided keys, where the value of each key matches its respective
+// pattern. When hasRest is false the object must not contain any other keys,
+// otherwise any remaining keys are bound as an object to restName, which may
+// be empty or `_` in order to discard them.
+func NewObjectPattern(keys []string, values []Pattern, hasRest bool, restName string) Pattern {
+	if restName == "_" {
+		restName = ""
+	}
+	return objectPattern{keys: keys, values: values, hasRest: hasRest, restName: restName}
+}
+
+func (o objectPattern) Match(v any, ctx FunctionContext) (FunctionContext, bool) {
+	obj, isObj := v.(map[string]any)
+	if !isObj {
+		return ctx, false
+	}
+	if len(obj) < len(o.keys) || (!o.hasRest && len(obj) != len(o.keys)) {
+		return ctx, false
+	}
+	for i, k := range o.keys {
+		kv, exists := obj[k]
+		if !exists {
+			return ctx, false
+		}
+		var matched bool
+		if ctx, matched = o.values[i].Match(kv, ctx); !matched {
+			return ctx, false
+		}
+	}
+	if o.restName != "" {
+		rest := make(map[string]any, len(obj)-len(o.keys))
+		for k, kv := range obj {
+			rest[k] = kv
+		}
+		for _, k := range o.keys {
+			delete(rest, k)
+		}
+		ctx = ctx.WithNamedValue(o.restName, rest)
+	}
+	return ctx, true
+}
+
+func (o objectPattern) Bindings() []PatternBinding {
+	var bindings []PatternBinding
+	for i, k := range o.keys {
+		for _, b := range o.values[i].Bindings() {
+			b.Path = append([]string{k}, b.Path...)
+			bindings = append(bindings, b)
+		}
+	}
+	if o.restName != "" {
+		bindings = append(bindings, PatternBinding{Name: o.restName})
+	}
+	return bindings
+}
+
+//------------------------------------------------------------------------------
+
+type arrayPattern struct {
+	elements []Pattern
+	hasRest  bool
+	restName string
+}
+
+// NewArrayPattern returns a pattern that matches arrays where each element
+// matches its respective pattern. When hasRest is false the array must be
+// exactly the length of the provided patterns, otherwise any remaining
+// elements are bound as an array to restName, which may be empty or `_` in
+// order to discard them.
+func NewArrayPattern(elements []Pattern, hasRest bool, restName string) Pattern {
+	if restName == "_" {
+		restName = ""
+	}
+	return arrayPattern{elements: elements, hasRest: hasRest, restName: restName}
+}
+
+func (a arrayPattern) Match(v any, ctx FunctionContext) (FunctionContext, bool) {
+	arr, isArr := v.([]any)
+	if !isArr {
+		return ctx, false
+	}
+	if len(arr) < len(a.elements) || (!a.hasRest && len(arr) != len(a.elements)) {
+		return ctx, false
+	}
+	for i, p := range a.elements {
+		var matched bool
+		if ctx, matched = p.Match(arr[i], ctx); !matched {
+			return ctx, false
+		}
+	}
+	if a.restName != "" {
+		rest := make([]any, len(arr)-len(a.elements))
+		copy(rest, arr[len(a.elements):])
+		ctx = ctx.WithNamedValue(a.restName, rest)
+	}
+	return ctx, true
+}
+
+func (a arrayPattern) Bindings() []PatternBinding {
+	var bindings []PatternBinding
+	for i, p := range a.elements {
+		for _, b := range p.Bindings() {
+			b.Path = append([]string{strconv.Itoa(i)}, b.Path...)
+			bindings = append(bindings, b)
+		}
+	}
+	if a.restName != "" {
+		bindings = append(bindings, PatternBinding{Name: a.restName})
+	}
+	return bindings
+}
@@ -132,6 +132,17 @@ func (ctx TargetsContext) WithValuesAsContext() TargetsContext {
 	return ctx
 }
 
+// withNamedPaths returns a targets context with a named context added that
+// refers to the provided paths.
+func (ctx TargetsContext) withNamedPaths(name string, paths []TargetPath) TargetsContext {
+	ctx.namedContext = &namedContextPath{
+		name:  name,
+		paths: paths,
+		next:  ctx.namedContext,
+	}
+	return ctx
+}
+
 // WithContextAsNamed moves the latest context into a named context and returns
 // the context prior to that one to the main context. This is a way for named
 // context mappings to correct the contexts so that the child query function
@@ -0,0 +1,46 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+// Package bytesutil provides helpers for decoding binary formats.
+package bytesutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// MaxPrealloc is the maximum number of bytes or elements that are allocated
+// up front based on a length declared by encoded data, which prevents small
+// inputs from causing large allocations.
+const MaxPrealloc = 1024
+
+// ReadN reads exactly n bytes from a reader, returning io.ErrUnexpectedEOF if
+// the reader ends first. Large lengths are read incrementally so that
+// allocations are bound by the size of the data rather than the declared
+// length.
+func ReadN(r io.Reader, n uint64) ([]byte, error) {
+	if n > math.MaxInt64 {
+		return nil, fmt.Errorf("declared length %v is too large", n)
+	}
+	if n <= MaxPrealloc {
+		b := make([]byte, n)
+		if _, err := io.ReadFull(r, b); err != nil {
+			if n > 0 && errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		return b, nil
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, r, int64(n)); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package bytesutil
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadN(t *testing.T) {
+	b, err := ReadN(bytes.NewReader([]byte("hello world")), 5)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(b))
+
+	large := bytes.Repeat([]byte("a"), MaxPrealloc*2)
+	b, err = ReadN(bytes.NewReader(large), uint64(len(large)))
+	require.NoError(t, err)
+	assert.Equal(t, large, b)
+
+	_, err = ReadN(bytes.NewReader([]byte("hi")), 5)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	_, err = ReadN(bytes.NewReader(large), MaxPrealloc*3)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	_, err = ReadN(bytes.NewReader(large), math.MaxUint64)
+	require.ErrorContains(t, err, "too large")
+}
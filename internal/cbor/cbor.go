@@ -0,0 +1,567 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+// Package cbor implements the encoding and decoding of CBOR (RFC 8949) values
+// into and from the generic value types used by Bloblang and messages.
+package cbor
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/redpanda-data/benthos/v4/internal/bytesutil"
+	"github.com/redpanda-data/benthos/v4/internal/value"
+)
+
+const (
+	majorUint = iota
+	majorNegInt
+	majorBytes
+	majorText
+	majorArray
+	majorMap
+	majorTag
+	majorSimple
+)
+
+const (
+	tagDateTimeString = 0
+	tagEpochDateTime  = 1
+	tagPosBignum      = 2
+	tagNegBignum      = 3
+)
+
+// The additional information value of indefinite length items.
+const infoIndefinite = 31
+
+// The maximum nesting of arrays, maps and tags allowed when decoding, which
+// protects against exhausting the stack with malicious input.
+const maxDepth = 10000
+
+// ErrMaxDepth is returned when decoding a value that is nested too deeply.
+var ErrMaxDepth = errors.New("exceeded maximum nesting depth")
+
+// breakMarker is returned when decoding the break stop code of indefinite
+// length items.
+type breakMarker struct{}
+
+// Decoder reads and decodes successive CBOR data items from a stream.
+type Decoder struct {
+	r   *bufio.Reader
+	raw *bytes.Buffer
+}
+
+// NewDecoder returns a decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+// Decode reads the next data item from the stream. Maps are decoded as
+// map[string]any, where keys that are not strings are converted into strings,
+// integers are decoded as int64 (or uint64 when they exceed the range of an
+// int64, and float64 for negative integers and bignums beyond the range of
+// both), floats as float64, byte strings as []byte and date/time tags as
+// time.Time. Undefined is decoded as nil and other tags are decoded as their
+// tagged content.
+//
+// Returns io.EOF if the stream ended before the start of a data item, and
+// io.ErrUnexpectedEOF if it ended within a data item.
+func (d *Decoder) Decode() (any, error) {
+	if _, err := d.r.Peek(1); err != nil {
+		return nil, err
+	}
+	v, err := d.decode(0)
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	if _, isBreak := v.(breakMarker); isBreak {
+		return nil, errors.New("unexpected break stop code")
+	}
+	return v, err
+}
+
+// DecodeRaw reads the next data item from the stream and returns its encoded
+// bytes after validating it.
+func (d *Decoder) DecodeRaw() ([]byte, error) {
+	d.raw = &bytes.Buffer{}
+	defer func() {
+		d.raw = nil
+	}()
+	if _, err := d.Decode(); err != nil {
+		return nil, err
+	}
+	return d.raw.Bytes(), nil
+}
+
+// Unmarshal decodes a single CBOR data item, returning an error if the data
+// contains anything after the item.
+func Unmarshal(data []byte) (any, error) {
+	d := NewDecoder(bytes.NewReader(data))
+	v, err := d.Decode()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if _, err := d.r.Peek(1); err == nil {
+		return nil, errors.New("unexpected data after data item")
+	}
+	return v, nil
+}
+
+func (d *Decoder) readByte() (byte, error) {
+	b, err := d.r.ReadByte()
+	if err == nil && d.raw != nil {
+		d.raw.WriteByte(b)
+	}
+	return b, err
+}
+
+func (d *Decoder) readN(n uint64) ([]byte, error) {
+	b, err := bytesutil.ReadN(d.r, n)
+	if err != nil {
+		return nil, err
+	}
+	if d.raw != nil {
+		d.raw.Write(b)
+	}
+	return b, nil
+}
+
+// readArgument reads the argument of a data item that follows its initial
+// byte.
+func (d *Decoder) readArgument(info byte) (uint64, error) {
+	if info < 24 {
+		return uint64(info), nil
+	}
+	var size uint64
+	switch info {
+	case 24:
+		size = 1
+	case 25:
+		size = 2
+	case 26:
+		size = 4
+	case 27:
+		size = 8
+	default:
+		return 0, fmt.Errorf("invalid additional information %v", info)
+	}
+	b, err := d.readN(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+func (d *Decoder) decode(depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, ErrMaxDepth
+	}
+
+	b, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	major, info := b>>5, b&0x1f
+
+	if info == infoIndefinite {
+		switch major {
+		case majorBytes, majorText:
+			return d.decodeIndefiniteString(major, depth)
+		case majorArray:
+			return d.decodeArray(0, true, depth)
+		case majorMap:
+			return d.decodeMap(0, true, depth)
+		case majorSimple:
+			return breakMarker{}, nil
+		}
+		return nil, fmt.Errorf("invalid indefinite length for major type %v", major)
+	}
+
+	if major == majorSimple {
+		return d.decodeSimple(info)
+	}
+
+	arg, err := d.readArgument(info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case majorUint:
+		if arg > math.MaxInt64 {
+			return arg, nil
+		}
+		return int64(arg), nil
+	case majorNegInt:
+		if arg > math.MaxInt64 {
+			return -1 - float64(arg), nil
+		}
+		return -1 - int64(arg), nil
+	case majorBytes:
+		return d.readN(arg)
+	case majorText:
+		s, err := d.readN(arg)
+		if err != nil {
+			return nil, err
+		}
+		return string(s), nil
+	case majorArray:
+		return d.decodeArray(arg, false, depth)
+	case majorMap:
+		return d.decodeMap(arg, false, depth)
+	}
+
+	v, err := d.decode(depth + 1)
+	if err != nil {
+		return nil, err
+	}
+	return decodeTag(arg, v)
+}
+
+func (d *Decoder) decodeSimple(info byte) (any, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25:
+		b, err := d.readN(2)
+		if err != nil {
+			return nil, err
+		}
+		return halfToFloat(binary.BigEndian.Uint16(b)), nil
+	case 26:
+		b, err := d.readN(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), nil
+	case 27:
+		b, err := d.readN(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), nil
+	}
+	return nil, fmt.Errorf("unsupported simple value %v", info)
+}
+
+func halfToFloat(h uint16) float64 {
+	exp, mant := (h>>10)&0x1f, h&0x3ff
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(float64(mant), -24)
+	case 0x1f:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(float64(mant|0x400), int(exp)-25)
+	}
+	if h&0x8000 != 0 {
+		f = -f
+	}
+	return f
+}
+
+func decodeTag(tag uint64, v any) (any, error) {
+	switch tag {
+	case tagDateTimeString:
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected text string content of date/time tag, got %T", v)
+		}
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse date/time tag: %w", err)
+		}
+		return t, nil
+	case tagEpochDateTime:
+		switch t := v.(type) {
+		case int64:
+			return time.Unix(t, 0).UTC(), nil
+		case uint64:
+			return time.Unix(int64(t), 0).UTC(), nil
+		case float64:
+			sec, frac := math.Modf(t)
+			return time.Unix(int64(sec), int64(frac*1e9)).UTC(), nil
+		}
+		return nil, fmt.Errorf("expected numeric content of epoch date/time tag, got %T", v)
+	case tagPosBignum, tagNegBignum:
+		b, ok := v.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("expected byte string content of bignum tag, got %T", v)
+		}
+		n := new(big.Int).SetBytes(b)
+		if tag == tagNegBignum {
+			n.Neg(n).Sub(n, big.NewInt(1))
+		}
+		if n.IsInt64() {
+			return n.Int64(), nil
+		}
+		if n.IsUint64() {
+			return n.Uint64(), nil
+		}
+		f, _ := new(big.Float).SetInt(n).Float64()
+		return f, nil
+	}
+	return v, nil
+}
+
+func (d *Decoder) decodeIndefiniteString(major byte, depth int) (any, error) {
+	var buf bytes.Buffer
+	for {
+		v, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		switch t := v.(type) {
+		case breakMarker:
+			if major == majorText {
+				return buf.String(), nil
+			}
+			return buf.Bytes(), nil
+		case []byte:
+			if major == majorBytes {
+				buf.Write(t)
+				continue
+			}
+		case string:
+			if major == majorText {
+				buf.WriteString(t)
+				continue
+			}
+		}
+		return nil, errors.New("invalid chunk within indefinite length string")
+	}
+}
+
+func (d *Decoder) decodeArray(n uint64, indefinite bool, depth int) (any, error) {
+	arr := make([]any, 0, min(n, bytesutil.MaxPrealloc))
+	for i := uint64(0); indefinite || i < n; i++ {
+		v, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if _, isBreak := v.(breakMarker); isBreak {
+			if !indefinite {
+				return nil, errors.New("unexpected break stop code")
+			}
+			break
+		}
+		arr = append(arr, v)
+	}
+	return arr, nil
+}
+
+func (d *Decoder) decodeMap(n uint64, indefinite bool, depth int) (any, error) {
+	obj := make(map[string]any, min(n, bytesutil.MaxPrealloc))
+	for i := uint64(0); indefinite || i < n; i++ {
+		k, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if _, isBreak := k.(breakMarker); isBreak {
+			if !indefinite {
+				return nil, errors.New("unexpected break stop code")
+			}
+			break
+		}
+		v, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if _, isBreak := v.(breakMarker); isBreak {
+			return nil, errors.New("unexpected break stop code")
+		}
+		obj[keyString(k)] = v
+	}
+	return obj, nil
+}
+
+func keyString(k any) string {
+	switch t := k.(type) {
+	case string:
+		return t
+	case []byte:
+		return string(t)
+	case int64:
+		return strconv.FormatInt(t, 10)
+	case uint64:
+		return strconv.FormatUint(t, 10)
+	case float64:
+		return strconv.FormatFloat(t, 'g', -1, 64)
+	case time.Time:
+		return t.Format(time.RFC3339Nano)
+	case nil:
+		return "null"
+	}
+	b, _ := json.Marshal(k)
+	return string(b)
+}
+
+//------------------------------------------------------------------------------
+
+// Marshal encodes a value as a CBOR data item. Floats that hold integer values
+// are encoded as integers, as numbers parsed from JSON documents are otherwise
+// always floats, and other floats are encoded with the smallest precision that
+// represents them exactly. Objects are encoded with their keys in
+// lexicographical order, and timestamps as RFC 3339 date/time strings.
+func Marshal(v any) ([]byte, error) {
+	return appendValue(nil, v, 0)
+}
+
+func appendHead(b []byte, major byte, arg uint64) []byte {
+	major <<= 5
+	switch {
+	case arg < 24:
+		return append(b, major|byte(arg))
+	case arg <= math.MaxUint8:
+		return append(b, major|24, byte(arg))
+	case arg <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, major|25), uint16(arg))
+	case arg <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, major|26), uint32(arg))
+	}
+	return binary.BigEndian.AppendUint64(append(b, major|27), arg)
+}
+
+func appendInt(b []byte, i int64) []byte {
+	if i >= 0 {
+		return appendHead(b, majorUint, uint64(i))
+	}
+	return appendHead(b, majorNegInt, uint64(-(i + 1)))
+}
+
+func appendFloat(b []byte, f float64) []byte {
+	if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+		return appendInt(b, int64(f))
+	}
+	if f32 := float32(f); float64(f32) == f {
+		return binary.BigEndian.AppendUint32(append(b, majorSimple<<5|26), math.Float32bits(f32))
+	}
+	return binary.BigEndian.AppendUint64(append(b, majorSimple<<5|27), math.Float64bits(f))
+}
+
+func appendString(b []byte, s string) []byte {
+	return append(appendHead(b, majorText, uint64(len(s))), s...)
+}
+
+func appendValue(b []byte, v any, depth int) ([]byte, error) {
+	if depth > maxDepth {
+		return nil, ErrMaxDepth
+	}
+	switch t := v.(type) {
+	case nil:
+		return append(b, majorSimple<<5|22), nil
+	case bool:
+		if t {
+			return append(b, majorSimple<<5|21), nil
+		}
+		return append(b, majorSimple<<5|20), nil
+	case int:
+		return appendInt(b, int64(t)), nil
+	case int8:
+		return appendInt(b, int64(t)), nil
+	case int16:
+		return appendInt(b, int64(t)), nil
+	case int32:
+		return appendInt(b, int64(t)), nil
+	case int64:
+		return appendInt(b, t), nil
+	case uint:
+		return appendHead(b, majorUint, uint64(t)), nil
+	case uint8:
+		return appendHead(b, majorUint, uint64(t)), nil
+	case uint16:
+		return appendHead(b, majorUint, uint64(t)), nil
+	case uint32:
+		return appendHead(b, majorUint, uint64(t)), nil
+	case uint64:
+		return appendHead(b, majorUint, t), nil
+	case float32:
+		return appendFloat(b, float64(t)), nil
+	case float64:
+		return appendFloat(b, t), nil
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return appendInt(b, i), nil
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return appendFloat(b, f), nil
+	case value.Decimal:
+		if t.IsInteger() {
+			if i, err := t.Int64(); err == nil {
+				return appendInt(b, i), nil
+			}
+			if u, err := t.Uint64(); err == nil {
+				return appendHead(b, majorUint, u), nil
+			}
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return appendFloat(b, f), nil
+	case string:
+		return appendString(b, t), nil
+	case []byte:
+		return append(appendHead(b, majorBytes, uint64(len(t))), t...), nil
+	case time.Time:
+		b = appendHead(b, majorTag, tagDateTimeString)
+		return appendString(b, t.Format(time.RFC3339Nano)), nil
+	case []any:
+		b = appendHead(b, majorArray, uint64(len(t)))
+		var err error
+		for _, e := range t {
+			if b, err = appendValue(b, e, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[string]any:
+		b = appendHead(b, majorMap, uint64(len(t)))
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var err error
+		for _, k := range keys {
+			b = appendString(b, k)
+			if b, err = appendValue(b, t[k], depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("unsupported value type %T", v)
+}
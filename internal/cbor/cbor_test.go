@@ -0,0 +1,201 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package cbor
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/internal/value"
+)
+
+func TestUnmarshal(t *testing.T) {
+	// Examples from RFC 8949 Appendix A.
+	tests := map[string]any{
+		"00":                     int64(0),
+		"17":                     int64(23),
+		"1818":                   int64(24),
+		"1903e8":                 int64(1000),
+		"1b000000e8d4a51000":     int64(1000000000000),
+		"1bffffffffffffffff":     uint64(18446744073709551615),
+		"c249010000000000000000": float64(18446744073709551616),
+		"3bffffffffffffffff":     float64(-18446744073709551616),
+		"20":                     int64(-1),
+		"3903e7":                 int64(-1000),
+		"f90000":                 float64(0),
+		"f93c00":                 float64(1),
+		"f93e00":                 1.5,
+		"f97bff":                 float64(65504),
+		"fa47c35000":             float64(100000),
+		"fb7e37e43c8800759c":     1.0e+300,
+		"f90001":                 5.960464477539063e-8,
+		"f9c400":                 float64(-4),
+		"f97c00":                 math.Inf(1),
+		"f4":                     false,
+		"f5":                     true,
+		"f6":                     nil,
+		"f7":                     nil,
+		"c074323031332d30332d32315432303a30343a30305a": time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC),
+		"c11a514b67b0":               time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC),
+		"c1fb41d452d9ec200000":       time.Date(2013, 3, 21, 20, 4, 0, 500000000, time.UTC),
+		"d74401020304":               []byte{1, 2, 3, 4},
+		"4401020304":                 []byte{1, 2, 3, 4},
+		"6161":                       "a",
+		"62c3bc":                     "ü",
+		"80":                         []any{},
+		"83010203":                   []any{int64(1), int64(2), int64(3)},
+		"8301820203820405":           []any{int64(1), []any{int64(2), int64(3)}, []any{int64(4), int64(5)}},
+		"a201020304":                 map[string]any{"1": int64(2), "3": int64(4)},
+		"a26161016162820203":         map[string]any{"a": int64(1), "b": []any{int64(2), int64(3)}},
+		"5f42010243030405ff":         []byte{1, 2, 3, 4, 5},
+		"7f657374726561646d696e67ff": "streaming",
+		"9f018202039f0405ffff":       []any{int64(1), []any{int64(2), int64(3)}, []any{int64(4), int64(5)}},
+		"bf61610161629f0203ffff":     map[string]any{"a": int64(1), "b": []any{int64(2), int64(3)}},
+	}
+
+	for input, exp := range tests {
+		t.Run(input, func(t *testing.T) {
+			b, err := hex.DecodeString(input)
+			require.NoError(t, err)
+
+			v, err := Unmarshal(b)
+			require.NoError(t, err)
+			assert.Equal(t, exp, v)
+		})
+	}
+
+	nan, err := Unmarshal([]byte{0xf9, 0x7e, 0x00})
+	require.NoError(t, err)
+	assert.True(t, math.IsNaN(nan.(float64)))
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := map[string]string{
+		"":                   "unexpected EOF",
+		"1a0000":             "unexpected EOF",
+		"62c3":               "unexpected EOF",
+		"0000":               "unexpected data after data item",
+		"ff":                 "unexpected break stop code",
+		"8201ff":             "unexpected break stop code",
+		"1c":                 "invalid additional information 28",
+		"5f6161ff":           "invalid chunk within indefinite length string",
+		"c06161":             "failed to parse date/time tag",
+		"5b00000000ffffffff": "unexpected EOF",
+		"5bffffffffffffffff": "declared length 18446744073709551615 is too large",
+		"7b8000000000000000": "declared length 9223372036854775808 is too large",
+	}
+
+	for input, exp := range tests {
+		t.Run(input, func(t *testing.T) {
+			b, err := hex.DecodeString(input)
+			require.NoError(t, err)
+
+			_, err = Unmarshal(b)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), exp)
+		})
+	}
+
+	_, err := Unmarshal(bytes.Repeat([]byte{0x81}, maxDepth+2))
+	require.ErrorIs(t, err, ErrMaxDepth)
+}
+
+func mustDecimal(t testing.TB, s string) value.Decimal {
+	t.Helper()
+	d, err := value.NewDecimalFromString(s)
+	require.NoError(t, err)
+	return d
+}
+
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		input any
+		exp   string
+	}{
+		{input: 0, exp: "00"},
+		{input: int64(1000000000000), exp: "1b000000e8d4a51000"},
+		{input: uint64(18446744073709551615), exp: "1bffffffffffffffff"},
+		{input: -1000, exp: "3903e7"},
+		{input: float64(24), exp: "1818"},
+		{input: 1.5, exp: "fa3fc00000"},
+		{input: 1.1, exp: "fb3ff199999999999a"},
+		{input: false, exp: "f4"},
+		{input: nil, exp: "f6"},
+		{input: "a", exp: "6161"},
+		{input: []byte{1, 2, 3, 4}, exp: "4401020304"},
+		{input: []any{1, []any{2, 3}}, exp: "8201820203"},
+		{input: map[string]any{"b": []any{2, 3}, "a": 1}, exp: "a26161016162820203"},
+		{input: time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC), exp: "c074323031332d30332d32315432303a30343a30305a"},
+		{input: mustDecimal(t, "24.0"), exp: "1818"},
+		{input: mustDecimal(t, "18446744073709551615"), exp: "1bffffffffffffffff"},
+		{input: mustDecimal(t, "1.1"), exp: "fb3ff199999999999a"},
+	}
+
+	for _, test := range tests {
+		b, err := Marshal(test.input)
+		require.NoError(t, err)
+		assert.Equal(t, test.exp, hex.EncodeToString(b), "%v", test.input)
+	}
+
+	_, err := Marshal(struct{}{})
+	require.Error(t, err)
+}
+
+func TestRoundTrip(t *testing.T) {
+	input := map[string]any{
+		"str":   "hello world",
+		"long":  strings.Repeat("a", 70000),
+		"bytes": bytes.Repeat([]byte{0xff}, 300),
+		"int":   int64(-300000),
+		"float": 0.333,
+		"arr":   []any{true, nil, "x", map[string]any{"nested": int64(1)}},
+		"time":  time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC),
+	}
+
+	b, err := Marshal(input)
+	require.NoError(t, err)
+
+	v, err := Unmarshal(b)
+	require.NoError(t, err)
+	assert.Equal(t, input, v)
+}
+
+func TestDecoderStream(t *testing.T) {
+	var stream []byte
+	for _, v := range []any{"a", int64(2), map[string]any{"c": []any{}}} {
+		b, err := Marshal(v)
+		require.NoError(t, err)
+		stream = append(stream, b...)
+	}
+
+	d := NewDecoder(bytes.NewReader(stream))
+
+	v, err := d.Decode()
+	require.NoError(t, err)
+	assert.Equal(t, "a", v)
+
+	raw, err := d.DecodeRaw()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x02}, raw)
+
+	raw, err = d.DecodeRaw()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xa1, 0x61, 0x63, 0x80}, raw)
+
+	_, err = d.Decode()
+	require.ErrorIs(t, err, io.EOF)
+
+	d = NewDecoder(bytes.NewReader(stream[:len(stream)-1]))
+	_, _ = d.Decode()
+	_, _ = d.Decode()
+	_, err = d.Decode()
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
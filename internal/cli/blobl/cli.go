@@ -81,6 +81,7 @@ Find out more about Bloblang at: {{.DocumentationURL}}/guides/bloblang/about`)[1
 			return run(ctx, opts)
 		},
 		Subcommands: []*cli.Command{
+			fmtCommand(opts),
 			{
 				Name:  "server",
 				Usage: "EXPERIMENTAL: Run a web server that hosts a Bloblang app",
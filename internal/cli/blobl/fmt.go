@@ -0,0 +1,126 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package blobl
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/parser"
+	"github.com/redpanda-data/benthos/v4/internal/cli/common"
+	"github.com/redpanda-data/benthos/v4/internal/filepath/ifs"
+)
+
+func fmtCommand(opts *common.CLIOpts) *cli.Command {
+	return &cli.Command{
+		Name:  "fmt",
+		Usage: "Format Bloblang mapping files",
+		Description: opts.ExecTemplate(`
+Formats one or more Bloblang mapping files into their canonical form and prints
+the result to stdout. Comments are preserved and the indentation of blocks and
+long method chains is normalised:
+
+  {{.BinaryName}} blobl fmt ./mappings/foo.blobl
+
+Use the -w flag in order to write the result back to the source files instead:
+
+  {{.BinaryName}} blobl fmt -w ./mappings/*.blobl
+
+When no files are specified the mapping is read from stdin.`)[1:],
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:    "write",
+				Aliases: []string{"w"},
+				Value:   false,
+				Usage:   "write the formatted result back to the source files rather than stdout.",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return runFmt(c, opts)
+		},
+	}
+}
+
+func formatMappingFile(opts *common.CLIOpts, path string, mapping []byte) (string, error) {
+	bEnv := opts.BloblEnvironment.Deactivated()
+	if path != "" {
+		bEnv = bEnv.WithImporterRelativeToFile(path)
+	}
+	if _, err := bEnv.NewMapping(string(mapping)); err != nil {
+		var perr *parser.Error
+		if errors.As(err, &perr) {
+			return "", fmt.Errorf("failed to parse mapping: %v", perr.ErrorAtPositionStructured("", []rune(string(mapping))))
+		}
+		return "", err
+	}
+
+	formatted, perr := parser.FormatMapping(string(mapping))
+	if perr != nil {
+		return "", fmt.Errorf("failed to format mapping: %v", perr.ErrorAtPositionStructured("", []rune(string(mapping))))
+	}
+	return formatted, nil
+}
+
+func runFmt(c *cli.Context, opts *common.CLIOpts) error {
+	write := c.Bool("write")
+	paths := c.Args().Slice()
+
+	if len(paths) == 0 {
+		if write {
+			return errors.New("cannot use the write flag when formatting stdin")
+		}
+		mapping, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to read stdin: %w", err)
+		}
+		formatted, err := formatMappingFile(opts, "", mapping)
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprint(opts.Stdout, formatted)
+		return nil
+	}
+
+	failed := false
+	for _, path := range paths {
+		mapping, err := ifs.ReadFile(ifs.OS(), path)
+		if err != nil {
+			fmt.Fprintln(opts.Stderr, red(fmt.Sprintf("%v: failed to read mapping file: %v", path, err)))
+			failed = true
+			continue
+		}
+
+		formatted, err := formatMappingFile(opts, path, mapping)
+		if err != nil {
+			fmt.Fprintln(opts.Stderr, red(fmt.Sprintf("%v: %v", path, err)))
+			failed = true
+			continue
+		}
+
+		if !write {
+			_, _ = fmt.Fprint(opts.Stdout, formatted)
+			continue
+		}
+		if formatted == string(mapping) {
+			continue
+		}
+
+		mode := os.FileMode(0o644)
+		if info, err := ifs.OS().Stat(path); err == nil {
+			mode = info.Mode()
+		}
+		if err := ifs.WriteFile(ifs.OS(), path, []byte(formatted), mode); err != nil {
+			fmt.Fprintln(opts.Stderr, red(fmt.Sprintf("%v: failed to write mapping file: %v", path, err)))
+			failed = true
+		}
+	}
+
+	if failed {
+		return &common.ErrExitCode{Err: errors.New("format errors"), Code: 1}
+	}
+	return nil
+}
@@ -35,6 +35,11 @@ func lintCliCommand(cliOpts *common.CLIOpts) *cli.Command {
 			Value: false,
 			Usage: "Print linting errors when components do not have labels.",
 		},
+		&cli.BoolFlag{
+			Name:  "bloblang-format",
+			Value: false,
+			Usage: "Print linting errors when Bloblang mappings within configs are not canonically formatted.",
+		},
 		&cli.BoolFlag{
 			Name:  "skip-env-var-check",
 			Value: false,
@@ -213,6 +218,7 @@ func LintAction(c *cli.Context, opts *common.CLIOpts, stderr io.Writer) error {
 	lConf.BloblangEnv = bloblang.XWrapEnvironment(opts.BloblEnvironment)
 	lConf.RejectDeprecated = c.Bool("deprecated")
 	lConf.RequireLabels = c.Bool("labels")
+	lConf.RequireFormattedBloblang = c.Bool("bloblang-format")
 	skipEnvVarCheck := c.Bool("skip-env-var-check")
 	verbose := c.Bool("verbose")
 
@@ -31,7 +31,7 @@ func (c CaseFailure) String() string {
 // using a JSON Pointer.
 type ProcProvider interface {
 	Provide(jsonPtr string, environment map[string]string, mocks map[string]any) ([]iprocessor.V1, error)
-	ProvideBloblang(path string) ([]iprocessor.V1, error)
+	ProvideBloblang(path, targetMap string) ([]iprocessor.V1, error)
 }
 
 // ExecuteFrom executes a test case from the perspective of a given directory,
@@ -39,7 +39,7 @@ type ProcProvider interface {
 func ExecuteFrom(fs fs.FS, dir string, c test.Case, provider ProcProvider) (failures []CaseFailure, err error) {
 	var procSet []iprocessor.V1
 	if c.TargetMapping != "" {
-		if procSet, err = provider.ProvideBloblang(c.TargetMapping); err != nil {
+		if procSet, err = provider.ProvideBloblang(c.TargetMapping, c.TargetMap); err != nil {
 			return nil, fmt.Errorf("failed to initialise Bloblang mapping '%v': %v", c.TargetMapping, err)
 		}
 	} else {
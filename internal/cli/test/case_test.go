@@ -32,7 +32,7 @@ func (m mockProvider) Provide(ptr string, env map[string]string, mocks map[strin
 	return nil, errors.New("processors not found")
 }
 
-func (m mockProvider) ProvideBloblang(name string) ([]processor.V1, error) {
+func (m mockProvider) ProvideBloblang(name, targetMap string) ([]processor.V1, error) {
 	if procs, ok := m[name]; ok {
 		return procs, nil
 	}
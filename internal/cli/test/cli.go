@@ -42,6 +42,10 @@ fail the process will report the errors and exit with a status code 1.
   {{.BinaryName}} test ./path/to/configs/...
   {{.BinaryName}} test ./foo_configs/*.yaml ./bar_configs/*.yaml
   {{.BinaryName}} test ./foo.yaml
+  {{.BinaryName}} test ./mappings/foo.blobl
+
+Bloblang files are tested with cases defined in # test: comment blocks within the
+file, or in an accompanying foo_benthos_test.yaml definition.
 
 For more information check out the docs at:
 {{.DocumentationURL}}/configuration/unit_testing`)[1:],
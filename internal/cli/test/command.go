@@ -77,18 +77,85 @@ func getDefinition(targetPath, definitionPath string) ([]test.Case, error) {
 	return cases, nil
 }
 
+// isBloblangPath returns whether a path is a Bloblang file, which can be the
+// target of tests defined within its comments or in an accompanying test
+// definition file.
+func isBloblangPath(path string) bool {
+	return filepath.Ext(path) == ".blobl"
+}
+
+// getBloblangPathPair returns the Bloblang file path and expected accompanying
+// test definition path for a path to either file, returns false if the path
+// does not belong to a Bloblang test target.
+func getBloblangPathPair(fullPath, testSuffix string) (bloblPath, definitionPath string, ok bool) {
+	configPath, definitionPath := GetPathPair(fullPath, testSuffix)
+	if isBloblangPath(configPath) {
+		return configPath, strings.TrimSuffix(definitionPath, ".blobl") + ".yaml", true
+	}
+	if configPath == filepath.Clean(fullPath) {
+		return "", "", false
+	}
+
+	// The path is a test definition, which targets a Bloblang file when a
+	// config of the same name does not exist.
+	if _, err := ifs.OS().Stat(configPath); err == nil {
+		return "", "", false
+	}
+	bloblPath = strings.TrimSuffix(configPath, filepath.Ext(configPath)) + ".blobl"
+	if _, err := ifs.OS().Stat(bloblPath); err != nil {
+		return "", "", false
+	}
+	return bloblPath, definitionPath, true
+}
+
+func getBloblangDefinition(bloblPath, definitionPath string) ([]test.Case, error) {
+	mappingBytes, err := ifs.ReadFile(ifs.OS(), bloblPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to access target Bloblang file '%v': %v", bloblPath, err)
+	}
+
+	cases, err := test.CasesFromBloblang(string(mappingBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tests from '%v': %v", bloblPath, err)
+	}
+
+	if _, err := ifs.OS().Stat(definitionPath); err == nil {
+		defCases, err := getDefinition(bloblPath, definitionPath)
+		if err != nil {
+			return nil, err
+		}
+		cases = append(cases, defCases...)
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("unable to access test definition file '%v': %v", definitionPath, err)
+	}
+
+	// Cases without an explicit target execute the Bloblang file itself.
+	for i := range cases {
+		if cases[i].TargetMapping == "" {
+			cases[i].TargetMapping = filepath.Base(bloblPath)
+		}
+	}
+	return cases, nil
+}
+
 // GetTestTargets searches for test definition targets in a path with a given
-// test suffix.
+// test suffix. Targets can either be config files or Bloblang files.
 func GetTestTargets(targetPaths []string, testSuffix string) (map[string][]test.Case, error) {
-	targetPaths, err := ifilepath.GlobsAndSuperPaths(ifs.OS(), targetPaths, "yaml", "yml")
+	targetPaths, err := ifilepath.GlobsAndSuperPaths(ifs.OS(), targetPaths, "yaml", "yml", "blobl")
 	if err != nil {
 		return nil, err
 	}
 
 	targetDefinitions := map[string][]test.Case{}
 	for _, tPath := range targetPaths {
-		configPath, definitionPath := GetPathPair(tPath, testSuffix)
-		def, err := getDefinition(configPath, definitionPath)
+		var def []test.Case
+		configPath, definitionPath, isBlobl := getBloblangPathPair(tPath, testSuffix)
+		if isBlobl {
+			def, err = getBloblangDefinition(configPath, definitionPath)
+		} else {
+			configPath, definitionPath = GetPathPair(tPath, testSuffix)
+			def, err = getDefinition(configPath, definitionPath)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -103,6 +170,10 @@ func GetTestTargets(targetPaths []string, testSuffix string) (map[string][]test.
 // Lints the config target of a test definition and either returns linting
 // errors (false for failed) or returns an error.
 func lintTarget(opts *common.CLIOpts, spec docs.FieldSpecs, path, testSuffix string) ([]docs.Lint, error) {
+	if isBloblangPath(path) {
+		return lintBloblangTarget(opts, path)
+	}
+
 	confPath, _ := GetPathPair(path, testSuffix)
 
 	lintConf := docs.NewLintConfig(opts.Environment)
@@ -120,6 +191,26 @@ func lintTarget(opts *common.CLIOpts, spec docs.FieldSpecs, path, testSuffix str
 	return lints, nil
 }
 
+// Lints a Bloblang target of a test definition by parsing it.
+func lintBloblangTarget(opts *common.CLIOpts, path string) ([]docs.Lint, error) {
+	mappingBytes, err := ifs.ReadFile(ifs.OS(), path)
+	if err != nil {
+		return nil, err
+	}
+
+	env := bloblang.XWrapEnvironment(opts.BloblEnvironment.WithImporterRelativeToFile(path))
+	if _, err := env.Parse(string(mappingBytes)); err != nil {
+		var pErr *bloblang.ParseError
+		if errors.As(err, &pErr) {
+			lint := docs.NewLintError(pErr.Line, docs.LintBadBloblang, pErr)
+			lint.Column = pErr.Column
+			return []docs.Lint{lint}, nil
+		}
+		return []docs.Lint{docs.NewLintError(1, docs.LintBadBloblang, err)}, nil
+	}
+	return nil, nil
+}
+
 //------------------------------------------------------------------------------
 
 // RunAll executes the test command for a slice of paths. The path can either be
@@ -259,3 +259,93 @@ tests:
 		t.Error("Unexpected result")
 	}
 }
+
+func TestGetTargetsBloblang(t *testing.T) {
+	testDir, err := initTestFiles(t, map[string]string{
+		"foo.blobl": `
+# test: foo test
+#   input: foo
+#   output: FOO
+root = content().uppercase()`,
+		"bar.blobl":              `root = content().uppercase()`,
+		"bar_benthos_test.yaml":  `tests: [{name: "bar test"}]`,
+		"baz.blobl":              `root = content().uppercase()`,
+		"nested/not_tested.blob": `root = content().uppercase()`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	paths, err := test.GetTestTargets([]string{testDir + "/..."}, "_benthos_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp, act := 2, len(paths); exp != act {
+		t.Fatalf("Wrong count of paths: %v != %v", act, exp)
+	}
+	if cases := paths[filepath.Join(testDir, "foo.blobl")]; len(cases) != 1 || cases[0].TargetMapping != "foo.blobl" {
+		t.Errorf("Wrong cases returned for foo.blobl: %v", cases)
+	}
+	if cases := paths[filepath.Join(testDir, "bar.blobl")]; len(cases) != 1 || cases[0].TargetMapping != "bar.blobl" {
+		t.Errorf("Wrong cases returned for bar.blobl: %v", cases)
+	}
+
+	paths, err = test.GetTestTargets([]string{filepath.Join(testDir, "bar_benthos_test.yaml")}, "_benthos_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, exists := paths[filepath.Join(testDir, "bar.blobl")]; !exists || len(paths) != 1 {
+		t.Errorf("Wrong paths returned: %v", paths)
+	}
+}
+
+func TestCommandRunBloblang(t *testing.T) {
+	testDir, err := initTestFiles(t, map[string]string{
+		"foo.blobl": `
+map greeting {
+  root = "hello " + this.name
+}
+
+# test: greets people
+#   input: {"name": "ash"}
+#   metadata: {lang: en}
+#   output: {"greeting": "hello ash", "lang": "en"}
+#
+# test: greeting map
+#   map: greeting
+#   input: {"name": "ash"}
+#   output: hello ash
+#
+# test: fails without a name
+#   input: {}
+#   error: cannot add types
+root.greeting = this.apply("greeting")
+root.lang = @lang`,
+		"foo_benthos_test.yaml": `
+tests:
+  - name: sidecar test
+    target_map: greeting
+    input_batch:
+      - json_content: { name: "bob" }
+    output_batches:
+      - - content_equals: hello bob`,
+		"bar.blobl": `
+# test: wrong output
+#   input: foo
+#   output: foo
+root = content().uppercase()`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testDir)
+
+	if !test.RunAll(common.NewCLIOpts("", ""), []string{filepath.Join(testDir, "foo.blobl")}, "_benthos_test", true, log.Noop(), nil) {
+		t.Error("Unexpected result")
+	}
+
+	if test.RunAll(common.NewCLIOpts("", ""), []string{testDir}, "_benthos_test", true, log.Noop(), nil) {
+		t.Error("Unexpected result")
+	}
+}
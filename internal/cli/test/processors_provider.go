@@ -72,8 +72,9 @@ func (p *ProcessorsProvider) Provide(jsonPtr string, environment map[string]stri
 }
 
 // ProvideBloblang attempts to parse a Bloblang mapping and returns a processor
-// slice that executes it.
-func (p *ProcessorsProvider) ProvideBloblang(pathStr string) ([]processor.V1, error) {
+// slice that executes it. If a target map is specified then only the map of
+// that name defined within the mapping is executed.
+func (p *ProcessorsProvider) ProvideBloblang(pathStr, targetMap string) ([]processor.V1, error) {
 	if !filepath.IsAbs(pathStr) {
 		pathStr = filepath.Join(filepath.Dir(p.targetPath), pathStr)
 	}
@@ -89,6 +90,26 @@ func (p *ProcessorsProvider) ProvideBloblang(pathStr string) ([]processor.V1, er
 		return nil, mapErr
 	}
 
+	if targetMap != "" {
+		mapFn, exists := exec.Maps()[targetMap]
+		if !exists {
+			return nil, fmt.Errorf("map %v was not found", targetMap)
+		}
+		if mapExec, isExec := mapFn.(*mapping.Executor); isExec {
+			// The statements of the map are executed directly so that errors
+			// report their line within the mapping file, or within the
+			// definition of the map when it was imported from another file.
+			input := mapExec.Input()
+			if mappingInput := []rune(string(mappingBytes)); len(input) <= len(mappingInput) &&
+				string(mappingInput[len(mappingInput)-len(input):]) == string(input) {
+				input = mappingInput
+			}
+			exec = mapping.NewExecutor("map "+targetMap, input, exec.Maps(), mapExec.Statements()...)
+		} else {
+			exec = mapping.NewExecutor("map "+targetMap, nil, exec.Maps(), mapping.NewSingleStatement(nil, mapping.NewJSONAssignment(), mapFn))
+		}
+	}
+
 	return []processor.V1{
 		processor.NewAutoObservedBatchedProcessor("bloblang", newBloblang(exec, p.logger), mock.NewManager()),
 	}, nil
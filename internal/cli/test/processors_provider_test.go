@@ -140,6 +140,39 @@ pipeline:
 	}
 }
 
+func TestProcessorsProviderBloblangTargetMap(t *testing.T) {
+	tCtx, done := context.WithTimeout(t.Context(), time.Second*30)
+	defer done()
+
+	testDir, err := initTestFiles(t, map[string]string{
+		"foo.blobl": `root = this.apply("greeting")
+
+map greeting {
+  root.upper = this.name.uppercase()
+  root.count = this.name.length() + this.count
+}`,
+	})
+	require.NoError(t, err)
+
+	procs, err := initTestProv(filepath.Join(testDir, "config.yaml")).ProvideBloblang("foo.blobl", "greeting")
+	require.NoError(t, err)
+
+	msgs, res := processor.ExecuteAll(tCtx, procs, message.QuickBatch([][]byte{
+		[]byte(`{"name":"ash","count":2}`),
+		[]byte(`{"name":"bob"}`),
+	}))
+	require.NoError(t, res)
+	require.Len(t, msgs, 1)
+	require.Equal(t, 2, msgs[0].Len())
+
+	assert.Equal(t, `{"count":5,"upper":"ASH"}`, string(msgs[0].Get(0).AsBytes()))
+	assert.NoError(t, msgs[0].Get(0).ErrorGet())
+	assert.ErrorContains(t, msgs[0].Get(1).ErrorGet(), "failed assignment (line 5)")
+
+	_, err = initTestProv(filepath.Join(testDir, "config.yaml")).ProvideBloblang("foo.blobl", "nope")
+	require.EqualError(t, err, "map nope was not found")
+}
+
 func TestProcessorsProviderLabel(t *testing.T) {
 	tCtx, done := context.WithTimeout(t.Context(), time.Second*30)
 	defer done()
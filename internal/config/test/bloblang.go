@@ -0,0 +1,229 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/redpanda-data/benthos/v4/internal/docs"
+)
+
+const bloblangTestMarker = "test:"
+
+const (
+	fieldBloblangTestInput          = "input"
+	fieldBloblangTestMetadata       = "metadata"
+	fieldBloblangTestOutput         = "output"
+	fieldBloblangTestOutputMetadata = "output_metadata"
+	fieldBloblangTestError          = "error"
+	fieldBloblangTestMap            = "map"
+)
+
+var bloblangTestFields = map[string]struct{}{
+	fieldBloblangTestInput:          {},
+	fieldBloblangTestMetadata:       {},
+	fieldBloblangTestOutput:         {},
+	fieldBloblangTestOutputMetadata: {},
+	fieldBloblangTestError:          {},
+	fieldBloblangTestMap:            {},
+}
+
+type bloblangTestBlock struct {
+	name string
+	line int
+	body []string
+}
+
+// CasesFromBloblang extracts test cases from the `# test: <name>` comment
+// blocks of a Bloblang mapping. The comment lines that follow the marker and
+// are indented further than it form a YAML object describing the case:
+//
+//	# test: uppercases the name
+//	#   input: {"name": "foo"}
+//	#   output: {"name": "FOO"}
+//
+// The field `input` sets the content of the input message, which is raw when
+// it is a string and otherwise marshalled as JSON, and `metadata` sets its
+// metadata. The field `output` checks the content of the resulting message in
+// the same way, `output_metadata` checks its metadata and `error` checks that
+// the mapping failed with an error containing a string. The field `map` names
+// a map within the mapping to execute instead of the mapping as a whole.
+//
+// The target mapping of the returned cases is left empty.
+func CasesFromBloblang(mapping string) ([]Case, error) {
+	var cases []Case
+	for _, b := range bloblangTestBlocks(mapping) {
+		c, err := b.toCase()
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %w", b.line, err)
+		}
+		cases = append(cases, c)
+	}
+	return cases, nil
+}
+
+// commentIndent returns the text of a comment line after the # symbol along
+// with the number of spaces that it is indented by.
+func commentIndent(line string) (text string, indent int, isComment bool) {
+	trimmed := strings.TrimLeft(line, " \t")
+	if !strings.HasPrefix(trimmed, "#") {
+		return "", 0, false
+	}
+	text = trimmed[1:]
+	return text, len(text) - len(strings.TrimLeft(text, " ")), true
+}
+
+func bloblangTestBlocks(mapping string) (blocks []bloblangTestBlock) {
+	var current *bloblangTestBlock
+	var markerIndent int
+	for i, line := range strings.Split(mapping, "\n") {
+		text, indent, isComment := commentIndent(line)
+		if current != nil {
+			if isComment && (strings.TrimSpace(text) == "" || indent > markerIndent) {
+				current.body = append(current.body, text)
+				continue
+			}
+			blocks = append(blocks, *current)
+			current = nil
+		}
+		if !isComment {
+			continue
+		}
+		if name, isMarker := strings.CutPrefix(strings.TrimSpace(text), bloblangTestMarker); isMarker {
+			current = &bloblangTestBlock{name: strings.TrimSpace(name), line: i + 1}
+			markerIndent = indent
+		}
+	}
+	if current != nil {
+		blocks = append(blocks, *current)
+	}
+	return
+}
+
+// dedentedBody returns the body of the block with the indentation common to
+// all lines removed.
+func (b bloblangTestBlock) dedentedBody() string {
+	minIndent := -1
+	for _, l := range b.body {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		if indent := len(l) - len(strings.TrimLeft(l, " ")); minIndent < 0 || indent < minIndent {
+			minIndent = indent
+		}
+	}
+	lines := make([]string, len(b.body))
+	for i, l := range b.body {
+		if len(l) >= minIndent && minIndent > 0 {
+			l = l[minIndent:]
+		}
+		lines[i] = l
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (b bloblangTestBlock) toCase() (c Case, err error) {
+	c.Name, c.line = b.name, b.line
+	if c.Name == "" {
+		return c, errors.New("test name must not be empty")
+	}
+
+	node, err := docs.UnmarshalYAML([]byte(b.dedentedBody()))
+	if err != nil {
+		return c, fmt.Errorf("failed to parse test: %w", err)
+	}
+	fields := map[string]any{}
+	if err = node.Decode(&fields); err != nil {
+		return c, fmt.Errorf("failed to parse test: %w", err)
+	}
+
+	var unknown []string
+	for k := range fields {
+		if _, exists := bloblangTestFields[k]; !exists {
+			unknown = append(unknown, k)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return c, fmt.Errorf("unrecognised test fields: %v", strings.Join(unknown, ", "))
+	}
+
+	var input InputConfig
+	if v, exists := fields[fieldBloblangTestInput]; exists {
+		if input.Content, err = bloblangTestContent(v); err != nil {
+			return c, fmt.Errorf("%v: %w", fieldBloblangTestInput, err)
+		}
+	}
+	if v, exists := fields[fieldBloblangTestMetadata]; exists {
+		if input.Metadata, err = bloblangTestMap(v); err != nil {
+			return c, fmt.Errorf("%v: %w", fieldBloblangTestMetadata, err)
+		}
+	}
+	c.InputBatches = [][]InputConfig{{input}}
+
+	conds := OutputConditionsMap{}
+	if v, exists := fields[fieldBloblangTestOutput]; exists {
+		if str, isStr := v.(string); isStr {
+			conds[fieldOutputContentEquals] = ContentEqualsCondition(str)
+		} else {
+			var content string
+			if content, err = bloblangTestContent(v); err != nil {
+				return c, fmt.Errorf("%v: %w", fieldBloblangTestOutput, err)
+			}
+			conds[fieldOutputJSONEquals] = ContentJSONEqualsCondition(content)
+		}
+	}
+	if v, exists := fields[fieldBloblangTestOutputMetadata]; exists {
+		var meta map[string]any
+		if meta, err = bloblangTestMap(v); err != nil {
+			return c, fmt.Errorf("%v: %w", fieldBloblangTestOutputMetadata, err)
+		}
+		conds[fieldOutputMetadataEquals] = MetadataEqualsCondition(meta)
+	}
+	if v, exists := fields[fieldBloblangTestError]; exists {
+		str, isStr := v.(string)
+		if !isStr {
+			return c, fmt.Errorf("%v: expected string value, got %T", fieldBloblangTestError, v)
+		}
+		conds[fieldOutputErrorContains] = ErrorContainsCondition(str)
+	}
+	if len(conds) == 0 {
+		return c, fmt.Errorf("test must specify at least one of the fields %v, %v or %v", fieldBloblangTestOutput, fieldBloblangTestOutputMetadata, fieldBloblangTestError)
+	}
+	c.OutputBatches = [][]OutputConditionsMap{{conds}}
+
+	if v, exists := fields[fieldBloblangTestMap]; exists {
+		str, isStr := v.(string)
+		if !isStr {
+			return c, fmt.Errorf("%v: expected string value, got %T", fieldBloblangTestMap, v)
+		}
+		c.TargetMap = str
+	}
+	return c, nil
+}
+
+func bloblangTestContent(v any) (string, error) {
+	if str, isStr := v.(string); isStr {
+		return str, nil
+	}
+	jBytes, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(jBytes), nil
+}
+
+func bloblangTestMap(v any) (map[string]any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	m, isMap := v.(map[string]any)
+	if !isMap {
+		return nil, fmt.Errorf("expected object value, got %T", v)
+	}
+	return m, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCasesFromBloblang(t *testing.T) {
+	cases, err := CasesFromBloblang(`# Some description of the mapping.
+map parse_name {
+  root = this.uppercase()
+}
+
+# test: uppercases names
+#   input: {"name": "foo"}
+#   metadata: {topic: bar}
+#   output: {"name": "FOO", "topic": "bar"}
+root.name = this.name.apply("parse_name")
+root.topic = @topic
+
+# test: named map
+#   map: parse_name
+#   input: foo
+#   output: FOO
+#
+# test: fails on numbers
+#   input: 10
+#   error: expected string value
+# This comment is not part of the test.
+`)
+	require.NoError(t, err)
+	require.Len(t, cases, 3)
+
+	assert.Equal(t, "uppercases names", cases[0].Name)
+	assert.Equal(t, 6, cases[0].Line())
+	assert.Equal(t, [][]InputConfig{{{
+		Content:  `{"name":"foo"}`,
+		Metadata: map[string]any{"topic": "bar"},
+	}}}, cases[0].InputBatches)
+	assert.Equal(t, [][]OutputConditionsMap{{{
+		"json_equals": ContentJSONEqualsCondition(`{"name":"FOO","topic":"bar"}`),
+	}}}, cases[0].OutputBatches)
+
+	assert.Equal(t, "named map", cases[1].Name)
+	assert.Equal(t, 13, cases[1].Line())
+	assert.Equal(t, "parse_name", cases[1].TargetMap)
+	assert.Equal(t, [][]InputConfig{{{Content: "foo"}}}, cases[1].InputBatches)
+	assert.Equal(t, [][]OutputConditionsMap{{{
+		"content_equals": ContentEqualsCondition("FOO"),
+	}}}, cases[1].OutputBatches)
+
+	assert.Equal(t, "fails on numbers", cases[2].Name)
+	assert.Equal(t, 18, cases[2].Line())
+	assert.Equal(t, [][]InputConfig{{{Content: "10"}}}, cases[2].InputBatches)
+	assert.Equal(t, [][]OutputConditionsMap{{{
+		"error_contains": ErrorContainsCondition("expected string value"),
+	}}}, cases[2].OutputBatches)
+}
+
+func TestCasesFromBloblangErrors(t *testing.T) {
+	tests := map[string]struct {
+		mapping string
+		err     string
+	}{
+		"missing name": {
+			mapping: `root = this
+# test:
+#   output: foo`,
+			err: "line 2: test name must not be empty",
+		},
+		"unknown fields": {
+			mapping: `# test: foo
+#   input: foo
+#   outptu: foo
+#   nope: bar`,
+			err: "line 1: unrecognised test fields: nope, outptu",
+		},
+		"no expectations": {
+			mapping: `# test: foo
+#   input: foo`,
+			err: "line 1: test must specify at least one of the fields output, output_metadata or error",
+		},
+		"bad metadata": {
+			mapping: `# test: foo
+#   metadata: [ foo ]
+#   output: bar`,
+			err: "line 1: metadata: expected object value, got []interface {}",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := CasesFromBloblang(test.mapping)
+			require.Error(t, err)
+			assert.Equal(t, test.err, err.Error())
+		})
+	}
+}
@@ -11,6 +11,7 @@ const (
 	fieldCaseEnvironment      = "environment"
 	fieldCaseTargetProcessors = "target_processors"
 	fieldCaseTargetMapping    = "target_mapping"
+	fieldCaseTargetMap        = "target_map"
 	fieldCaseMocks            = "mocks"
 	fieldCaseInputBatch       = "input_batch"
 	fieldCaseInputBatches     = "input_batches"
@@ -23,6 +24,7 @@ type Case struct {
 	Environment      map[string]string
 	TargetProcessors string
 	TargetMapping    string
+	TargetMap        string
 	Mocks            map[string]any
 	InputBatches     [][]InputConfig
 	OutputBatches    [][]OutputConditionsMap
@@ -52,6 +54,10 @@ It is also possible to target processors in a separate file by prefixing the tar
 		docs.FieldString(fieldCaseTargetMapping,
 			"A file path relative to the test definition path of a Bloblang file to execute as an alternative to testing processors with the `target_processors` field. This allows you to define unit tests for Bloblang mappings directly.",
 		).HasDefault(""),
+		docs.FieldString(fieldCaseTargetMap,
+			"The name of a map defined within the Bloblang file of `target_mapping` to execute instead of the mapping as a whole. This allows you to test individual named maps in isolation.",
+			"parse_user",
+		).HasDefault(""),
 		docs.FieldAnything(fieldCaseMocks,
 			"An optional map of processors to mock. Keys should contain either a label or a JSON pointer of a processor that should be mocked. Values should contain a processor definition, which will replace the mocked processor. Most of the time you'll want to use a [`mapping` processor][processors.mapping] here, and use it to create a result that emulates the target processor.",
 			map[string]any{
@@ -100,6 +106,9 @@ func CaseFromParsed(pConf *docs.ParsedConfig) (c Case, err error) {
 	if c.TargetMapping, err = pConf.FieldString(fieldCaseTargetMapping); err != nil {
 		return
 	}
+	if c.TargetMap, err = pConf.FieldString(fieldCaseTargetMap); err != nil {
+		return
+	}
 
 	if pConf.Contains(fieldCaseMocks) {
 		var tmpMocksAny map[string]*docs.ParsedConfig
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/nsf/jsondiff"
@@ -38,6 +39,7 @@ const (
 	fieldOutputFileJSONContains = "file_json_contains"
 	fieldOutputJSONEquals       = "json_equals"
 	fieldOutputJSONContains     = "json_contains"
+	fieldOutputErrorContains    = "error_contains"
 )
 
 func outputFields() docs.FieldSpecs {
@@ -67,6 +69,7 @@ func outputFields() docs.FieldSpecs {
 		docs.FieldString(fieldOutputFileJSONContains, "Checks that both the message and the file contents are valid JSON documents, and that the message is a superset of the condition. Will ignore formatting and ordering differences. The path of the file should be relative to the path of the test file.",
 			"./foo/bar.json",
 		).Optional(),
+		docs.FieldString(fieldOutputErrorContains, "Checks that the processing of the message failed with an error that contains a string.", "failed assignment").Optional(),
 	}
 }
 
@@ -186,6 +189,14 @@ func OutputConditionsFromParsed(pConf *docs.ParsedConfig) (m OutputConditionsMap
 		}
 		m[fieldOutputJSONContains] = ContentJSONContainsCondition(tmpStr)
 	}
+
+	if pConf.Contains(fieldOutputErrorContains) {
+		var tmpStr string
+		if tmpStr, err = pConf.FieldString(fieldOutputErrorContains); err != nil {
+			return
+		}
+		m[fieldOutputErrorContains] = ErrorContainsCondition(tmpStr)
+	}
 	return
 }
 
@@ -314,6 +325,21 @@ func (c FileJSONContainsCondition) Check(fs fs.FS, dir string, p *message.Part)
 	return comparison.Check(fs, dir, p)
 }
 
+// ErrorContainsCondition represents a test ErrorContains condition.
+type ErrorContainsCondition string
+
+// Check runs the ErrorContains condition check.
+func (c ErrorContainsCondition) Check(fs fs.FS, dir string, p *message.Part) error {
+	procErr := p.ErrorGet()
+	if procErr == nil {
+		return fmt.Errorf("expected error containing %v but message was processed successfully", blue(string(c)))
+	}
+	if !strings.Contains(procErr.Error(), string(c)) {
+		return fmt.Errorf("error mismatch\n  expected: %v\n  received: %v", blue(string(c)), red(procErr.Error()))
+	}
+	return nil
+}
+
 // MetadataEqualsCondition represents a test MetadataEquals condition.
 type MetadataEqualsCondition map[string]any
 
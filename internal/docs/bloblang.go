@@ -3,6 +3,11 @@
 package docs
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/parser"
 	"github.com/redpanda-data/benthos/v4/public/bloblang"
 )
 
@@ -18,6 +23,9 @@ func LintBloblangMapping(ctx LintContext, line, col int, v any) []Lint {
 	}
 	_, err := ctx.conf.BloblangEnv.Parse(str)
 	if err == nil {
+		if ctx.conf.RequireFormattedBloblang {
+			return lintBloblangFormatted(line, str)
+		}
 		return nil
 	}
 	if mErr, ok := err.(*bloblang.ParseError); ok {
@@ -28,6 +36,17 @@ func LintBloblangMapping(ctx LintContext, line, col int, v any) []Lint {
 	return []Lint{NewLintError(line, LintBadBloblang, err)}
 }
 
+func lintBloblangFormatted(line int, mapping string) []Lint {
+	formatted, err := parser.FormatMapping(mapping)
+	if err != nil {
+		return []Lint{NewLintError(line, LintUnformattedBloblang, fmt.Errorf("failed to format mapping: %w", err))}
+	}
+	if strings.TrimRight(formatted, "\n") == strings.TrimRight(mapping, "\n") {
+		return nil
+	}
+	return []Lint{NewLintError(line, LintUnformattedBloblang, errors.New("mapping is not canonically formatted, use the blobl fmt subcommand to format it"))}
+}
+
 // LintBloblangField is function for linting a config field expected to be an
 // interpolation string.
 func LintBloblangField(ctx LintContext, line, col int, v any) []Lint {
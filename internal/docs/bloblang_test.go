@@ -117,3 +117,39 @@ func TestLintBloblangField(t *testing.T) {
 		})
 	}
 }
+
+func TestLintBloblangMappingFormatted(t *testing.T) {
+	tests := map[string]struct {
+		mapping   string
+		wantLints []docs.Lint
+	}{
+		"formatted mapping": {
+			mapping: "root = this\nroot.foo = \"bar\"\n",
+		},
+		"formatted mapping without trailing line break": {
+			mapping: `root.foo = this.bar.uppercase()`,
+		},
+		"unformatted mapping": {
+			mapping: `root.foo = this.bar+this.baz`,
+			wantLints: []docs.Lint{
+				{
+					Line:   2,
+					Column: 1,
+					Level:  docs.LintError,
+					Type:   docs.LintUnformattedBloblang,
+					What:   `mapping is not canonically formatted, use the blobl fmt subcommand to format it`,
+				},
+			},
+		},
+	}
+
+	lConf := docs.NewLintConfig(bundle.GlobalEnvironment)
+	lConf.RequireFormattedBloblang = true
+	ctx := docs.NewLintContext(lConf)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gotLints := docs.LintBloblangMapping(ctx, 2, 4, test.mapping)
+			require.EqualValues(t, test.wantLints, gotLints)
+		})
+	}
+}
@@ -685,6 +685,9 @@ type LintConfig struct {
 	// Require labels for components.
 	RequireLabels bool
 
+	// Require Bloblang mappings to be canonically formatted.
+	RequireFormattedBloblang bool
+
 	// Ignore object fields that aren't recognized.
 	IgnoreUnrecognized bool
 }
@@ -787,6 +790,10 @@ const (
 
 	// LintDeprecated means a field is deprecated and should not be used.
 	LintDeprecated LintType = iota
+
+	// LintUnformattedBloblang means the field contains a Bloblang mapping that
+	// is valid but not canonically formatted.
+	LintUnformattedBloblang LintType = iota
 )
 
 // Lint describes a single linting issue found with a Benthos config.
@@ -50,6 +50,20 @@ func WriteFile(f fs.FS, name string, data []byte, perm fs.FileMode) error {
 	return err
 }
 
+// Renamer is implemented by FS implementations that support renaming files.
+type Renamer interface {
+	Rename(oldpath, newpath string) error
+}
+
+// Rename renames (moves) a file provided the FS implementation supports it.
+func Rename(f FS, oldpath, newpath string) error {
+	r, ok := f.(Renamer)
+	if !ok {
+		return errors.New("filesystem does not support renaming files")
+	}
+	return r.Rename(oldpath, newpath)
+}
+
 // FileWrite attempts to write to an fs.File provided it supports io.Writer.
 func FileWrite(file fs.File, data []byte) (int, error) {
 	writer, isw := file.(io.Writer)
@@ -95,3 +109,7 @@ func (o *osPT) Remove(name string) error {
 func (o *osPT) MkdirAll(path string, perm fs.FileMode) error {
 	return os.MkdirAll(path, perm)
 }
+
+func (o *osPT) Rename(oldpath, newpath string) error {
+	return os.Rename(oldpath, newpath)
+}
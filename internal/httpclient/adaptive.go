@@ -0,0 +1,202 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	hcFieldAdaptiveRateLimit               = "adaptive_rate_limit"
+	hcFieldAdaptiveRateLimitEnabled        = "enabled"
+	hcFieldAdaptiveRateLimitInitialPeriod  = "initial_interval"
+	hcFieldAdaptiveRateLimitMaxPeriod      = "max_interval"
+	hcFieldAdaptiveRateLimitBackoffFactor  = "backoff_factor"
+	hcFieldAdaptiveRateLimitRecoveryFactor = "recovery_factor"
+)
+
+func adaptiveRateLimitField() *service.ConfigField {
+	return service.NewObjectField(hcFieldAdaptiveRateLimit,
+		service.NewBoolField(hcFieldAdaptiveRateLimitEnabled).
+			Description("Whether to adapt the rate of requests to throttled responses.").
+			Default(false),
+		service.NewDurationField(hcFieldAdaptiveRateLimitInitialPeriod).
+			Description("The interval between requests applied after the first throttled response.").
+			Default("100ms"),
+		service.NewDurationField(hcFieldAdaptiveRateLimitMaxPeriod).
+			Description("The maximum interval between requests.").
+			Default("30s"),
+		service.NewFloatField(hcFieldAdaptiveRateLimitBackoffFactor).
+			Description("A factor, greater than one, by which the interval between requests is multiplied after each throttled response.").
+			Default(2.0),
+		service.NewFloatField(hcFieldAdaptiveRateLimitRecoveryFactor).
+			Description("A factor, between zero and one, by which the interval between requests is multiplied after each successful response. Once the interval drops below `initial_interval` requests are no longer delayed.").
+			Default(0.9),
+	).
+		Description("Slow down requests when responses indicate that the server is throttling them, i.e. a 429 status code or a status code within `backoff_on`, and gradually recover as requests succeed. A `Retry-After` header of a throttled response pauses all requests for the given period. When a `rate_limit` is specified the adaptive limit is shared by all components that specify the same rate limit resource, where the settings of the first component to be created are used, and it is applied in addition to the rate limit resource.").
+		Advanced().
+		Version("4.58.0")
+}
+
+// AdaptiveRateLimitConfig describes how the rate of requests is adapted to
+// throttled responses.
+type AdaptiveRateLimitConfig struct {
+	Enabled         bool
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	BackoffFactor   float64
+	RecoveryFactor  float64
+}
+
+func adaptiveRateLimitConfigFromParsed(pConf *service.ParsedConfig) (conf AdaptiveRateLimitConfig, err error) {
+	if conf.Enabled, err = pConf.FieldBool(hcFieldAdaptiveRateLimitEnabled); err != nil {
+		return
+	}
+	if conf.InitialInterval, err = pConf.FieldDuration(hcFieldAdaptiveRateLimitInitialPeriod); err != nil {
+		return
+	}
+	if conf.MaxInterval, err = pConf.FieldDuration(hcFieldAdaptiveRateLimitMaxPeriod); err != nil {
+		return
+	}
+	if conf.BackoffFactor, err = pConf.FieldFloat(hcFieldAdaptiveRateLimitBackoffFactor); err != nil {
+		return
+	}
+	if conf.RecoveryFactor, err = pConf.FieldFloat(hcFieldAdaptiveRateLimitRecoveryFactor); err != nil {
+		return
+	}
+	if !conf.Enabled {
+		return
+	}
+	if conf.InitialInterval <= 0 {
+		err = errors.New("adaptive rate limit initial_interval must be greater than zero")
+		return
+	}
+	if conf.MaxInterval < conf.InitialInterval {
+		err = errors.New("adaptive rate limit max_interval must not be less than initial_interval")
+		return
+	}
+	if conf.BackoffFactor <= 1 {
+		err = errors.New("adaptive rate limit backoff_factor must be greater than one")
+		return
+	}
+	if conf.RecoveryFactor <= 0 || conf.RecoveryFactor >= 1 {
+		err = errors.New("adaptive rate limit recovery_factor must be between zero and one")
+	}
+	return
+}
+
+//------------------------------------------------------------------------------
+
+type adaptiveLimiterKey struct {
+	rateLimit string
+}
+
+// adaptiveLimiter spaces requests by an interval that increases when requests
+// are throttled and decreases as they succeed.
+type adaptiveLimiter struct {
+	conf AdaptiveRateLimitConfig
+
+	mut      sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newAdaptiveLimiter returns the adaptive limiter shared by clients of the same
+// rate limit resource, or a new one when no rate limit resource is used.
+func newAdaptiveLimiter(conf AdaptiveRateLimitConfig, rateLimit string, mgr *service.Resources) *adaptiveLimiter {
+	if !conf.Enabled {
+		return nil
+	}
+	l := &adaptiveLimiter{conf: conf}
+	if rateLimit == "" {
+		return l
+	}
+	actual, _ := mgr.GetOrSetGeneric(adaptiveLimiterKey{rateLimit: rateLimit}, l)
+	return actual.(*adaptiveLimiter)
+}
+
+// wait blocks until the next request is permitted, returning false if the
+// context was cancelled beforehand.
+func (a *adaptiveLimiter) wait(ctx context.Context) bool {
+	a.mut.Lock()
+	now := time.Now()
+	if a.next.Before(now) {
+		a.next = now
+	}
+	period := a.next.Sub(now)
+	a.next = a.next.Add(a.interval)
+	a.mut.Unlock()
+
+	if period <= 0 {
+		return true
+	}
+	select {
+	case <-time.After(period):
+	case <-ctx.Done():
+		return false
+	}
+	return true
+}
+
+// throttled increases the interval between requests, and pauses requests for
+// the period specified by a Retry-After header when non-zero.
+func (a *adaptiveLimiter) throttled(retryAfter time.Duration) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	if a.interval < a.conf.InitialInterval {
+		a.interval = a.conf.InitialInterval
+	} else {
+		a.interval = min(time.Duration(float64(a.interval)*a.conf.BackoffFactor), a.conf.MaxInterval)
+	}
+	if retryAfter > 0 {
+		if until := time.Now().Add(retryAfter); until.After(a.next) {
+			a.next = until
+		}
+	}
+}
+
+// succeeded decreases the interval between requests.
+func (a *adaptiveLimiter) succeeded() {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+
+	if a.interval == 0 {
+		return
+	}
+	if a.interval = time.Duration(float64(a.interval) * a.conf.RecoveryFactor); a.interval < a.conf.InitialInterval {
+		a.interval = 0
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date, into the period to wait from now.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v = strings.TrimSpace(v); v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	t, err := http.ParseTime(v)
+	if err != nil {
+		return 0, false
+	}
+	if t.Before(now) {
+		return 0, true
+	}
+	return t.Sub(now), true
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for _, test := range []struct {
+		value  string
+		period time.Duration
+		ok     bool
+	}{
+		{value: "", ok: false},
+		{value: "120", period: time.Minute * 2, ok: true},
+		{value: " 0 ", period: 0, ok: true},
+		{value: "-5", ok: false},
+		{value: "soon", ok: false},
+		{value: "Thu, 02 Jan 2025 03:04:35 GMT", period: time.Second * 30, ok: true},
+		{value: "Thu, 02 Jan 2025 03:00:00 GMT", period: 0, ok: true},
+	} {
+		period, ok := parseRetryAfter(test.value, now)
+		assert.Equal(t, test.ok, ok, test.value)
+		assert.Equal(t, test.period, period, test.value)
+	}
+}
+
+func TestAdaptiveLimiter(t *testing.T) {
+	a := &adaptiveLimiter{conf: AdaptiveRateLimitConfig{
+		Enabled:         true,
+		InitialInterval: time.Second,
+		MaxInterval:     time.Second * 5,
+		BackoffFactor:   2,
+		RecoveryFactor:  0.5,
+	}}
+
+	a.succeeded()
+	assert.Equal(t, time.Duration(0), a.interval)
+
+	a.throttled(0)
+	assert.Equal(t, time.Second, a.interval)
+	a.throttled(0)
+	assert.Equal(t, time.Second*2, a.interval)
+	a.throttled(0)
+	a.throttled(0)
+	assert.Equal(t, time.Second*5, a.interval)
+
+	a.succeeded()
+	assert.Equal(t, time.Millisecond*2500, a.interval)
+	a.succeeded()
+	assert.Equal(t, time.Millisecond*1250, a.interval)
+	a.succeeded()
+	assert.Equal(t, time.Duration(0), a.interval)
+
+	a.throttled(time.Hour)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), a.next, time.Minute)
+
+	ctx, done := context.WithTimeout(t.Context(), time.Millisecond*10)
+	defer done()
+	assert.False(t, a.wait(ctx))
+}
+
+func TestAdaptiveLimiterShared(t *testing.T) {
+	conf := AdaptiveRateLimitConfig{Enabled: true}
+	res := service.MockResources()
+
+	assert.Nil(t, newAdaptiveLimiter(AdaptiveRateLimitConfig{}, "foo", res))
+
+	a := newAdaptiveLimiter(conf, "foo", res)
+	assert.Same(t, a, newAdaptiveLimiter(conf, "foo", res))
+	assert.NotSame(t, a, newAdaptiveLimiter(conf, "bar", res))
+	assert.NotSame(t, newAdaptiveLimiter(conf, "", res), newAdaptiveLimiter(conf, "", res))
+}
+
+func TestHTTPClientRetryAfter(t *testing.T) {
+	var reqCount atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if reqCount.Add(1) == 1 {
+			w.Header().Set("Retry-After", r.URL.Query().Get("retry_after"))
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("hello world"))
+	}))
+	defer ts.Close()
+
+	for _, test := range []struct {
+		name       string
+		retryAfter string
+		maxBackoff string
+		minPeriod  time.Duration
+		maxPeriod  time.Duration
+	}{
+		{name: "seconds", retryAfter: "1", maxBackoff: "300s", minPeriod: time.Second, maxPeriod: time.Second * 3},
+		{name: "capped", retryAfter: "3600", maxBackoff: "10ms", minPeriod: time.Millisecond * 10, maxPeriod: time.Second},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			reqCount.Store(0)
+
+			conf := clientConfig(t, `
+url: %v/?retry_after=%v
+retry_period: 1ms
+max_retry_backoff: %v
+`, ts.URL, test.retryAfter, test.maxBackoff)
+
+			h, err := NewClientFromOldConfig(conf, service.MockResources())
+			require.NoError(t, err)
+			defer h.Close(t.Context())
+
+			startedAt := time.Now()
+			resMsg, err := h.Send(t.Context(), nil)
+			require.NoError(t, err)
+
+			elapsed := time.Since(startedAt)
+			assert.GreaterOrEqual(t, elapsed, test.minPeriod)
+			assert.Less(t, elapsed, test.maxPeriod)
+
+			require.Len(t, resMsg, 1)
+			mBytes, err := resMsg[0].AsBytes()
+			require.NoError(t, err)
+			assert.Equal(t, "hello world", string(mBytes))
+			assert.Equal(t, int32(2), reqCount.Load())
+		})
+	}
+}
+
+func TestHTTPClientAdaptiveRateLimit(t *testing.T) {
+	var throttle atomic.Bool
+	var reqCount atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqCount.Add(1)
+		if throttle.Load() {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte("hello world"))
+	}))
+	defer ts.Close()
+
+	res := service.MockResources(service.MockResourcesOptAddRateLimit("foo", func(context.Context) (time.Duration, error) {
+		return 0, nil
+	}))
+
+	newClient := func() *Client {
+		t.Helper()
+		conf := clientConfig(t, `
+url: %v
+rate_limit: foo
+retries: 0
+adaptive_rate_limit:
+  enabled: true
+  initial_interval: 200ms
+`, ts.URL)
+		h, err := NewClientFromOldConfig(conf, res)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = h.Close(context.Background()) })
+		return h
+	}
+	a, b := newClient(), newClient()
+
+	throttle.Store(true)
+	_, err := a.Send(t.Context(), nil)
+	require.Error(t, err)
+	throttle.Store(false)
+
+	// The throttled response of one client slows down the other.
+	startedAt := time.Now()
+	for range 3 {
+		_, err = b.Send(t.Context(), nil)
+		require.NoError(t, err)
+	}
+	assert.GreaterOrEqual(t, time.Since(startedAt), time.Millisecond*200)
+	assert.Equal(t, int32(4), reqCount.Load())
+}
+
+func TestHTTPClientAdaptiveRateLimitConfigErrors(t *testing.T) {
+	spec := service.NewConfigSpec().Field(ConfigField("GET", false))
+
+	for _, conf := range []string{
+		`{ enabled: true, initial_interval: 0s }`,
+		`{ enabled: true, initial_interval: 10s, max_interval: 1s }`,
+		`{ enabled: true, backoff_factor: 0.5 }`,
+		`{ enabled: true, recovery_factor: 1.5 }`,
+	} {
+		parsed, err := spec.ParseYAML(fmt.Sprintf(`
+url: http://localhost:1234
+adaptive_rate_limit: %v
+`, conf), nil)
+		require.NoError(t, err)
+
+		_, err = ConfigFromParsed(parsed)
+		require.Error(t, err, conf)
+	}
+}
@@ -33,15 +33,18 @@ type Client struct {
 
 	// Request execution and retry logic
 	rateLimit       string
+	adaptive        *adaptiveLimiter
 	numRetries      int
 	followRedirects bool
 	retryThrottle   *throttle.Type
+	maxRetryAfter   time.Duration
 	backoffOn       map[int]struct{}
 	dropOn          map[int]struct{}
 	successOn       map[int]struct{}
 
 	// Response extraction
 	metaExtractFilter *service.MetadataFilter
+	conditional       *conditionalStore
 
 	// Observability
 	log *service.Logger
@@ -139,6 +142,10 @@ func NewClientFromOldConfig(conf OldConfig, mgr *service.Resources, opts ...Requ
 		h.successOn[c] = struct{}{}
 	}
 
+	if h.conditional, err = newConditionalStore(conf.Conditional, mgr); err != nil {
+		return nil, err
+	}
+
 	h.mLatency = h.mgr.Metrics().NewTimer("http_request_latency_ns")
 	h.mCodes = map[int]*service.MetricCounter{}
 
@@ -147,6 +154,7 @@ func NewClientFromOldConfig(conf OldConfig, mgr *service.Resources, opts ...Requ
 			return nil, fmt.Errorf("rate limit resource '%v' was not found", h.rateLimit)
 		}
 	}
+	h.adaptive = newAdaptiveLimiter(conf.AdaptiveRateLimit, h.rateLimit, mgr)
 
 	h.numRetries = conf.NumRetries
 	h.retryThrottle = throttle.New(
@@ -154,6 +162,7 @@ func NewClientFromOldConfig(conf OldConfig, mgr *service.Resources, opts ...Requ
 		throttle.OptThrottlePeriod(conf.Retry),
 		throttle.OptMaxExponentPeriod(conf.MaxBackoff),
 	)
+	h.maxRetryAfter = conf.MaxBackoff
 
 	return &h, nil
 }
@@ -183,6 +192,9 @@ func (h *Client) incrCode(code int) {
 }
 
 func (h *Client) waitForAccess(ctx context.Context) bool {
+	if h.adaptive != nil && !h.adaptive.wait(ctx) {
+		return false
+	}
 	if h.rateLimit == "" {
 		return true
 	}
@@ -332,7 +344,22 @@ var errTimedOut = errors.New("timed out waiting for next request")
 // SendToResponse attempts to create an HTTP request from a provided message,
 // performs it, and then returns the *http.Response, allowing the raw response
 // to be consumed.
-func (h *Client) SendToResponse(ctx context.Context, sendMsg service.MessageBatch) (res *http.Response, err error) {
+func (h *Client) SendToResponse(ctx context.Context, sendMsg service.MessageBatch) (*http.Response, error) {
+	res, commit, err := h.SendToResponseWithCommit(ctx, sendMsg)
+	if err != nil {
+		return nil, err
+	}
+	if err := commit(ctx); err != nil {
+		h.log.Errorf("Failed to store conditional request headers: %v", err)
+	}
+	return res, nil
+}
+
+// SendToResponseWithCommit performs a request in the same way as
+// SendToResponse, but rather than persisting the conditional request headers of
+// the response immediately it returns a function that does so, which should be
+// called once the response has been delivered.
+func (h *Client) SendToResponseWithCommit(ctx context.Context, sendMsg service.MessageBatch) (res *http.Response, commit func(context.Context) error, err error) {
 	var spans []*tracing.Span
 	if sendMsg != nil {
 		sendMsg, spans = tracing.WithChildSpans(h.mgr.OtelTracer(), "http_request", sendMsg)
@@ -352,9 +379,9 @@ func (h *Client) SendToResponse(ctx context.Context, sendMsg service.MessageBatc
 	}
 
 	var req *http.Request
-	if req, err = h.reqCreator.Create(sendMsg); err != nil {
+	if req, err = h.createRequest(ctx, sendMsg); err != nil {
 		logErr(err)
-		return nil, err
+		return nil, nil, err
 	}
 	// Make sure we log the actual request URL
 	defer func() {
@@ -365,17 +392,22 @@ func (h *Client) SendToResponse(ctx context.Context, sendMsg service.MessageBatc
 
 	if !h.waitForAccess(ctx) {
 		if ctx.Err() != nil {
-			return nil, ctx.Err()
+			return nil, nil, ctx.Err()
 		}
-		return nil, errTimedOut
+		return nil, nil, errTimedOut
 	}
 
 	rateLimited := false
+	var retryAfter time.Duration
 	numRetries := h.numRetries
 
 	startedAt := time.Now()
 	if res, err = h.client.Do(req.WithContext(ctx)); err == nil {
 		h.incrCode(res.StatusCode)
+		retryAfter = h.observeResponse(res)
+		if h.isNotModified(res) {
+			return nil, nil, ErrNotModified
+		}
 		if resolved, retryStrat := h.checkStatus(res.StatusCode); !resolved {
 			rateLimited = retryStrat == retryBackoff
 			if retryStrat == noRetry {
@@ -392,35 +424,51 @@ func (h *Client) SendToResponse(ctx context.Context, sendMsg service.MessageBatc
 	i, j := 0, numRetries
 	for i < j && err != nil {
 		logErr(err)
-		if req, err = h.reqCreator.Create(sendMsg); err != nil {
-			continue
-		}
-		if rateLimited {
+		if retryAfter > 0 {
+			select {
+			case <-time.After(retryAfter):
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			}
+		} else if rateLimited {
 			if !h.retryThrottle.ExponentialRetryWithContext(ctx) {
 				if ctx.Err() != nil {
-					return nil, ctx.Err()
+					return nil, nil, ctx.Err()
 				}
-				return nil, errTimedOut
+				return nil, nil, errTimedOut
 			}
 		} else {
 			if !h.retryThrottle.RetryWithContext(ctx) {
 				if ctx.Err() != nil {
-					return nil, ctx.Err()
+					return nil, nil, ctx.Err()
 				}
-				return nil, errTimedOut
+				return nil, nil, errTimedOut
 			}
 		}
 		if !h.waitForAccess(ctx) {
 			if ctx.Err() != nil {
-				return nil, ctx.Err()
+				return nil, nil, ctx.Err()
 			}
-			return nil, errTimedOut
+			return nil, nil, errTimedOut
 		}
 		rateLimited = false
+		retryAfter = 0
+
+		i++
+		nextReq, cErr := h.createRequest(ctx, sendMsg)
+		if cErr != nil {
+			err = cErr
+			continue
+		}
+		req = nextReq
 
 		startedAt = time.Now()
 		if res, err = h.client.Do(req.WithContext(ctx)); err == nil {
 			h.incrCode(res.StatusCode)
+			retryAfter = h.observeResponse(res)
+			if h.isNotModified(res) {
+				return nil, nil, ErrNotModified
+			}
 			if resolved, retryStrat := h.checkStatus(res.StatusCode); !resolved {
 				rateLimited = retryStrat == retryBackoff
 				if retryStrat == noRetry {
@@ -433,15 +481,67 @@ func (h *Client) SendToResponse(ctx context.Context, sendMsg service.MessageBatc
 			}
 		}
 		h.mLatency.Timing(time.Since(startedAt).Nanoseconds())
-		i++
 	}
 	if err != nil {
 		logErr(err)
-		return nil, err
+		return nil, nil, err
 	}
 
 	h.retryThrottle.Reset()
-	return res, nil
+	commit = func(context.Context) error { return nil }
+	if h.conditional != nil {
+		commit = h.conditional.storeResponse(req, res)
+	}
+	return res, commit, nil
+}
+
+// createRequest creates a request from a message batch, adding conditional
+// request headers when enabled.
+func (h *Client) createRequest(ctx context.Context, sendMsg service.MessageBatch) (*http.Request, error) {
+	req, err := h.reqCreator.Create(sendMsg)
+	if err != nil {
+		return nil, err
+	}
+	if h.conditional != nil {
+		if err := h.conditional.applyRequest(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+// observeResponse returns the period to wait before retrying a request as
+// specified by the Retry-After header of a response, and informs the adaptive
+// rate limiter of whether the request was throttled.
+func (h *Client) observeResponse(res *http.Response) time.Duration {
+	var retryAfter time.Duration
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		retryAfter, _ = parseRetryAfter(res.Header.Get("Retry-After"), time.Now())
+		if h.maxRetryAfter > 0 && retryAfter > h.maxRetryAfter {
+			retryAfter = h.maxRetryAfter
+		}
+	}
+	if h.adaptive != nil {
+		_, backoff := h.backoffOn[res.StatusCode]
+		if backoff || res.StatusCode == http.StatusTooManyRequests {
+			h.adaptive.throttled(retryAfter)
+		} else if res.StatusCode >= 200 && res.StatusCode <= 399 {
+			h.adaptive.succeeded()
+		}
+	}
+	return retryAfter
+}
+
+// isNotModified returns true if a response indicates that the resource of a
+// conditional request hasn't changed, in which case the response is closed.
+func (h *Client) isNotModified(res *http.Response) bool {
+	if h.conditional == nil || res.StatusCode != http.StatusNotModified {
+		return false
+	}
+	if res.Body != nil {
+		res.Body.Close()
+	}
+	return true
 }
 
 func unexpectedErr(res *http.Response) error {
@@ -60,6 +60,34 @@ retries: 3
 	assert.Equal(t, uint32(4), atomic.LoadUint32(&reqCount))
 }
 
+func TestHTTPClientRetriesFailedRequestCreation(t *testing.T) {
+	var reqCount uint32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddUint32(&reqCount, 1)
+		http.Error(w, "test error", http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	// Only the first request can be created.
+	conf := clientConfig(t, `
+url: %v
+retry_period: 10ms
+retries: 3
+headers:
+  X-Attempt: '${! if count("http_client_retries_failed_creation") > 1 { throw("nope") } else { "1" } }'
+`, ts.URL+"/testpost")
+
+	h, err := NewClientFromOldConfig(conf, service.MockResources())
+	require.NoError(t, err)
+	defer h.Close(t.Context())
+
+	startedAt := time.Now()
+	_, err = h.Send(t.Context(), service.MessageBatch{service.NewMessage([]byte("test"))})
+	require.ErrorContains(t, err, "nope")
+	assert.GreaterOrEqual(t, time.Since(startedAt), time.Millisecond*30)
+	assert.Equal(t, uint32(1), atomic.LoadUint32(&reqCount))
+}
+
 func TestHTTPClientBadRequest(t *testing.T) {
 	conf := clientConfig(t, `
 url: htp://notvalid:1111
@@ -907,3 +935,181 @@ tls:
 		})
 	}
 }
+
+func TestHTTPClientConditional(t *testing.T) {
+	var version atomic.Int32
+	version.Store(1)
+
+	var reqCount, notModifiedCount atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqCount.Add(1)
+		etag := fmt.Sprintf(`"v%v"`, version.Load())
+		if r.Header.Get("If-None-Match") == etag {
+			notModifiedCount.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(r.URL.Path + " " + etag))
+	}))
+	defer ts.Close()
+
+	spec := service.NewConfigSpec().Field(ConfigField("GET", false, ConditionalField()))
+	parsed, err := spec.ParseYAML(fmt.Sprintf(`
+url: %v/${! content() }
+retry_period: 1ms
+conditional:
+  enabled: true
+  cache: foocache
+`, ts.URL), nil)
+	require.NoError(t, err)
+
+	conf, err := ConfigFromParsed(parsed)
+	require.NoError(t, err)
+
+	res := service.MockResources(service.MockResourcesOptAddCache("foocache"))
+
+	h, err := NewClientFromOldConfig(conf, res)
+	require.NoError(t, err)
+
+	send := func(path string) (string, error) {
+		t.Helper()
+		resMsg, err := h.Send(t.Context(), service.MessageBatch{service.NewMessage([]byte(path))})
+		if err != nil {
+			return "", err
+		}
+		require.Len(t, resMsg, 1)
+		mBytes, err := resMsg[0].AsBytes()
+		require.NoError(t, err)
+		return string(mBytes), nil
+	}
+
+	body, err := send("a")
+	require.NoError(t, err)
+	assert.Equal(t, `/a "v1"`, body)
+
+	_, err = send("a")
+	require.ErrorIs(t, err, ErrNotModified)
+
+	// Validators are stored for each URL.
+	body, err = send("b")
+	require.NoError(t, err)
+	assert.Equal(t, `/b "v1"`, body)
+
+	version.Store(2)
+	body, err = send("a")
+	require.NoError(t, err)
+	assert.Equal(t, `/a "v2"`, body)
+
+	require.NoError(t, h.Close(t.Context()))
+
+	// Validators stored in a cache are shared with new clients.
+	h, err = NewClientFromOldConfig(conf, res)
+	require.NoError(t, err)
+	defer h.Close(t.Context())
+
+	_, err = send("a")
+	require.ErrorIs(t, err, ErrNotModified)
+
+	assert.Equal(t, int32(5), reqCount.Load())
+	assert.Equal(t, int32(2), notModifiedCount.Load())
+}
+
+func TestHTTPClientConditionalCommit(t *testing.T) {
+	var reqCount atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqCount.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte("hello world"))
+	}))
+	defer ts.Close()
+
+	spec := service.NewConfigSpec().Field(ConfigField("GET", false, ConditionalField()))
+	parsed, err := spec.ParseYAML(fmt.Sprintf(`
+url: %v
+retry_period: 1ms
+conditional:
+  enabled: true
+  cache: foocache
+`, ts.URL), nil)
+	require.NoError(t, err)
+
+	conf, err := ConfigFromParsed(parsed)
+	require.NoError(t, err)
+
+	res := service.MockResources(service.MockResourcesOptAddCache("foocache"))
+
+	h, err := NewClientFromOldConfig(conf, res)
+	require.NoError(t, err)
+	defer h.Close(t.Context())
+
+	other, err := NewClientFromOldConfig(conf, res)
+	require.NoError(t, err)
+	defer other.Close(t.Context())
+
+	hRes, commit, err := h.SendToResponseWithCommit(t.Context(), nil)
+	require.NoError(t, err)
+	hRes.Body.Close()
+
+	// Validators are used by the same client straight away, but are only
+	// stored in the cache once committed.
+	_, err = h.SendToResponse(t.Context(), nil)
+	require.ErrorIs(t, err, ErrNotModified)
+
+	oRes, _, err := other.SendToResponseWithCommit(t.Context(), nil)
+	require.NoError(t, err)
+	oRes.Body.Close()
+
+	require.NoError(t, commit(t.Context()))
+
+	other, err = NewClientFromOldConfig(conf, res)
+	require.NoError(t, err)
+	defer other.Close(t.Context())
+
+	_, err = other.SendToResponse(t.Context(), nil)
+	require.ErrorIs(t, err, ErrNotModified)
+	assert.Equal(t, int32(4), reqCount.Load())
+}
+
+func TestHTTPClientConditionalLastModified(t *testing.T) {
+	lastModified := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var reqCount atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reqCount.Add(1)
+		if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !lastModified.After(since) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+		_, _ = w.Write([]byte("hello world"))
+	}))
+	defer ts.Close()
+
+	spec := service.NewConfigSpec().Field(ConfigField("GET", false, ConditionalField()))
+	parsed, err := spec.ParseYAML(fmt.Sprintf(`
+url: %v
+retry_period: 1ms
+conditional:
+  enabled: true
+`, ts.URL), nil)
+	require.NoError(t, err)
+
+	conf, err := ConfigFromParsed(parsed)
+	require.NoError(t, err)
+
+	h, err := NewClientFromOldConfig(conf, service.MockResources())
+	require.NoError(t, err)
+	defer h.Close(t.Context())
+
+	_, err = h.Send(t.Context(), nil)
+	require.NoError(t, err)
+
+	_, err = h.Send(t.Context(), nil)
+	require.ErrorIs(t, err, ErrNotModified)
+	assert.Equal(t, int32(2), reqCount.Load())
+}
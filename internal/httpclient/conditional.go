@@ -0,0 +1,200 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	hcFieldConditional               = "conditional"
+	hcFieldConditionalEnabled        = "enabled"
+	hcFieldConditionalCache          = "cache"
+	hcFieldConditionalCacheKeyPrefix = "cache_key_prefix"
+)
+
+// ErrNotModified is returned when a conditional request results in a 304 Not
+// Modified response, indicating that the resource hasn't changed since it was
+// last fetched.
+var ErrNotModified = errors.New("resource not modified")
+
+// ConditionalField returns a config field spec for conditional requests, which
+// can be added to the extra children of ConfigField by components that fetch
+// resources.
+func ConditionalField() *service.ConfigField {
+	return service.NewObjectField(hcFieldConditional,
+		service.NewBoolField(hcFieldConditionalEnabled).
+			Description("Whether to make conditional requests.").
+			Default(false),
+		service.NewStringField(hcFieldConditionalCache).
+			Description("An optional xref:components:caches/about.adoc[cache resource] to store the `ETag` and `Last-Modified` headers of responses in, allowing them to persist across restarts. When empty they are stored in memory.").
+			Default(""),
+		service.NewStringField(hcFieldConditionalCacheKeyPrefix).
+			Description("A prefix to add to the URL of each request in order to obtain the key under which its headers are stored within the `cache`.").
+			Default("http_conditional_").
+			Advanced(),
+	).
+		Description("Make conditional `GET` and `HEAD` requests, where the `ETag` and `Last-Modified` headers of the last successful response for each URL are stored and sent as `If-None-Match` and `If-Modified-Since` headers respectively with subsequent requests to the same URL. A `304 Not Modified` response results in no message, which prevents unchanged resources from being consumed repeatedly. When a `cache` is configured the `http_client` input only stores headers in it once the messages of a response have been acknowledged, whereas the `http` processor stores them as soon as a response is received. When storing headers in memory a value is kept for each distinct URL requested, and therefore a cache with a TTL is recommended when URLs are dynamic.").
+		Advanced()
+}
+
+// ConditionalConfig describes how conditional requests are made.
+type ConditionalConfig struct {
+	Enabled        bool
+	Cache          string
+	CacheKeyPrefix string
+}
+
+func conditionalConfigFromParsed(pConf *service.ParsedConfig) (conf ConditionalConfig, err error) {
+	if conf.Enabled, err = pConf.FieldBool(hcFieldConditionalEnabled); err != nil {
+		return
+	}
+	if conf.Cache, err = pConf.FieldString(hcFieldConditionalCache); err != nil {
+		return
+	}
+	if conf.CacheKeyPrefix, err = pConf.FieldString(hcFieldConditionalCacheKeyPrefix); err != nil {
+		return
+	}
+	return
+}
+
+//------------------------------------------------------------------------------
+
+// conditionalValidators are the headers of a response used to determine
+// whether the resource has changed since.
+type conditionalValidators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+type conditionalStore struct {
+	mgr       *service.Resources
+	cache     string
+	keyPrefix string
+
+	// When a cache is configured validators are held in memory only until
+	// they have been committed to the cache.
+	memMut sync.Mutex
+	mem    map[string]conditionalValidators
+}
+
+func newConditionalStore(conf ConditionalConfig, mgr *service.Resources) (*conditionalStore, error) {
+	if !conf.Enabled {
+		return nil, nil
+	}
+	if conf.Cache != "" && !mgr.HasCache(conf.Cache) {
+		return nil, fmt.Errorf("cache resource '%v' was not found", conf.Cache)
+	}
+	return &conditionalStore{
+		mgr:       mgr,
+		cache:     conf.Cache,
+		keyPrefix: conf.CacheKeyPrefix,
+		mem:       map[string]conditionalValidators{},
+	}, nil
+}
+
+func isConditionalMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+func (c *conditionalStore) get(ctx context.Context, url string) (v conditionalValidators, err error) {
+	c.memMut.Lock()
+	v, exists := c.mem[url]
+	c.memMut.Unlock()
+	if exists || c.cache == "" {
+		return
+	}
+
+	var b []byte
+	var cErr error
+	if err = c.mgr.AccessCache(ctx, c.cache, func(cache service.Cache) {
+		b, cErr = cache.Get(ctx, c.keyPrefix+url)
+	}); err != nil {
+		return
+	}
+	if cErr != nil {
+		if !errors.Is(cErr, service.ErrKeyNotFound) {
+			err = cErr
+		}
+		return
+	}
+	err = json.Unmarshal(b, &v)
+	return
+}
+
+func (c *conditionalStore) setCache(ctx context.Context, url string, v conditionalValidators) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var cErr error
+	if err := c.mgr.AccessCache(ctx, c.cache, func(cache service.Cache) {
+		cErr = cache.Set(ctx, c.keyPrefix+url, b, nil)
+	}); err != nil {
+		return err
+	}
+	if cErr != nil {
+		return cErr
+	}
+
+	c.memMut.Lock()
+	if c.mem[url] == v {
+		delete(c.mem, url)
+	}
+	c.memMut.Unlock()
+	return nil
+}
+
+// applyRequest adds the stored validators of a URL to a request, unless the
+// request already specifies them.
+func (c *conditionalStore) applyRequest(ctx context.Context, req *http.Request) error {
+	if !isConditionalMethod(req.Method) {
+		return nil
+	}
+	v, err := c.get(ctx, req.URL.String())
+	if err != nil {
+		return fmt.Errorf("failed to obtain conditional request headers: %w", err)
+	}
+	if v.ETag != "" && req.Header.Get("If-None-Match") == "" {
+		req.Header.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" && req.Header.Get("If-Modified-Since") == "" {
+		req.Header.Set("If-Modified-Since", v.LastModified)
+	}
+	return nil
+}
+
+// storeResponse records the validators of a successful response in memory, and
+// returns a function that commits them to the cache when one is configured.
+func (c *conditionalStore) storeResponse(req *http.Request, res *http.Response) func(context.Context) error {
+	commit := func(context.Context) error { return nil }
+	if !isConditionalMethod(req.Method) {
+		return commit
+	}
+	v := conditionalValidators{
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+	}
+	if v.ETag == "" && v.LastModified == "" {
+		return commit
+	}
+
+	url := req.URL.String()
+	c.memMut.Lock()
+	c.mem[url] = v
+	c.memMut.Unlock()
+
+	if c.cache == "" {
+		return commit
+	}
+	return func(ctx context.Context) error {
+		return c.setCache(ctx, url, v)
+	}
+}
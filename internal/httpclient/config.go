@@ -74,15 +74,16 @@ func ConfigField(defaultVerb string, forOutput bool, extraChildren ...*service.C
 		service.NewStringField(hcFieldRateLimit).
 			Description("An optional xref:components:rate_limits/about.adoc[rate limit] to throttle requests by.").
 			Optional(),
+		adaptiveRateLimitField(),
 		service.NewDurationField(hcFieldTimeout).
 			Description("A static timeout to apply to requests.").
 			Default("5s"),
 		service.NewDurationField(hcFieldRetryPeriod).
-			Description("The base period to wait between failed requests.").
+			Description("The base period to wait between failed requests. When a failed response has a `Retry-After` header, either in seconds or as an HTTP date, the next attempt is made after the period it specifies instead.").
 			Advanced().
 			Default("1s"),
 		service.NewDurationField(hcFieldMaxRetryBackoff).
-			Description("The maximum period to wait between failed requests.").
+			Description("The maximum period to wait between failed requests. This also caps the period specified by the `Retry-After` header of a response.").
 			Advanced().
 			Default("300s"),
 		service.NewIntField(hcFieldRetries).
@@ -141,6 +142,9 @@ func ConfigFromParsed(pConf *service.ParsedConfig) (conf OldConfig, err error) {
 		return
 	}
 	conf.RateLimit, _ = pConf.FieldString(hcFieldRateLimit)
+	if conf.AdaptiveRateLimit, err = adaptiveRateLimitConfigFromParsed(pConf.Namespace(hcFieldAdaptiveRateLimit)); err != nil {
+		return
+	}
 	if conf.Timeout, err = pConf.FieldDuration(hcFieldTimeout); err != nil {
 		return
 	}
@@ -179,6 +183,11 @@ func ConfigFromParsed(pConf *service.ParsedConfig) (conf OldConfig, err error) {
 	if conf.clientCtor, err = oauth2ClientCtorFromParsed(pConf); err != nil {
 		return
 	}
+	if pConf.Contains(hcFieldConditional) {
+		if conf.Conditional, err = conditionalConfigFromParsed(pConf.Namespace(hcFieldConditional)); err != nil {
+			return
+		}
+	}
 	return
 }
 
@@ -190,6 +199,7 @@ type OldConfig struct {
 	Metadata            *service.MetadataFilter
 	ExtractMetadata     *service.MetadataFilter
 	RateLimit           string
+	AdaptiveRateLimit   AdaptiveRateLimitConfig
 	Timeout             time.Duration
 	Retry               time.Duration
 	MaxBackoff          time.Duration
@@ -203,6 +213,7 @@ type OldConfig struct {
 	TLSConf             *tls.Config
 	ProxyURL            string
 	DisableHTTP2        bool
+	Conditional         ConditionalConfig
 	authSigner          func(f fs.FS, req *http.Request) error
 	clientCtor          func(context.Context, *http.Client) *http.Client
 }
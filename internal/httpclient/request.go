@@ -41,6 +41,7 @@ type RequestCreator struct {
 	verb             string
 	headers          map[string]*service.InterpolatedString
 	metaInsertFilter *service.MetadataFilter
+	reqModifiers     []func(req *http.Request) error
 }
 
 // RequestOpt represents a customisation of a request creator.
@@ -92,6 +93,16 @@ func WithExplicitMultipart(m []MultipartExpressions) RequestOpt {
 	}
 }
 
+// WithRequestModifier modifies the request creator to apply a function to each
+// request after it has been created and before it is signed, allowing callers
+// to customise requests based on state that isn't available to interpolation
+// functions. Multiple modifiers are applied in the order they were added.
+func WithRequestModifier(fn func(req *http.Request) error) RequestOpt {
+	return func(r *RequestCreator) {
+		r.reqModifiers = append(r.reqModifiers, fn)
+	}
+}
+
 func (r *RequestCreator) bodyFromExplicit(refBatch service.MessageBatch) (body io.Reader, overrideContentType string, err error) {
 	if _, exists := r.headers["Content-Type"]; !exists {
 		overrideContentType = "application/octet-stream"
@@ -255,6 +266,11 @@ func (r *RequestCreator) Create(refBatch service.MessageBatch) (req *http.Reques
 		req.Header.Del("Content-Type")
 		req.Header.Add("Content-Type", overrideContentType)
 	}
+	for _, modify := range r.reqModifiers {
+		if err = modify(req); err != nil {
+			return
+		}
+	}
 
 	err = r.reqSigner(r.fs, req)
 	return
@@ -3,6 +3,7 @@
 package httpclient
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/redpanda-data/benthos/v4/public/service"
@@ -41,3 +42,31 @@ metadata:
 	assert.Equal(t, []string{"barvalue"}, req.Header.Values("more_bar"))
 	assert.Equal(t, []string(nil), req.Header.Values("ignore_baz"))
 }
+
+func TestRequestModifiersApplyInOrder(t *testing.T) {
+	spec := service.NewConfigSpec().Field(ConfigField("GET", false))
+	parsed, err := spec.ParseYAML(`
+url: example.com/foo
+`, nil)
+	require.NoError(t, err)
+
+	oldConf, err := ConfigFromParsed(parsed)
+	require.NoError(t, err)
+
+	reqCreator, err := RequestCreatorFromOldConfig(oldConf, service.MockResources(),
+		WithRequestModifier(func(req *http.Request) error {
+			req.Header.Add("X-Order", "first")
+			return nil
+		}),
+		WithRequestModifier(func(req *http.Request) error {
+			req.Header.Add("X-Order", "second")
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+
+	req, err := reqCreator.Create(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"first", "second"}, req.Header.Values("X-Order"))
+}
@@ -0,0 +1,53 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package httpserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/fs"
+
+	"github.com/redpanda-data/benthos/v4/internal/filepath/ifs"
+)
+
+// ClientAuthTLSConfig returns a TLS config that requires clients to present a
+// certificate signed by one of the certificate authorities within a file.
+func ClientAuthTLSConfig(f fs.FS, caFile string) (*tls.Config, error) {
+	caBytes, err := ifs.ReadFile(f, caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caBytes) {
+		return nil, errors.New("failed to parse client CA file: no PEM encoded certificates found")
+	}
+	return &tls.Config{
+		ClientCAs:  pool,
+		ClientAuth: tls.RequireAndVerifyClientCert,
+	}, nil
+}
+
+// TLSClientIdentity returns the subject and subject alternative names of the
+// verified client certificate of a TLS connection, where alternative names
+// are prefixed by their type, e.g. `DNS:example.com`.
+func TLSClientIdentity(state *tls.ConnectionState) (subject string, sans []string, ok bool) {
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return "", nil, false
+	}
+	cert := state.VerifiedChains[0][0]
+	for _, name := range cert.DNSNames {
+		sans = append(sans, "DNS:"+name)
+	}
+	for _, email := range cert.EmailAddresses {
+		sans = append(sans, "email:"+email)
+	}
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, "IP:"+ip.String())
+	}
+	for _, uri := range cert.URIs {
+		sans = append(sans, "URI:"+uri.String())
+	}
+	return cert.Subject.String(), sans, true
+}
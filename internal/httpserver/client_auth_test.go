@@ -0,0 +1,126 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package httpserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/internal/filepath/ifs"
+)
+
+func createTestCA(t testing.TB) (*x509.Certificate, *ecdsa.PrivateKey, []byte) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &priv.PublicKey, priv)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+	return cert, priv, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+}
+
+func createTestClientCert(t testing.TB, ca *x509.Certificate, caKey *ecdsa.PrivateKey) tls.Certificate {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:   big.NewInt(2),
+		Subject:        pkix.Name{CommonName: "foo"},
+		NotBefore:      time.Now().Add(-time.Hour),
+		NotAfter:       time.Now().Add(time.Hour),
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		DNSNames:       []string{"foo.example.com"},
+		EmailAddresses: []string{"foo@example.com"},
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &priv.PublicKey, caKey)
+	require.NoError(t, err)
+
+	return tls.Certificate{
+		Certificate: [][]byte{certBytes},
+		PrivateKey:  priv,
+	}
+}
+
+func TestClientAuthTLS(t *testing.T) {
+	ca, caKey, caPEM := createTestCA(t)
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caPath, caPEM, 0o644))
+
+	tlsConf, err := ClientAuthTLSConfig(ifs.OS(), caPath)
+	require.NoError(t, err)
+
+	var subject string
+	var sans []string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		subject, sans, _ = TLSClientIdentity(r.TLS)
+	}))
+	server.TLS = tlsConf
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	client := server.Client()
+	client.Transport.(*http.Transport).TLSClientConfig.Certificates = []tls.Certificate{
+		createTestClientCert(t, ca, caKey),
+	}
+
+	res, err := client.Get(server.URL)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "CN=foo", subject)
+	assert.Equal(t, []string{"DNS:foo.example.com", "email:foo@example.com"}, sans)
+
+	otherCA, otherCAKey, _ := createTestCA(t)
+	client.Transport.(*http.Transport).TLSClientConfig.Certificates = []tls.Certificate{
+		createTestClientCert(t, otherCA, otherCAKey),
+	}
+	client.Transport.(*http.Transport).CloseIdleConnections()
+
+	_, err = client.Get(server.URL)
+	require.Error(t, err)
+}
+
+func TestClientAuthTLSConfigErrors(t *testing.T) {
+	_, err := ClientAuthTLSConfig(ifs.OS(), "/does/not/exist.pem")
+	require.Error(t, err)
+
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("nope"), 0o644))
+
+	_, err = ClientAuthTLSConfig(ifs.OS(), notPEM)
+	require.Error(t, err)
+
+	_, _, ok := TLSClientIdentity(nil)
+	assert.False(t, ok)
+}
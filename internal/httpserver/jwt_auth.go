@@ -0,0 +1,388 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package httpserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/redpanda-data/benthos/v4/internal/docs"
+	"github.com/redpanda-data/benthos/v4/internal/filepath/ifs"
+)
+
+const (
+	fieldJWTAuth                  = "jwt_auth"
+	fieldJWTAuthEnabled           = "enabled"
+	fieldJWTAuthHMACSecret        = "hmac_secret"
+	fieldJWTAuthPublicKeyFiles    = "public_key_files"
+	fieldJWTAuthJWKSFile          = "jwks_file"
+	fieldJWTAuthAlgorithms        = "algorithms"
+	fieldJWTAuthIssuer            = "issuer"
+	fieldJWTAuthAudience          = "audience"
+	fieldJWTAuthClockSkew         = "clock_skew"
+	fieldJWTAuthRequireExpiration = "require_expiration"
+)
+
+var jwtAlgorithms = []string{
+	"HS256", "HS384", "HS512",
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// JWTAuthConfig contains struct based fields for bearer JWT authentication.
+type JWTAuthConfig struct {
+	Enabled           bool     `json:"enabled" yaml:"enabled"`
+	HMACSecret        string   `json:"hmac_secret" yaml:"hmac_secret"`
+	PublicKeyFiles    []string `json:"public_key_files" yaml:"public_key_files"`
+	JWKSFile          string   `json:"jwks_file" yaml:"jwks_file"`
+	Algorithms        []string `json:"algorithms" yaml:"algorithms"`
+	Issuer            string   `json:"issuer" yaml:"issuer"`
+	Audience          string   `json:"audience" yaml:"audience"`
+	ClockSkew         string   `json:"clock_skew" yaml:"clock_skew"`
+	RequireExpiration bool     `json:"require_expiration" yaml:"require_expiration"`
+}
+
+// NewJWTAuthConfig returns a JWTAuthConfig with default values.
+func NewJWTAuthConfig() JWTAuthConfig {
+	return JWTAuthConfig{
+		Enabled:           false,
+		HMACSecret:        "",
+		PublicKeyFiles:    []string{},
+		JWKSFile:          "",
+		Algorithms:        []string{},
+		Issuer:            "",
+		Audience:          "",
+		ClockSkew:         "0s",
+		RequireExpiration: true,
+	}
+}
+
+// JWTAuthFieldSpec returns the spec for an HTTP server JWT authentication
+// component.
+func JWTAuthFieldSpec() docs.FieldSpec {
+	return docs.FieldObject(fieldJWTAuth, "Allows you to enforce bearer JSON Web Token authentication for requests to the HTTP server, where tokens are read from the `Authorization` header and verified with the keys provided.").WithChildren(
+		docs.FieldBool(fieldJWTAuthEnabled, "Enable JWT authentication.").HasDefault(false),
+		docs.FieldString(fieldJWTAuthHMACSecret, "A secret used to verify tokens signed with an HMAC algorithm.").HasDefault("").Secret(),
+		docs.FieldString(fieldJWTAuthPublicKeyFiles, "A list of paths to PEM encoded RSA, ECDSA or Ed25519 public keys used to verify tokens.").Array().HasDefault([]any{}),
+		docs.FieldString(fieldJWTAuthJWKSFile, "An optional path to a local JSON Web Key Set file containing RSA, EC, OKP (Ed25519) or symmetric keys used to verify tokens, where the key identifier (`kid`) of a token is used to select a key when present.").HasDefault(""),
+		docs.FieldString(fieldJWTAuthAlgorithms, "An optional list of signing algorithms that tokens are permitted to use, when empty all supported algorithms are permitted.", []any{"RS256", "ES256"}).Array().HasDefault([]any{}),
+		docs.FieldString(fieldJWTAuthIssuer, "An optional issuer that the `iss` claim of tokens must match.").HasDefault(""),
+		docs.FieldString(fieldJWTAuthAudience, "An optional audience that the `aud` claim of tokens must contain.").HasDefault(""),
+		docs.FieldString(fieldJWTAuthClockSkew, "The clock skew permitted when validating the time based claims `exp`, `nbf` and `iat` of tokens.").HasDefault("0s"),
+		docs.FieldBool(fieldJWTAuthRequireExpiration, "Whether tokens must contain an `exp` claim. Disabling this allows tokens that never expire to be used.").HasDefault(true),
+	).Advanced()
+}
+
+// JWTAuthConfigFromParsed extracts the JWT auth fields from the parsed config
+// and returns a JWTAuth config.
+func JWTAuthConfigFromParsed(pConf *docs.ParsedConfig) (conf JWTAuthConfig, err error) {
+	pConf = pConf.Namespace(fieldJWTAuth)
+	if conf.Enabled, err = pConf.FieldBool(fieldJWTAuthEnabled); err != nil {
+		return
+	}
+	if conf.HMACSecret, err = pConf.FieldString(fieldJWTAuthHMACSecret); err != nil {
+		return
+	}
+	if conf.PublicKeyFiles, err = pConf.FieldStringList(fieldJWTAuthPublicKeyFiles); err != nil {
+		return
+	}
+	if conf.JWKSFile, err = pConf.FieldString(fieldJWTAuthJWKSFile); err != nil {
+		return
+	}
+	if conf.Algorithms, err = pConf.FieldStringList(fieldJWTAuthAlgorithms); err != nil {
+		return
+	}
+	if conf.Issuer, err = pConf.FieldString(fieldJWTAuthIssuer); err != nil {
+		return
+	}
+	if conf.Audience, err = pConf.FieldString(fieldJWTAuthAudience); err != nil {
+		return
+	}
+	if conf.ClockSkew, err = pConf.FieldString(fieldJWTAuthClockSkew); err != nil {
+		return
+	}
+	if conf.RequireExpiration, err = pConf.FieldBool(fieldJWTAuthRequireExpiration); err != nil {
+		return
+	}
+	return
+}
+
+//------------------------------------------------------------------------------
+
+type jwtClaimsKey struct{}
+
+// JWTClaimsFromContext returns the claims of a verified token added to the
+// context of a request by JWT authentication.
+func JWTClaimsFromContext(ctx context.Context) (map[string]any, bool) {
+	claims, ok := ctx.Value(jwtClaimsKey{}).(map[string]any)
+	return claims, ok
+}
+
+type jwtKey struct {
+	id  string
+	key any
+}
+
+// JWTAuthenticator verifies the bearer tokens of requests.
+type JWTAuthenticator struct {
+	keys   []jwtKey
+	parser *jwt.Parser
+}
+
+// NewJWTAuthenticator creates a JWT authenticator from a config, reading key
+// files from the provided filesystem. Returns nil if JWT authentication is not
+// enabled.
+func NewJWTAuthenticator(conf JWTAuthConfig, f fs.FS) (*JWTAuthenticator, error) {
+	if !conf.Enabled {
+		return nil, nil
+	}
+
+	j := &JWTAuthenticator{}
+	if conf.HMACSecret != "" {
+		j.keys = append(j.keys, jwtKey{key: []byte(conf.HMACSecret)})
+	}
+	for _, path := range conf.PublicKeyFiles {
+		keyBytes, err := ifs.ReadFile(f, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read public key file: %w", err)
+		}
+		key, err := parsePublicKeyPEM(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key file %v: %w", path, err)
+		}
+		j.keys = append(j.keys, jwtKey{key: key})
+	}
+	if conf.JWKSFile != "" {
+		jwksBytes, err := ifs.ReadFile(f, conf.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read jwks file: %w", err)
+		}
+		keys, err := parseJWKS(jwksBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse jwks file %v: %w", conf.JWKSFile, err)
+		}
+		j.keys = append(j.keys, keys...)
+	}
+	if len(j.keys) == 0 {
+		return nil, errors.New("at least one of hmac_secret, public_key_files or jwks_file must be specified for jwt_auth")
+	}
+
+	algorithms := conf.Algorithms
+	if len(algorithms) == 0 {
+		algorithms = jwtAlgorithms
+	}
+	for _, alg := range algorithms {
+		if jwt.GetSigningMethod(alg) == nil {
+			return nil, fmt.Errorf("unsupported jwt_auth algorithm: %v", alg)
+		}
+	}
+
+	var skew time.Duration
+	if conf.ClockSkew != "" {
+		var err error
+		if skew, err = time.ParseDuration(conf.ClockSkew); err != nil {
+			return nil, fmt.Errorf("failed to parse jwt_auth clock_skew: %w", err)
+		}
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(algorithms),
+		jwt.WithLeeway(skew),
+	}
+	if conf.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(conf.Issuer))
+	}
+	if conf.Audience != "" {
+		opts = append(opts, jwt.WithAudience(conf.Audience))
+	}
+	if conf.RequireExpiration {
+		opts = append(opts, jwt.WithExpirationRequired())
+	}
+	j.parser = jwt.NewParser(opts...)
+	return j, nil
+}
+
+func (j *JWTAuthenticator) keyFunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	var set jwt.VerificationKeySet
+	for _, k := range j.keys {
+		if kid == "" || k.id == "" || k.id == kid {
+			set.Keys = append(set.Keys, k.key)
+		}
+	}
+	if len(set.Keys) == 0 {
+		return nil, fmt.Errorf("no key found matching kid %v", kid)
+	}
+	return set, nil
+}
+
+// Authenticate verifies the bearer token of a request and returns its claims.
+func (j *JWTAuthenticator) Authenticate(r *http.Request) (map[string]any, error) {
+	scheme, tokenStr, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return nil, errors.New("bearer token not found")
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := j.parser.ParseWithClaims(strings.TrimSpace(tokenStr), claims, j.keyFunc); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// WrapHandler wraps the provided HTTP handler with middleware that enforces
+// JWT authentication if it's enabled, adding the claims of verified tokens to
+// the request context.
+func (j *JWTAuthenticator) WrapHandler(next http.HandlerFunc) http.HandlerFunc {
+	if j == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, err := j.Authenticate(r)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), jwtClaimsKey{}, claims)))
+	})
+}
+
+//------------------------------------------------------------------------------
+
+func parsePublicKeyPEM(b []byte) (any, error) {
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(b); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseECPublicKeyFromPEM(b); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseEdPublicKeyFromPEM(b); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("expected a PEM encoded RSA, ECDSA or Ed25519 public key")
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	K   string `json:"k"`
+}
+
+func parseJWKS(b []byte) ([]jwtKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(b, &set); err != nil {
+		return nil, err
+	}
+
+	var keys []jwtKey
+	for i, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		key, err := k.verificationKey()
+		if err != nil {
+			return nil, fmt.Errorf("key %v: %w", i, err)
+		}
+		keys = append(keys, jwtKey{id: k.Kid, key: key})
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no signature keys found")
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) verificationKey() (any, error) {
+	decode := func(name, v string) ([]byte, error) {
+		if v == "" {
+			return nil, fmt.Errorf("missing parameter %v", name)
+		}
+		b, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(v, "="))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode parameter %v: %w", name, err)
+		}
+		return b, nil
+	}
+
+	switch k.Kty {
+	case "oct":
+		return decode("k", k.K)
+	case "RSA":
+		n, err := decode("n", k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode("e", k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %v", k.Crv)
+		}
+		x, err := decode("x", k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode("y", k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %v", k.Crv)
+		}
+		x, err := decode("x", k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("expected parameter x to contain %v bytes, got %v", ed25519.PublicKeySize, len(x))
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %v", k.Kty)
+}
@@ -0,0 +1,228 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package httpserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/internal/filepath/ifs"
+)
+
+func signedToken(t testing.TB, method jwt.SigningMethod, key any, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+func authStatus(t testing.TB, j *JWTAuthenticator, token string) (int, map[string]any) {
+	t.Helper()
+
+	var claims map[string]any
+	handler := j.WrapHandler(func(w http.ResponseWriter, r *http.Request) {
+		claims, _ = JWTClaimsFromContext(r.Context())
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", http.NoBody)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	res := httptest.NewRecorder()
+	handler(res, req)
+	return res.Code, claims
+}
+
+func TestJWTAuthHMAC(t *testing.T) {
+	conf := NewJWTAuthConfig()
+	conf.Enabled = true
+	conf.HMACSecret = "shh"
+	conf.Issuer = "foo"
+	conf.Audience = "bar"
+	conf.ClockSkew = "1m"
+
+	j, err := NewJWTAuthenticator(conf, ifs.OS())
+	require.NoError(t, err)
+
+	now := time.Now()
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub": "buz",
+			"iss": "foo",
+			"aud": "bar",
+			"exp": now.Add(time.Minute).Unix(),
+		}
+	}
+
+	code, claims := authStatus(t, j, signedToken(t, jwt.SigningMethodHS256, []byte("shh"), "", validClaims()))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "buz", claims["sub"])
+
+	skewed := validClaims()
+	skewed["exp"] = now.Add(-time.Second * 30).Unix()
+	code, _ = authStatus(t, j, signedToken(t, jwt.SigningMethodHS256, []byte("shh"), "", skewed))
+	assert.Equal(t, http.StatusOK, code)
+
+	for name, tokenFn := range map[string]func() string{
+		"missing": func() string { return "" },
+		"wrong secret": func() string {
+			return signedToken(t, jwt.SigningMethodHS256, []byte("nope"), "", validClaims())
+		},
+		"expired": func() string {
+			c := validClaims()
+			c["exp"] = now.Add(-time.Hour).Unix()
+			return signedToken(t, jwt.SigningMethodHS256, []byte("shh"), "", c)
+		},
+		"wrong issuer": func() string {
+			c := validClaims()
+			c["iss"] = "baz"
+			return signedToken(t, jwt.SigningMethodHS256, []byte("shh"), "", c)
+		},
+		"wrong audience": func() string {
+			c := validClaims()
+			c["aud"] = "baz"
+			return signedToken(t, jwt.SigningMethodHS256, []byte("shh"), "", c)
+		},
+		"unsigned": func() string {
+			return signedToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", validClaims())
+		},
+		"no expiry": func() string {
+			c := validClaims()
+			delete(c, "exp")
+			return signedToken(t, jwt.SigningMethodHS256, []byte("shh"), "", c)
+		},
+	} {
+		code, claims := authStatus(t, j, tokenFn())
+		assert.Equal(t, http.StatusUnauthorized, code, name)
+		assert.Nil(t, claims, name)
+	}
+}
+
+func TestJWTAuthPublicKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	rsaPubBytes, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	rsaPath := filepath.Join(dir, "rsa.pem")
+	require.NoError(t, os.WriteFile(rsaPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rsaPubBytes}), 0o644))
+
+	ecKeyA, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	ecKeyB, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwk := func(kid string, k *ecdsa.PrivateKey) map[string]any {
+		return map[string]any{
+			"kty": "EC",
+			"kid": kid,
+			"use": "sig",
+			"crv": "P-256",
+			"x":   base64.RawURLEncoding.EncodeToString(k.X.FillBytes(make([]byte, 32))),
+			"y":   base64.RawURLEncoding.EncodeToString(k.Y.FillBytes(make([]byte, 32))),
+		}
+	}
+	edPub, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwksBytes, err := json.Marshal(map[string]any{
+		"keys": []any{jwk("a", ecKeyA), jwk("b", ecKeyB), map[string]any{
+			"kty": "OKP",
+			"kid": "c",
+			"crv": "Ed25519",
+			"x":   base64.RawURLEncoding.EncodeToString(edPub),
+		}},
+	})
+	require.NoError(t, err)
+	jwksPath := filepath.Join(dir, "jwks.json")
+	require.NoError(t, os.WriteFile(jwksPath, jwksBytes, 0o644))
+
+	conf := NewJWTAuthConfig()
+	conf.Enabled = true
+	conf.PublicKeyFiles = []string{rsaPath}
+	conf.JWKSFile = jwksPath
+	conf.Algorithms = []string{"RS256", "ES256", "EdDSA"}
+
+	j, err := NewJWTAuthenticator(conf, ifs.OS())
+	require.NoError(t, err)
+
+	claims := jwt.MapClaims{"sub": "foo", "exp": time.Now().Add(time.Minute).Unix()}
+
+	code, _ := authStatus(t, j, signedToken(t, jwt.SigningMethodRS256, rsaKey, "", claims))
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _ = authStatus(t, j, signedToken(t, jwt.SigningMethodES256, ecKeyB, "b", claims))
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _ = authStatus(t, j, signedToken(t, jwt.SigningMethodES256, ecKeyA, "", claims))
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _ = authStatus(t, j, signedToken(t, jwt.SigningMethodEdDSA, edKey, "c", claims))
+	assert.Equal(t, http.StatusOK, code)
+
+	// The key identifier selects the key.
+	code, _ = authStatus(t, j, signedToken(t, jwt.SigningMethodES256, ecKeyA, "b", claims))
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	// Algorithms not listed are rejected.
+	code, _ = authStatus(t, j, signedToken(t, jwt.SigningMethodRS512, rsaKey, "", claims))
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestJWTAuthExpirationOptional(t *testing.T) {
+	conf := NewJWTAuthConfig()
+	conf.Enabled = true
+	conf.HMACSecret = "shh"
+	conf.RequireExpiration = false
+
+	j, err := NewJWTAuthenticator(conf, ifs.OS())
+	require.NoError(t, err)
+
+	code, _ := authStatus(t, j, signedToken(t, jwt.SigningMethodHS256, []byte("shh"), "", jwt.MapClaims{"sub": "foo"}))
+	assert.Equal(t, http.StatusOK, code)
+
+	// Expired tokens are still rejected.
+	code, _ = authStatus(t, j, signedToken(t, jwt.SigningMethodHS256, []byte("shh"), "", jwt.MapClaims{
+		"sub": "foo",
+		"exp": time.Now().Add(-time.Hour).Unix(),
+	}))
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestJWTAuthConfigErrors(t *testing.T) {
+	_, err := NewJWTAuthenticator(JWTAuthConfig{Enabled: true}, ifs.OS())
+	require.Error(t, err)
+
+	_, err = NewJWTAuthenticator(JWTAuthConfig{Enabled: true, HMACSecret: "foo", Algorithms: []string{"nope"}}, ifs.OS())
+	require.Error(t, err)
+
+	_, err = NewJWTAuthenticator(JWTAuthConfig{Enabled: true, PublicKeyFiles: []string{"/does/not/exist.pem"}}, ifs.OS())
+	require.Error(t, err)
+
+	j, err := NewJWTAuthenticator(JWTAuthConfig{}, ifs.OS())
+	require.NoError(t, err)
+	assert.Nil(t, j)
+}
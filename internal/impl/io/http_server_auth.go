@@ -0,0 +1,115 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package io
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/redpanda-data/benthos/v4/internal/bundle"
+	"github.com/redpanda-data/benthos/v4/internal/httpserver"
+	"github.com/redpanda-data/benthos/v4/internal/message"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	hsFieldClientCAFile             = "client_ca_file"
+	hsFieldJWTAuth                  = "jwt_auth"
+	hsFieldJWTAuthEnabled           = "enabled"
+	hsFieldJWTAuthHMACSecret        = "hmac_secret"
+	hsFieldJWTAuthPublicKeyFiles    = "public_key_files"
+	hsFieldJWTAuthJWKSFile          = "jwks_file"
+	hsFieldJWTAuthAlgorithms        = "algorithms"
+	hsFieldJWTAuthIssuer            = "issuer"
+	hsFieldJWTAuthAudience          = "audience"
+	hsFieldJWTAuthClockSkew         = "clock_skew"
+	hsFieldJWTAuthRequireExpiration = "require_expiration"
+)
+
+func hsClientCAFileField() *service.ConfigField {
+	return service.NewStringField(hsFieldClientCAFile).
+		Description("An optional file containing certificate authorities, which when specified requires clients to present a certificate signed by one of them. Only valid with a custom `address` and TLS enabled.").
+		Advanced().
+		Default("")
+}
+
+func hsJWTAuthField() *service.ConfigField {
+	jwtSpec := httpserver.JWTAuthFieldSpec()
+	jwtSpec.Description += " Only valid with a custom `address`, requests to the service-wide HTTP server are authenticated according to its own `jwt_auth` config."
+	return service.NewInternalField(jwtSpec)
+}
+
+func jwtAuthConfigFromParsed(pConf *service.ParsedConfig) (conf httpserver.JWTAuthConfig, err error) {
+	if conf.Enabled, err = pConf.FieldBool(hsFieldJWTAuthEnabled); err != nil {
+		return
+	}
+	if conf.HMACSecret, err = pConf.FieldString(hsFieldJWTAuthHMACSecret); err != nil {
+		return
+	}
+	if conf.PublicKeyFiles, err = pConf.FieldStringList(hsFieldJWTAuthPublicKeyFiles); err != nil {
+		return
+	}
+	if conf.JWKSFile, err = pConf.FieldString(hsFieldJWTAuthJWKSFile); err != nil {
+		return
+	}
+	if conf.Algorithms, err = pConf.FieldStringList(hsFieldJWTAuthAlgorithms); err != nil {
+		return
+	}
+	if conf.Issuer, err = pConf.FieldString(hsFieldJWTAuthIssuer); err != nil {
+		return
+	}
+	if conf.Audience, err = pConf.FieldString(hsFieldJWTAuthAudience); err != nil {
+		return
+	}
+	if conf.ClockSkew, err = pConf.FieldString(hsFieldJWTAuthClockSkew); err != nil {
+		return
+	}
+	if conf.RequireExpiration, err = pConf.FieldBool(hsFieldJWTAuthRequireExpiration); err != nil {
+		return
+	}
+	return
+}
+
+var errHSAuthRequiresAddress = errors.New("client_ca_file and jwt_auth are only valid with a custom address")
+
+// hsServerAuth applies JWT and client certificate authentication to a custom
+// HTTP server, returning the handler to serve the router with.
+func hsServerAuth(server *http.Server, handler http.Handler, certFile, caFile string, jwtConf httpserver.JWTAuthConfig, mgr bundle.NewManagement) (http.Handler, error) {
+	if caFile != "" {
+		if certFile == "" {
+			return nil, errors.New("client_ca_file requires cert_file and key_file to be specified")
+		}
+		tlsConf, err := httpserver.ClientAuthTLSConfig(mgr.FS(), caFile)
+		if err != nil {
+			return nil, err
+		}
+		server.TLSConfig = tlsConf
+	}
+
+	jwtAuth, err := httpserver.NewJWTAuthenticator(jwtConf, mgr.FS())
+	if err != nil {
+		return nil, err
+	}
+	if jwtAuth == nil {
+		return handler, nil
+	}
+	return jwtAuth.WrapHandler(handler.ServeHTTP), nil
+}
+
+// setRequestIdentityMetadata adds the verified identities of the client of a
+// request to a message as metadata.
+func setRequestIdentityMetadata(p *message.Part, r *http.Request) {
+	if subject, sans, ok := httpserver.TLSClientIdentity(r.TLS); ok {
+		p.MetaSetMut("http_server_tls_subject", subject)
+		if len(sans) > 0 {
+			sansAny := make([]any, len(sans))
+			for i, s := range sans {
+				sansAny[i] = s
+			}
+			p.MetaSetMut("http_server_tls_sans", sansAny)
+		}
+	}
+	if claims, ok := httpserver.JWTClaimsFromContext(r.Context()); ok {
+		p.MetaSetMut("http_server_jwt_claims", claims)
+	}
+}
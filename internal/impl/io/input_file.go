@@ -37,7 +37,21 @@ This input adds the following metadata fields to each message:
 `+"```"+`
 
 You can access these metadata fields using
-xref:configuration:interpolation.adoc#bloblang-queries[function interpolation].`).
+xref:configuration:interpolation.adoc#bloblang-queries[function interpolation].
+
+== Following files
+
+When `+"`follow.enabled`"+` is set the input keeps consuming files after reaching their end, consuming data as it is appended and consuming new files that match the configured paths as they appear. A file is considered rotated when its path refers to a different inode, in which case the remainder of the old file is consumed before the new file is consumed from its start, and a file is considered truncated when its size is smaller than the amount consumed, in which case it is consumed again from its start.
+
+In this mode files are consumed in chunks of complete lines, where each chunk is scanned independently and a line is only consumed once its terminating newline has been written. Therefore scanners that depend on earlier data within a file, such as the `+"`header_row`"+` of the `+"`csv`"+` scanner or decompression, are not suitable for followed files.
+
+The offsets of consumed files can be persisted to either a cache resource or a file, allowing the input to resume where it left off after a restart. An offset is only advanced once all messages preceding it have been acknowledged, and therefore delivery resumes from the first message that was not acknowledged. Detecting rotation across restarts is only supported on platforms with inodes.
+
+== Watching directories
+
+When `+"`watch.enabled`"+` is set the input watches the directories of the configured paths for filesystem notifications and consumes files that match the configured paths as they arrive, which includes files that are renamed into place. The directory of each path must therefore not contain glob patterns. A file is only consumed once its size and modification time have remained unchanged for the `+"`watch.settle_period`"+`, and files that already exist when the input starts are consumed unless `+"`watch.backfill`"+` is disabled.
+
+Once all messages of a file have been acknowledged the file is either deleted when `+"`delete_on_finish`"+` is set, moved into the `+"`watch.done_dir`"+` when set, or otherwise left in place and only consumed again if it is modified. If a file cannot be consumed, or its messages are rejected, it is moved into the `+"`watch.failed_dir`"+` when set. Since rejected messages are retried indefinitely by default, `+"`auto_replay_nacks`"+` must be disabled for files to be moved into the `+"`watch.failed_dir`"+` due to rejected messages.`).
 		Example(
 			"Read a Bunch of CSVs",
 			"If we wished to consume a directory of CSV files as structured documents we can use a glob pattern and the `csv` scanner:",
@@ -59,14 +73,55 @@ input:
 				Description("Whether to delete input files from the disk once they are fully consumed.").
 				Advanced().
 				Default(false),
+			fileInputFollowField(),
+			fileInputWatchField(),
 			service.NewAutoRetryNacksToggleField(),
+		).
+		Example(
+			"Follow Application Logs",
+			"Files can be followed as they grow, similar to `tail -F`, with the offsets of consumed data stored in a file so that a restarted pipeline resumes where delivery left off:",
+			`
+input:
+  file:
+    paths: [ /var/log/app/*.log ]
+    scanner:
+      lines: {}
+    follow:
+      enabled: true
+      checkpoint_file: /var/lib/connect/app_log_offsets.json
+`,
+		).
+		Example(
+			"Process Files as They Arrive",
+			"A directory can be watched for new files, where each file is moved to another directory once it has been processed, or to a separate directory when processing failed:",
+			`
+input:
+  file:
+    paths: [ /data/inbox/*.json ]
+    scanner:
+      lines: {}
+    auto_replay_nacks: false
+    watch:
+      enabled: true
+      settle_period: 5s
+      done_dir: /data/done
+      failed_dir: /data/failed
+`,
 		)
 }
 
 func init() {
 	service.MustRegisterBatchInput("file", fileInputSpec(),
 		func(pConf *service.ParsedConfig, res *service.Resources) (service.BatchInput, error) {
-			r, err := fileConsumerFromParsed(pConf, res)
+			var r service.BatchInput
+			var err error
+			if watch, _ := pConf.FieldBool(fileInputFieldWatch, fileInputFieldWatchEnabled); watch {
+				r, err = fileWatchConsumerFromParsed(pConf, res)
+			} else if follow, _ := pConf.FieldBool(fileInputFieldFollow, fileInputFieldFollowEnabled); follow {
+				r, err = fileFollowConsumerFromParsed(pConf, res)
+			} else {
+				r, err = fileConsumerFromParsed(pConf, res)
+			}
 			if err != nil {
 				return nil, err
 			}
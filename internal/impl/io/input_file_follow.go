@@ -0,0 +1,751 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package io
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sync"
+	"time"
+
+	"github.com/Jeffail/shutdown"
+
+	"github.com/redpanda-data/benthos/v4/internal/component"
+	"github.com/redpanda-data/benthos/v4/internal/filepath"
+	"github.com/redpanda-data/benthos/v4/internal/filepath/ifs"
+	"github.com/redpanda-data/benthos/v4/public/service"
+	"github.com/redpanda-data/benthos/v4/public/service/codec"
+)
+
+const (
+	fileInputFieldFollow                   = "follow"
+	fileInputFieldFollowEnabled            = "enabled"
+	fileInputFieldFollowPollInterval       = "poll_interval"
+	fileInputFieldFollowCheckpointCache    = "checkpoint_cache"
+	fileInputFieldFollowCheckpointCacheKey = "checkpoint_cache_key"
+	fileInputFieldFollowCheckpointFile     = "checkpoint_file"
+	fileInputFieldFollowCheckpointPeriod   = "checkpoint_period"
+)
+
+// The maximum number of bytes read from a followed file and scanned at once.
+// Lines longer than this are split.
+const fileFollowMaxChunkSize = 1024 * 1024
+
+func fileInputFollowField() *service.ConfigField {
+	return service.NewObjectField(fileInputFieldFollow,
+		service.NewBoolField(fileInputFieldFollowEnabled).
+			Description("Whether to keep following files once they have been consumed to the end.").
+			Default(false),
+		service.NewDurationField(fileInputFieldFollowPollInterval).
+			Description("The period between checks for new data, new files matching the configured paths, and rotated or truncated files.").
+			Default("1s"),
+		service.NewStringField(fileInputFieldFollowCheckpointCache).
+			Description("An optional xref:components:caches/about.adoc[cache resource] to persist the offsets of consumed files to.").
+			Default(""),
+		service.NewStringField(fileInputFieldFollowCheckpointCacheKey).
+			Description("The key under which offsets are stored within the `checkpoint_cache`.").
+			Default("file_input_offsets").
+			Advanced(),
+		service.NewStringField(fileInputFieldFollowCheckpointFile).
+			Description("An optional path of a file to persist the offsets of consumed files to, as an alternative to `checkpoint_cache`.").
+			Default(""),
+		service.NewDurationField(fileInputFieldFollowCheckpointPeriod).
+			Description("The period between writes of acknowledged offsets to the checkpoint cache or file. Offsets are also written when the input shuts down.").
+			Default("1s").
+			Advanced(),
+	).Description("Keep consuming files as they grow, similar to `tail -F`. When enabled the input never finishes, instead new data appended to files is consumed as it is written and new files matching the configured paths are consumed as they appear.").
+		Advanced()
+}
+
+//------------------------------------------------------------------------------
+
+// fileOffset is the checkpointed progress of a followed file, along with the
+// path the file was last found at.
+type fileOffset struct {
+	Path   string `json:"path"`
+	Offset int64  `json:"offset"`
+}
+
+// fileOffsetStore persists the offsets of followed files keyed by their device
+// and inode numbers, or by their path on platforms where those aren't
+// supported.
+type fileOffsetStore interface {
+	Load(ctx context.Context) (map[string]fileOffset, error)
+	Store(ctx context.Context, offsets map[string]fileOffset) error
+}
+
+type cacheFileOffsetStore struct {
+	res   *service.Resources
+	cache string
+	key   string
+}
+
+func (c *cacheFileOffsetStore) Load(ctx context.Context) (offsets map[string]fileOffset, err error) {
+	var b []byte
+	if cerr := c.res.AccessCache(ctx, c.cache, func(cache service.Cache) {
+		b, err = cache.Get(ctx, c.key)
+	}); cerr != nil {
+		return nil, cerr
+	}
+	if errors.Is(err, service.ErrKeyNotFound) {
+		return map[string]fileOffset{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &offsets); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+	return offsets, nil
+}
+
+func (c *cacheFileOffsetStore) Store(ctx context.Context, offsets map[string]fileOffset) (err error) {
+	b, err := json.Marshal(offsets)
+	if err != nil {
+		return err
+	}
+	if cerr := c.res.AccessCache(ctx, c.cache, func(cache service.Cache) {
+		err = cache.Set(ctx, c.key, b, nil)
+	}); cerr != nil {
+		return cerr
+	}
+	return err
+}
+
+type stateFileOffsetStore struct {
+	fs   *service.FS
+	path string
+}
+
+func (s *stateFileOffsetStore) Load(ctx context.Context) (map[string]fileOffset, error) {
+	b, err := ifs.ReadFile(s.fs, s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return map[string]fileOffset{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var offsets map[string]fileOffset
+	if err := json.Unmarshal(b, &offsets); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint file: %w", err)
+	}
+	return offsets, nil
+}
+
+func (s *stateFileOffsetStore) Store(ctx context.Context, offsets map[string]fileOffset) error {
+	b, err := json.Marshal(offsets)
+	if err != nil {
+		return err
+	}
+	return ifs.WriteFile(s.fs, s.path, b, 0o644)
+}
+
+//------------------------------------------------------------------------------
+
+// fileOffsetCommitter tracks the end offsets of chunks read from a file and
+// yields the offset up to which all chunks have been acknowledged, regardless
+// of the order in which they're acknowledged.
+type fileOffsetCommitter struct {
+	mut      sync.Mutex
+	firstSeq uint64
+	ends     []int64
+	acked    []bool
+
+	// Set once the offsets of the file are no longer meaningful due to it being
+	// truncated, protected by the offsets mutex of the consumer.
+	detached bool
+
+	// Set once the file is no longer followed, at which point its stored offset
+	// is removed once all chunks are acknowledged. Protected by the offsets
+	// mutex of the consumer.
+	dropped bool
+}
+
+// track adds a chunk ending at an offset and returns its sequence number.
+func (c *fileOffsetCommitter) track(end int64) uint64 {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.ends = append(c.ends, end)
+	c.acked = append(c.acked, false)
+	return c.firstSeq + uint64(len(c.ends)) - 1
+}
+
+// pending returns whether any chunks are yet to be acknowledged.
+func (c *fileOffsetCommitter) pending() bool {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	return len(c.ends) > 0
+}
+
+// ack marks a chunk as acknowledged and returns the offset up to which all
+// chunks are now acknowledged, if it has changed.
+func (c *fileOffsetCommitter) ack(seq uint64) (int64, bool) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.acked[seq-c.firstSeq] = true
+
+	var n int
+	for n < len(c.acked) && c.acked[n] {
+		n++
+	}
+	if n == 0 {
+		return 0, false
+	}
+	end := c.ends[n-1]
+	c.ends, c.acked = c.ends[n:], c.acked[n:]
+	c.firstSeq += uint64(n)
+	return end, true
+}
+
+// followedFile is a file being followed, which is read in chunks of complete
+// lines.
+type followedFile struct {
+	// Modified only while holding both the files and offsets mutexes of the
+	// consumer.
+	path string
+	file fs.File
+
+	// The device and inode numbers of the file, empty when not supported.
+	id string
+
+	// The offset of the next byte to be read from the file, and the bytes read
+	// following the last complete line.
+	readOffset int64
+	partial    []byte
+
+	// Set once the path no longer refers to this file, at which point it is
+	// consumed to the end and dropped.
+	finishing bool
+
+	committer *fileOffsetCommitter
+}
+
+// offsetKey returns the key under which the offset of the file is stored,
+// which identifies the file regardless of its path when supported so that
+// files renamed during rotation are resumed rather than consumed again.
+func (f *followedFile) offsetKey() string {
+	if f.id != "" {
+		return f.id
+	}
+	return f.path
+}
+
+func (f *followedFile) seekTo(offset int64) error {
+	if s, ok := f.file.(io.Seeker); ok {
+		if _, err := s.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+	} else if _, err := io.CopyN(io.Discard, f.file, offset); err != nil {
+		return err
+	}
+	f.readOffset = offset
+	f.partial = nil
+	return nil
+}
+
+// readChunk reads the data available from the file into a buffer and returns
+// the complete lines read so far along with the offset that follows them. An
+// empty chunk is returned when there is no complete line available.
+func (f *followedFile) readChunk(buf []byte) ([]byte, int64, error) {
+	n, err := io.ReadFull(f.file, buf[:len(buf)-len(f.partial)])
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, 0, err
+	}
+	f.readOffset += int64(n)
+
+	data := append(f.partial, buf[:n]...)
+	f.partial = nil
+
+	switch idx := bytes.LastIndexByte(data, '\n'); {
+	case f.finishing && n == 0:
+		// The file won't grow any further and so the remaining data is
+		// consumed even when it doesn't end with a newline.
+	case idx >= 0:
+		f.partial = append([]byte(nil), data[idx+1:]...)
+		data = data[:idx+1]
+	case len(data) < len(buf):
+		f.partial = data
+		return nil, 0, nil
+	}
+	return data, f.readOffset - int64(len(f.partial)), nil
+}
+
+//------------------------------------------------------------------------------
+
+type fileFollowConsumer struct {
+	log *service.Logger
+	nm  *service.Resources
+
+	paths        []string
+	scannerCtor  codec.DeprecatedFallbackCodec
+	pollInterval time.Duration
+
+	store       fileOffsetStore
+	storePeriod time.Duration
+
+	filesMut    sync.Mutex
+	files       map[string]*followedFile
+	nextFiles   []*followedFile
+	readBuf     []byte
+	lastRefresh time.Time
+	scanner     codec.DeprecatedFallbackStream
+	scannerFile *followedFile
+	modTimeUTC  time.Time
+
+	offsetsMut   sync.Mutex
+	offsets      map[string]fileOffset
+	offsetsDirty bool
+
+	connected bool
+	shutSig   *shutdown.Signaller
+}
+
+func fileFollowConsumerFromParsed(pConf *service.ParsedConfig, nm *service.Resources) (*fileFollowConsumer, error) {
+	paths, err := pConf.FieldStringList(fileInputFieldPaths)
+	if err != nil {
+		return nil, err
+	}
+	if deleteOnFinish, _ := pConf.FieldBool(fileInputFieldDeleteOnFinish); deleteOnFinish {
+		return nil, fmt.Errorf("%v cannot be used when following files", fileInputFieldDeleteOnFinish)
+	}
+	ctor, err := codec.DeprecatedCodecFromParsed(pConf)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := pConf.Namespace(fileInputFieldFollow)
+
+	pollInterval, err := conf.FieldDuration(fileInputFieldFollowPollInterval)
+	if err != nil {
+		return nil, err
+	}
+	storePeriod, err := conf.FieldDuration(fileInputFieldFollowCheckpointPeriod)
+	if err != nil {
+		return nil, err
+	}
+	cacheName, err := conf.FieldString(fileInputFieldFollowCheckpointCache)
+	if err != nil {
+		return nil, err
+	}
+	cacheKey, err := conf.FieldString(fileInputFieldFollowCheckpointCacheKey)
+	if err != nil {
+		return nil, err
+	}
+	stateFile, err := conf.FieldString(fileInputFieldFollowCheckpointFile)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &fileFollowConsumer{
+		log:          nm.Logger(),
+		nm:           nm,
+		paths:        paths,
+		scannerCtor:  ctor,
+		pollInterval: pollInterval,
+		storePeriod:  storePeriod,
+		files:        map[string]*followedFile{},
+		readBuf:      make([]byte, fileFollowMaxChunkSize),
+		offsets:      map[string]fileOffset{},
+		shutSig:      shutdown.NewSignaller(),
+	}
+
+	switch {
+	case cacheName != "" && stateFile != "":
+		return nil, fmt.Errorf("only one of %v and %v may be set", fileInputFieldFollowCheckpointCache, fileInputFieldFollowCheckpointFile)
+	case cacheName != "":
+		if !nm.HasCache(cacheName) {
+			return nil, fmt.Errorf("cache resource '%v' was not found", cacheName)
+		}
+		f.store = &cacheFileOffsetStore{res: nm, cache: cacheName, key: cacheKey}
+	case stateFile != "":
+		f.store = &stateFileOffsetStore{fs: nm.FS(), path: stateFile}
+	}
+	return f, nil
+}
+
+func (f *fileFollowConsumer) Connect(ctx context.Context) error {
+	f.filesMut.Lock()
+	defer f.filesMut.Unlock()
+	if f.connected {
+		return nil
+	}
+
+	if f.store != nil {
+		offsets, err := f.store.Load(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load offsets: %w", err)
+		}
+		f.offsetsMut.Lock()
+		f.offsets = offsets
+		f.offsetsMut.Unlock()
+		go f.storeLoop()
+	} else {
+		f.shutSig.TriggerHasStopped()
+	}
+
+	f.connected = true
+	return nil
+}
+
+func (f *fileFollowConsumer) storeLoop() {
+	defer f.shutSig.TriggerHasStopped()
+
+	ticker := time.NewTicker(f.storePeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-f.shutSig.SoftStopChan():
+			ctx, done := f.shutSig.HardStopCtx(context.Background())
+			if err := f.storeOffsets(ctx); err != nil {
+				f.log.Errorf("Failed to store file offsets: %v", err)
+			}
+			done()
+			return
+		}
+		ctx, done := f.shutSig.SoftStopCtx(context.Background())
+		if err := f.storeOffsets(ctx); err != nil {
+			f.log.Errorf("Failed to store file offsets: %v", err)
+		}
+		done()
+	}
+}
+
+func (f *fileFollowConsumer) storeOffsets(ctx context.Context) error {
+	f.offsetsMut.Lock()
+	if !f.offsetsDirty {
+		f.offsetsMut.Unlock()
+		return nil
+	}
+	offsets := make(map[string]fileOffset, len(f.offsets))
+	for k, v := range f.offsets {
+		offsets[k] = v
+	}
+	f.offsetsDirty = false
+	f.offsetsMut.Unlock()
+
+	if err := f.store.Store(ctx, offsets); err != nil {
+		f.offsetsMut.Lock()
+		f.offsetsDirty = true
+		f.offsetsMut.Unlock()
+		return err
+	}
+	return nil
+}
+
+// commitOffset records the acknowledged offset of a file, unless the file has
+// since been truncated. The offset of a file that is no longer followed is
+// removed once all of its chunks are acknowledged.
+func (f *fileFollowConsumer) commitOffset(ff *followedFile, committer *fileOffsetCommitter, seq uint64) {
+	offset, ok := committer.ack(seq)
+
+	f.offsetsMut.Lock()
+	defer f.offsetsMut.Unlock()
+	if committer.detached {
+		return
+	}
+	if committer.dropped && !committer.pending() {
+		delete(f.offsets, ff.offsetKey())
+		f.offsetsDirty = true
+		return
+	}
+	if ok {
+		f.offsets[ff.offsetKey()] = fileOffset{Path: ff.path, Offset: offset}
+		f.offsetsDirty = true
+	}
+}
+
+// resetOffset detaches the current committer of a truncated file so that
+// acknowledgments of chunks read previously are ignored, and resets the stored
+// offset of the file to the start.
+func (f *fileFollowConsumer) resetOffset(ff *followedFile) {
+	f.offsetsMut.Lock()
+	defer f.offsetsMut.Unlock()
+
+	ff.committer.detached = true
+	ff.committer = &fileOffsetCommitter{}
+	f.offsets[ff.offsetKey()] = fileOffset{Path: ff.path}
+	f.offsetsDirty = true
+}
+
+// dropOffset removes the stored offset of a file that is no longer followed,
+// which is deferred until any chunks read from the file are acknowledged.
+func (f *fileFollowConsumer) dropOffset(ff *followedFile) {
+	f.offsetsMut.Lock()
+	defer f.offsetsMut.Unlock()
+
+	ff.committer.dropped = true
+	if !ff.committer.pending() {
+		delete(f.offsets, ff.offsetKey())
+		f.offsetsDirty = true
+	}
+}
+
+// openFile starts following a file, resuming from a stored offset when the
+// file is the same as the one the offset was stored for.
+func (f *fileFollowConsumer) openFile(path string) (*followedFile, error) {
+	file, err := f.nm.FS().Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if info.IsDir() {
+		file.Close()
+		return nil, nil
+	}
+
+	ff := &followedFile{path: path, file: file, committer: &fileOffsetCommitter{}}
+	ff.id, _ = fileID(info)
+
+	f.offsetsMut.Lock()
+	stored, exists := f.offsets[ff.offsetKey()]
+	f.offsetsMut.Unlock()
+
+	if exists && stored.Offset <= info.Size() {
+		if err := ff.seekTo(stored.Offset); err != nil {
+			file.Close()
+			return nil, err
+		}
+		f.log.Debugf("Resuming file '%v' from offset %v", path, stored.Offset)
+	} else if exists {
+		f.log.Infof("File '%v' was truncated since its offset was stored, consuming it from the start", path)
+	}
+	return ff, nil
+}
+
+// refresh checks followed files for rotation and truncation and starts
+// following any new files that match the configured paths.
+func (f *fileFollowConsumer) refresh() {
+	for path, ff := range f.files {
+		if ff.finishing {
+			continue
+		}
+		info, err := f.nm.FS().Stat(path)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				f.log.Errorf("Failed to check file '%v': %v", path, err)
+				continue
+			}
+			f.log.Debugf("File '%v' was removed", path)
+			ff.finishing = true
+			continue
+		}
+		if id, ok := fileID(info); ok && id != ff.id {
+			f.log.Debugf("File '%v' was rotated", path)
+			ff.finishing = true
+			continue
+		}
+		if info.Size() < ff.readOffset {
+			f.log.Infof("File '%v' was truncated, consuming it from the start", path)
+			f.resetOffset(ff)
+			ff.file.Close()
+			delete(f.files, path)
+		}
+	}
+
+	paths, err := filepath.Globs(f.nm.FS(), f.paths)
+	if err != nil {
+		f.log.Errorf("Failed to expand file paths: %v", err)
+		return
+	}
+	for _, path := range paths {
+		if _, exists := f.files[path]; exists {
+			continue
+		}
+		if f.renamedFile(path) {
+			continue
+		}
+		ff, err := f.openFile(path)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) {
+				f.log.Errorf("Failed to open file '%v': %v", path, err)
+			}
+			continue
+		}
+		if ff == nil {
+			continue
+		}
+		f.log.Debugf("Following file '%v'", path)
+		f.files[path] = ff
+	}
+}
+
+// renamedFile checks whether a path refers to a file that is already followed
+// under another path, which is the case when a file is renamed during rotation
+// to a path that also matches. When the previous path of the file no longer
+// refers to it the file continues to be followed under the new path. Returns
+// true if the path is already followed.
+func (f *fileFollowConsumer) renamedFile(path string) bool {
+	info, err := f.nm.FS().Stat(path)
+	if err != nil {
+		return false
+	}
+	id, ok := fileID(info)
+	if !ok {
+		return false
+	}
+	for prevPath, ff := range f.files {
+		if ff.id != id {
+			continue
+		}
+		if ff.finishing {
+			f.log.Debugf("File '%v' was renamed to '%v'", prevPath, path)
+			delete(f.files, prevPath)
+			f.offsetsMut.Lock()
+			ff.path = path
+			f.offsetsMut.Unlock()
+			ff.finishing = false
+			f.files[path] = ff
+		}
+		return true
+	}
+	return false
+}
+
+// nextChunk returns the next chunk of complete lines from any followed file,
+// or an empty chunk if there is no data available. Files are read in turn so
+// that a busy file doesn't starve the others.
+func (f *fileFollowConsumer) nextChunk() (*followedFile, []byte, int64, error) {
+	refilled := false
+	for {
+		if len(f.nextFiles) == 0 {
+			if refilled {
+				return nil, nil, 0, nil
+			}
+			for _, ff := range f.files {
+				f.nextFiles = append(f.nextFiles, ff)
+			}
+			if len(f.nextFiles) == 0 {
+				return nil, nil, 0, nil
+			}
+			refilled = true
+		}
+
+		ff := f.nextFiles[0]
+		f.nextFiles = f.nextFiles[1:]
+		if f.files[ff.path] != ff {
+			continue
+		}
+
+		chunk, end, err := ff.readChunk(f.readBuf)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("failed to read file '%v': %w", ff.path, err)
+		}
+		if len(chunk) > 0 {
+			return ff, chunk, end, nil
+		}
+		if ff.finishing {
+			f.log.Debugf("Finished following file '%v'", ff.path)
+			ff.file.Close()
+			f.dropOffset(ff)
+			delete(f.files, ff.path)
+		}
+	}
+}
+
+func (f *fileFollowConsumer) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
+	f.filesMut.Lock()
+	defer f.filesMut.Unlock()
+
+	for {
+		if f.scanner != nil {
+			parts, codecAckFn, err := f.scanner.NextBatch(ctx)
+			if err != nil {
+				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+					return nil, nil, component.ErrTimeout
+				}
+				_ = f.scanner.Close(ctx)
+				f.scanner = nil
+				if errors.Is(err, io.EOF) {
+					continue
+				}
+				return nil, nil, err
+			}
+
+			for _, part := range parts {
+				part.MetaSetMut("path", f.scannerFile.path)
+				part.MetaSetMut("mod_time_unix", f.modTimeUTC.Unix())
+				part.MetaSetMut("mod_time", f.modTimeUTC.Format(time.RFC3339))
+			}
+			if len(parts) == 0 {
+				_ = codecAckFn(ctx, nil)
+				continue
+			}
+			return parts, codecAckFn, nil
+		}
+
+		if time.Since(f.lastRefresh) >= f.pollInterval {
+			f.refresh()
+			f.lastRefresh = time.Now()
+		}
+
+		ff, chunk, end, err := f.nextChunk()
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(chunk) == 0 {
+			select {
+			case <-time.After(f.pollInterval - time.Since(f.lastRefresh)):
+			case <-ctx.Done():
+				return nil, nil, component.ErrTimeout
+			}
+			continue
+		}
+
+		committer := ff.committer
+		seq := committer.track(end)
+
+		details := service.NewScannerSourceDetails()
+		details.SetName(ff.path)
+		if f.scanner, err = f.scannerCtor.Create(io.NopCloser(bytes.NewReader(chunk)), func(ctx context.Context, err error) error {
+			if err == nil {
+				f.commitOffset(ff, committer, seq)
+			}
+			return nil
+		}, details); err != nil {
+			return nil, nil, err
+		}
+		f.scannerFile = ff
+
+		f.modTimeUTC = time.Time{}
+		if info, err := ff.file.Stat(); err == nil {
+			f.modTimeUTC = info.ModTime().UTC()
+		}
+	}
+}
+
+func (f *fileFollowConsumer) Close(ctx context.Context) error {
+	f.filesMut.Lock()
+	if f.scanner != nil {
+		_ = f.scanner.Close(ctx)
+		f.scanner = nil
+	}
+	for path, ff := range f.files {
+		ff.file.Close()
+		delete(f.files, path)
+	}
+	connected := f.connected
+	f.filesMut.Unlock()
+
+	if !connected {
+		return nil
+	}
+	f.shutSig.TriggerSoftStop()
+	select {
+	case <-f.shutSig.HasStoppedChan():
+	case <-ctx.Done():
+		f.shutSig.TriggerHardStop()
+		return ctx.Err()
+	}
+	return nil
+}
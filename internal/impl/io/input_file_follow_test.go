@@ -0,0 +1,116 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package io
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileOffsetCommitter(t *testing.T) {
+	c := &fileOffsetCommitter{}
+
+	first := c.track(10)
+	second := c.track(25)
+	third := c.track(40)
+
+	_, ok := c.ack(second)
+	assert.False(t, ok)
+
+	offset, ok := c.ack(first)
+	require.True(t, ok)
+	assert.Equal(t, int64(25), offset)
+
+	fourth := c.track(60)
+	_, ok = c.ack(fourth)
+	assert.False(t, ok)
+
+	offset, ok = c.ack(third)
+	require.True(t, ok)
+	assert.Equal(t, int64(60), offset)
+}
+
+type nopFSFile struct {
+	io.Reader
+}
+
+func (nopFSFile) Stat() (fs.FileInfo, error) { return nil, errors.New("not supported") }
+func (nopFSFile) Close() error               { return nil }
+
+func TestFollowedFileReadChunk(t *testing.T) {
+	r := strings.NewReader("")
+	ff := &followedFile{file: nopFSFile{r}}
+	buf := make([]byte, 8)
+
+	r.Reset("foo\nba")
+	chunk, end, err := ff.readChunk(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "foo\n", string(chunk))
+	assert.Equal(t, int64(4), end)
+
+	r.Reset("r\nbaz")
+	chunk, end, err = ff.readChunk(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "bar\n", string(chunk))
+	assert.Equal(t, int64(8), end)
+
+	r.Reset("")
+	chunk, _, err = ff.readChunk(buf)
+	require.NoError(t, err)
+	assert.Empty(t, chunk)
+
+	// Lines longer than the buffer are split.
+	r.Reset("0123456789\n")
+	chunk, end, err = ff.readChunk(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "baz01234", string(chunk))
+	assert.Equal(t, int64(16), end)
+
+	r.Reset("56789\nqux")
+	chunk, end, err = ff.readChunk(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "56789\n", string(chunk))
+	assert.Equal(t, int64(22), end)
+
+	// Once finishing the remaining data is emitted without a newline.
+	ff.finishing = true
+	chunk, _, err = ff.readChunk(buf)
+	require.NoError(t, err)
+	assert.Empty(t, chunk)
+
+	chunk, end, err = ff.readChunk(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "qux", string(chunk))
+	assert.Equal(t, int64(25), end)
+}
+
+func TestFileFollowDropOffsetAwaitsAcks(t *testing.T) {
+	f := &fileFollowConsumer{offsets: map[string]fileOffset{}}
+	ff := &followedFile{path: "a.log", id: "1:2", committer: &fileOffsetCommitter{}}
+
+	committer := ff.committer
+	first := committer.track(10)
+	second := committer.track(20)
+
+	f.commitOffset(ff, committer, first)
+	assert.Equal(t, map[string]fileOffset{"1:2": {Path: "a.log", Offset: 10}}, f.offsets)
+
+	// The offset is retained until the remaining chunk is acknowledged.
+	f.dropOffset(ff)
+	assert.Equal(t, map[string]fileOffset{"1:2": {Path: "a.log", Offset: 10}}, f.offsets)
+
+	f.commitOffset(ff, committer, second)
+	assert.Empty(t, f.offsets)
+
+	// Files without pending chunks are removed immediately.
+	ff = &followedFile{path: "b.log", committer: &fileOffsetCommitter{}}
+	f.offsets["b.log"] = fileOffset{Path: "b.log", Offset: 5}
+	f.dropOffset(ff)
+	assert.Empty(t, f.offsets)
+}
@@ -0,0 +1,20 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+//go:build unix
+
+package io
+
+import (
+	"io/fs"
+	"strconv"
+	"syscall"
+)
+
+// fileID returns the device and inode numbers of a file, which identify the
+// file regardless of the path it is found at.
+func fileID(info fs.FileInfo) (string, bool) {
+	if st, ok := info.Sys().(*syscall.Stat_t); ok {
+		return strconv.FormatUint(uint64(st.Dev), 10) + ":" + strconv.FormatUint(uint64(st.Ino), 10), true
+	}
+	return "", false
+}
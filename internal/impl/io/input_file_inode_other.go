@@ -0,0 +1,15 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+//go:build !unix
+
+package io
+
+import (
+	"io/fs"
+)
+
+// fileID returns the device and inode numbers of a file, which isn't supported
+// on this platform.
+func fileID(info fs.FileInfo) (string, bool) {
+	return "", false
+}
@@ -3,17 +3,23 @@
 package io_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/redpanda-data/benthos/v4/internal/component/input"
 	"github.com/redpanda-data/benthos/v4/internal/component/testutil"
 	"github.com/redpanda-data/benthos/v4/internal/manager/mock"
 	"github.com/redpanda-data/benthos/v4/internal/message"
@@ -203,3 +209,375 @@ func assertValidMetaData(t *testing.T, res *message.Part, tmpFile *os.File) {
 func mockTime() time.Time {
 	return time.Date(2015, 8, 25, 23, 23, 0, 0, time.UTC)
 }
+
+func TestFileFollow(t *testing.T) {
+	tmpDir := t.TempDir()
+	checkpointPath := filepath.Join(tmpDir, "offsets.json")
+
+	appendFile := func(name, content string) {
+		t.Helper()
+		f, err := os.OpenFile(filepath.Join(tmpDir, name), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		require.NoError(t, err)
+		_, err = f.WriteString(content)
+		require.NoError(t, err)
+		require.NoError(t, f.Close())
+	}
+
+	newInput := func() input.Streamed {
+		t.Helper()
+		conf, err := testutil.InputFromYAML(fmt.Sprintf(`
+file:
+  paths: [ "%v/*.log" ]
+  scanner:
+    lines: {}
+  follow:
+    enabled: true
+    poll_interval: 10ms
+    checkpoint_file: %v
+    checkpoint_period: 10ms
+`, tmpDir, checkpointPath))
+		require.NoError(t, err)
+
+		i, err := mock.NewManager().NewInput(conf)
+		require.NoError(t, err)
+		return i
+	}
+
+	readMessages := func(i input.Streamed, n int) []string {
+		t.Helper()
+		var msgs []string
+		for len(msgs) < n {
+			select {
+			case tran, open := <-i.TransactionChan():
+				require.True(t, open)
+				for _, p := range tran.Payload {
+					msgs = append(msgs, p.MetaGetStr("path")+": "+string(p.AsBytes()))
+				}
+				require.NoError(t, tran.Ack(t.Context(), nil))
+			case <-time.After(time.Second * 5):
+				t.Fatalf("timed out after %v messages: %v", len(msgs), msgs)
+			}
+		}
+		return msgs
+	}
+
+	closeInput := func(i input.Streamed) {
+		t.Helper()
+		i.TriggerStopConsuming()
+		require.NoError(t, i.WaitForClose(t.Context()))
+	}
+
+	aPath, bPath := filepath.Join(tmpDir, "a.log"), filepath.Join(tmpDir, "b.log")
+
+	appendFile("a.log", "a1\na2\n")
+	i := newInput()
+	assert.Equal(t, []string{aPath + ": a1", aPath + ": a2"}, readMessages(i, 2))
+
+	// Appended data is consumed once its line is complete.
+	appendFile("a.log", "a3\na4")
+	assert.Equal(t, []string{aPath + ": a3"}, readMessages(i, 1))
+	appendFile("a.log", "\n")
+	assert.Equal(t, []string{aPath + ": a4"}, readMessages(i, 1))
+
+	// New files are discovered.
+	appendFile("b.log", "b1\n")
+	assert.Equal(t, []string{bPath + ": b1"}, readMessages(i, 1))
+
+	// Rotated files are consumed to the end before the new file.
+	appendFile("a.log", "a5\n")
+	require.NoError(t, os.Rename(aPath, filepath.Join(tmpDir, "a.log.1")))
+	appendFile("a.log.1", "a6\n")
+	appendFile("a.log", "new1\n")
+	assert.ElementsMatch(t, []string{aPath + ": a5", aPath + ": a6", aPath + ": new1"}, readMessages(i, 3))
+
+	// Files renamed to a path that also matches are not consumed again.
+	appendFile("b.log", "b2\n")
+	assert.Equal(t, []string{bPath + ": b2"}, readMessages(i, 1))
+	require.NoError(t, os.Rename(bPath, filepath.Join(tmpDir, "b.1.log")))
+	appendFile("b.1.log", "b3\n")
+	appendFile("b.log", "newb1\n")
+	var contents []string
+	for _, m := range readMessages(i, 2) {
+		contents = append(contents, m[strings.LastIndex(m, " ")+1:])
+	}
+	assert.ElementsMatch(t, []string{"b3", "newb1"}, contents)
+	require.NoError(t, os.Remove(filepath.Join(tmpDir, "b.1.log")))
+
+	// Truncated files are consumed from the start.
+	require.NoError(t, os.Truncate(bPath, 0))
+	time.Sleep(time.Millisecond * 100)
+	appendFile("b.log", "newb2\n")
+	assert.Equal(t, []string{bPath + ": newb2"}, readMessages(i, 1))
+
+	closeInput(i)
+
+	// Offsets are restored following a restart.
+	appendFile("a.log", "new2\n")
+	appendFile("b.log", "newb3\n")
+	i = newInput()
+	assert.ElementsMatch(t, []string{aPath + ": new2", bPath + ": newb3"}, readMessages(i, 2))
+	closeInput(i)
+}
+
+func TestFileFollowUnackedResumes(t *testing.T) {
+	tmpDir := t.TempDir()
+	logPath := filepath.Join(tmpDir, "a.log")
+	checkpointPath := filepath.Join(tmpDir, "offsets.json")
+
+	require.NoError(t, os.WriteFile(logPath, []byte("foo\n"), 0o644))
+
+	conf, err := testutil.InputFromYAML(fmt.Sprintf(`
+file:
+  paths: [ "%v" ]
+  scanner:
+    lines: {}
+  follow:
+    enabled: true
+    poll_interval: 10ms
+    checkpoint_file: %v
+  auto_replay_nacks: false
+`, logPath, checkpointPath))
+	require.NoError(t, err)
+
+	i, err := mock.NewManager().NewInput(conf)
+	require.NoError(t, err)
+
+	readTran := func() *message.Transaction {
+		t.Helper()
+		select {
+		case tran := <-i.TransactionChan():
+			return &tran
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out")
+		}
+		return nil
+	}
+
+	// Only chunks that have been fully acknowledged advance the offset.
+	require.NoError(t, readTran().Ack(t.Context(), nil))
+	require.NoError(t, os.WriteFile(logPath, []byte("foo\nbar\n"), 0o644))
+	require.NoError(t, readTran().Ack(t.Context(), errors.New("nope")))
+
+	i.TriggerStopConsuming()
+	require.NoError(t, i.WaitForClose(t.Context()))
+
+	b, err := os.ReadFile(checkpointPath)
+	require.NoError(t, err)
+
+	var offsets map[string]struct {
+		Path   string `json:"path"`
+		Offset int64  `json:"offset"`
+	}
+	require.NoError(t, json.Unmarshal(b, &offsets))
+	require.Len(t, offsets, 1)
+	for _, o := range offsets {
+		assert.Equal(t, logPath, o.Path)
+		assert.Equal(t, int64(4), o.Offset)
+	}
+}
+
+func TestFileWatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	inboxDir, doneDir := filepath.Join(tmpDir, "inbox"), filepath.Join(tmpDir, "done")
+	require.NoError(t, os.MkdirAll(inboxDir, 0o755))
+
+	require.NoError(t, os.WriteFile(filepath.Join(inboxDir, "a.txt"), []byte("a1\na2\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(inboxDir, "ignored.csv"), []byte("nope\n"), 0o644))
+
+	conf, err := testutil.InputFromYAML(fmt.Sprintf(`
+file:
+  paths: [ "%v/*.txt" ]
+  scanner:
+    lines: {}
+  watch:
+    enabled: true
+    settle_period: 50ms
+    done_dir: %v
+`, inboxDir, doneDir))
+	require.NoError(t, err)
+
+	i, err := mock.NewManager().NewInput(conf)
+	require.NoError(t, err)
+
+	readMessages := func(n int) []string {
+		t.Helper()
+		var msgs []string
+		for len(msgs) < n {
+			select {
+			case tran, open := <-i.TransactionChan():
+				require.True(t, open)
+				for _, p := range tran.Payload {
+					msgs = append(msgs, filepath.Base(p.MetaGetStr("path"))+": "+string(p.AsBytes()))
+				}
+				require.NoError(t, tran.Ack(t.Context(), nil))
+			case <-time.After(time.Second * 5):
+				t.Fatalf("timed out after %v messages: %v", len(msgs), msgs)
+			}
+		}
+		return msgs
+	}
+
+	// Existing files are backfilled.
+	assert.Equal(t, []string{"a.txt: a1", "a.txt: a2"}, readMessages(2))
+
+	// Files renamed into place are consumed.
+	require.NoError(t, os.WriteFile(filepath.Join(inboxDir, "b.tmp"), []byte("b1\n"), 0o644))
+	require.NoError(t, os.Rename(filepath.Join(inboxDir, "b.tmp"), filepath.Join(inboxDir, "b.txt")))
+	assert.Equal(t, []string{"b.txt: b1"}, readMessages(1))
+
+	// Files still being written are consumed once they settle.
+	f, err := os.Create(filepath.Join(inboxDir, "c.txt"))
+	require.NoError(t, err)
+	for _, line := range []string{"c1\n", "c2\n", "c3\n"} {
+		_, err = f.WriteString(line)
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond * 20)
+	}
+	require.NoError(t, f.Close())
+	assert.Equal(t, []string{"c.txt: c1", "c.txt: c2", "c.txt: c3"}, readMessages(3))
+
+	assert.Eventually(t, func() bool {
+		entries, err := os.ReadDir(doneDir)
+		return err == nil && len(entries) == 3
+	}, time.Second*5, time.Millisecond*10)
+
+	i.TriggerStopConsuming()
+	require.NoError(t, i.WaitForClose(t.Context()))
+
+	entries, err := os.ReadDir(inboxDir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "ignored.csv", entries[0].Name())
+}
+
+func TestFileWatchFailedDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	inboxDir, failedDir := filepath.Join(tmpDir, "inbox"), filepath.Join(tmpDir, "failed")
+	require.NoError(t, os.MkdirAll(inboxDir, 0o755))
+
+	conf, err := testutil.InputFromYAML(fmt.Sprintf(`
+file:
+  paths: [ "%v/*.txt" ]
+  scanner:
+    lines: {}
+  auto_replay_nacks: false
+  watch:
+    enabled: true
+    settle_period: 10ms
+    backfill: false
+    failed_dir: %v
+`, inboxDir, failedDir))
+	require.NoError(t, err)
+
+	i, err := mock.NewManager().NewInput(conf)
+	require.NoError(t, err)
+
+	// Allow the watcher to start before creating the file.
+	time.Sleep(time.Millisecond * 100)
+	require.NoError(t, os.WriteFile(filepath.Join(inboxDir, "a.txt"), []byte("a1\n"), 0o644))
+
+	select {
+	case tran, open := <-i.TransactionChan():
+		require.True(t, open)
+		require.NoError(t, tran.Ack(t.Context(), errors.New("nope")))
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out")
+	}
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(filepath.Join(failedDir, "a.txt"))
+		return err == nil
+	}, time.Second*5, time.Millisecond*10)
+
+	i.TriggerStopConsuming()
+	require.NoError(t, i.WaitForClose(t.Context()))
+}
+
+func TestFileWatchUnopenableFailedDir(t *testing.T) {
+	tmpDir := t.TempDir()
+	inboxDir, failedDir := filepath.Join(tmpDir, "inbox"), filepath.Join(tmpDir, "failed")
+	require.NoError(t, os.MkdirAll(inboxDir, 0o755))
+
+	// The gzip header is invalid and therefore the scanner cannot be created.
+	require.NoError(t, os.WriteFile(filepath.Join(inboxDir, "a.txt"), []byte("not gzip\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(inboxDir, "b.txt"), gzipped(t, "b1\n"), 0o644))
+
+	conf, err := testutil.InputFromYAML(fmt.Sprintf(`
+file:
+  paths: [ "%v/*.txt" ]
+  scanner:
+    decompress:
+      algorithm: gzip
+      into:
+        lines: {}
+  watch:
+    enabled: true
+    settle_period: 10ms
+    failed_dir: %v
+`, inboxDir, failedDir))
+	require.NoError(t, err)
+
+	i, err := mock.NewManager().NewInput(conf)
+	require.NoError(t, err)
+
+	select {
+	case tran, open := <-i.TransactionChan():
+		require.True(t, open)
+		require.Len(t, tran.Payload, 1)
+		assert.Equal(t, "b1", string(tran.Payload.Get(0).AsBytes()))
+		require.NoError(t, tran.Ack(t.Context(), nil))
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out")
+	}
+
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(filepath.Join(failedDir, "a.txt"))
+		return err == nil
+	}, time.Second*5, time.Millisecond*10)
+
+	i.TriggerStopConsuming()
+	require.NoError(t, i.WaitForClose(t.Context()))
+}
+
+func gzipped(t testing.TB, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(s))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestFileWatchConfigErrors(t *testing.T) {
+	for _, conf := range []string{
+		`
+file:
+  paths: [ "./data/*/foo.txt" ]
+  watch:
+    enabled: true
+`,
+		`
+file:
+  paths: [ "./data/*.txt" ]
+  delete_on_finish: true
+  watch:
+    enabled: true
+    done_dir: ./done
+`,
+		`
+file:
+  paths: [ "./data/*.txt" ]
+  follow:
+    enabled: true
+  watch:
+    enabled: true
+`,
+	} {
+		iConf, err := testutil.InputFromYAML(conf)
+		require.NoError(t, err)
+
+		_, err = mock.NewManager().NewInput(iConf)
+		require.Error(t, err, conf)
+	}
+}
@@ -0,0 +1,519 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package io
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Jeffail/shutdown"
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/redpanda-data/benthos/v4/internal/component"
+	ifilepath "github.com/redpanda-data/benthos/v4/internal/filepath"
+	"github.com/redpanda-data/benthos/v4/public/service"
+	"github.com/redpanda-data/benthos/v4/public/service/codec"
+)
+
+const (
+	fileInputFieldWatch             = "watch"
+	fileInputFieldWatchEnabled      = "enabled"
+	fileInputFieldWatchSettlePeriod = "settle_period"
+	fileInputFieldWatchBackfill     = "backfill"
+	fileInputFieldWatchDoneDir      = "done_dir"
+	fileInputFieldWatchFailedDir    = "failed_dir"
+)
+
+func fileInputWatchField() *service.ConfigField {
+	return service.NewObjectField(fileInputFieldWatch,
+		service.NewBoolField(fileInputFieldWatchEnabled).
+			Description("Whether to watch the directories of the configured paths for new files.").
+			Default(false),
+		service.NewDurationField(fileInputFieldWatchSettlePeriod).
+			Description("The period for which the size and modification time of a new file must remain unchanged before it is consumed, which prevents consuming files that are still being written.").
+			Default("1s"),
+		service.NewBoolField(fileInputFieldWatchBackfill).
+			Description("Whether to consume files that already match the configured paths when the input starts.").
+			Default(true),
+		service.NewStringField(fileInputFieldWatchDoneDir).
+			Description("An optional directory to move files into once all of their messages have been acknowledged. This directory must be on the same filesystem as the watched directories.").
+			Default(""),
+		service.NewStringField(fileInputFieldWatchFailedDir).
+			Description("An optional directory to move files into when they could not be consumed or their messages were rejected. This directory must be on the same filesystem as the watched directories.").
+			Default(""),
+	).Description("Watch the directories of the configured paths for files as they arrive, using filesystem notifications. When enabled the input never finishes, instead files that match the configured paths are consumed as they are created, written to or renamed into place.").
+		Advanced()
+}
+
+//------------------------------------------------------------------------------
+
+type fileFingerprint struct {
+	size    int64
+	modTime time.Time
+}
+
+func fingerprintOf(info fs.FileInfo) fileFingerprint {
+	return fileFingerprint{size: info.Size(), modTime: info.ModTime()}
+}
+
+// pendingFile is a file that has been observed but is yet to settle.
+type pendingFile struct {
+	fingerprint fileFingerprint
+	changedAt   time.Time
+}
+
+// watchedFileAck tracks the outcome of a consumed file, where the source ack of
+// a scanner may be called multiple times.
+type watchedFileAck struct {
+	path     string
+	mut      sync.Mutex
+	eof      bool
+	resolved bool
+}
+
+type watchedFileScanner struct {
+	scanner    codec.DeprecatedFallbackStream
+	ack        *watchedFileAck
+	modTimeUTC time.Time
+}
+
+type fileWatchConsumer struct {
+	log *service.Logger
+	nm  *service.Resources
+
+	paths        []string
+	dirs         []string
+	scannerCtor  codec.DeprecatedFallbackCodec
+	settlePeriod time.Duration
+	backfill     bool
+	delete       bool
+	doneDir      string
+	failedDir    string
+
+	scannerMut sync.Mutex
+	current    *watchedFileScanner
+
+	watchMut  sync.Mutex
+	pending   map[string]*pendingFile
+	ready     []string
+	inFlight  map[string]struct{}
+	consumed  map[string]fileFingerprint
+	readyChan chan struct{}
+	connected bool
+	closing   atomic.Bool
+
+	shutSig *shutdown.Signaller
+}
+
+func fileWatchConsumerFromParsed(pConf *service.ParsedConfig, nm *service.Resources) (*fileWatchConsumer, error) {
+	paths, err := pConf.FieldStringList(fileInputFieldPaths)
+	if err != nil {
+		return nil, err
+	}
+	deleteOnFinish, err := pConf.FieldBool(fileInputFieldDeleteOnFinish)
+	if err != nil {
+		return nil, err
+	}
+	if follow, _ := pConf.FieldBool(fileInputFieldFollow, fileInputFieldFollowEnabled); follow {
+		return nil, fmt.Errorf("%v and %v cannot both be enabled", fileInputFieldFollow, fileInputFieldWatch)
+	}
+	ctor, err := codec.DeprecatedCodecFromParsed(pConf)
+	if err != nil {
+		return nil, err
+	}
+
+	conf := pConf.Namespace(fileInputFieldWatch)
+
+	settlePeriod, err := conf.FieldDuration(fileInputFieldWatchSettlePeriod)
+	if err != nil {
+		return nil, err
+	}
+	backfill, err := conf.FieldBool(fileInputFieldWatchBackfill)
+	if err != nil {
+		return nil, err
+	}
+	doneDir, err := conf.FieldString(fileInputFieldWatchDoneDir)
+	if err != nil {
+		return nil, err
+	}
+	failedDir, err := conf.FieldString(fileInputFieldWatchFailedDir)
+	if err != nil {
+		return nil, err
+	}
+	if deleteOnFinish && doneDir != "" {
+		return nil, fmt.Errorf("%v cannot be used with a %v", fileInputFieldDeleteOnFinish, fileInputFieldWatchDoneDir)
+	}
+
+	f := &fileWatchConsumer{
+		log:          nm.Logger(),
+		nm:           nm,
+		scannerCtor:  ctor,
+		settlePeriod: settlePeriod,
+		backfill:     backfill,
+		delete:       deleteOnFinish,
+		doneDir:      doneDir,
+		failedDir:    failedDir,
+		pending:      map[string]*pendingFile{},
+		inFlight:     map[string]struct{}{},
+		consumed:     map[string]fileFingerprint{},
+		readyChan:    make(chan struct{}, 1),
+		shutSig:      shutdown.NewSignaller(),
+	}
+
+	seenDirs := map[string]struct{}{}
+	for _, p := range paths {
+		p = filepath.Clean(p)
+		dir := filepath.Dir(p)
+		if strings.ContainsAny(dir, "*?[") {
+			return nil, fmt.Errorf("path '%v' cannot be watched as its directory contains a glob pattern", p)
+		}
+		f.paths = append(f.paths, p)
+		if _, exists := seenDirs[dir]; !exists {
+			seenDirs[dir] = struct{}{}
+			f.dirs = append(f.dirs, dir)
+		}
+	}
+	return f, nil
+}
+
+func (f *fileWatchConsumer) Connect(ctx context.Context) error {
+	f.watchMut.Lock()
+	defer f.watchMut.Unlock()
+	if f.connected {
+		return nil
+	}
+
+	for _, dir := range []string{f.doneDir, f.failedDir} {
+		if dir == "" {
+			continue
+		}
+		if err := f.nm.FS().MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	for _, dir := range f.dirs {
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return fmt.Errorf("failed to watch directory '%v': %w", dir, err)
+		}
+	}
+
+	if f.backfill {
+		f.scanPaths()
+	}
+
+	go f.watchLoop(watcher)
+	f.connected = true
+	return nil
+}
+
+// matches returns true if a path matches any of the configured paths.
+func (f *fileWatchConsumer) matches(path string) bool {
+	for _, p := range f.paths {
+		if matched, _ := filepath.Match(p, path); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// scanPaths observes all existing files that match the configured paths, which
+// is necessary on startup and whenever notifications may have been dropped.
+func (f *fileWatchConsumer) scanPaths() {
+	paths, err := ifilepath.Globs(f.nm.FS(), f.paths)
+	if err != nil {
+		f.log.Errorf("Failed to expand file paths: %v", err)
+		return
+	}
+	for _, path := range paths {
+		f.observe(filepath.Clean(path))
+	}
+}
+
+// observe records activity on a file, which delays its consumption until it
+// has settled.
+func (f *fileWatchConsumer) observe(path string) {
+	if _, exists := f.inFlight[path]; exists {
+		return
+	}
+	if p, exists := f.pending[path]; exists {
+		p.changedAt = time.Now()
+		return
+	}
+	info, err := f.nm.FS().Stat(path)
+	if err != nil || info.IsDir() {
+		return
+	}
+	fp := fingerprintOf(info)
+	if consumedFP, exists := f.consumed[path]; exists && consumedFP == fp {
+		return
+	}
+	f.pending[path] = &pendingFile{fingerprint: fp, changedAt: time.Now()}
+}
+
+// settle queues pending files for consumption once they have stopped changing.
+func (f *fileWatchConsumer) settle() {
+	queued := false
+	for path, p := range f.pending {
+		info, err := f.nm.FS().Stat(path)
+		if err != nil || info.IsDir() {
+			delete(f.pending, path)
+			continue
+		}
+		if fp := fingerprintOf(info); fp != p.fingerprint {
+			p.fingerprint = fp
+			p.changedAt = time.Now()
+			continue
+		}
+		if time.Since(p.changedAt) < f.settlePeriod {
+			continue
+		}
+		delete(f.pending, path)
+		f.inFlight[path] = struct{}{}
+		f.ready = append(f.ready, path)
+		queued = true
+	}
+	if queued {
+		select {
+		case f.readyChan <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (f *fileWatchConsumer) watchLoop(watcher *fsnotify.Watcher) {
+	defer f.shutSig.TriggerHasStopped()
+	defer watcher.Close()
+
+	ticker := time.NewTicker(max(f.settlePeriod/2, time.Millisecond*10))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case event, open := <-watcher.Events:
+			if !open {
+				return
+			}
+			path := filepath.Clean(event.Name)
+			if !f.matches(path) {
+				continue
+			}
+			f.watchMut.Lock()
+			if event.Has(fsnotify.Remove) || event.Has(fsnotify.Rename) {
+				delete(f.pending, path)
+				delete(f.consumed, path)
+			} else {
+				f.observe(path)
+			}
+			f.watchMut.Unlock()
+		case err, open := <-watcher.Errors:
+			if !open {
+				return
+			}
+			if errors.Is(err, fsnotify.ErrEventOverflow) {
+				f.log.Warnf("File notifications were dropped, scanning for files")
+				f.watchMut.Lock()
+				f.scanPaths()
+				f.watchMut.Unlock()
+				continue
+			}
+			f.log.Errorf("File watcher error: %v", err)
+		case <-ticker.C:
+			f.watchMut.Lock()
+			f.settle()
+			f.watchMut.Unlock()
+		case <-f.shutSig.SoftStopChan():
+			return
+		}
+	}
+}
+
+// resolve carries out the post-processing of a consumed file once its outcome
+// is known.
+func (f *fileWatchConsumer) resolve(wAck *watchedFileAck, err error) error {
+	wAck.mut.Lock()
+	defer wAck.mut.Unlock()
+	if wAck.resolved {
+		return nil
+	}
+	if !wAck.eof && f.closing.Load() {
+		// The file was abandoned during shutdown and is therefore left in place
+		// in order to be consumed again.
+		f.watchMut.Lock()
+		delete(f.inFlight, wAck.path)
+		f.watchMut.Unlock()
+		return nil
+	}
+	if err == nil && !wAck.eof {
+		return nil
+	}
+	wAck.resolved = true
+
+	var info fs.FileInfo
+	var actErr error
+	switch {
+	case err == nil && f.delete:
+		actErr = f.nm.FS().Remove(wAck.path)
+	case err == nil && f.doneDir != "":
+		actErr = f.nm.FS().Rename(wAck.path, filepath.Join(f.doneDir, filepath.Base(wAck.path)))
+	case err != nil && f.failedDir != "":
+		f.log.Errorf("Moving file '%v' to failed directory: %v", wAck.path, err)
+		actErr = f.nm.FS().Rename(wAck.path, filepath.Join(f.failedDir, filepath.Base(wAck.path)))
+	default:
+		if err != nil {
+			f.log.Errorf("Failed to consume file '%v': %v", wAck.path, err)
+		}
+		info, _ = f.nm.FS().Stat(wAck.path)
+	}
+
+	f.watchMut.Lock()
+	delete(f.inFlight, wAck.path)
+	if info != nil {
+		// Files that remain in place are only consumed again once modified.
+		f.consumed[wAck.path] = fingerprintOf(info)
+	}
+	f.watchMut.Unlock()
+	return actErr
+}
+
+func (f *fileWatchConsumer) nextFile() (string, bool) {
+	f.watchMut.Lock()
+	defer f.watchMut.Unlock()
+	if len(f.ready) == 0 {
+		return "", false
+	}
+	path := f.ready[0]
+	f.ready = f.ready[1:]
+	return path, true
+}
+
+func (f *fileWatchConsumer) openFile(path string) (*watchedFileScanner, error) {
+	file, err := f.nm.FS().Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	wAck := &watchedFileAck{path: path}
+	details := service.NewScannerSourceDetails()
+	details.SetName(path)
+	scanner, err := f.scannerCtor.Create(file, func(ctx context.Context, err error) error {
+		return f.resolve(wAck, err)
+	}, details)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	var modTimeUTC time.Time
+	if info, err := file.Stat(); err == nil {
+		modTimeUTC = info.ModTime().UTC()
+	} else {
+		f.log.Errorf("Failed to read metadata from file '%v'", path)
+	}
+	return &watchedFileScanner{scanner: scanner, ack: wAck, modTimeUTC: modTimeUTC}, nil
+}
+
+func (f *fileWatchConsumer) ReadBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
+	f.scannerMut.Lock()
+	defer f.scannerMut.Unlock()
+
+	for {
+		if f.current != nil {
+			parts, codecAckFn, err := f.current.scanner.NextBatch(ctx)
+			if err != nil {
+				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+					return nil, nil, component.ErrTimeout
+				}
+				if errors.Is(err, io.EOF) {
+					f.current.ack.mut.Lock()
+					f.current.ack.eof = true
+					f.current.ack.mut.Unlock()
+				}
+				_ = f.current.scanner.Close(ctx)
+				f.current = nil
+				if errors.Is(err, io.EOF) {
+					continue
+				}
+				return nil, nil, err
+			}
+
+			for _, part := range parts {
+				part.MetaSetMut("path", f.current.ack.path)
+				part.MetaSetMut("mod_time_unix", f.current.modTimeUTC.Unix())
+				part.MetaSetMut("mod_time", f.current.modTimeUTC.Format(time.RFC3339))
+			}
+			if len(parts) == 0 {
+				_ = codecAckFn(ctx, nil)
+				continue
+			}
+			return parts, codecAckFn, nil
+		}
+
+		path, ok := f.nextFile()
+		if !ok {
+			select {
+			case <-f.readyChan:
+			case <-ctx.Done():
+				return nil, nil, component.ErrTimeout
+			}
+			continue
+		}
+
+		current, err := f.openFile(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				f.watchMut.Lock()
+				delete(f.inFlight, path)
+				f.watchMut.Unlock()
+				continue
+			}
+			// Files that cannot be opened are resolved in the same way as
+			// files that were rejected downstream.
+			if rErr := f.resolve(&watchedFileAck{path: path, eof: true}, fmt.Errorf("failed to open file: %w", err)); rErr != nil {
+				f.log.Errorf("Failed to resolve file '%v': %v", path, rErr)
+			}
+			continue
+		}
+		f.log.Debugf("Consuming from file '%v'", path)
+		f.current = current
+	}
+}
+
+func (f *fileWatchConsumer) Close(ctx context.Context) error {
+	f.closing.Store(true)
+
+	f.scannerMut.Lock()
+	if f.current != nil {
+		_ = f.current.scanner.Close(ctx)
+		f.current = nil
+	}
+	f.scannerMut.Unlock()
+
+	f.watchMut.Lock()
+	connected := f.connected
+	f.watchMut.Unlock()
+	if !connected {
+		return nil
+	}
+
+	f.shutSig.TriggerSoftStop()
+	select {
+	case <-f.shutSig.HasStoppedChan():
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
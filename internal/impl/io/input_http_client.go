@@ -5,6 +5,7 @@ package io
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"strings"
 	"sync"
@@ -19,6 +20,7 @@ func httpClientInputSpec() *service.ConfigSpec {
 	streamFields := []*service.ConfigField{
 		service.NewBoolField("enabled").Description("Enables streaming mode.").Default(false),
 		service.NewBoolField("reconnect").Description("Sets whether to re-establish the connection once it is lost.").Default(true),
+		hciStreamSSEField(),
 	}
 	streamFields = append(streamFields, codec.DeprecatedCodecFields("lines")...)
 
@@ -37,9 +39,17 @@ The URL and header values of this type can be dynamically set using function int
 
 If you enable streaming then Redpanda Connect will consume the body of the response as a continuous stream of data, breaking messages out following a chosen scanner. This allows you to consume APIs that provide long lived streamed data feeds (such as Twitter).
 
+Setting `+"`stream.sse`"+` to `+"`true`"+` instead consumes the response as a stream of server-sent events, where the data of each event is a message with the metadata fields `+"`event` and `id`"+`. When the connection is lost and `+"`stream.reconnect`"+` is enabled the stream is resumed by sending the ID of the last event received within a `+"`Last-Event-ID`"+` header.
+
 == Pagination
 
-This input supports interpolation functions in the `+"`url` and `headers`"+` fields where data from the previous successfully consumed message (if there was one) can be referenced. This can be used in order to support basic levels of pagination. However, in cases where pagination depends on logic it is recommended that you use an `+"xref:components:processors/http.adoc[`http` processor] instead, often combined with a xref:components:inputs/generate.adoc[`generate` input]"+` in order to schedule the processor.`).
+The `+"`pagination`"+` field configures a strategy for following paginated results, where each request obtains the next page based on the previous response. The next page can be located by the `+"`Link`"+` header of the response, by a cursor extracted from the response with a Bloblang mapping, or by incrementing an offset or page number query parameter, and a stop condition can be used in order to detect the last page. Once the last page is reached the input either continues to request it or starts again from the first page. The position of the next page can optionally be stored in a cache once the messages of a page have been acknowledged, allowing pagination to resume where it left off after a restart. Pagination cannot be used with streaming mode.
+
+== Conditional requests
+
+When `+"`conditional.enabled`"+` is set the `+"`ETag` and `Last-Modified`"+` headers of responses are stored for each URL and sent as `+"`If-None-Match` and `If-Modified-Since`"+` headers with subsequent requests to the same URL, and a `+"`304 Not Modified`"+` response results in no message. This prevents unchanged resources from being consumed each time they are polled, and reduces the cost of polling for servers that support it.
+
+This input also supports interpolation functions in the `+"`url` and `headers`"+` fields where data from the previous successfully consumed message (if there was one) can be referenced. This can be used in order to support basic levels of pagination.`).
 		Example(
 			"Basic Pagination",
 			"Interpolation functions within the `url` and `headers` fields can be used to reference the previously consumed message, which allows simple pagination.",
@@ -63,12 +73,41 @@ rate_limit_resources:
     local:
       count: 1
       interval: 30s
+`,
+		).
+		Example(
+			"Cursor Pagination",
+			"A cursor can be extracted from each response in order to request the next page, where the cursor of the next page is stored in a cache so that a restarted pipeline resumes from where it left off:",
+			`
+input:
+  http_client:
+    url: https://api.example.com/events
+    verb: GET
+    rate_limit: event_polls
+    pagination:
+      strategy: cursor
+      param: after
+      cursor_mapping: 'root = this.next_cursor'
+      checkpoint_cache: cursors
+
+cache_resources:
+  - label: cursors
+    file:
+      directory: /var/lib/connect/cursors
+
+rate_limit_resources:
+  - label: event_polls
+    local:
+      count: 1
+      interval: 10s
 `,
 		).
 		Field(httpclient.ConfigField("GET", false,
 			service.NewInterpolatedStringField("payload").Description("An optional payload to deliver for each request.").Optional(),
 			service.NewBoolField("drop_empty_bodies").Description("Whether empty payloads received from the target server should be dropped.").Default(true).Advanced(),
 			streamField,
+			httpClientPaginationField(),
+			httpclient.ConditionalField(),
 		)).
 		Field(service.NewAutoRetryNacksToggleField())
 }
@@ -92,11 +131,14 @@ type httpClientInput struct {
 	prevResponse service.MessageBatch
 
 	codecCtor       codec.DeprecatedFallbackCodec
+	sse             *hciSSEState
 	reconnectStream bool
 	dropEmptyBodies bool
 
 	codecMut sync.Mutex
 	codec    codec.DeprecatedFallbackStream
+
+	paginator *httpPaginator
 }
 
 func newHTTPClientInputFromParsed(conf *service.ParsedConfig, mgr *service.Resources) (*httpClientInput, error) {
@@ -106,15 +148,22 @@ func newHTTPClientInputFromParsed(conf *service.ParsedConfig, mgr *service.Resou
 	}
 
 	var codecCtor codec.DeprecatedFallbackCodec
+	var sse *hciSSEState
 
 	streamEnabled, err := conf.FieldBool("stream", "enabled")
 	if err != nil {
 		return nil, err
 	}
 	if streamEnabled {
+		if oldConf.Conditional.Enabled {
+			return nil, errors.New("conditional requests cannot be used with streaming mode")
+		}
 		// Timeout should be left at zero if we are streaming.
 		oldConf.Timeout = 0
-		if codecCtor, err = codec.DeprecatedCodecFromParsed(conf.Namespace("stream")); err != nil {
+		if sseEnabled, _ := conf.FieldBool("stream", hciFieldStreamSSE); sseEnabled {
+			sse = &hciSSEState{}
+			codecCtor = sse
+		} else if codecCtor, err = codec.DeprecatedCodecFromParsed(conf.Namespace("stream")); err != nil {
 			return nil, err
 		}
 	}
@@ -132,7 +181,23 @@ func newHTTPClientInputFromParsed(conf *service.ParsedConfig, mgr *service.Resou
 		return nil, err
 	}
 
-	client, err := httpclient.NewClientFromOldConfig(oldConf, mgr, httpclient.WithExplicitBody(payloadExpr))
+	paginator, err := httpPaginatorFromParsed(conf.Namespace(hciFieldPagination), mgr)
+	if err != nil {
+		return nil, err
+	}
+
+	clientOpts := []httpclient.RequestOpt{httpclient.WithExplicitBody(payloadExpr)}
+	if paginator != nil {
+		if streamEnabled {
+			return nil, errors.New("pagination cannot be used with streaming mode")
+		}
+		clientOpts = append(clientOpts, httpclient.WithRequestModifier(paginator.modifyRequest))
+	}
+	if sse != nil {
+		clientOpts = append(clientOpts, httpclient.WithRequestModifier(sse.modifyRequest))
+	}
+
+	client, err := httpclient.NewClientFromOldConfig(oldConf, mgr, clientOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -145,10 +210,17 @@ func newHTTPClientInputFromParsed(conf *service.ParsedConfig, mgr *service.Resou
 		reconnectStream: reconnectStream,
 
 		codecCtor: codecCtor,
+		sse:       sse,
+		paginator: paginator,
 	}, nil
 }
 
 func (h *httpClientInput) Connect(ctx context.Context) (err error) {
+	if h.paginator != nil {
+		if err := h.paginator.load(ctx); err != nil {
+			return fmt.Errorf("failed to load pagination checkpoint: %w", err)
+		}
+	}
 	if h.codecCtor == nil {
 		return nil
 	}
@@ -160,6 +232,12 @@ func (h *httpClientInput) Connect(ctx context.Context) (err error) {
 		return nil
 	}
 
+	if h.sse != nil {
+		if err := h.sse.waitToReconnect(ctx); err != nil {
+			return err
+		}
+	}
+
 	res, err := h.client.SendToResponse(context.Background(), h.prevResponse)
 	if err != nil {
 		if strings.Contains(err.Error(), "(Client.Timeout exceeded while awaiting headers)") {
@@ -249,14 +327,27 @@ func (h *httpClientInput) readStreamed(ctx context.Context) (service.MessageBatc
 }
 
 func (h *httpClientInput) readNotStreamed(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
-	msg, err := h.client.Send(ctx, h.prevResponse)
+	res, commit, err := h.client.SendToResponseWithCommit(ctx, h.prevResponse)
 	if err != nil {
-		if strings.Contains(err.Error(), "(Client.Timeout exceeded while awaiting headers)") {
+		if strings.Contains(err.Error(), "(Client.Timeout exceeded while awaiting headers)") ||
+			errors.Is(err, httpclient.ErrNotModified) {
 			err = component.ErrTimeout
 		}
 		return nil, nil, err
 	}
 
+	msg, err := h.client.ResponseToBatch(res)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	storeCheckpoint := func(context.Context) error { return nil }
+	if h.paginator != nil && len(msg) > 0 {
+		if storeCheckpoint, err = h.paginator.advance(res, msg[0]); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	if len(msg) == 0 {
 		return nil, nil, component.ErrTimeout
 	}
@@ -267,8 +358,14 @@ func (h *httpClientInput) readNotStreamed(ctx context.Context) (service.MessageB
 	}
 
 	h.prevResponse = msg
-	return msg.Copy(), func(context.Context, error) error {
-		return nil
+	return msg.Copy(), func(ctx context.Context, err error) error {
+		if err != nil {
+			return nil
+		}
+		if err := commit(ctx); err != nil {
+			return fmt.Errorf("failed to store conditional request headers: %w", err)
+		}
+		return storeCheckpoint(ctx)
 	}, nil
 }
 
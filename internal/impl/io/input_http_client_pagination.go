@@ -0,0 +1,417 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package io
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/redpanda-data/benthos/v4/internal/value"
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	hciFieldPagination                = "pagination"
+	hciFieldPaginationStrategy        = "strategy"
+	hciFieldPaginationParam           = "param"
+	hciFieldPaginationCursorMapping   = "cursor_mapping"
+	hciFieldPaginationStart           = "start"
+	hciFieldPaginationPageSize        = "page_size"
+	hciFieldPaginationStopCondition   = "stop_condition"
+	hciFieldPaginationOnLastPage      = "on_last_page"
+	hciFieldPaginationCheckpointCache = "checkpoint_cache"
+	hciFieldPaginationCheckpointKey   = "checkpoint_key"
+)
+
+func httpClientPaginationField() *service.ConfigField {
+	return service.NewObjectField(hciFieldPagination,
+		service.NewStringAnnotatedEnumField(hciFieldPaginationStrategy, map[string]string{
+			"none":        "Requests are not paginated.",
+			"link_header": "The next request is made to the URL of the `Link` header of the response with the relation `next`, which must share the scheme and host of the `url`.",
+			"cursor":      "The next request sets the query parameter `param` to the cursor extracted from the response by the `cursor_mapping`.",
+			"offset":      "The next request sets the query parameter `param` to an offset that is incremented by `page_size` for each page.",
+			"page":        "The next request sets the query parameter `param` to a page number that is incremented by one for each page.",
+		}).
+			Description("The strategy used to obtain the next page of results.").
+			Default("none"),
+		service.NewStringField(hciFieldPaginationParam).
+			Description("The query parameter to set to the cursor, offset or page number of each request. This field is required by the `cursor`, `offset` and `page` strategies.").
+			Default(""),
+		service.NewBloblangField(hciFieldPaginationCursorMapping).
+			Description("A Bloblang mapping that extracts the cursor of the next page from the response, where the response body is the input document and all response headers are available as metadata. The last page is reached when the mapping results in `null` or deletes the root. This field is required by the `cursor` strategy.").
+			Examples(`root = this.meta.next_cursor`, `root = metadata("x-next-token")`).
+			Optional(),
+		service.NewIntField(hciFieldPaginationStart).
+			Description("The offset or page number of the first request, defaulting to `0` for the `offset` strategy and `1` for the `page` strategy.").
+			Optional(),
+		service.NewIntField(hciFieldPaginationPageSize).
+			Description("The amount to increment the offset by for each page with the `offset` strategy.").
+			Default(100),
+		service.NewBloblangField(hciFieldPaginationStopCondition).
+			Description("A Bloblang query executed on each response, in the same way as the `cursor_mapping`, that should return a boolean indicating whether the last page has been reached. This field is required by the `offset` and `page` strategies.").
+			Examples(`root = this.items.length() == 0`, `root = this.has_more == false`).
+			Optional(),
+		service.NewStringAnnotatedEnumField(hciFieldPaginationOnLastPage, map[string]string{
+			"repeat":  "Continue to request the last page, which suits feeds where new results are added to the last page.",
+			"restart": "Start again from the first page.",
+		}).
+			Description("What to do once the last page has been reached.").
+			Default("repeat"),
+		service.NewStringField(hciFieldPaginationCheckpointCache).
+			Description("An optional xref:components:caches/about.adoc[cache resource] to store the position of the next page in once the messages of a page are acknowledged, allowing pagination to resume after a restart.").
+			Default("").
+			Advanced(),
+		service.NewStringField(hciFieldPaginationCheckpointKey).
+			Description("The key under which the position of the next page is stored within the `checkpoint_cache`.").
+			Default("http_client_pagination").
+			Advanced(),
+	).
+		Description("Follow paginated results across requests, where each request obtains the next page of results based on the previous response.").
+		Advanced()
+}
+
+//------------------------------------------------------------------------------
+
+// httpPagePosition is the position of the next page to request, and is also
+// the representation of a checkpoint.
+type httpPagePosition struct {
+	URL    string `json:"url,omitempty"`
+	Cursor string `json:"cursor,omitempty"`
+}
+
+type httpPaginator struct {
+	res *service.Resources
+
+	strategy      string
+	param         string
+	cursorMapping *bloblang.Executor
+	stopCondition *bloblang.Executor
+	start         int64
+	pageSize      int64
+	restartOnEnd  bool
+	cacheName     string
+	cacheKey      string
+
+	mut       sync.Mutex
+	pos       httpPagePosition
+	seq       uint64
+	storedSeq uint64
+	loaded    bool
+	storeMut  sync.Mutex
+}
+
+func httpPaginatorFromParsed(pConf *service.ParsedConfig, res *service.Resources) (*httpPaginator, error) {
+	p := &httpPaginator{res: res}
+
+	var err error
+	if p.strategy, err = pConf.FieldString(hciFieldPaginationStrategy); err != nil {
+		return nil, err
+	}
+	if p.strategy == "none" {
+		return nil, nil
+	}
+	if p.param, err = pConf.FieldString(hciFieldPaginationParam); err != nil {
+		return nil, err
+	}
+	if pConf.Contains(hciFieldPaginationCursorMapping) {
+		if p.cursorMapping, err = pConf.FieldBloblang(hciFieldPaginationCursorMapping); err != nil {
+			return nil, err
+		}
+	}
+	if pConf.Contains(hciFieldPaginationStopCondition) {
+		if p.stopCondition, err = pConf.FieldBloblang(hciFieldPaginationStopCondition); err != nil {
+			return nil, err
+		}
+	}
+	if p.strategy == "page" {
+		p.start, p.pageSize = 1, 1
+	} else {
+		var pageSize int
+		if pageSize, err = pConf.FieldInt(hciFieldPaginationPageSize); err != nil {
+			return nil, err
+		}
+		p.pageSize = int64(pageSize)
+	}
+	if pConf.Contains(hciFieldPaginationStart) {
+		var start int
+		if start, err = pConf.FieldInt(hciFieldPaginationStart); err != nil {
+			return nil, err
+		}
+		p.start = int64(start)
+	}
+
+	var onLastPage string
+	if onLastPage, err = pConf.FieldString(hciFieldPaginationOnLastPage); err != nil {
+		return nil, err
+	}
+	p.restartOnEnd = onLastPage == "restart"
+
+	if p.cacheName, err = pConf.FieldString(hciFieldPaginationCheckpointCache); err != nil {
+		return nil, err
+	}
+	if p.cacheKey, err = pConf.FieldString(hciFieldPaginationCheckpointKey); err != nil {
+		return nil, err
+	}
+	if p.cacheName != "" && !res.HasCache(p.cacheName) {
+		return nil, fmt.Errorf("cache resource '%v' was not found", p.cacheName)
+	}
+
+	switch p.strategy {
+	case "cursor":
+		if p.cursorMapping == nil {
+			return nil, fmt.Errorf("a %v is required by the cursor strategy", hciFieldPaginationCursorMapping)
+		}
+	case "offset", "page":
+		if p.stopCondition == nil {
+			return nil, fmt.Errorf("a %v is required by the %v strategy", hciFieldPaginationStopCondition, p.strategy)
+		}
+	}
+	if p.strategy != "link_header" && p.param == "" {
+		return nil, fmt.Errorf("a %v is required by the %v strategy", hciFieldPaginationParam, p.strategy)
+	}
+
+	p.pos = p.initialPosition()
+	return p, nil
+}
+
+func (p *httpPaginator) initialPosition() httpPagePosition {
+	switch p.strategy {
+	case "offset", "page":
+		return httpPagePosition{Cursor: strconv.FormatInt(p.start, 10)}
+	}
+	return httpPagePosition{}
+}
+
+// load restores the position of the next page from the checkpoint cache.
+func (p *httpPaginator) load(ctx context.Context) error {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	if p.cacheName == "" || p.loaded {
+		return nil
+	}
+
+	var b []byte
+	var cErr error
+	if err := p.res.AccessCache(ctx, p.cacheName, func(c service.Cache) {
+		b, cErr = c.Get(ctx, p.cacheKey)
+	}); err != nil {
+		return err
+	}
+	if cErr != nil {
+		if errors.Is(cErr, service.ErrKeyNotFound) {
+			p.loaded = true
+			return nil
+		}
+		return cErr
+	}
+	if err := json.Unmarshal(b, &p.pos); err != nil {
+		return fmt.Errorf("failed to parse pagination checkpoint: %w", err)
+	}
+	p.loaded = true
+	return nil
+}
+
+// modifyRequest applies the position of the next page to a request.
+func (p *httpPaginator) modifyRequest(req *http.Request) error {
+	p.mut.Lock()
+	pos := p.pos
+	p.mut.Unlock()
+
+	if pos.URL != "" {
+		u, err := req.URL.Parse(pos.URL)
+		if err != nil {
+			return fmt.Errorf("failed to parse next page URL: %w", err)
+		}
+		// Requests carry the credentials of the configured URL, and therefore
+		// pages are only followed when they share its origin.
+		if u.Scheme != req.URL.Scheme || u.Host != req.URL.Host {
+			return fmt.Errorf("next page URL %v does not share the scheme and host of %v", u.Redacted(), req.URL.Redacted())
+		}
+		req.URL = u
+	}
+	if pos.Cursor != "" && p.param != "" {
+		q := req.URL.Query()
+		q.Set(p.param, pos.Cursor)
+		req.URL.RawQuery = q.Encode()
+	}
+	return nil
+}
+
+// advance moves the position to the next page following a response, and
+// returns a function that stores the new position once the messages of the
+// response have been acknowledged.
+func (p *httpPaginator) advance(res *http.Response, body *service.Message) (func(context.Context) error, error) {
+	resMsg := body.Copy()
+	for k, values := range res.Header {
+		if len(values) > 0 {
+			resMsg.MetaSetMut(strings.ToLower(k), values[0])
+		}
+	}
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	next, last, err := p.nextPosition(res, resMsg)
+	if err != nil {
+		return nil, err
+	}
+	if last {
+		if p.restartOnEnd {
+			p.pos = p.initialPosition()
+		}
+	} else {
+		p.pos = next
+	}
+
+	if p.cacheName == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	p.seq++
+	seq, pos := p.seq, p.pos
+	return func(ctx context.Context) error {
+		return p.store(ctx, seq, pos)
+	}, nil
+}
+
+func (p *httpPaginator) nextPosition(res *http.Response, resMsg *service.Message) (next httpPagePosition, last bool, err error) {
+	if p.stopCondition != nil {
+		var v any
+		if v, err = queryResponse(resMsg, p.stopCondition); err != nil {
+			err = fmt.Errorf("failed to execute %v: %w", hciFieldPaginationStopCondition, err)
+			return
+		}
+		stop, isBool := v.(bool)
+		if !isBool {
+			err = fmt.Errorf("%v must return a boolean, got %T", hciFieldPaginationStopCondition, v)
+			return
+		}
+		if stop {
+			return next, true, nil
+		}
+	}
+
+	switch p.strategy {
+	case "link_header":
+		nextURL := parseLinkHeaderNext(res.Header.Values("Link"))
+		if nextURL == "" {
+			return next, true, nil
+		}
+		var u *url.URL
+		if u, err = res.Request.URL.Parse(nextURL); err != nil {
+			err = fmt.Errorf("failed to parse next page URL: %w", err)
+			return
+		}
+		next.URL = u.String()
+	case "cursor":
+		var v any
+		if v, err = queryResponse(resMsg, p.cursorMapping); err != nil {
+			err = fmt.Errorf("failed to execute %v: %w", hciFieldPaginationCursorMapping, err)
+			return
+		}
+		switch v.(type) {
+		case nil:
+			return next, true, nil
+		case map[string]any, []any:
+			err = fmt.Errorf("%v must return a string or number, got %T", hciFieldPaginationCursorMapping, v)
+			return
+		}
+		next.Cursor = value.IToString(v)
+		if next.Cursor == "" {
+			return next, true, nil
+		}
+	case "offset", "page":
+		var current int64
+		if current, err = strconv.ParseInt(p.pos.Cursor, 10, 64); err != nil {
+			current = p.start
+		}
+		next.Cursor = strconv.FormatInt(current+p.pageSize, 10)
+	}
+	return next, false, nil
+}
+
+// queryResponse executes a mapping on a response and returns the resulting
+// value, where a deleted root results in nil.
+func queryResponse(resMsg *service.Message, blobl *bloblang.Executor) (any, error) {
+	res, err := resMsg.BloblangQuery(blobl)
+	if err != nil || res == nil {
+		return nil, err
+	}
+	if v, err := res.AsStructured(); err == nil {
+		return v, nil
+	}
+	b, err := res.AsBytes()
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// store writes a position to the checkpoint cache, unless a more recent
+// position has already been written.
+func (p *httpPaginator) store(ctx context.Context, seq uint64, pos httpPagePosition) error {
+	p.storeMut.Lock()
+	defer p.storeMut.Unlock()
+	if seq <= p.storedSeq {
+		return nil
+	}
+
+	b, err := json.Marshal(pos)
+	if err != nil {
+		return err
+	}
+	var cErr error
+	if err := p.res.AccessCache(ctx, p.cacheName, func(c service.Cache) {
+		cErr = c.Set(ctx, p.cacheKey, b, nil)
+	}); err != nil {
+		return err
+	}
+	if cErr != nil {
+		return cErr
+	}
+	p.storedSeq = seq
+	return nil
+}
+
+// parseLinkHeaderNext returns the target of the link with the relation type
+// next from Link header values, as described in RFC 8288.
+func parseLinkHeaderNext(values []string) string {
+	for _, v := range values {
+		for v != "" {
+			start := strings.IndexByte(v, '<')
+			if start == -1 {
+				break
+			}
+			end := strings.IndexByte(v[start:], '>')
+			if end == -1 {
+				break
+			}
+			target := v[start+1 : start+end]
+			v = v[start+end+1:]
+
+			params := v
+			if next := strings.IndexByte(v, '<'); next != -1 {
+				params = v[:next]
+			}
+			for _, param := range strings.Split(params, ";") {
+				k, pv, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(k), "rel") {
+					continue
+				}
+				for _, rel := range strings.Fields(strings.Trim(strings.TrimSpace(pv), `",`)) {
+					if strings.EqualFold(rel, "next") {
+						return target
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
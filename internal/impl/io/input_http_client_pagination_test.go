@@ -0,0 +1,27 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package io
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLinkHeaderNext(t *testing.T) {
+	tests := []struct {
+		values []string
+		next   string
+	}{
+		{values: nil, next: ""},
+		{values: []string{`<https://example.com/a?page=2>; rel="next"`}, next: "https://example.com/a?page=2"},
+		{values: []string{`<https://example.com/a?x=1,2>; rel="prev", <https://example.com/b>; rel=next`}, next: "https://example.com/b"},
+		{values: []string{`</first>; rel="first"`, `</next>; title="next"; rel="next last"`}, next: "/next"},
+		{values: []string{`</last>; rel="last"`}, next: ""},
+		{values: []string{`</c>; REL="Next"`}, next: "/c"},
+		{values: []string{`garbage`, `<broken`}, next: ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.next, parseLinkHeaderNext(test.values), test.values)
+	}
+}
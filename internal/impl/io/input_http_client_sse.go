@@ -0,0 +1,171 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package io
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+	"github.com/redpanda-data/benthos/v4/public/service/codec"
+)
+
+const (
+	hciFieldStreamSSE = "sse"
+)
+
+func hciStreamSSEField() *service.ConfigField {
+	return service.NewBoolField(hciFieldStreamSSE).
+		Description("Whether to consume the response as a stream of https://html.spec.whatwg.org/multipage/server-sent-events.html[server-sent events^], where each event is a message with its type and ID added as the metadata fields `event` and `id`. When reconnecting the ID of the last event received is sent within a `Last-Event-ID` header, and any reconnection time sent by the server is honoured. The scanner of the stream is ignored when enabled.").
+		Advanced().
+		Default(false)
+}
+
+// hciSSEState tracks the position of a server-sent event stream across
+// reconnects.
+type hciSSEState struct {
+	mut         sync.Mutex
+	lastEventID string
+	retry       time.Duration
+	connected   bool
+}
+
+// modifyRequest adds the headers of an event stream request.
+func (s *hciSSEState) modifyRequest(req *http.Request) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	req.Header.Set("Accept", "text/event-stream")
+	if s.lastEventID != "" {
+		req.Header.Set("Last-Event-ID", s.lastEventID)
+	}
+	return nil
+}
+
+// waitToReconnect blocks for the reconnection time given by the server when a
+// previous connection has been established.
+func (s *hciSSEState) waitToReconnect(ctx context.Context) error {
+	s.mut.Lock()
+	retry, connected := s.retry, s.connected
+	s.connected = true
+	s.mut.Unlock()
+
+	if !connected || retry <= 0 {
+		return nil
+	}
+	select {
+	case <-time.After(retry):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return nil
+}
+
+// Create implements codec.DeprecatedFallbackCodec by parsing a response body
+// as an event stream.
+func (s *hciSSEState) Create(rdr io.ReadCloser, aFn service.AckFunc, details *service.ScannerSourceDetails) (codec.DeprecatedFallbackStream, error) {
+	s.mut.Lock()
+	idBuffer := s.lastEventID
+	s.mut.Unlock()
+	return &hciSSEStream{state: s, r: bufio.NewReader(rdr), closer: rdr, idBuffer: idBuffer}, nil
+}
+
+// Close implements codec.DeprecatedFallbackCodec.
+func (s *hciSSEState) Close(context.Context) error {
+	return nil
+}
+
+type hciSSEStream struct {
+	state    *hciSSEState
+	r        *bufio.Reader
+	closer   io.Closer
+	idBuffer string
+}
+
+// NextBatch reads lines until the next event is dispatched, following the
+// parsing rules of the event stream format. Incomplete events at the end of
+// the stream are discarded.
+func (s *hciSSEStream) NextBatch(ctx context.Context) (service.MessageBatch, service.AckFunc, error) {
+	// Event streams are long lived and may be idle, therefore the body is
+	// closed in order to unblock reads once the context is cancelled.
+	stop := context.AfterFunc(ctx, func() {
+		_ = s.closer.Close()
+	})
+	defer stop()
+
+	var eventType string
+	var data strings.Builder
+	var hasData bool
+
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+			if ctx.Err() != nil {
+				err = ctx.Err()
+			}
+			return nil, nil, err
+		}
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+
+		if line == "" {
+			s.state.mut.Lock()
+			s.state.lastEventID = s.idBuffer
+			s.state.mut.Unlock()
+
+			if !hasData {
+				eventType = ""
+				continue
+			}
+
+			if eventType == "" {
+				eventType = "message"
+			}
+
+			msg := service.NewMessage([]byte(strings.TrimSuffix(data.String(), "\n")))
+			msg.MetaSetMut("event", eventType)
+			if s.idBuffer != "" {
+				msg.MetaSetMut("id", s.idBuffer)
+			}
+			return service.MessageBatch{msg}, func(context.Context, error) error {
+				return nil
+			}, nil
+		}
+
+		if strings.HasPrefix(line, ":") {
+			continue
+		}
+
+		field, value, _ := strings.Cut(line, ":")
+		value = strings.TrimPrefix(value, " ")
+
+		switch field {
+		case "event":
+			eventType = value
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+			hasData = true
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				s.idBuffer = value
+			}
+		case "retry":
+			if ms, err := strconv.ParseUint(value, 10, 64); err == nil {
+				s.state.mut.Lock()
+				s.state.retry = time.Duration(ms) * time.Millisecond
+				s.state.mut.Unlock()
+			}
+		}
+	}
+}
+
+func (s *hciSSEStream) Close(context.Context) error {
+	return s.closer.Close()
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package io
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPClientSSEParsing(t *testing.T) {
+	body := ": comment\n" +
+		"data: foo\n\n" +
+		"event: bar\r\nid: 1\r\ndata:baz\r\ndata:  buz\r\n\r\n" +
+		"retry: 250\n" +
+		"id\n\n" +
+		"data\n\n" +
+		"event: ignored\n\n" +
+		"data: incomplete"
+
+	state := &hciSSEState{}
+	strm, err := state.Create(io.NopCloser(strings.NewReader(body)), nil, nil)
+	require.NoError(t, err)
+
+	type event struct {
+		Data  string
+		Event string
+		ID    string
+	}
+
+	var events []event
+	for {
+		batch, aFn, err := strm.NextBatch(t.Context())
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		require.Len(t, batch, 1)
+		require.NoError(t, aFn(t.Context(), nil))
+
+		mBytes, err := batch[0].AsBytes()
+		require.NoError(t, err)
+		eventType, _ := batch[0].MetaGet("event")
+		id, _ := batch[0].MetaGet("id")
+		events = append(events, event{Data: string(mBytes), Event: eventType, ID: id})
+	}
+
+	assert.Equal(t, []event{
+		{Data: "foo", Event: "message"},
+		{Data: "baz\n buz", Event: "bar", ID: "1"},
+		{Data: "", Event: "message"},
+	}, events)
+	assert.Empty(t, state.lastEventID)
+	assert.Equal(t, time.Millisecond*250, state.retry)
+	require.NoError(t, strm.Close(t.Context()))
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost", http.NoBody)
+	require.NoError(t, err)
+
+	state.lastEventID = "foo"
+	require.NoError(t, state.modifyRequest(req))
+	assert.Equal(t, "text/event-stream", req.Header.Get("Accept"))
+	assert.Equal(t, "foo", req.Header.Get("Last-Event-ID"))
+}
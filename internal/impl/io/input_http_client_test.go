@@ -898,3 +898,375 @@ http_client:
 		b.Error(err)
 	}
 }
+
+func readHTTPClientPayloads(t *testing.T, h input.Streamed, n int) []string {
+	t.Helper()
+
+	var payloads []string
+	for len(payloads) < n {
+		select {
+		case tr, open := <-h.TransactionChan():
+			require.True(t, open)
+			for _, p := range tr.Payload {
+				payloads = append(payloads, string(p.AsBytes()))
+			}
+			require.NoError(t, tr.Ack(t.Context(), nil))
+		case <-time.After(time.Second * 5):
+			t.Fatal("Action timed out")
+		}
+	}
+	return payloads
+}
+
+func TestHTTPClientPaginationLinkHeader(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		switch page {
+		case "":
+			w.Header().Set("Link", `<`+ts.URL+`/items?page=2>; rel="next", <`+ts.URL+`/items?page=3>; rel="last"`)
+		case "2":
+			w.Header().Set("Link", `</items?page=1>; rel="prev", </items?page=3>; rel="next last"`)
+		}
+		fmt.Fprintf(w, "page%v", page)
+	}))
+	defer ts.Close()
+
+	conf := parseYAMLInputConf(t, `
+http_client:
+  url: "%v/items"
+  retry_period: 1ms
+  pagination:
+    strategy: link_header
+`, ts.URL)
+
+	h, err := mock.NewManager().NewInput(conf)
+	require.NoError(t, err)
+
+	// The last page is repeated once reached.
+	assert.Equal(t, []string{"page", "page2", "page3", "page3"}, readHTTPClientPayloads(t, h, 4))
+
+	h.TriggerStopConsuming()
+	require.NoError(t, h.WaitForClose(t.Context()))
+}
+
+func TestHTTPClientPaginationLinkHeaderCrossOrigin(t *testing.T) {
+	var otherReqs atomic.Int32
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		otherReqs.Add(1)
+		fmt.Fprint(w, "other")
+	}))
+	defer other.Close()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", `<`+other.URL+`/items?page=2>; rel="next"`)
+		fmt.Fprint(w, "page")
+	}))
+	defer ts.Close()
+
+	conf := parseYAMLInputConf(t, `
+http_client:
+  url: "%v/items"
+  retry_period: 1ms
+  headers:
+    Authorization: Bearer shh
+  pagination:
+    strategy: link_header
+`, ts.URL)
+
+	h, err := mock.NewManager().NewInput(conf)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"page"}, readHTTPClientPayloads(t, h, 1))
+
+	// Links to other origins are not followed, preventing credentials from
+	// being sent to them.
+	select {
+	case <-h.TransactionChan():
+		t.Fatal("unexpected message")
+	case <-time.After(time.Millisecond * 200):
+	}
+	assert.Equal(t, int32(0), otherReqs.Load())
+
+	h.TriggerStopConsuming()
+	require.NoError(t, h.WaitForClose(t.Context()))
+}
+
+func TestHTTPClientPaginationCursorCheckpoint(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		after := r.URL.Query().Get("after")
+		next := map[string]string{"": "a", "a": "b"}[after]
+		w.Header().Set("X-Next", next)
+		fmt.Fprintf(w, `{"after":%q}`, after)
+	}))
+	defer ts.Close()
+
+	conf := parseYAMLInputConf(t, `
+http_client:
+  url: "%v/events"
+  retry_period: 1ms
+  pagination:
+    strategy: cursor
+    param: after
+    cursor_mapping: 'root = metadata("x-next").or("")'
+    checkpoint_cache: foocache
+`, ts.URL)
+
+	mgr := mock.NewManager()
+	mgr.Caches["foocache"] = map[string]mock.CacheItem{}
+
+	h, err := mgr.NewInput(conf)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`{"after":""}`, `{"after":"a"}`, `{"after":"b"}`}, readHTTPClientPayloads(t, h, 3))
+
+	h.TriggerStopConsuming()
+	require.NoError(t, h.WaitForClose(t.Context()))
+
+	assert.JSONEq(t, `{"cursor":"b"}`, mgr.Caches["foocache"]["http_client_pagination"].Value)
+
+	// A restarted input resumes from the stored cursor.
+	h, err = mgr.NewInput(conf)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`{"after":"b"}`}, readHTTPClientPayloads(t, h, 1))
+
+	h.TriggerStopConsuming()
+	require.NoError(t, h.WaitForClose(t.Context()))
+}
+
+func TestHTTPClientPaginationOffsetRestart(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset := r.URL.Query().Get("offset")
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		if offset == "20" {
+			_, _ = w.Write([]byte(`{"items":[]}`))
+			return
+		}
+		fmt.Fprintf(w, `{"items":[%q]}`, offset)
+	}))
+	defer ts.Close()
+
+	conf := parseYAMLInputConf(t, `
+http_client:
+  url: "%v/items?limit=10"
+  retry_period: 1ms
+  pagination:
+    strategy: offset
+    param: offset
+    page_size: 10
+    stop_condition: 'root = this.items.length() == 0'
+    on_last_page: restart
+`, ts.URL)
+
+	h, err := mock.NewManager().NewInput(conf)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		`{"items":["0"]}`, `{"items":["10"]}`, `{"items":[]}`,
+		`{"items":["0"]}`, `{"items":["10"]}`,
+	}, readHTTPClientPayloads(t, h, 5))
+
+	h.TriggerStopConsuming()
+	require.NoError(t, h.WaitForClose(t.Context()))
+}
+
+func TestHTTPClientPaginationConfigErrors(t *testing.T) {
+	for _, conf := range []string{
+		`
+http_client:
+  url: http://localhost:1234
+  pagination:
+    strategy: cursor
+    param: after
+`,
+		`
+http_client:
+  url: http://localhost:1234
+  pagination:
+    strategy: page
+    param: page
+`,
+		`
+http_client:
+  url: http://localhost:1234
+  pagination:
+    strategy: link_header
+  stream:
+    enabled: true
+`,
+		`
+http_client:
+  url: http://localhost:1234
+  pagination:
+    strategy: link_header
+    checkpoint_cache: nope
+`,
+	} {
+		_, err := mock.NewManager().NewInput(parseYAMLInputConf(t, "%v", conf))
+		require.Error(t, err, conf)
+	}
+}
+
+func TestHTTPClientConditional(t *testing.T) {
+	var reqCount atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The resource changes on every third request.
+		etag := fmt.Sprintf(`"v%v"`, reqCount.Add(1)/3)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(etag))
+	}))
+	defer ts.Close()
+
+	conf := parseYAMLInputConf(t, `
+http_client:
+  url: "%v/resource"
+  retry_period: 1ms
+  conditional:
+    enabled: true
+`, ts.URL)
+
+	h, err := mock.NewManager().NewInput(conf)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`"v0"`, `"v1"`, `"v2"`}, readHTTPClientPayloads(t, h, 3))
+
+	h.TriggerStopConsuming()
+	require.NoError(t, h.WaitForClose(t.Context()))
+}
+
+func TestHTTPClientConditionalStoredOnAck(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte("hello world"))
+	}))
+	defer ts.Close()
+
+	conf := parseYAMLInputConf(t, `
+http_client:
+  url: "%v/resource"
+  retry_period: 1ms
+  conditional:
+    enabled: true
+    cache: foocache
+`, ts.URL)
+
+	mgr := mock.NewManager()
+	mgr.Caches["foocache"] = map[string]mock.CacheItem{}
+
+	h, err := mgr.NewInput(conf)
+	require.NoError(t, err)
+
+	key := "http_conditional_" + ts.URL + "/resource"
+	select {
+	case tr, open := <-h.TransactionChan():
+		require.True(t, open)
+		assert.Equal(t, "hello world", string(tr.Payload[0].AsBytes()))
+
+		// Headers are only stored once the message is acknowledged.
+		assert.NotContains(t, mgr.Caches["foocache"], key)
+		require.NoError(t, tr.Ack(t.Context(), nil))
+	case <-time.After(time.Second * 5):
+		t.Fatal("Action timed out")
+	}
+
+	h.TriggerStopConsuming()
+	require.NoError(t, h.WaitForClose(t.Context()))
+
+	assert.JSONEq(t, `{"etag":"\"v1\""}`, mgr.Caches["foocache"][key].Value)
+}
+
+func TestHTTPClientConditionalConfigErrors(t *testing.T) {
+	for _, conf := range []string{
+		`
+http_client:
+  url: http://localhost:1234
+  conditional:
+    enabled: true
+  stream:
+    enabled: true
+`,
+		`
+http_client:
+  url: http://localhost:1234
+  conditional:
+    enabled: true
+    cache: nope
+`,
+	} {
+		_, err := mock.NewManager().NewInput(parseYAMLInputConf(t, "%v", conf))
+		require.Error(t, err, conf)
+	}
+}
+
+func TestHTTPClientStreamSSE(t *testing.T) {
+	tCtx, done := context.WithTimeout(t.Context(), time.Second*30)
+	defer done()
+
+	lastIDs := make(chan string, 2)
+	var reqCount atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+		lastIDs <- r.Header.Get("Last-Event-ID")
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		if reqCount.Add(1) == 1 {
+			_, _ = w.Write([]byte("retry: 10\nid: 1\ndata: foo\n\n"))
+			return
+		}
+		_, _ = w.Write([]byte(": hello\nid: 2\nevent: bar\ndata: baz\ndata: buz\n\n"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer ts.Close()
+
+	conf := parseYAMLInputConf(t, `
+http_client:
+  url: %v/events
+  retry_period: 1ms
+  stream:
+    enabled: true
+    sse: true
+`, ts.URL)
+
+	h, err := mock.NewManager().NewInput(conf)
+	require.NoError(t, err)
+
+	readNext := func() *message.Part {
+		t.Helper()
+		select {
+		case tran := <-h.TransactionChan():
+			require.Len(t, tran.Payload, 1)
+			require.NoError(t, tran.Ack(tCtx, nil))
+			return tran.Payload.Get(0)
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out")
+		}
+		return nil
+	}
+
+	p := readNext()
+	assert.Equal(t, "foo", string(p.AsBytes()))
+	assert.Equal(t, "message", p.MetaGetStr("event"))
+	assert.Equal(t, "1", p.MetaGetStr("id"))
+
+	p = readNext()
+	assert.Equal(t, "baz\nbuz", string(p.AsBytes()))
+	assert.Equal(t, "bar", p.MetaGetStr("event"))
+	assert.Equal(t, "2", p.MetaGetStr("id"))
+
+	assert.Equal(t, "", <-lastIDs)
+	assert.Equal(t, "1", <-lastIDs)
+
+	h.TriggerStopConsuming()
+	h.TriggerCloseNow()
+	require.NoError(t, h.WaitForClose(tCtx))
+}
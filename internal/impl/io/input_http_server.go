@@ -32,6 +32,7 @@ import (
 	"github.com/redpanda-data/benthos/v4/internal/component/interop"
 	"github.com/redpanda-data/benthos/v4/internal/component/metrics"
 	"github.com/redpanda-data/benthos/v4/internal/component/ratelimit"
+	"github.com/redpanda-data/benthos/v4/internal/component/scanner"
 	"github.com/redpanda-data/benthos/v4/internal/httpserver"
 	"github.com/redpanda-data/benthos/v4/internal/log"
 	"github.com/redpanda-data/benthos/v4/internal/message"
@@ -72,8 +73,12 @@ type hsiConfig struct {
 	RateLimit          string
 	CertFile           string
 	KeyFile            string
+	ClientCAFile       string
 	CORS               httpserver.CORSConfig
+	JWTAuth            httpserver.JWTAuthConfig
 	Response           hsiResponseConfig
+	Verification       hsiVerificationConfig
+	Form               hsiFormConfig
 }
 
 type hsiResponseConfig struct {
@@ -124,12 +129,24 @@ func hsiConfigFromParsed(pConf *service.ParsedConfig) (conf hsiConfig, err error
 	if conf.KeyFile, err = pConf.FieldString(hsiFieldKeyFile); err != nil {
 		return
 	}
+	if conf.ClientCAFile, err = pConf.FieldString(hsFieldClientCAFile); err != nil {
+		return
+	}
 	if conf.CORS, err = corsConfigFromParsed(pConf.Namespace(hsiFieldCORS)); err != nil {
 		return
 	}
+	if conf.JWTAuth, err = jwtAuthConfigFromParsed(pConf.Namespace(hsFieldJWTAuth)); err != nil {
+		return
+	}
 	if conf.Response, err = hsiResponseConfigFromParsed(pConf.Namespace(hsiFieldResponse)); err != nil {
 		return
 	}
+	if conf.Verification, err = hsiVerificationConfigFromParsed(pConf.Namespace(hsiFieldVerification)); err != nil {
+		return
+	}
+	if conf.Form, err = hsiFormConfigFromParsed(pConf.Namespace(hsiFieldForm)); err != nil {
+		return
+	}
 	return
 }
 
@@ -175,6 +192,14 @@ When the rate limit is breached HTTP requests will have a 429 response returned
 
 It's possible to return a response for each message received using xref:guides:sync_responses.adoc[synchronous responses]. When doing so you can customize headers with the `+"`sync_response` field `headers`"+`, which can also use xref:configuration:interpolation.adoc#bloblang-queries[function interpolation] in the value based on the response message contents.
 
+== Authentication
+
+Requests to a custom `+"`address`"+` can be authenticated with bearer JSON Web Tokens by enabling `+"`jwt_auth`"+`, and with client certificates by specifying a `+"`client_ca_file`"+` along with the `+"`cert_file`"+` and `+"`key_file`"+` used to enable TLS. Requests to the xref:components:http/about.adoc[service-wide HTTP server] are authenticated according to its own `+"`basic_auth`"+`, `+"`jwt_auth`"+` and `+"`client_ca_file`"+` fields. In both cases the verified identities of clients are added to messages as metadata.
+
+== Signature verification
+
+Webhooks are commonly signed by the sender with an HMAC of the request body, and optionally a timestamp, using a shared secret. When `+"`signature_verification`"+` is enabled the signature found within the `+"`header`"+` of each request to the `+"`path`"+` endpoint is compared with an HMAC of the string built by the `+"`mapping`"+`, and requests with a missing or mismatched signature, or a timestamp outside of the `+"`timestamp_tolerance`"+`, are rejected with a 401 response before entering the pipeline. Rejected requests are counted by the metric `+"`http_server_signature_rejected`"+`, labelled by the reason for rejection.
+
 == Endpoints
 
 The following fields specify endpoints that are registered for sending messages, and support path parameters of the form `+"`/\\{foo}`"+`, which are added to ingested messages as metadata. A path ending in `+"`/`"+` will match against all extensions of that path:
@@ -185,6 +210,8 @@ This endpoint expects POST requests where the entire request body is consumed as
 
 If the request contains a multipart `+"`content-type`"+` header as per https://www.w3.org/Protocols/rfc1341/7_2_Multipart.html[RFC1341^] then the multiple parts are consumed as a batch of messages, where each body part is a message of the batch.
 
+When `+"`form.enabled`"+` is `+"`true`"+` requests with a `+"`multipart/form-data`"+` or `+"`application/x-www-form-urlencoded`"+` content type are instead parsed as forms, where each part or field is a message of the batch with the metadata fields `+"`http_server_form_field`"+`, `+"`http_server_form_filename`"+` and `+"`http_server_form_content_type`"+` added when present. Uploaded files can be broken out into multiple messages by configuring a `+"`form.scanner`"+`, and requests with more parts than `+"`form.max_parts`"+` or a part larger than `+"`form.max_part_size`"+` are rejected with a 413 response.
+
 === `+"`ws_path` (defaults to `/post/ws`)"+`
 
 Creates a websocket connection, where payloads received on the socket are passed through the pipeline as a batch of one message.
@@ -214,9 +241,12 @@ If HTTPS is enabled, the following fields are added as well:
 `+"```text"+`
 - http_server_tls_version
 - http_server_tls_subject
+- http_server_tls_sans
 - http_server_tls_cipher_suite
 `+"```"+`
 
+The fields `+"`http_server_tls_subject`"+` and `+"`http_server_tls_sans`"+` describe the verified certificate of the client, and are therefore only added when client certificates are verified with `+"`client_ca_file`"+`, where `+"`http_server_tls_sans`"+` is an array of subject alternative names prefixed by their type, e.g. `+"`DNS:example.com`"+`. When a request is authenticated with a JSON Web Token the verified claims of the token are added as a structured object to the field `+"`http_server_jwt_claims`"+`, which can be accessed with a mapping such as `+"`root.user = @http_server_jwt_claims.sub`"+`.
+
 You can access these metadata fields using xref:configuration:interpolation.adoc#bloblang-queries[function interpolation].`).
 		Fields(
 			service.NewStringField(hsiFieldAddress).
@@ -254,7 +284,9 @@ You can access these metadata fields using xref:configuration:interpolation.adoc
 				Description("Enable TLS by specifying a certificate and key file. Only valid with a custom `address`.").
 				Advanced().
 				Default(""),
+			hsClientCAFileField(),
 			service.NewInternalField(corsSpec),
+			hsJWTAuthField(),
 			service.NewObjectField(hsiFieldResponse,
 				service.NewInterpolatedStringField(hsiFieldResponseStatus).
 					Description("Specify the status code to return with synchronous responses. This is a string value, which allows you to customize it based on resulting payloads and their metadata.").
@@ -270,6 +302,8 @@ You can access these metadata fields using xref:configuration:interpolation.adoc
 			).
 				Description("Customize messages returned via xref:guides:sync_responses.adoc[synchronous responses].").
 				Advanced(),
+			hsiVerificationField(),
+			hsiFormField(),
 		).
 		Example(
 			"Path Switching",
@@ -322,6 +356,39 @@ input:
 
     - sync_response: {}
     - mapping: 'root = deleted()'
+`).
+		Example(
+			"Verify Webhook Signatures",
+			"This example shows an `http_server` input that only accepts Slack webhooks with a valid signature and a timestamp within the last five minutes:", `
+input:
+  http_server:
+    path: /slack/events
+    signature_verification:
+      enabled: true
+      header: X-Slack-Signature
+      signature_prefix: v0=
+      mapping: 'root = "v0:%s:%s".format(metadata("X-Slack-Request-Timestamp"), content().string())'
+      secret: ${SLACK_SIGNING_SECRET}
+      timestamp_header: X-Slack-Request-Timestamp
+      timestamp_tolerance: 5m
+`).
+		Example(
+			"Receive File Uploads",
+			"This example shows an `http_server` input that accepts CSV files uploaded with an HTML form, where each row of an uploaded file becomes a message and other fields of the form are dropped:", `
+input:
+  http_server:
+    path: /upload
+    form:
+      enabled: true
+      scanner:
+        csv: {}
+      max_parts: 10
+      max_part_size: 104857600
+
+  processors:
+    - mapping: |
+        root = if !@http_server_form_filename.or("").has_suffix(".csv") { deleted() }
+        meta uploaded_file = @http_server_form_filename
 `)
 }
 
@@ -362,9 +429,12 @@ type httpServerInput struct {
 
 	shutSig *shutdown.Signaller
 
-	mPostRcvd metrics.StatCounter
-	mWSRcvd   metrics.StatCounter
-	mLatency  metrics.StatTimer
+	formScanner scanner.Creator
+
+	mPostRcvd       metrics.StatCounter
+	mWSRcvd         metrics.StatCounter
+	mLatency        metrics.StatTimer
+	mVerifyRejected metrics.StatCounterVec
 }
 
 func newHTTPServerInput(conf hsiConfig, mgr bundle.NewManagement) (input.Streamed, error) {
@@ -375,9 +445,15 @@ func newHTTPServerInput(conf hsiConfig, mgr bundle.NewManagement) (input.Streame
 	if conf.Address != "" {
 		gMux = mux.NewRouter()
 		server = &http.Server{Addr: conf.Address}
-		if server.Handler, err = conf.CORS.WrapHandler(gMux); err != nil {
+		var handler http.Handler
+		if handler, err = hsServerAuth(server, gMux, conf.CertFile, conf.ClientCAFile, conf.JWTAuth, mgr); err != nil {
+			return nil, err
+		}
+		if server.Handler, err = conf.CORS.WrapHandler(handler); err != nil {
 			return nil, fmt.Errorf("bad CORS configuration: %w", err)
 		}
+	} else if conf.ClientCAFile != "" || conf.JWTAuth.Enabled {
+		return nil, errHSAuthRequiresAddress
 	}
 
 	mRcvd := mgr.Metrics().GetCounter("input_received")
@@ -390,9 +466,10 @@ func newHTTPServerInput(conf hsiConfig, mgr bundle.NewManagement) (input.Streame
 		server:       server,
 		transactions: make(chan message.Transaction),
 
-		mLatency:  mgr.Metrics().GetTimer("input_latency_ns"),
-		mWSRcvd:   mRcvd,
-		mPostRcvd: mRcvd,
+		mLatency:        mgr.Metrics().GetTimer("input_latency_ns"),
+		mWSRcvd:         mRcvd,
+		mPostRcvd:       mRcvd,
+		mVerifyRejected: mgr.Metrics().GetCounterVec("http_server_signature_rejected", "reason"),
 	}
 
 	postHdlr := gzipHandler(h.postHandler)
@@ -423,6 +500,16 @@ func newHTTPServerInput(conf hsiConfig, mgr bundle.NewManagement) (input.Streame
 		}
 	}
 
+	if h.conf.Form.Scanner != nil {
+		sConf, err := scanner.FromAny(mgr.Environment(), h.conf.Form.Scanner)
+		if err != nil {
+			return nil, err
+		}
+		if h.formScanner, err = mgr.IntoPath(hsiFieldForm, hsiFieldFormScanner).NewScanner(sConf); err != nil {
+			return nil, err
+		}
+	}
+
 	go h.loop()
 	return &h, nil
 }
@@ -442,7 +529,11 @@ func (h *httpServerInput) extractMessageFromRequest(r *http.Request) (message.Ba
 		return nil, err
 	}
 
-	if strings.HasPrefix(mediaType, "multipart/") {
+	if h.conf.Form.Enabled && isFormMediaType(mediaType) {
+		if msg, err = h.extractForm(r, mediaType, params["boundary"]); err != nil {
+			return nil, err
+		}
+	} else if strings.HasPrefix(mediaType, "multipart/") {
 		mr := multipart.NewReader(r.Body, params["boundary"])
 		for {
 			var p *multipart.Part
@@ -487,11 +578,9 @@ func (h *httpServerInput) extractMessageFromRequest(r *http.Request) (message.Ba
 				tlsVersion = "TLSv1.3"
 			}
 			p.MetaSetMut("http_server_tls_version", tlsVersion)
-			if len(r.TLS.VerifiedChains) > 0 && len(r.TLS.VerifiedChains[0]) > 0 {
-				p.MetaSetMut("http_server_tls_subject", r.TLS.VerifiedChains[0][0].Subject.String())
-			}
 			p.MetaSetMut("http_server_tls_cipher_suite", tls.CipherSuiteName(r.TLS.CipherSuite))
 		}
+		setRequestIdentityMetadata(p, r)
 		for k, v := range r.Header {
 			if len(v) > 0 {
 				p.MetaSetMut(k, v[0])
@@ -558,9 +647,29 @@ func (h *httpServerInput) postHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if h.conf.Verification.Enabled {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "Bad request", http.StatusBadRequest)
+			h.log.Warn("Request read failed: %v\n", err)
+			return
+		}
+		if reason, err := h.conf.Verification.verify(r, body, time.Now()); err != nil {
+			h.mVerifyRejected.With(reason).Incr(1)
+			h.log.Debug("Request signature verification failed: %v\n", err)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
 	msg, err := h.extractMessageFromRequest(r)
 	if err != nil {
-		http.Error(w, "Bad request", http.StatusBadRequest)
+		if errors.Is(err, errHSIFormLimitExceeded) {
+			http.Error(w, "Request entity too large", http.StatusRequestEntityTooLarge)
+		} else {
+			http.Error(w, "Bad request", http.StatusBadRequest)
+		}
 		h.log.Warn("Request read failed: %v\n", err)
 		return
 	}
@@ -795,6 +904,7 @@ func (h *httpServerInput) wsHandler(w http.ResponseWriter, r *http.Request) {
 		for _, c := range r.Cookies() {
 			part.MetaSetMut(c.Name, c.Value)
 		}
+		setRequestIdentityMetadata(part, r)
 		tracing.InitSpans(h.mgr.Tracer(), "input_http_server_websocket", msg)
 
 		store := transaction.NewResultStore()
@@ -869,6 +979,9 @@ func (h *httpServerInput) loop() {
 		}
 
 		h.handlerWG.Wait()
+		if h.formScanner != nil {
+			_ = h.formScanner.Close(context.Background())
+		}
 
 		close(h.transactions)
 		h.shutSig.TriggerHasStopped()
@@ -0,0 +1,259 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package io
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/redpanda-data/benthos/v4/internal/component/scanner"
+	"github.com/redpanda-data/benthos/v4/internal/message"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	hsiFieldForm            = "form"
+	hsiFieldFormEnabled     = "enabled"
+	hsiFieldFormScanner     = "scanner"
+	hsiFieldFormMaxParts    = "max_parts"
+	hsiFieldFormMaxPartSize = "max_part_size"
+)
+
+func hsiFormField() *service.ConfigField {
+	return service.NewObjectField(hsiFieldForm,
+		service.NewBoolField(hsiFieldFormEnabled).
+			Description("Whether to parse form requests into a batch of messages.").
+			Default(false),
+		service.NewScannerField(hsiFieldFormScanner).
+			Description("An optional xref:components:scanners/about.adoc[scanner] by which uploaded files are broken out into individual messages, which are added to the batch of the request in place of a single message for the file. Since the batch of a request is only dispatched once the whole form has been read, files remain subject to `max_part_size`. Fields that are not files are always consumed as a single message.").
+			Optional(),
+		service.NewIntField(hsiFieldFormMaxParts).
+			Description("The maximum number of parts or fields within a form, requests exceeding this are rejected with a 413 response. Set to zero to disable.").
+			Default(1000),
+		service.NewIntField(hsiFieldFormMaxPartSize).
+			Description("The maximum size in bytes of each part or field within a form, requests exceeding this are rejected with a 413 response. Set to zero to disable.").
+			Default(33554432),
+	).
+		Description("Parse `multipart/form-data` and `application/x-www-form-urlencoded` requests to the `path` endpoint into a batch with a message for each part or field.").
+		Advanced()
+}
+
+type hsiFormConfig struct {
+	Enabled     bool
+	Scanner     any
+	MaxParts    int
+	MaxPartSize int64
+}
+
+func hsiFormConfigFromParsed(pConf *service.ParsedConfig) (conf hsiFormConfig, err error) {
+	if conf.Enabled, err = pConf.FieldBool(hsiFieldFormEnabled); err != nil || !conf.Enabled {
+		return
+	}
+	if pConf.Contains(hsiFieldFormScanner) {
+		if conf.Scanner, err = pConf.FieldAny(hsiFieldFormScanner); err != nil {
+			return
+		}
+	}
+	if conf.MaxParts, err = pConf.FieldInt(hsiFieldFormMaxParts); err != nil {
+		return
+	}
+	var maxPartSize int
+	if maxPartSize, err = pConf.FieldInt(hsiFieldFormMaxPartSize); err != nil {
+		return
+	}
+	if conf.MaxParts < 0 || maxPartSize < 0 {
+		err = fmt.Errorf("%v and %v must not be negative", hsiFieldFormMaxParts, hsiFieldFormMaxPartSize)
+		return
+	}
+	conf.MaxPartSize = int64(maxPartSize)
+	return
+}
+
+var errHSIFormLimitExceeded = errors.New("form limit exceeded")
+
+// hsiFormPartReader reads a part of a form, returning an error once more than
+// a maximum number of bytes has been read.
+type hsiFormPartReader struct {
+	r        io.Reader
+	limit    int64
+	read     int64
+	exceeded bool
+}
+
+func (f *hsiFormPartReader) Read(p []byte) (n int, err error) {
+	if f.exceeded {
+		return 0, errHSIFormLimitExceeded
+	}
+	n, err = f.r.Read(p)
+	if f.read += int64(n); f.limit > 0 && f.read > f.limit {
+		f.exceeded = true
+		return n, errHSIFormLimitExceeded
+	}
+	return
+}
+
+// readFormToken reads a key or value of a URL encoded form up to the next of a
+// set of delimiters, returning the delimiter found, or zero at the end of the
+// form. An error is returned once the token exceeds a maximum number of bytes.
+func readFormToken(br *bufio.Reader, delims string, limit int64) (tok []byte, delim byte, err error) {
+	for {
+		var b byte
+		if b, err = br.ReadByte(); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			return
+		}
+		if strings.IndexByte(delims, b) >= 0 {
+			return tok, b, nil
+		}
+		if limit > 0 && int64(len(tok)) >= limit {
+			return nil, 0, errHSIFormLimitExceeded
+		}
+		tok = append(tok, b)
+	}
+}
+
+func isFormMediaType(mediaType string) bool {
+	return mediaType == "multipart/form-data" || mediaType == "application/x-www-form-urlencoded"
+}
+
+// extractForm parses the parts or fields of a form request into a batch of
+// messages, where each message carries the name of its field, and for files
+// the filename and content type, as metadata.
+func (h *httpServerInput) extractForm(r *http.Request, mediaType, boundary string) (message.Batch, error) {
+	var msg message.Batch
+
+	nParts := 0
+	addPart := func(parts message.Batch, field, filename, contentType string) error {
+		if nParts++; h.conf.Form.MaxParts > 0 && nParts > h.conf.Form.MaxParts {
+			return fmt.Errorf("%w: form contains more than %v parts", errHSIFormLimitExceeded, h.conf.Form.MaxParts)
+		}
+		for _, p := range parts {
+			p.MetaSetMut("http_server_form_field", field)
+			if filename != "" {
+				p.MetaSetMut("http_server_form_filename", filename)
+			}
+			if contentType != "" {
+				p.MetaSetMut("http_server_form_content_type", contentType)
+			}
+		}
+		msg = append(msg, parts...)
+		return nil
+	}
+
+	if mediaType == "application/x-www-form-urlencoded" {
+		// Fields are parsed manually rather than with url.ParseQuery in order
+		// to preserve their ordering within the batch, and are read from the
+		// body one at a time such that the size of each can be limited before
+		// it is decoded. An encoded byte occupies up to three bytes.
+		encLimit := 3 * h.conf.Form.MaxPartSize
+		br := bufio.NewReader(r.Body)
+		for {
+			kBytes, delim, err := readFormToken(br, "=&", encLimit)
+			if err != nil {
+				if errors.Is(err, errHSIFormLimitExceeded) {
+					err = fmt.Errorf("%w: field name exceeds %v bytes", err, h.conf.Form.MaxPartSize)
+				}
+				return nil, err
+			}
+			var vBytes []byte
+			if delim == '=' {
+				if vBytes, delim, err = readFormToken(br, "&", encLimit); err != nil {
+					if errors.Is(err, errHSIFormLimitExceeded) {
+						err = fmt.Errorf("%w: field %v exceeds %v bytes", err, string(kBytes), h.conf.Form.MaxPartSize)
+					}
+					return nil, err
+				}
+			} else if len(kBytes) == 0 {
+				if delim == 0 {
+					return msg, nil
+				}
+				continue
+			}
+
+			k, err := url.QueryUnescape(string(kBytes))
+			if err != nil {
+				return nil, err
+			}
+			v, err := url.QueryUnescape(string(vBytes))
+			if err != nil {
+				return nil, err
+			}
+			if h.conf.Form.MaxPartSize > 0 && int64(len(v)) > h.conf.Form.MaxPartSize {
+				return nil, fmt.Errorf("%w: field %v exceeds %v bytes", errHSIFormLimitExceeded, k, h.conf.Form.MaxPartSize)
+			}
+			if err = addPart(message.Batch{message.NewPart([]byte(v))}, k, "", ""); err != nil {
+				return nil, err
+			}
+			if delim == 0 {
+				return msg, nil
+			}
+		}
+	}
+
+	mr := multipart.NewReader(r.Body, boundary)
+	for {
+		p, err := mr.NextPart()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return msg, nil
+			}
+			return nil, err
+		}
+
+		rdr := &hsiFormPartReader{r: p, limit: h.conf.Form.MaxPartSize}
+
+		var parts message.Batch
+		if h.formScanner != nil && p.FileName() != "" {
+			parts, err = h.scanFormPart(r.Context(), rdr, p.FileName())
+		} else {
+			var partBytes []byte
+			partBytes, err = io.ReadAll(rdr)
+			parts = message.Batch{message.NewPart(partBytes)}
+		}
+		if rdr.exceeded {
+			return nil, fmt.Errorf("%w: part %v exceeds %v bytes", errHSIFormLimitExceeded, p.FormName(), h.conf.Form.MaxPartSize)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if err = addPart(parts, p.FormName(), p.FileName(), p.Header.Get("Content-Type")); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// scanFormPart consumes an uploaded file with the configured scanner. The
+// messages are acknowledged immediately as delivery is instead acknowledged by
+// the response to the request.
+func (h *httpServerInput) scanFormPart(ctx context.Context, rdr io.Reader, filename string) (message.Batch, error) {
+	s, err := h.formScanner.Create(io.NopCloser(rdr), func(context.Context, error) error {
+		return nil
+	}, scanner.SourceDetails{Name: filename})
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close(ctx)
+
+	var batch message.Batch
+	for {
+		parts, ackFn, err := s.Next(ctx)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return batch, nil
+			}
+			return nil, err
+		}
+		_ = ackFn(ctx, nil)
+		batch = append(batch, parts...)
+	}
+}
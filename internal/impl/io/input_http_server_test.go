@@ -5,6 +5,9 @@ package io_test
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -15,10 +18,13 @@ import (
 	"net/http/httptest"
 	"net/textproto"
 	"net/url"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
@@ -1338,3 +1344,410 @@ http_server:
 	assert.Equal(t, "200 OK", resp.Status)
 	assert.Equal(t, "foo", resp.Header.Get("Access-Control-Allow-Origin"))
 }
+
+func TestHTTPServerSignatureVerification(t *testing.T) {
+	tCtx, done := context.WithTimeout(t.Context(), time.Minute)
+	defer done()
+
+	stats := metrics.NewLocal()
+	reg := apiRegGorillaMutWrapper{mut: mux.NewRouter()}
+	mgr, err := manager.New(manager.ResourceConfig{},
+		manager.OptSetAPIReg(reg),
+		manager.OptSetMetrics(metrics.NewNamespaced(stats)),
+	)
+	require.NoError(t, err)
+
+	conf := parseYAMLInputConf(t, `
+http_server:
+  path: /webhook
+  signature_verification:
+    enabled: true
+    header: X-Signature
+    signature_prefix: v0=
+    mapping: 'root = "v0:%%s:%%s".format(metadata("X-Timestamp"), content().string())'
+    secret: shh
+    timestamp_header: X-Timestamp
+    timestamp_tolerance: 1m
+`)
+
+	h, err := mgr.NewInput(conf)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(reg.mut)
+	defer server.Close()
+
+	sign := func(ts, body string) string {
+		mac := hmac.New(sha256.New, []byte("shh"))
+		_, _ = mac.Write([]byte("v0:" + ts + ":" + body))
+		return "v0=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	post := func(ts, sig, body string) int {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/webhook", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		if ts != "" {
+			req.Header.Set("X-Timestamp", ts)
+		}
+		if sig != "" {
+			req.Header.Set("X-Signature", sig)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		return res.StatusCode
+	}
+
+	go func() {
+		select {
+		case ts := <-h.TransactionChan():
+			assert.Equal(t, "hello world", string(ts.Payload.Get(0).AsBytes()))
+			assert.NoError(t, ts.Ack(tCtx, nil))
+		case <-time.After(time.Second * 5):
+			t.Error("Timed out waiting for message")
+		}
+	}()
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	assert.Equal(t, http.StatusOK, post(now, sign(now, "hello world"), "hello world"))
+
+	old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	assert.Equal(t, http.StatusUnauthorized, post(old, sign(old, "hello world"), "hello world"))
+	assert.Equal(t, http.StatusUnauthorized, post(now, sign(now, "hello world"), "tampered"))
+	assert.Equal(t, http.StatusUnauthorized, post(now, "v0=nothex", "hello world"))
+	assert.Equal(t, http.StatusUnauthorized, post(now, "", "hello world"))
+
+	rejected := map[string]int64{}
+	for k, v := range stats.GetCounters() {
+		name, tagNames, tagValues := metrics.ReverseLabelledPath(k)
+		if name != "http_server_signature_rejected" {
+			continue
+		}
+		for i, tag := range tagNames {
+			if tag == "reason" {
+				rejected[tagValues[i]] += v
+			}
+		}
+	}
+	assert.Equal(t, map[string]int64{
+		"expired_timestamp": 1,
+		"invalid_signature": 2,
+		"missing_signature": 1,
+	}, rejected)
+
+	h.TriggerStopConsuming()
+	require.NoError(t, h.WaitForClose(tCtx))
+}
+
+func TestHTTPServerSignatureVerificationExtracted(t *testing.T) {
+	tCtx, done := context.WithTimeout(t.Context(), time.Minute)
+	defer done()
+
+	reg := apiRegGorillaMutWrapper{mut: mux.NewRouter()}
+	mgr, err := manager.New(manager.ResourceConfig{}, manager.OptSetAPIReg(reg))
+	require.NoError(t, err)
+
+	conf := parseYAMLInputConf(t, `
+http_server:
+  path: /webhook
+  signature_verification:
+    enabled: true
+    extract_mapping: |
+      let parts = metadata("Stripe-Signature").split(",")
+      root.signatures = $parts.filter(p -> p.has_prefix("v1=")).map_each(p -> p.trim_prefix("v1="))
+      root.timestamp = $parts.filter(p -> p.has_prefix("t=")).index(0).trim_prefix("t=")
+    mapping: 'root = "%%s.%%s".format(metadata("signature_timestamp"), content().string())'
+    secret: new
+    secrets: [ old ]
+    timestamp_tolerance: 1m
+`)
+
+	h, err := mgr.NewInput(conf)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(reg.mut)
+	defer server.Close()
+
+	sign := func(secret, ts, body string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		_, _ = mac.Write([]byte(ts + "." + body))
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+
+	post := func(sigHeader, body string) int {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodPost, server.URL+"/webhook", bytes.NewBufferString(body))
+		require.NoError(t, err)
+		req.Header.Set("Stripe-Signature", sigHeader)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		return res.StatusCode
+	}
+
+	go func() {
+		for range 2 {
+			select {
+			case ts := <-h.TransactionChan():
+				assert.Equal(t, "hello world", string(ts.Payload.Get(0).AsBytes()))
+				assert.NoError(t, ts.Ack(tCtx, nil))
+			case <-time.After(time.Second * 5):
+				t.Error("Timed out waiting for message")
+			}
+		}
+	}()
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	assert.Equal(t, http.StatusOK, post(fmt.Sprintf("t=%v,v1=%v,v0=nothex", now, sign("new", now, "hello world")), "hello world"))
+
+	// Any of the signatures may match any of the secrets.
+	assert.Equal(t, http.StatusOK, post(fmt.Sprintf("t=%v,v1=%v,v1=%v", now, sign("nope", now, "hello world"), sign("old", now, "hello world")), "hello world"))
+
+	old := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+	assert.Equal(t, http.StatusUnauthorized, post(fmt.Sprintf("t=%v,v1=%v", old, sign("new", old, "hello world")), "hello world"))
+	assert.Equal(t, http.StatusUnauthorized, post(fmt.Sprintf("t=%v,v1=%v", now, sign("nope", now, "hello world")), "hello world"))
+	assert.Equal(t, http.StatusUnauthorized, post(fmt.Sprintf("t=%v", now), "hello world"))
+
+	h.TriggerStopConsuming()
+	require.NoError(t, h.WaitForClose(tCtx))
+}
+
+func TestHTTPServerSignatureVerificationConfigErrors(t *testing.T) {
+	for _, conf := range []string{
+		`
+http_server:
+  path: /webhook
+  signature_verification:
+    enabled: true
+    secret: shh
+`,
+		`
+http_server:
+  path: /webhook
+  signature_verification:
+    enabled: true
+    header: X-Signature
+`,
+	} {
+		_, err := mock.NewManager().NewInput(parseYAMLInputConf(t, "%v", conf))
+		require.Error(t, err, conf)
+	}
+}
+
+func TestHTTPServerJWTAuth(t *testing.T) {
+	tCtx, done := context.WithTimeout(t.Context(), time.Minute)
+	defer done()
+
+	freePort := getFreePort(t)
+
+	conf := parseYAMLInputConf(t, `
+http_server:
+  address: 0.0.0.0:%v
+  path: /test
+  jwt_auth:
+    enabled: true
+    hmac_secret: shh
+    issuer: foo
+    require_expiration: false
+`, freePort)
+
+	h, err := mock.NewManager().NewInput(conf)
+	require.NoError(t, err)
+
+	defer func() {
+		h.TriggerStopConsuming()
+		assert.NoError(t, h.WaitForClose(tCtx))
+	}()
+
+	serverURL := fmt.Sprintf("http://localhost:%v/test", freePort)
+
+	post := func(token string) (int, error) {
+		req, err := http.NewRequest(http.MethodPost, serverURL, bytes.NewBufferString("hello world"))
+		if err != nil {
+			return 0, err
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		_ = res.Body.Close()
+		return res.StatusCode, nil
+	}
+
+	assert.Eventually(t, func() bool {
+		code, err := post("")
+		return err == nil && code == http.StatusUnauthorized
+	}, time.Second*5, 50*time.Millisecond)
+
+	wrongIssuer, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"iss": "bar"}).SignedString([]byte("shh"))
+	require.NoError(t, err)
+
+	code, err := post(wrongIssuer)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"iss": "foo", "sub": "buz"}).SignedString([]byte("shh"))
+	require.NoError(t, err)
+
+	go func() {
+		code, err := post(token)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, code)
+	}()
+
+	select {
+	case tran := <-h.TransactionChan():
+		part := tran.Payload.Get(0)
+		assert.Equal(t, "hello world", string(part.AsBytes()))
+
+		claims, _ := part.MetaGetMut("http_server_jwt_claims")
+		assert.Equal(t, map[string]any{"iss": "foo", "sub": "buz"}, claims)
+		require.NoError(t, tran.Ack(tCtx, nil))
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out")
+	}
+}
+
+func TestHTTPServerAuthConfigErrors(t *testing.T) {
+	for _, conf := range []string{
+		`
+http_server:
+  path: /test
+  jwt_auth:
+    enabled: true
+    hmac_secret: shh
+`,
+		`
+http_server:
+  path: /test
+  client_ca_file: ./ca.pem
+`,
+		`
+http_server:
+  address: 0.0.0.0:0
+  path: /test
+  client_ca_file: ./ca.pem
+`,
+		`
+http_server:
+  address: 0.0.0.0:0
+  path: /test
+  jwt_auth:
+    enabled: true
+`,
+	} {
+		_, err := mock.NewManager().NewInput(parseYAMLInputConf(t, "%v", conf))
+		require.Error(t, err, conf)
+	}
+}
+
+func TestHTTPServerForm(t *testing.T) {
+	tCtx, done := context.WithTimeout(t.Context(), time.Minute)
+	defer done()
+
+	reg := apiRegGorillaMutWrapper{mut: mux.NewRouter()}
+	mgr, err := manager.New(manager.ResourceConfig{}, manager.OptSetAPIReg(reg))
+	require.NoError(t, err)
+
+	conf := parseYAMLInputConf(t, `
+http_server:
+  path: /upload
+  form:
+    enabled: true
+    scanner:
+      lines: {}
+    max_parts: 3
+    max_part_size: 20
+`)
+
+	h, err := mgr.NewInput(conf)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(reg.mut)
+	defer server.Close()
+
+	post := func(contentType string, body io.Reader) int {
+		t.Helper()
+		res, err := http.Post(server.URL+"/upload", contentType, body)
+		require.NoError(t, err)
+		_ = res.Body.Close()
+		return res.StatusCode
+	}
+
+	type formMsg struct {
+		Content     string
+		Field       string
+		Filename    string
+		ContentType string
+	}
+
+	readMsgs := func() (msgs []formMsg) {
+		t.Helper()
+		select {
+		case tran := <-h.TransactionChan():
+			for _, p := range tran.Payload {
+				msgs = append(msgs, formMsg{
+					Content:     string(p.AsBytes()),
+					Field:       p.MetaGetStr("http_server_form_field"),
+					Filename:    p.MetaGetStr("http_server_form_filename"),
+					ContentType: p.MetaGetStr("http_server_form_content_type"),
+				})
+			}
+			require.NoError(t, tran.Ack(tCtx, nil))
+		case <-time.After(time.Second * 5):
+			t.Fatal("timed out")
+		}
+		return
+	}
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	require.NoError(t, mw.WriteField("title", "hello world"))
+	fh := textproto.MIMEHeader{}
+	fh.Set("Content-Disposition", `form-data; name="doc"; filename="foo.txt"`)
+	fh.Set("Content-Type", "text/plain")
+	fw, err := mw.CreatePart(fh)
+	require.NoError(t, err)
+	_, err = fw.Write([]byte("first\nsecond\nthird"))
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+
+	go func() {
+		assert.Equal(t, http.StatusOK, post(mw.FormDataContentType(), &buf))
+	}()
+	assert.Equal(t, []formMsg{
+		{Content: "hello world", Field: "title"},
+		{Content: "first", Field: "doc", Filename: "foo.txt", ContentType: "text/plain"},
+		{Content: "second", Field: "doc", Filename: "foo.txt", ContentType: "text/plain"},
+		{Content: "third", Field: "doc", Filename: "foo.txt", ContentType: "text/plain"},
+	}, readMsgs())
+
+	go func() {
+		assert.Equal(t, http.StatusOK, post("application/x-www-form-urlencoded", bytes.NewBufferString("b=foo+bar&a=baz%21&b=")))
+	}()
+	assert.Equal(t, []formMsg{
+		{Content: "foo bar", Field: "b"},
+		{Content: "baz!", Field: "a"},
+		{Content: "", Field: "b"},
+	}, readMsgs())
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post("application/x-www-form-urlencoded", bytes.NewBufferString("a=1&b=2&c=3&d=4")))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post("application/x-www-form-urlencoded", bytes.NewBufferString("a=this+value+is+far+too+long")))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post("application/x-www-form-urlencoded", bytes.NewBufferString("a="+strings.Repeat("%21", 21))))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post("application/x-www-form-urlencoded", bytes.NewBufferString(strings.Repeat("a", 1<<20))))
+
+	buf.Reset()
+	mw = multipart.NewWriter(&buf)
+	fw, err = mw.CreateFormFile("doc", "foo.txt")
+	require.NoError(t, err)
+	_, err = fw.Write(bytes.Repeat([]byte("a\n"), 20))
+	require.NoError(t, err)
+	require.NoError(t, mw.Close())
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post(mw.FormDataContentType(), &buf))
+
+	h.TriggerStopConsuming()
+	require.NoError(t, h.WaitForClose(tCtx))
+}
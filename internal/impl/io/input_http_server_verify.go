@@ -0,0 +1,310 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package io
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	hsiFieldVerification                   = "signature_verification"
+	hsiFieldVerificationEnabled            = "enabled"
+	hsiFieldVerificationAlgorithm          = "algorithm"
+	hsiFieldVerificationHeader             = "header"
+	hsiFieldVerificationPrefix             = "signature_prefix"
+	hsiFieldVerificationEncoding           = "encoding"
+	hsiFieldVerificationExtractMapping     = "extract_mapping"
+	hsiFieldVerificationMapping            = "mapping"
+	hsiFieldVerificationSecret             = "secret"
+	hsiFieldVerificationSecrets            = "secrets"
+	hsiFieldVerificationTimestampHeader    = "timestamp_header"
+	hsiFieldVerificationTimestampTolerance = "timestamp_tolerance"
+)
+
+func hsiVerificationField() *service.ConfigField {
+	return service.NewObjectField(hsiFieldVerification,
+		service.NewBoolField(hsiFieldVerificationEnabled).
+			Description("Whether to verify the signatures of requests.").
+			Default(false),
+		service.NewStringEnumField(hsiFieldVerificationAlgorithm, "sha1", "sha256", "sha512").
+			Description("The hash function of the HMAC used to sign requests.").
+			Default("sha256"),
+		service.NewStringField(hsiFieldVerificationHeader).
+			Description("The header containing the signature of a request. Required unless `extract_mapping` is specified.").
+			Examples("X-Hub-Signature-256", "X-Signature").
+			Default(""),
+		service.NewStringField(hsiFieldVerificationPrefix).
+			Description("An optional prefix of the signature header value to remove before decoding the signature. Ignored when `extract_mapping` is specified.").
+			Examples("sha256=", "v0=").
+			Default(""),
+		service.NewStringEnumField(hsiFieldVerificationEncoding, "hex", "base64").
+			Description("The encoding of the signature within the header.").
+			Default("hex"),
+		service.NewBloblangField(hsiFieldVerificationExtractMapping).
+			Description("An optional xref:guides:bloblang/about.adoc[Bloblang mapping] that extracts the signatures and timestamp of a request from headers that combine them, executed on a message containing the request body with the request headers as metadata. The mapping must result in an object with a field `signatures` containing a string or an array of strings, where a request is accepted when any of them is valid, and an optional field `timestamp` containing the time at which the request was signed as a unix timestamp in seconds, which is checked against `timestamp_tolerance` and added to the message given to `mapping` as the metadata field `signature_timestamp`.").
+			Examples(
+				`let parts = metadata("Stripe-Signature").split(",")
+root.signatures = $parts.filter(p -> p.has_prefix("v1=")).map_each(p -> p.trim_prefix("v1="))
+root.timestamp = $parts.filter(p -> p.has_prefix("t=")).index(0).trim_prefix("t=")`,
+			).
+			Optional(),
+		service.NewBloblangField(hsiFieldVerificationMapping).
+			Description("A xref:guides:bloblang/about.adoc[Bloblang mapping] that builds the string that was signed, executed on a message containing the request body with the request headers as metadata.").
+			Examples(
+				`root = content()`,
+				`root = "v0:%s:%s".format(metadata("X-Slack-Request-Timestamp"), content().string())`,
+				`root = "%s.%s".format(metadata("signature_timestamp"), content().string())`,
+			).
+			Default("root = content()"),
+		service.NewStringField(hsiFieldVerificationSecret).
+			Description("The secret used to sign requests.").
+			Secret().
+			Default(""),
+		service.NewStringListField(hsiFieldVerificationSecrets).
+			Description("A list of additional secrets used to sign requests, where a request is accepted when its signature matches any of them along with `secret`. This allows secrets to be rotated without rejecting requests.").
+			Secret().
+			Default([]any{}),
+		service.NewStringField(hsiFieldVerificationTimestampHeader).
+			Description("An optional header containing the time at which a request was signed as a unix timestamp in seconds, requests where the time differs from the current time by more than `timestamp_tolerance` are rejected in order to prevent replay attacks.").
+			Examples("X-Slack-Request-Timestamp").
+			Default(""),
+		service.NewDurationField(hsiFieldVerificationTimestampTolerance).
+			Description("The maximum difference between the time within the `timestamp_header` of a request and the current time.").
+			Default("5m"),
+	).
+		Description("Verify HMAC signatures of requests to the `path` endpoint, where requests that fail verification are rejected with a 401 response before entering the pipeline.").
+		Advanced()
+}
+
+type hsiVerificationConfig struct {
+	Enabled            bool
+	Algorithm          func() hash.Hash
+	Header             string
+	Prefix             string
+	Encoding           string
+	ExtractMapping     *bloblang.Executor
+	Mapping            *bloblang.Executor
+	Secrets            [][]byte
+	TimestampHeader    string
+	TimestampTolerance time.Duration
+}
+
+func hsiVerificationConfigFromParsed(pConf *service.ParsedConfig) (conf hsiVerificationConfig, err error) {
+	if conf.Enabled, err = pConf.FieldBool(hsiFieldVerificationEnabled); err != nil || !conf.Enabled {
+		return
+	}
+
+	var algorithm string
+	if algorithm, err = pConf.FieldString(hsiFieldVerificationAlgorithm); err != nil {
+		return
+	}
+	switch algorithm {
+	case "sha1":
+		conf.Algorithm = sha1.New
+	case "sha256":
+		conf.Algorithm = sha256.New
+	case "sha512":
+		conf.Algorithm = sha512.New
+	default:
+		err = fmt.Errorf("unsupported signature algorithm: %v", algorithm)
+		return
+	}
+
+	if conf.Header, err = pConf.FieldString(hsiFieldVerificationHeader); err != nil {
+		return
+	}
+	if pConf.Contains(hsiFieldVerificationExtractMapping) {
+		if conf.ExtractMapping, err = pConf.FieldBloblang(hsiFieldVerificationExtractMapping); err != nil {
+			return
+		}
+	}
+	if conf.Header == "" && conf.ExtractMapping == nil {
+		err = fmt.Errorf("either a signature header or %v must be specified", hsiFieldVerificationExtractMapping)
+		return
+	}
+	if conf.Prefix, err = pConf.FieldString(hsiFieldVerificationPrefix); err != nil {
+		return
+	}
+	if conf.Encoding, err = pConf.FieldString(hsiFieldVerificationEncoding); err != nil {
+		return
+	}
+	if conf.Mapping, err = pConf.FieldBloblang(hsiFieldVerificationMapping); err != nil {
+		return
+	}
+
+	var secret string
+	if secret, err = pConf.FieldString(hsiFieldVerificationSecret); err != nil {
+		return
+	}
+	var secrets []string
+	if secrets, err = pConf.FieldStringList(hsiFieldVerificationSecrets); err != nil {
+		return
+	}
+	for _, s := range append([]string{secret}, secrets...) {
+		if s != "" {
+			conf.Secrets = append(conf.Secrets, []byte(s))
+		}
+	}
+	if len(conf.Secrets) == 0 {
+		err = errors.New("a signature secret must be specified")
+		return
+	}
+
+	if conf.TimestampHeader, err = pConf.FieldString(hsiFieldVerificationTimestampHeader); err != nil {
+		return
+	}
+	if conf.TimestampTolerance, err = pConf.FieldDuration(hsiFieldVerificationTimestampTolerance); err != nil {
+		return
+	}
+	return
+}
+
+// extractSignatures obtains the encoded signatures of a request along with the
+// timestamp at which it was signed, which is empty when not provided.
+func (v hsiVerificationConfig) extractSignatures(r *http.Request, msg *service.Message) (sigs []string, ts, reason string, err error) {
+	if v.ExtractMapping == nil {
+		sigStr := r.Header.Get(v.Header)
+		if sigStr == "" {
+			return nil, "", "missing_signature", fmt.Errorf("header %v is missing", v.Header)
+		}
+		sigStr, hasPrefix := strings.CutPrefix(strings.TrimSpace(sigStr), v.Prefix)
+		if !hasPrefix {
+			return nil, "", "invalid_signature", fmt.Errorf("signature is missing prefix %v", v.Prefix)
+		}
+		if v.TimestampHeader != "" {
+			ts = r.Header.Get(v.TimestampHeader)
+			if ts == "" {
+				return nil, "", "missing_timestamp", fmt.Errorf("header %v is missing", v.TimestampHeader)
+			}
+		}
+		return []string{sigStr}, ts, "", nil
+	}
+
+	res, err := msg.BloblangQueryValue(v.ExtractMapping)
+	if err != nil {
+		return nil, "", "mapping_error", fmt.Errorf("failed to extract signatures: %w", err)
+	}
+	obj, ok := res.(map[string]any)
+	if !ok {
+		return nil, "", "mapping_error", fmt.Errorf("expected signature extraction to result in an object, got %T", res)
+	}
+	switch t := obj["signatures"].(type) {
+	case string:
+		sigs = []string{t}
+	case []any:
+		for _, s := range t {
+			sigStr, ok := s.(string)
+			if !ok {
+				return nil, "", "mapping_error", fmt.Errorf("expected extracted signatures to be strings, got %T", s)
+			}
+			sigs = append(sigs, sigStr)
+		}
+	case nil:
+	default:
+		return nil, "", "mapping_error", fmt.Errorf("expected extracted signatures to be a string or array, got %T", t)
+	}
+	if len(sigs) == 0 {
+		return nil, "", "missing_signature", errors.New("no signatures were extracted")
+	}
+
+	switch t := obj["timestamp"].(type) {
+	case nil:
+		if v.TimestampHeader == "" {
+			break
+		}
+		if ts = r.Header.Get(v.TimestampHeader); ts == "" {
+			return nil, "", "missing_timestamp", fmt.Errorf("header %v is missing", v.TimestampHeader)
+		}
+	case string:
+		ts = t
+	case int64:
+		ts = strconv.FormatInt(t, 10)
+	case uint64:
+		ts = strconv.FormatUint(t, 10)
+	case float64:
+		ts = strconv.FormatInt(int64(t), 10)
+	default:
+		return nil, "", "mapping_error", fmt.Errorf("expected extracted timestamp to be a string or number, got %T", t)
+	}
+	return sigs, ts, "", nil
+}
+
+// verify checks the signature of a request and its body, returning the reason
+// for rejecting the request along with an error when verification fails.
+func (v hsiVerificationConfig) verify(r *http.Request, body []byte, now time.Time) (reason string, err error) {
+	msg := service.NewMessage(body)
+	for k, vals := range r.Header {
+		if len(vals) > 0 {
+			msg.MetaSetMut(k, vals[0])
+		}
+	}
+
+	sigStrs, tsStr, reason, err := v.extractSignatures(r, msg)
+	if err != nil {
+		return reason, err
+	}
+
+	if tsStr != "" {
+		ts, err := strconv.ParseInt(strings.TrimSpace(tsStr), 10, 64)
+		if err != nil {
+			return "invalid_timestamp", fmt.Errorf("failed to parse timestamp: %w", err)
+		}
+		if diff := now.Sub(time.Unix(ts, 0)).Abs(); diff > v.TimestampTolerance {
+			return "expired_timestamp", fmt.Errorf("timestamp differs from the current time by %v", diff)
+		}
+		msg.MetaSetMut("signature_timestamp", strings.TrimSpace(tsStr))
+	}
+
+	sigs := make([][]byte, 0, len(sigStrs))
+	for _, sigStr := range sigStrs {
+		var sig []byte
+		if v.Encoding == "base64" {
+			sig, err = base64.StdEncoding.DecodeString(strings.TrimSpace(sigStr))
+		} else {
+			sig, err = hex.DecodeString(strings.TrimSpace(sigStr))
+		}
+		if err != nil {
+			return "invalid_signature", fmt.Errorf("failed to decode signature: %w", err)
+		}
+		sigs = append(sigs, sig)
+	}
+
+	signed, err := msg.BloblangQuery(v.Mapping)
+	if err != nil {
+		return "mapping_error", fmt.Errorf("failed to build signed string: %w", err)
+	}
+	if signed == nil {
+		return "mapping_error", errors.New("signed string mapping resulted in a deleted message")
+	}
+	signedBytes, err := signed.AsBytes()
+	if err != nil {
+		return "mapping_error", fmt.Errorf("failed to build signed string: %w", err)
+	}
+
+	for _, secret := range v.Secrets {
+		mac := hmac.New(v.Algorithm, secret)
+		_, _ = mac.Write(signedBytes)
+		expected := mac.Sum(nil)
+		for _, sig := range sigs {
+			if hmac.Equal(expected, sig) {
+				return "", nil
+			}
+		}
+	}
+	return "invalid_signature", errors.New("signature does not match")
+}
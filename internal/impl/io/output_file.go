@@ -26,7 +26,13 @@ func fileOutputSpec() *service.ConfigSpec {
 		Stable().
 		Categories("Local").
 		Summary(`Writes messages to files on disk based on a chosen codec.`).
-		Description(`Messages can be written to different files by using xref:configuration:interpolation.adoc#bloblang-queries[interpolation functions] in the path field. However, only one file is ever open at a given time, and therefore when the path changes the previously open file is closed.`).
+		Description(`Messages can be written to different files by using xref:configuration:interpolation.adoc#bloblang-queries[interpolation functions] in the path field. However, only one file is ever open at a given time, and therefore when the path changes the previously open file is closed.
+
+== Rotation
+
+When `+"`rotation.enabled`"+` is set each path is written as a series of segments, where a file is kept open for each path being written to and is closed once it reaches a size, age or message count limit, or once it has not been written to for the `+"`rotation.idle_timeout`"+`. Since segments are only renamed to their final name once closed, downstream consumers can safely pick up any file that does not end with `+"`.tmp`"+`.
+
+Closed segments can optionally be compressed, and the number and age of closed segments retained for each path can be limited, where segments are identified by the time they were opened and therefore the path should not itself contain a timestamp when retention limits are used.`).
 		Fields(
 			service.NewInterpolatedStringField(fileOutputFieldPath).
 				Description("The file to write to, if the file does not yet exist it will be created.").
@@ -37,12 +43,31 @@ func fileOutputSpec() *service.ConfigSpec {
 				).
 				Version("3.33.0"),
 			service.NewInternalField(codec.NewWriterDocs(fileOutputFieldCodec)).Version("3.33.0").Default("lines"),
+			fileOutputRotationField(),
+		).
+		Example(
+			"Archive With Rotation",
+			"Messages can be archived to files that are rotated hourly or once they reach 100MB, compressed once closed, with only the last week of files retained:",
+			`
+output:
+  file:
+    path: /var/archive/events.jsonl
+    codec: lines
+    rotation:
+      enabled: true
+      max_bytes: 100000000
+      max_age: 1h
+      compression: zstd
+      retain_age: 168h
+`,
 		)
 }
 
 type fileOutputConfig struct {
-	Path  *service.InterpolatedString
-	Codec string
+	Path     *service.InterpolatedString
+	Codec    string
+	Rotate   bool
+	Rotation fileRotationConfig
 }
 
 func fileOutputConfigFromParsed(pConf *service.ParsedConfig) (conf fileOutputConfig, err error) {
@@ -52,6 +77,12 @@ func fileOutputConfigFromParsed(pConf *service.ParsedConfig) (conf fileOutputCon
 	if conf.Codec, err = pConf.FieldString(fileOutputFieldCodec); err != nil {
 		return
 	}
+	if conf.Rotate, err = pConf.FieldBool(fileOutputFieldRotation, fileOutputFieldRotationEnabled); err != nil {
+		return
+	}
+	if conf.Rotate {
+		conf.Rotation, err = fileRotationConfigFromParsed(pConf.Namespace(fileOutputFieldRotation))
+	}
 	return
 }
 
@@ -64,7 +95,11 @@ func init() {
 			}
 
 			mif = 1
-			out, err = newFileWriter(conf.Path, conf.Codec, res)
+			if conf.Rotate {
+				out, err = newRollingFileWriter(conf.Path, conf.Codec, conf.Rotation, res)
+			} else {
+				out, err = newFileWriter(conf.Path, conf.Codec, res)
+			}
 			return
 		})
 }
@@ -0,0 +1,539 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package io
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Jeffail/shutdown"
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/redpanda-data/benthos/v4/internal/codec"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	fileOutputFieldRotation               = "rotation"
+	fileOutputFieldRotationEnabled        = "enabled"
+	fileOutputFieldRotationMaxBytes       = "max_bytes"
+	fileOutputFieldRotationMaxAge         = "max_age"
+	fileOutputFieldRotationMaxMessages    = "max_messages"
+	fileOutputFieldRotationIdleTimeout    = "idle_timeout"
+	fileOutputFieldRotationCompression    = "compression"
+	fileOutputFieldRotationRetainSegments = "retain_segments"
+	fileOutputFieldRotationRetainAge      = "retain_age"
+)
+
+// The layout of the timestamp inserted into the names of closed segments, which
+// sorts lexically in the order that segments were opened.
+const fileSegmentTimeLayout = "20060102T150405.000000000"
+
+func fileOutputRotationField() *service.ConfigField {
+	return service.NewObjectField(fileOutputFieldRotation,
+		service.NewBoolField(fileOutputFieldRotationEnabled).
+			Description("Whether to write files as rotated segments.").
+			Default(false),
+		service.NewIntField(fileOutputFieldRotationMaxBytes).
+			Description("The maximum number of bytes to write to a segment before it is closed, where a message that would exceed the limit is written to a new segment instead. Set to zero to disable.").
+			Default(0),
+		service.NewDurationField(fileOutputFieldRotationMaxAge).
+			Description("The maximum period of time a segment is written to before it is closed. Set to zero to disable.").
+			Default("0s"),
+		service.NewIntField(fileOutputFieldRotationMaxMessages).
+			Description("The maximum number of messages to write to a segment before it is closed. Set to zero to disable.").
+			Default(0),
+		service.NewDurationField(fileOutputFieldRotationIdleTimeout).
+			Description("The period of time after which a segment that hasn't been written to is closed, which prevents file handles opened for dynamic paths from being held open indefinitely. Set to zero to disable.").
+			Default("1m"),
+		service.NewStringEnumField(fileOutputFieldRotationCompression, "none", "gzip", "zstd").
+			Description("An optional algorithm to compress closed segments with, where the compressed segment replaces the original and is given the extension `.gz` or `.zst` respectively.").
+			Default("none"),
+		service.NewIntField(fileOutputFieldRotationRetainSegments).
+			Description("The maximum number of closed segments to retain for each path, where the oldest segments are deleted once exceeded. Set to zero to retain all segments.").
+			Default(0).
+			Advanced(),
+		service.NewDurationField(fileOutputFieldRotationRetainAge).
+			Description("The maximum age of closed segments to retain for each path, measured from when they were opened, where older segments are deleted. Set to zero to retain all segments.").
+			Default("0s").
+			Advanced(),
+	).Description("Write files as segments that are rotated by size, age or message count. While a segment is open it is written to the path with the suffix `.tmp`, and once closed it is atomically renamed to the path with the time it was opened inserted before its extension, such that `/tmp/data.txt` becomes `/tmp/data-20250102T150405.000000000.txt`.").
+		Advanced()
+}
+
+type fileRotationConfig struct {
+	maxBytes       int64
+	maxAge         time.Duration
+	maxMessages    int64
+	idleTimeout    time.Duration
+	compression    string
+	retainSegments int
+	retainAge      time.Duration
+}
+
+func fileRotationConfigFromParsed(pConf *service.ParsedConfig) (conf fileRotationConfig, err error) {
+	var maxBytes, maxMessages int
+	if maxBytes, err = pConf.FieldInt(fileOutputFieldRotationMaxBytes); err != nil {
+		return
+	}
+	if conf.maxAge, err = pConf.FieldDuration(fileOutputFieldRotationMaxAge); err != nil {
+		return
+	}
+	if maxMessages, err = pConf.FieldInt(fileOutputFieldRotationMaxMessages); err != nil {
+		return
+	}
+	if conf.idleTimeout, err = pConf.FieldDuration(fileOutputFieldRotationIdleTimeout); err != nil {
+		return
+	}
+	if conf.compression, err = pConf.FieldString(fileOutputFieldRotationCompression); err != nil {
+		return
+	}
+	if conf.retainSegments, err = pConf.FieldInt(fileOutputFieldRotationRetainSegments); err != nil {
+		return
+	}
+	if conf.retainAge, err = pConf.FieldDuration(fileOutputFieldRotationRetainAge); err != nil {
+		return
+	}
+	if maxBytes < 0 || maxMessages < 0 || conf.retainSegments < 0 {
+		err = fmt.Errorf("%v, %v and %v must not be negative", fileOutputFieldRotationMaxBytes, fileOutputFieldRotationMaxMessages, fileOutputFieldRotationRetainSegments)
+		return
+	}
+	conf.maxBytes, conf.maxMessages = int64(maxBytes), int64(maxMessages)
+	return
+}
+
+//------------------------------------------------------------------------------
+
+// fileSegment is an open segment of a path that is being written to. Once
+// closed the handle is nil, and the segment remains tracked until it has been
+// renamed to its final name.
+type fileSegment struct {
+	path      string
+	handle    io.WriteCloser
+	openedAt  time.Time
+	lastWrite time.Time
+	bytes     int64
+	messages  int64
+}
+
+func (s *fileSegment) tmpPath() string {
+	return s.path + ".tmp"
+}
+
+// splitSegmentPath splits a path into the prefix and extension that segment
+// names are composed of.
+func splitSegmentPath(path string) (prefix, ext string) {
+	ext = filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "-", ext
+}
+
+type rollingFileWriter struct {
+	log *service.Logger
+	nm  *service.Resources
+
+	path     *service.InterpolatedString
+	suffixFn codec.SuffixFn
+	conf     fileRotationConfig
+
+	segmentsMut sync.Mutex
+	segments    map[string]*fileSegment
+	connected   bool
+
+	// Compression and pruning of closed segments is performed in the
+	// background, one segment at a time. Segments that are yet to be
+	// compressed are ignored when pruning.
+	finalizeMut sync.Mutex
+	finalizeWG  sync.WaitGroup
+	pendingMut  sync.Mutex
+	pending     map[string]struct{}
+
+	shutSig *shutdown.Signaller
+}
+
+func newRollingFileWriter(path *service.InterpolatedString, codecStr string, conf fileRotationConfig, mgr *service.Resources) (*rollingFileWriter, error) {
+	suffixFn, appendMode, err := codec.GetWriter(codecStr)
+	if err != nil {
+		return nil, err
+	}
+	if !appendMode {
+		return nil, fmt.Errorf("codec %v cannot be used with rotation as it does not append to files", codecStr)
+	}
+	return &rollingFileWriter{
+		log:      mgr.Logger(),
+		nm:       mgr,
+		path:     path,
+		suffixFn: suffixFn,
+		conf:     conf,
+		segments: map[string]*fileSegment{},
+		pending:  map[string]struct{}{},
+		shutSig:  shutdown.NewSignaller(),
+	}, nil
+}
+
+func (w *rollingFileWriter) Connect(ctx context.Context) error {
+	w.segmentsMut.Lock()
+	defer w.segmentsMut.Unlock()
+	if w.connected {
+		return nil
+	}
+
+	var checkPeriod time.Duration
+	for _, d := range []time.Duration{w.conf.idleTimeout, w.conf.maxAge} {
+		if d > 0 && (checkPeriod == 0 || d < checkPeriod) {
+			checkPeriod = d
+		}
+	}
+	if checkPeriod > 0 {
+		go w.expiryLoop(max(checkPeriod/2, time.Millisecond*10))
+	} else {
+		w.shutSig.TriggerHasStopped()
+	}
+
+	w.connected = true
+	return nil
+}
+
+// expiryLoop closes segments that have exceeded their maximum age or have been
+// idle for too long, even when no further messages are written.
+func (w *rollingFileWriter) expiryLoop(period time.Duration) {
+	defer w.shutSig.TriggerHasStopped()
+
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-w.shutSig.SoftStopChan():
+			return
+		}
+
+		w.segmentsMut.Lock()
+		for _, seg := range w.segments {
+			if seg.handle != nil && !w.expired(seg) {
+				continue
+			}
+			if err := w.closeSegment(seg); err != nil {
+				w.log.Errorf("Failed to close file '%v': %v", seg.tmpPath(), err)
+			}
+		}
+		w.segmentsMut.Unlock()
+	}
+}
+
+func (w *rollingFileWriter) expired(seg *fileSegment) bool {
+	if w.conf.maxAge > 0 && time.Since(seg.openedAt) >= w.conf.maxAge {
+		return true
+	}
+	return w.conf.idleTimeout > 0 && time.Since(seg.lastWrite) >= w.conf.idleTimeout
+}
+
+// openSegment opens the temporary file of a segment for a path, resuming a
+// segment that was left behind when the file wasn't closed.
+func (w *rollingFileWriter) openSegment(path string) (*fileSegment, error) {
+	if err := w.nm.FS().MkdirAll(filepath.Dir(path), fs.FileMode(0o777)); err != nil {
+		return nil, err
+	}
+
+	seg := &fileSegment{path: path, openedAt: time.Now(), lastWrite: time.Now()}
+	file, err := w.nm.FS().OpenFile(seg.tmpPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, fs.FileMode(0o666))
+	if err != nil {
+		return nil, err
+	}
+
+	handle, ok := file.(io.WriteCloser)
+	if !ok {
+		_ = file.Close()
+		return nil, errors.New("failed to open file for writing")
+	}
+	if info, err := file.Stat(); err == nil {
+		seg.bytes = info.Size()
+	}
+	seg.handle = handle
+	return seg, nil
+}
+
+// closeSegment closes a segment and renames it to its final name. A segment
+// that fails to close or be renamed remains tracked such that closing it can be
+// retried.
+func (w *rollingFileWriter) closeSegment(seg *fileSegment) error {
+	if seg.handle != nil {
+		err := seg.handle.Close()
+		seg.handle = nil
+		if err != nil {
+			return err
+		}
+	}
+
+	prefix, ext := splitSegmentPath(seg.path)
+	stamp := seg.openedAt.UTC().Format(fileSegmentTimeLayout)
+	finalPath := prefix + stamp + ext
+	for i := 1; ; i++ {
+		if _, err := w.nm.FS().Stat(finalPath); errors.Is(err, fs.ErrNotExist) {
+			break
+		}
+		finalPath = fmt.Sprintf("%v%v-%v%v", prefix, stamp, i, ext)
+	}
+	// The segment is marked as pending compression before it is renamed in
+	// order to prevent a concurrent prune from deleting it.
+	compress := w.conf.compression != "none"
+	if compress {
+		w.pendingMut.Lock()
+		w.pending[finalPath] = struct{}{}
+		w.pendingMut.Unlock()
+	}
+	if err := w.nm.FS().Rename(seg.tmpPath(), finalPath); err != nil {
+		if compress {
+			w.pendingMut.Lock()
+			delete(w.pending, finalPath)
+			w.pendingMut.Unlock()
+		}
+		return err
+	}
+	delete(w.segments, seg.path)
+	w.log.Debugf("Closed file '%v'", finalPath)
+
+	if !compress && w.conf.retainSegments == 0 && w.conf.retainAge == 0 {
+		return nil
+	}
+	w.finalizeWG.Add(1)
+	go func() {
+		defer w.finalizeWG.Done()
+
+		w.finalizeMut.Lock()
+		defer w.finalizeMut.Unlock()
+
+		err := w.compressSegment(finalPath)
+		w.pendingMut.Lock()
+		delete(w.pending, finalPath)
+		w.pendingMut.Unlock()
+		if err != nil {
+			w.log.Errorf("Failed to compress file '%v': %v", finalPath, err)
+		}
+		if err := w.pruneSegments(seg.path); err != nil {
+			w.log.Errorf("Failed to prune files of '%v': %v", seg.path, err)
+		}
+	}()
+	return nil
+}
+
+func (w *rollingFileWriter) compressSegment(path string) error {
+	var ext string
+	var newWriter func(io.Writer) (io.WriteCloser, error)
+	switch w.conf.compression {
+	case "gzip":
+		ext = ".gz"
+		newWriter = func(wtr io.Writer) (io.WriteCloser, error) {
+			return gzip.NewWriter(wtr), nil
+		}
+	case "zstd":
+		ext = ".zst"
+		newWriter = func(wtr io.Writer) (io.WriteCloser, error) {
+			return zstd.NewWriter(wtr)
+		}
+	default:
+		return nil
+	}
+
+	src, err := w.nm.FS().Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmpPath := path + ext + ".tmp"
+	file, err := w.nm.FS().OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fs.FileMode(0o666))
+	if err != nil {
+		return err
+	}
+	dst, ok := file.(io.WriteCloser)
+	if !ok {
+		_ = file.Close()
+		return errors.New("failed to open file for writing")
+	}
+
+	cWtr, err := newWriter(dst)
+	if err == nil {
+		_, err = io.Copy(cWtr, src)
+		if cErr := cWtr.Close(); err == nil {
+			err = cErr
+		}
+	}
+	if cErr := dst.Close(); err == nil {
+		err = cErr
+	}
+	if err == nil {
+		err = w.nm.FS().Rename(tmpPath, path+ext)
+	}
+	if err != nil {
+		_ = w.nm.FS().Remove(tmpPath)
+		return err
+	}
+	return w.nm.FS().Remove(path)
+}
+
+// pruneSegments deletes the closed segments of a path that exceed the retention
+// limits.
+func (w *rollingFileWriter) pruneSegments(path string) error {
+	if w.conf.retainSegments == 0 && w.conf.retainAge == 0 {
+		return nil
+	}
+
+	prefix, ext := splitSegmentPath(path)
+	entries, err := fs.ReadDir(w.nm.FS(), filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	type closedSegment struct {
+		path     string
+		openedAt time.Time
+	}
+	var closed []closedSegment
+	for _, e := range entries {
+		name := filepath.Join(filepath.Dir(path), e.Name())
+		if e.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(name, prefix)
+		if len(rest) < len(fileSegmentTimeLayout) {
+			continue
+		}
+		openedAt, err := time.Parse(fileSegmentTimeLayout, rest[:len(fileSegmentTimeLayout)])
+		if err != nil {
+			continue
+		}
+		rest = strings.TrimLeft(strings.TrimPrefix(rest[len(fileSegmentTimeLayout):], "-"), "0123456789")
+		switch rest {
+		case ext, ext + ".gz", ext + ".zst":
+			closed = append(closed, closedSegment{path: name, openedAt: openedAt})
+		}
+	}
+	sort.Slice(closed, func(i, j int) bool {
+		return closed[i].path < closed[j].path
+	})
+
+	w.pendingMut.Lock()
+	defer w.pendingMut.Unlock()
+
+	var errs []error
+	for i, s := range closed {
+		expired := w.conf.retainAge > 0 && time.Since(s.openedAt) > w.conf.retainAge
+		excess := w.conf.retainSegments > 0 && i < len(closed)-w.conf.retainSegments
+		if !expired && !excess {
+			continue
+		}
+		if _, pending := w.pending[s.path]; pending {
+			// The segment is pruned once it has been compressed.
+			continue
+		}
+		if err := w.nm.FS().Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (w *rollingFileWriter) Write(ctx context.Context, msg *service.Message) error {
+	path, err := w.path.TryString(msg)
+	if err != nil {
+		return fmt.Errorf("path interpolation error: %w", err)
+	}
+	path = filepath.Clean(path)
+
+	mBytes, err := msg.AsBytes()
+	if err != nil {
+		return err
+	}
+	suffix, addSuffix := w.suffixFn(mBytes)
+	if !addSuffix {
+		suffix = nil
+	}
+	size := int64(len(mBytes) + len(suffix))
+
+	w.segmentsMut.Lock()
+	defer w.segmentsMut.Unlock()
+
+	seg := w.segments[path]
+	if seg != nil && (seg.handle == nil || w.expired(seg) || (w.conf.maxBytes > 0 && seg.bytes > 0 && seg.bytes+size > w.conf.maxBytes)) {
+		if err := w.closeSegment(seg); err != nil {
+			return err
+		}
+		seg = nil
+	}
+	if seg == nil {
+		if seg, err = w.openSegment(path); err != nil {
+			return err
+		}
+		w.segments[path] = seg
+	}
+
+	if _, err := seg.handle.Write(mBytes); err != nil {
+		return err
+	}
+	if len(suffix) > 0 {
+		if _, err := seg.handle.Write(suffix); err != nil {
+			return err
+		}
+	}
+	seg.bytes += size
+	seg.messages++
+	seg.lastWrite = time.Now()
+
+	if (w.conf.maxMessages > 0 && seg.messages >= w.conf.maxMessages) ||
+		(w.conf.maxBytes > 0 && seg.bytes >= w.conf.maxBytes) {
+		// The message has already been written, and therefore a failure to
+		// close the segment is logged rather than rejecting it, and the
+		// segment remains tracked such that closing it is retried.
+		if err := w.closeSegment(seg); err != nil {
+			w.log.Errorf("Failed to close file '%v': %v", seg.tmpPath(), err)
+		}
+	}
+	return nil
+}
+
+func (w *rollingFileWriter) Close(ctx context.Context) error {
+	w.segmentsMut.Lock()
+	connected := w.connected
+	w.segmentsMut.Unlock()
+
+	if connected {
+		w.shutSig.TriggerSoftStop()
+		select {
+		case <-w.shutSig.HasStoppedChan():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	w.segmentsMut.Lock()
+	var errs []error
+	for _, seg := range w.segments {
+		if err := w.closeSegment(seg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	w.segmentsMut.Unlock()
+
+	finalized := make(chan struct{})
+	go func() {
+		w.finalizeWG.Wait()
+		close(finalized)
+	}()
+	select {
+	case <-finalized:
+	case <-ctx.Done():
+		errs = append(errs, ctx.Err())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,242 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package io
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func newTestRollingFileWriter(t *testing.T, path string, conf fileRotationConfig) *rollingFileWriter {
+	t.Helper()
+
+	pathStr, err := service.NewInterpolatedString(path)
+	require.NoError(t, err)
+
+	if conf.compression == "" {
+		conf.compression = "none"
+	}
+	w, err := newRollingFileWriter(pathStr, "lines", conf, service.MockResources())
+	require.NoError(t, err)
+	require.NoError(t, w.Connect(t.Context()))
+	return w
+}
+
+func readSegments(t *testing.T, dir string) map[string]string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	segments := map[string]string{}
+	for _, e := range entries {
+		b, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		require.NoError(t, err)
+		if strings.HasSuffix(e.Name(), ".gz") {
+			r, err := gzip.NewReader(bytes.NewReader(b))
+			require.NoError(t, err)
+			b, err = io.ReadAll(r)
+			require.NoError(t, err)
+		}
+		segments[e.Name()] = string(b)
+	}
+	return segments
+}
+
+func sortedValues(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	values := make([]string, 0, len(m))
+	for _, k := range keys {
+		values = append(values, m[k])
+	}
+	return values
+}
+
+func TestRollingFileWriterMaxMessages(t *testing.T) {
+	dir := t.TempDir()
+	w := newTestRollingFileWriter(t, filepath.Join(dir, "data.txt"), fileRotationConfig{maxMessages: 2})
+
+	for _, msg := range []string{"a", "b", "c", "d", "e"} {
+		require.NoError(t, w.Write(t.Context(), service.NewMessage([]byte(msg))))
+	}
+
+	// The open segment is written to a temporary file.
+	segments := readSegments(t, dir)
+	assert.Equal(t, "e\n", segments["data.txt.tmp"])
+	assert.Len(t, segments, 3)
+
+	require.NoError(t, w.Close(t.Context()))
+
+	segments = readSegments(t, dir)
+	for name := range segments {
+		assert.Regexp(t, `^data-\d{8}T\d{6}\.\d{9}(-\d+)?\.txt$`, name)
+	}
+	assert.Equal(t, []string{"a\nb\n", "c\nd\n", "e\n"}, sortedValues(segments))
+}
+
+func TestRollingFileWriterMaxBytesCompressionRetention(t *testing.T) {
+	dir := t.TempDir()
+	w := newTestRollingFileWriter(t, filepath.Join(dir, "data.txt"), fileRotationConfig{
+		maxBytes:       6,
+		compression:    "gzip",
+		retainSegments: 2,
+	})
+
+	for _, msg := range []string{"aa", "bb", "cc", "dddddddd", "ee", "ff"} {
+		require.NoError(t, w.Write(t.Context(), service.NewMessage([]byte(msg))))
+	}
+	require.NoError(t, w.Close(t.Context()))
+
+	segments := readSegments(t, dir)
+	for name := range segments {
+		assert.True(t, strings.HasSuffix(name, ".txt.gz"), name)
+	}
+	assert.Equal(t, []string{"dddddddd\n", "ee\nff\n"}, sortedValues(segments))
+}
+
+func TestRollingFileWriterIdleTimeout(t *testing.T) {
+	dir := t.TempDir()
+	w := newTestRollingFileWriter(t, filepath.Join(dir, `${! content() }`, "data.txt"), fileRotationConfig{
+		idleTimeout: time.Millisecond * 50,
+	})
+
+	for _, msg := range []string{"a", "b", "a"} {
+		require.NoError(t, w.Write(t.Context(), service.NewMessage([]byte(msg))))
+	}
+
+	assert.Eventually(t, func() bool {
+		w.segmentsMut.Lock()
+		defer w.segmentsMut.Unlock()
+		return len(w.segments) == 0
+	}, time.Second*5, time.Millisecond*10)
+
+	assert.Equal(t, []string{"a\na\n"}, sortedValues(readSegments(t, filepath.Join(dir, "a"))))
+	assert.Equal(t, []string{"b\n"}, sortedValues(readSegments(t, filepath.Join(dir, "b"))))
+
+	require.NoError(t, w.Close(t.Context()))
+}
+
+func TestRollingFileWriterResumesSegment(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "data.txt.tmp"), []byte("a\n"), 0o644))
+
+	w := newTestRollingFileWriter(t, filepath.Join(dir, "data.txt"), fileRotationConfig{maxBytes: 4})
+	require.NoError(t, w.Write(t.Context(), service.NewMessage([]byte("b"))))
+	require.NoError(t, w.Write(t.Context(), service.NewMessage([]byte("c"))))
+	require.NoError(t, w.Close(t.Context()))
+
+	assert.Equal(t, []string{"a\nb\n", "c\n"}, sortedValues(readSegments(t, dir)))
+}
+
+type failingCloseHandle struct {
+	io.WriteCloser
+}
+
+func (h failingCloseHandle) Close() error {
+	_ = h.WriteCloser.Close()
+	return errors.New("nope")
+}
+
+func TestRollingFileWriterRetriesFailedClose(t *testing.T) {
+	dir := t.TempDir()
+	w := newTestRollingFileWriter(t, filepath.Join(dir, "data.txt"), fileRotationConfig{})
+	require.NoError(t, w.Write(t.Context(), service.NewMessage([]byte("a"))))
+
+	w.segmentsMut.Lock()
+	seg := w.segments[filepath.Join(dir, "data.txt")]
+	seg.handle = failingCloseHandle{seg.handle}
+	w.segmentsMut.Unlock()
+
+	require.ErrorContains(t, w.Close(t.Context()), "nope")
+	assert.Equal(t, map[string]string{"data.txt.tmp": "a\n"}, readSegments(t, dir))
+
+	// The segment is renamed once it is closed again.
+	require.NoError(t, w.Close(t.Context()))
+	segments := readSegments(t, dir)
+	assert.NotContains(t, segments, "data.txt.tmp")
+	assert.Equal(t, []string{"a\n"}, sortedValues(segments))
+}
+
+func TestRollingFileWriterFailedRotationAcksMessage(t *testing.T) {
+	dir := t.TempDir()
+	w := newTestRollingFileWriter(t, filepath.Join(dir, "data.txt"), fileRotationConfig{maxMessages: 2})
+	require.NoError(t, w.Write(t.Context(), service.NewMessage([]byte("a"))))
+
+	w.segmentsMut.Lock()
+	seg := w.segments[filepath.Join(dir, "data.txt")]
+	seg.handle = failingCloseHandle{seg.handle}
+	w.segmentsMut.Unlock()
+
+	// The message that triggers the rotation was written and is therefore not
+	// rejected when the segment fails to close.
+	require.NoError(t, w.Write(t.Context(), service.NewMessage([]byte("b"))))
+	assert.Equal(t, map[string]string{"data.txt.tmp": "a\nb\n"}, readSegments(t, dir))
+
+	// Closing the segment is retried by the next write.
+	require.NoError(t, w.Write(t.Context(), service.NewMessage([]byte("c"))))
+	require.NoError(t, w.Close(t.Context()))
+	assert.Equal(t, []string{"a\nb\n", "c\n"}, sortedValues(readSegments(t, dir)))
+}
+
+func TestRollingFileWriterPruneSkipsPending(t *testing.T) {
+	dir := t.TempDir()
+	w := newTestRollingFileWriter(t, filepath.Join(dir, "data.txt"), fileRotationConfig{
+		compression:    "gzip",
+		retainSegments: 1,
+	})
+
+	older := filepath.Join(dir, "data-20250102T150405.000000000.txt")
+	newer := filepath.Join(dir, "data-20250102T150406.000000000.txt.gz")
+	require.NoError(t, os.WriteFile(older, nil, 0o644))
+	require.NoError(t, os.WriteFile(newer, nil, 0o644))
+
+	// Segments that are yet to be compressed are left in place.
+	w.pending[older] = struct{}{}
+	require.NoError(t, w.pruneSegments(filepath.Join(dir, "data.txt")))
+	assert.Equal(t, []string{filepath.Base(older), filepath.Base(newer)}, segmentNames(t, dir))
+
+	delete(w.pending, older)
+	require.NoError(t, w.pruneSegments(filepath.Join(dir, "data.txt")))
+	assert.Equal(t, []string{filepath.Base(newer)}, segmentNames(t, dir))
+
+	require.NoError(t, w.Close(t.Context()))
+}
+
+func segmentNames(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestRollingFileWriterRejectsNonAppendCodec(t *testing.T) {
+	pathStr, err := service.NewInterpolatedString("/tmp/foo.txt")
+	require.NoError(t, err)
+
+	_, err = newRollingFileWriter(pathStr, "all-bytes", fileRotationConfig{}, service.MockResources())
+	require.Error(t, err)
+}
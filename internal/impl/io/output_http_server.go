@@ -55,7 +55,10 @@ type hsoConfig struct {
 	Timeout      time.Duration
 	CertFile     string
 	KeyFile      string
+	ClientCAFile string
 	CORS         httpserver.CORSConfig
+	JWTAuth      httpserver.JWTAuthConfig
+	SSE          hsoSSEConfig
 }
 
 func hsoConfigFromParsed(pConf *service.ParsedConfig) (conf hsoConfig, err error) {
@@ -94,9 +97,18 @@ func hsoConfigFromParsed(pConf *service.ParsedConfig) (conf hsoConfig, err error
 	if conf.KeyFile, err = pConf.FieldString(hsoFieldKeyFile); err != nil {
 		return
 	}
+	if conf.ClientCAFile, err = pConf.FieldString(hsFieldClientCAFile); err != nil {
+		return
+	}
 	if conf.CORS, err = corsConfigFromParsed(pConf.Namespace(hsoFieldCORS)); err != nil {
 		return
 	}
+	if conf.JWTAuth, err = jwtAuthConfigFromParsed(pConf.Namespace(hsFieldJWTAuth)); err != nil {
+		return
+	}
+	if conf.SSE, err = hsoSSEConfigFromParsed(pConf.Namespace(hsoFieldSSE)); err != nil {
+		return
+	}
 	return
 }
 
@@ -116,6 +128,12 @@ When messages are batched the `+"`path`"+` endpoint encodes the batch according
 
 Please note, messages are considered delivered as soon as the data is written to the client. There is no concept of at least once delivery on this output.
 
+== Server-sent events
+
+When `+"`sse.enabled`"+` is `+"`true`"+` the output instead serves messages as server-sent events from the SSE `+"`path`"+`, where each message is broadcast to all connected clients as an event with an optional type and ID. Comments are sent periodically in order to keep idle connections open, and a bounded buffer of recent events is kept so that clients reconnecting with a `+"`Last-Event-ID`"+` header receive the events they missed. Each client has a queue of events, and the `+"`backpressure`"+` policy determines whether a client that is too slow to consume its queue blocks delivery, misses events or is disconnected. Messages are considered delivered once they are queued for all connected clients, and are therefore only received by clients that are connected, or that reconnect before the messages leave the replay buffer.
+
+Requests to a custom `+"`address`"+` can be authenticated with bearer JSON Web Tokens by enabling `+"`jwt_auth`"+`, and with client certificates by specifying a `+"`client_ca_file`"+` along with the `+"`cert_file`"+` and `+"`key_file`"+` used to enable TLS. Requests to the xref:components:http/about.adoc[service-wide HTTP server] are authenticated according to its own `+"`basic_auth`"+`, `+"`jwt_auth`"+` and `+"`client_ca_file`"+` fields.
+
 `+api.EndpointCaveats()+`
 `).
 		Fields(
@@ -146,8 +164,25 @@ Please note, messages are considered delivered as soon as the data is written to
 				Description("Enable TLS by specifying a certificate and key file. Only valid with a custom `address`.").
 				Advanced().
 				Default(""),
+			hsClientCAFileField(),
 			service.NewInternalField(corsSpec),
-		)
+			hsJWTAuthField(),
+			hsoSSEField(),
+		).
+		Example(
+			"Live Dashboard",
+			"This example shows an `http_server` output that broadcasts messages as server-sent events to browsers, where the event type of each message is taken from its topic and a browser that loses its connection is able to resume from the last event it received:", `
+output:
+  http_server:
+    allowed_verbs: [ GET ]
+    sse:
+      enabled: true
+      path: /events
+      event: ${! @kafka_topic }
+      id: ${! @kafka_partition }-${! @kafka_offset }
+      replay_buffer_size: 1000
+      backpressure: disconnect
+`)
 }
 
 func init() {
@@ -197,6 +232,12 @@ type httpServerOutput struct {
 	mStreamBatchSent metrics.StatCounter
 	mStreamError     metrics.StatCounter
 
+	sse              *sseHub
+	mSSESent         metrics.StatCounter
+	mSSEBatchSent    metrics.StatCounter
+	mSSEError        metrics.StatCounter
+	mSSEBackpressure metrics.StatCounterVec
+
 	closeServerOnce sync.Once
 	shutSig         *shutdown.Signaller
 }
@@ -209,9 +250,15 @@ func newHTTPServerOutput(conf hsoConfig, mgr bundle.NewManagement) (output.Strea
 	if conf.Address != "" {
 		gMux = mux.NewRouter()
 		server = &http.Server{Addr: conf.Address}
-		if server.Handler, err = conf.CORS.WrapHandler(gMux); err != nil {
+		var handler http.Handler
+		if handler, err = hsServerAuth(server, gMux, conf.CertFile, conf.ClientCAFile, conf.JWTAuth, mgr); err != nil {
+			return nil, err
+		}
+		if server.Handler, err = conf.CORS.WrapHandler(handler); err != nil {
 			return nil, fmt.Errorf("bad CORS configuration: %w", err)
 		}
+	} else if conf.ClientCAFile != "" || conf.JWTAuth.Enabled {
+		return nil, errHSAuthRequiresAddress
 	}
 
 	stats := mgr.Metrics()
@@ -237,9 +284,25 @@ func newHTTPServerOutput(conf hsoConfig, mgr bundle.NewManagement) (output.Strea
 		mStreamSent:      mSent,
 		mStreamBatchSent: mBatchSent,
 		mStreamError:     mError,
+
+		mSSESent:         mSent,
+		mSSEBatchSent:    mBatchSent,
+		mSSEError:        mError,
+		mSSEBackpressure: stats.GetCounterVec("http_server_sse_backpressure", "action"),
 	}
 
-	if gMux != nil {
+	if h.conf.SSE.Enabled {
+		h.sse = newSSEHub(h.conf.SSE.ReplayBufferSize, h.conf.SSE.ClientBufferSize)
+		if gMux != nil {
+			api.GetMuxRoute(gMux, h.conf.SSE.Path).HandlerFunc(h.sseHandler)
+		} else {
+			mgr.RegisterEndpoint(
+				h.conf.SSE.Path,
+				"Read messages from Benthos as server-sent events.",
+				h.sseHandler,
+			)
+		}
+	} else if gMux != nil {
 		if h.conf.Path != "" {
 			api.GetMuxRoute(gMux, h.conf.Path).HandlerFunc(h.getHandler)
 		}
@@ -445,6 +508,10 @@ func (h *httpServerOutput) Consume(ts <-chan message.Transaction) error {
 	}
 	h.transactions = ts
 
+	if h.sse != nil {
+		go h.sseLoop()
+	}
+
 	if h.server != nil {
 		go func() {
 			if h.conf.KeyFile != "" || h.conf.CertFile != "" {
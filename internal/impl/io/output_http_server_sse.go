@@ -0,0 +1,396 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package io
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redpanda-data/benthos/v4/internal/batch"
+	"github.com/redpanda-data/benthos/v4/internal/message"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	hsoFieldSSE                  = "sse"
+	hsoFieldSSEEnabled           = "enabled"
+	hsoFieldSSEPath              = "path"
+	hsoFieldSSEEvent             = "event"
+	hsoFieldSSEID                = "id"
+	hsoFieldSSEHeartbeatInterval = "heartbeat_interval"
+	hsoFieldSSEReplayBufferSize  = "replay_buffer_size"
+	hsoFieldSSEClientBufferSize  = "client_buffer_size"
+	hsoFieldSSEBackpressure      = "backpressure"
+)
+
+func hsoSSEField() *service.ConfigField {
+	return service.NewObjectField(hsoFieldSSE,
+		service.NewBoolField(hsoFieldSSEEnabled).
+			Description("Whether to serve messages as server-sent events. When enabled messages are broadcast to all clients of the SSE `path` and the `path`, `stream_path` and `ws_path` endpoints are not registered.").
+			Default(false),
+		service.NewStringField(hsoFieldSSEPath).
+			Description("The path from which server-sent events can be consumed.").
+			Default("/get/sse"),
+		service.NewInterpolatedStringField(hsoFieldSSEEvent).
+			Description("An optional event type to set for each message. When empty the field is omitted and clients receive events of the default type `message`.").
+			Examples(`${! @kafka_topic }`, `${! this.type }`).
+			Default(""),
+		service.NewInterpolatedStringField(hsoFieldSSEID).
+			Description("An optional ID to set for each message, which clients send within the `Last-Event-ID` header when they reconnect. When empty a sequence number is used.").
+			Examples(`${! @kafka_offset }`, `${! this.id }`).
+			Default(""),
+		service.NewDurationField(hsoFieldSSEHeartbeatInterval).
+			Description("The period of time between comments sent to idle clients in order to keep connections open. Set to zero to disable.").
+			Default("15s"),
+		service.NewIntField(hsoFieldSSEReplayBufferSize).
+			Description("The number of recent events to keep in memory in order to replay them to clients that reconnect with a `Last-Event-ID` header. Set to zero to disable.").
+			Default(100),
+		service.NewIntField(hsoFieldSSEClientBufferSize).
+			Description("The number of events that can be queued for each client before the `backpressure` policy applies.").
+			Default(100),
+		service.NewStringAnnotatedEnumField(hsoFieldSSEBackpressure, map[string]string{
+			"block":      "Wait for the client to consume queued events, which slows the delivery of messages to all clients.",
+			"drop":       "Drop events that do not fit within the queue of the client.",
+			"disconnect": "Disconnect the client, which can resume from where it left off by reconnecting with a `Last-Event-ID` header.",
+		}).
+			Description("The policy applied to clients that are too slow to consume events.").
+			Default("block"),
+	).
+		Description("Serve messages as https://html.spec.whatwg.org/multipage/server-sent-events.html[server-sent events^], which can be consumed by browsers with an `EventSource`.").
+		Advanced()
+}
+
+type hsoSSEConfig struct {
+	Enabled           bool
+	Path              string
+	Event             *service.InterpolatedString
+	ID                *service.InterpolatedString
+	HeartbeatInterval time.Duration
+	ReplayBufferSize  int
+	ClientBufferSize  int
+	Backpressure      string
+}
+
+func hsoSSEConfigFromParsed(pConf *service.ParsedConfig) (conf hsoSSEConfig, err error) {
+	if conf.Enabled, err = pConf.FieldBool(hsoFieldSSEEnabled); err != nil || !conf.Enabled {
+		return
+	}
+	if conf.Path, err = pConf.FieldString(hsoFieldSSEPath); err != nil {
+		return
+	}
+	if conf.Path == "" {
+		err = errors.New("an SSE path must be specified")
+		return
+	}
+	if conf.Event, err = pConf.FieldInterpolatedString(hsoFieldSSEEvent); err != nil {
+		return
+	}
+	if conf.ID, err = pConf.FieldInterpolatedString(hsoFieldSSEID); err != nil {
+		return
+	}
+	if conf.HeartbeatInterval, err = pConf.FieldDuration(hsoFieldSSEHeartbeatInterval); err != nil {
+		return
+	}
+	if conf.ReplayBufferSize, err = pConf.FieldInt(hsoFieldSSEReplayBufferSize); err != nil {
+		return
+	}
+	if conf.ClientBufferSize, err = pConf.FieldInt(hsoFieldSSEClientBufferSize); err != nil {
+		return
+	}
+	if conf.ReplayBufferSize < 0 {
+		err = fmt.Errorf("%v must not be negative", hsoFieldSSEReplayBufferSize)
+		return
+	}
+	if conf.ClientBufferSize < 1 {
+		err = fmt.Errorf("%v must be greater than zero", hsoFieldSSEClientBufferSize)
+		return
+	}
+	if conf.Backpressure, err = pConf.FieldString(hsoFieldSSEBackpressure); err != nil {
+		return
+	}
+	return
+}
+
+//------------------------------------------------------------------------------
+
+type sseEvent struct {
+	id   string
+	wire []byte
+}
+
+// newSSEEvent encodes an event in the text/event-stream format, where each line
+// of the payload is written as a separate data field.
+func newSSEEvent(id, event string, data []byte) sseEvent {
+	// Line breaks within fields other than data would terminate the field.
+	fieldReplacer := strings.NewReplacer("\r", "", "\n", "")
+	id, event = fieldReplacer.Replace(id), fieldReplacer.Replace(event)
+
+	var buf bytes.Buffer
+	buf.WriteString("id: " + id + "\n")
+	if event != "" {
+		buf.WriteString("event: " + event + "\n")
+	}
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	data = bytes.ReplaceAll(data, []byte("\r"), []byte("\n"))
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	return sseEvent{id: id, wire: buf.Bytes()}
+}
+
+type sseClient struct {
+	events   chan sseEvent
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func (c *sseClient) close() {
+	c.doneOnce.Do(func() {
+		close(c.done)
+	})
+}
+
+// sseHub broadcasts events to all connected clients, keeping a bounded buffer
+// of recent events that are replayed to clients resuming from an earlier
+// event.
+type sseHub struct {
+	mut     sync.Mutex
+	clients map[*sseClient]struct{}
+	replay  []sseEvent
+	seq     uint64
+
+	replaySize int
+	clientSize int
+}
+
+func newSSEHub(replaySize, clientSize int) *sseHub {
+	return &sseHub{
+		clients:    map[*sseClient]struct{}{},
+		replaySize: replaySize,
+		clientSize: clientSize,
+	}
+}
+
+func (s *sseHub) nextSeq() string {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.seq++
+	return strconv.FormatUint(s.seq, 10)
+}
+
+// subscribe registers a new client along with the events it has missed, which
+// are all buffered events after the event with the last ID, or all buffered
+// events if that event is no longer buffered.
+func (s *sseHub) subscribe(lastID string) (*sseClient, []sseEvent) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	var backlog []sseEvent
+	if lastID != "" {
+		backlog = s.replay
+		for i := len(s.replay) - 1; i >= 0; i-- {
+			if s.replay[i].id == lastID {
+				backlog = s.replay[i+1:]
+				break
+			}
+		}
+		backlog = append([]sseEvent(nil), backlog...)
+	}
+
+	c := &sseClient{
+		events: make(chan sseEvent, s.clientSize),
+		done:   make(chan struct{}),
+	}
+	s.clients[c] = struct{}{}
+	return c, backlog
+}
+
+func (s *sseHub) unsubscribe(c *sseClient) {
+	s.mut.Lock()
+	delete(s.clients, c)
+	s.mut.Unlock()
+	c.close()
+}
+
+// publish adds events to the replay buffer and queues them for all connected
+// clients according to a backpressure policy, the function onFull is called
+// whenever an event does not fit within the queue of a client and returns
+// true if the client was disconnected.
+func (s *sseHub) publish(ctx context.Context, events []sseEvent, policy string, onFull func(c *sseClient) bool) error {
+	s.mut.Lock()
+	if s.replaySize > 0 {
+		s.replay = append(s.replay, events...)
+		if excess := len(s.replay) - s.replaySize; excess > 0 {
+			s.replay = append([]sseEvent(nil), s.replay[excess:]...)
+		}
+	}
+	clients := make([]*sseClient, 0, len(s.clients))
+	for c := range s.clients {
+		clients = append(clients, c)
+	}
+	s.mut.Unlock()
+
+	for _, c := range clients {
+	eventsLoop:
+		for _, e := range events {
+			if policy == "block" {
+				select {
+				case c.events <- e:
+				case <-c.done:
+					break eventsLoop
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				continue
+			}
+			select {
+			case c.events <- e:
+			case <-c.done:
+				break eventsLoop
+			default:
+				if onFull(c) {
+					break eventsLoop
+				}
+			}
+		}
+	}
+	return nil
+}
+
+//------------------------------------------------------------------------------
+
+func (h *httpServerOutput) sseEvents(b message.Batch) ([]sseEvent, error) {
+	svcBatch := make(service.MessageBatch, len(b))
+	for i, p := range b {
+		svcBatch[i] = service.NewInternalMessage(p)
+	}
+
+	events := make([]sseEvent, len(b))
+	for i, p := range b {
+		id, err := svcBatch.TryInterpolatedString(i, h.conf.SSE.ID)
+		if err != nil {
+			return nil, fmt.Errorf("event id interpolation error: %w", err)
+		}
+		if id == "" {
+			id = h.sse.nextSeq()
+		}
+		event, err := svcBatch.TryInterpolatedString(i, h.conf.SSE.Event)
+		if err != nil {
+			return nil, fmt.Errorf("event type interpolation error: %w", err)
+		}
+		events[i] = newSSEEvent(id, event, p.AsBytes())
+	}
+	return events, nil
+}
+
+func (h *httpServerOutput) sseLoop() {
+	ctx, done := h.shutSig.HardStopCtx(context.Background())
+	defer done()
+
+	onFull := func(c *sseClient) bool {
+		if h.conf.SSE.Backpressure == "disconnect" {
+			h.mSSEBackpressure.With("disconnected").Incr(1)
+			c.close()
+			return true
+		}
+		h.mSSEBackpressure.With("dropped").Incr(1)
+		return false
+	}
+
+	for {
+		var ts message.Transaction
+		var open bool
+
+		select {
+		case ts, open = <-h.transactions:
+			if !open {
+				go h.TriggerCloseNow()
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+
+		events, err := h.sseEvents(ts.Payload)
+		if err == nil {
+			err = h.sse.publish(ctx, events, h.conf.SSE.Backpressure, onFull)
+		}
+		_ = ts.Ack(ctx, err)
+		if err != nil {
+			h.mSSEError.Incr(1)
+			h.log.Error("Failed to send server-sent events: %v\n", err)
+			continue
+		}
+		h.mSSESent.Incr(int64(batch.MessageCollapsedCount(ts.Payload)))
+		h.mSSEBatchSent.Incr(1)
+	}
+}
+
+func (h *httpServerOutput) sseHandler(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Server error", http.StatusInternalServerError)
+		h.log.Error("Failed to cast response writer to flusher")
+		return
+	}
+
+	if _, exists := h.conf.AllowedVerbs[r.Method]; !exists {
+		http.Error(w, "Incorrect method", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.shutSig.IsSoftStopSignalled() {
+		http.Error(w, "Server closed", http.StatusServiceUnavailable)
+		return
+	}
+
+	c, backlog := h.sse.subscribe(r.Header.Get("Last-Event-ID"))
+	defer h.sse.unsubscribe(c)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	for _, e := range backlog {
+		if _, err := w.Write(e.wire); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	var heartbeat <-chan time.Time
+	if h.conf.SSE.HeartbeatInterval > 0 {
+		ticker := time.NewTicker(h.conf.SSE.HeartbeatInterval)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+
+	for {
+		var data []byte
+		select {
+		case e := <-c.events:
+			data = e.wire
+		case <-heartbeat:
+			data = []byte(": heartbeat\n\n")
+		case <-c.done:
+			return
+		case <-r.Context().Done():
+			return
+		case <-h.shutSig.SoftStopChan():
+			return
+		}
+		if _, err := w.Write(data); err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
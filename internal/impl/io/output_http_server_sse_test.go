@@ -0,0 +1,94 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package io
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSSEEventEncoding(t *testing.T) {
+	assert.Equal(t, "id: 1\ndata: foo\n\n", string(newSSEEvent("1", "", []byte("foo")).wire))
+	assert.Equal(t, "id: 2\nevent: bar\ndata: foo\ndata: \ndata: baz\n\n", string(newSSEEvent("2", "bar", []byte("foo\r\n\nbaz")).wire))
+	assert.Equal(t, "id: 34\nevent: ab\ndata: \n\n", string(newSSEEvent("3\n4", "a\rb", nil).wire))
+}
+
+func sseEventIDs(events []sseEvent) (ids []string) {
+	for _, e := range events {
+		ids = append(ids, e.id)
+	}
+	return
+}
+
+func TestSSEHubReplay(t *testing.T) {
+	hub := newSSEHub(3, 10)
+	ctx := t.Context()
+
+	for _, id := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, hub.publish(ctx, []sseEvent{newSSEEvent(id, "", nil)}, "block", nil))
+	}
+
+	for lastID, exp := range map[string][]string{
+		"":  nil,
+		"b": {"c", "d"},
+		"d": nil,
+		"a": {"b", "c", "d"},
+		"z": {"b", "c", "d"},
+	} {
+		c, backlog := hub.subscribe(lastID)
+		assert.Equal(t, exp, sseEventIDs(backlog), lastID)
+		hub.unsubscribe(c)
+	}
+}
+
+func TestSSEHubBackpressure(t *testing.T) {
+	events := []sseEvent{
+		newSSEEvent("a", "", nil),
+		newSSEEvent("b", "", nil),
+		newSSEEvent("c", "", nil),
+	}
+
+	t.Run("drop", func(t *testing.T) {
+		hub := newSSEHub(0, 2)
+		c, _ := hub.subscribe("")
+
+		var dropped int
+		require.NoError(t, hub.publish(t.Context(), events, "drop", func(*sseClient) bool {
+			dropped++
+			return false
+		}))
+		assert.Equal(t, 1, dropped)
+		assert.Equal(t, "a", (<-c.events).id)
+		assert.Equal(t, "b", (<-c.events).id)
+	})
+
+	t.Run("disconnect", func(t *testing.T) {
+		hub := newSSEHub(0, 2)
+		c, _ := hub.subscribe("")
+
+		require.NoError(t, hub.publish(t.Context(), events, "disconnect", func(c *sseClient) bool {
+			c.close()
+			return true
+		}))
+		select {
+		case <-c.done:
+		default:
+			t.Fatal("expected client to be disconnected")
+		}
+	})
+
+	t.Run("block", func(t *testing.T) {
+		hub := newSSEHub(0, 2)
+		c, _ := hub.subscribe("")
+
+		ctx, done := context.WithCancel(t.Context())
+		done()
+		require.ErrorIs(t, hub.publish(ctx, events, "block", nil), context.Canceled)
+
+		hub.unsubscribe(c)
+		require.NoError(t, hub.publish(t.Context(), events, "block", nil))
+	})
+}
@@ -3,12 +3,15 @@
 package io_test
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/redpanda-data/benthos/v4/internal/component/output"
@@ -161,3 +164,95 @@ http_server:
 	h.TriggerCloseNow()
 	require.NoError(t, h.WaitForClose(ctx))
 }
+
+func TestHTTPServerOutputSSE(t *testing.T) {
+	ctx, done := context.WithTimeout(t.Context(), time.Second*30)
+	defer done()
+
+	port := getFreePort(t)
+	conf := parseYAMLOutputConf(t, `
+http_server:
+  address: localhost:%v
+  sse:
+    enabled: true
+    path: /events
+    event: ${! meta("type").or("") }
+    heartbeat_interval: 50ms
+`, port)
+
+	h, err := mock.NewManager().NewOutput(conf)
+	require.NoError(t, err)
+
+	msgChan := make(chan message.Transaction)
+	require.NoError(t, h.Consume(msgChan))
+
+	connect := func(lastID string) *bufio.Reader {
+		t.Helper()
+		var res *http.Response
+		require.Eventually(t, func() bool {
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://localhost:%v/events", port), http.NoBody)
+			require.NoError(t, err)
+			if lastID != "" {
+				req.Header.Set("Last-Event-ID", lastID)
+			}
+			res, err = http.DefaultClient.Do(req)
+			return err == nil
+		}, time.Second*5, time.Millisecond*50)
+		t.Cleanup(func() {
+			res.Body.Close()
+		})
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+		return bufio.NewReader(res.Body)
+	}
+
+	// Reads the next event, skipping heartbeat comments.
+	readEvent := func(r *bufio.Reader) string {
+		t.Helper()
+		var event string
+		for {
+			line, err := r.ReadString('\n')
+			require.NoError(t, err)
+			if strings.HasPrefix(line, ":") {
+				continue
+			}
+			if line == "\n" {
+				if event != "" {
+					return event
+				}
+				continue
+			}
+			event += line
+		}
+	}
+
+	clientA := connect("")
+
+	fooPart := message.NewPart([]byte("foo"))
+	fooPart.MetaSetMut("type", "a")
+	resChan := make(chan error)
+	select {
+	case msgChan <- message.NewTransaction(message.Batch{fooPart, message.NewPart([]byte("bar\nbaz"))}, resChan):
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out")
+	}
+	require.NoError(t, <-resChan)
+
+	assert.Equal(t, "id: 1\nevent: a\ndata: foo\n", readEvent(clientA))
+	assert.Equal(t, "id: 2\ndata: bar\ndata: baz\n", readEvent(clientA))
+
+	clientB := connect("1")
+	assert.Equal(t, "id: 2\ndata: bar\ndata: baz\n", readEvent(clientB))
+
+	line, err := clientB.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, ": heartbeat\n", line)
+
+	res, err := http.Get(fmt.Sprintf("http://localhost:%v/get", port))
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	h.TriggerCloseNow()
+	require.NoError(t, h.WaitForClose(ctx))
+}
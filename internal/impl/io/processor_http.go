@@ -37,6 +37,10 @@ If the request returns an error response code this processor sets a metadata fie
 
 Use the field `+"`extract_headers`"+` to specify rules for which other headers should be copied into the resulting message from the response.
 
+== Conditional requests
+
+When `+"`conditional.enabled`"+` is set `+"`GET` and `HEAD`"+` requests are made conditional on the resource having changed since it was last fetched from the same URL, and messages that result in a `+"`304 Not Modified`"+` response are dropped from the pipeline.
+
 == Error handling
 
 When all retry attempts for a message are exhausted the processor cancels the attempt. These failed messages will continue through the pipeline unchanged, but can be dropped or placed in a dead letter queue according to your config, you can read about xref:configuration:error_handling.adoc[these patterns].`).
@@ -59,7 +63,8 @@ pipeline:
 		).
 		Field(httpclient.ConfigField("POST", false,
 			service.NewBoolField("batch_as_multipart").Description("Send message batches as a single request using https://www.w3.org/Protocols/rfc1341/7_2_Multipart.html[RFC1341^].").Advanced().Default(false),
-			service.NewBoolField("parallel").Description("When processing batched messages, whether to send messages of the batch in parallel, otherwise they are sent serially.").Default(false)),
+			service.NewBoolField("parallel").Description("When processing batched messages, whether to send messages of the batch in parallel, otherwise they are sent serially.").Default(false),
+			httpclient.ConditionalField()),
 		)
 }
 
@@ -115,6 +120,9 @@ func (h *httpProc) ProcessBatch(ctx context.Context, msg service.MessageBatch) (
 	if h.asMultipart || len(msg) == 1 {
 		// Easy, just do a single request.
 		resultMsg, err := h.client.Send(context.Background(), msg)
+		if errors.Is(err, httpclient.ErrNotModified) {
+			return nil, nil
+		}
 		if err != nil {
 			var code int
 			var hErr httpclient.ErrUnexpectedHTTPRes
@@ -150,9 +158,14 @@ func (h *httpProc) ProcessBatch(ctx context.Context, msg service.MessageBatch) (
 			responseMsg = parts
 		}
 	} else if !h.parallel {
+		var dropped int
 		for _, p := range msg {
 			tmpMsg := service.MessageBatch{p}
 			result, err := h.client.Send(context.Background(), tmpMsg)
+			if errors.Is(err, httpclient.ErrNotModified) {
+				dropped++
+				continue
+			}
 			if err != nil {
 				h.log.Errorf("HTTP request to '%v' failed: %v", h.rawURL, err)
 
@@ -179,12 +192,16 @@ func (h *httpProc) ProcessBatch(ctx context.Context, msg service.MessageBatch) (
 				responseMsg = append(responseMsg, tmpPart)
 			}
 		}
+		if dropped == len(msg) {
+			return nil, nil
+		}
 	} else {
 		// Hard, need to do parallel requests limited by max parallelism.
 		results := make(service.MessageBatch, len(msg))
 		for i, p := range msg {
 			results[i] = p.Copy()
 		}
+		notModified := make([]bool, len(msg))
 		reqChan, resChan := make(chan int), make(chan error)
 
 		for i := 0; i < len(msg); i++ {
@@ -192,6 +209,11 @@ func (h *httpProc) ProcessBatch(ctx context.Context, msg service.MessageBatch) (
 				for index := range reqChan {
 					tmpMsg := service.MessageBatch{msg[index]}
 					result, err := h.client.Send(context.Background(), tmpMsg)
+					if errors.Is(err, httpclient.ErrNotModified) {
+						notModified[index] = true
+						resChan <- nil
+						continue
+					}
 					if err == nil && len(result) != 1 {
 						err = fmt.Errorf("unexpected response size: %v", len(result))
 					}
@@ -225,7 +247,14 @@ func (h *httpProc) ProcessBatch(ctx context.Context, msg service.MessageBatch) (
 		}
 
 		close(reqChan)
-		responseMsg = results
+		for i, p := range results {
+			if !notModified[i] {
+				responseMsg = append(responseMsg, p)
+			}
+		}
+		if len(responseMsg) == 0 {
+			return nil, nil
+		}
 	}
 
 	if len(responseMsg) < 1 {
@@ -433,3 +433,59 @@ http:
 		}
 	}
 }
+
+func TestHTTPProcessorConditional(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := `"` + r.URL.Path + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte("foobar " + r.URL.Path))
+	}))
+	defer ts.Close()
+
+	for _, parallel := range []bool{false, true} {
+		t.Run(fmt.Sprintf("parallel %v", parallel), func(t *testing.T) {
+			conf := parseYAMLProcConf(t, `
+http:
+  url: %v/${! content() }
+  verb: GET
+  retry_period: 1ms
+  parallel: %v
+  conditional:
+    enabled: true
+`, ts.URL, parallel)
+
+			h, err := mock.NewManager().NewProcessor(conf)
+			require.NoError(t, err)
+
+			msgs, res := h.ProcessBatch(t.Context(), message.QuickBatch([][]byte{
+				[]byte("foo"),
+				[]byte("bar"),
+			}))
+			require.NoError(t, res)
+			require.Len(t, msgs, 1)
+			assert.Equal(t, [][]byte{[]byte("foobar /foo"), []byte("foobar /bar")}, message.GetAllBytes(msgs[0]))
+
+			// Resources that have not been modified result in no message.
+			msgs, res = h.ProcessBatch(t.Context(), message.QuickBatch([][]byte{
+				[]byte("foo"),
+				[]byte("baz"),
+				[]byte("bar"),
+			}))
+			require.NoError(t, res)
+			require.Len(t, msgs, 1)
+			assert.Equal(t, [][]byte{[]byte("foobar /baz")}, message.GetAllBytes(msgs[0]))
+
+			msgs, res = h.ProcessBatch(t.Context(), message.QuickBatch([][]byte{
+				[]byte("foo"),
+			}))
+			require.NoError(t, res)
+			assert.Empty(t, msgs)
+
+			require.NoError(t, h.Close(t.Context()))
+		})
+	}
+}
@@ -0,0 +1,455 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
+	"github.com/redpanda-data/benthos/v4/internal/value"
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+const calendarDateLayout = "2006-01-02"
+
+// calendarTZ returns the location specified by an optional tz argument, or nil
+// if the argument is not set.
+func calendarTZ(args *bloblang.ParsedParams) (*time.Location, error) {
+	tzOpt, err := args.GetOptionalString("tz")
+	if err != nil || tzOpt == nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(*tzOpt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse timezone location name: %w", err)
+	}
+	return loc, nil
+}
+
+// calendarTimestampMethod creates a timestamp method where the target is
+// converted to an optional timezone before being provided to the method.
+func calendarTimestampMethod(loc *time.Location, fn func(t time.Time) (any, error)) bloblang.Method {
+	return bloblang.TimestampMethod(func(t time.Time) (any, error) {
+		if loc != nil {
+			t = t.In(loc)
+		}
+		return fn(t)
+	})
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(s, d.String()) {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unrecognised day of the week: %v", s)
+}
+
+// isoWeekday returns the day of the week of a timestamp numbered from 1
+// (Monday) to 7 (Sunday).
+func isoWeekday(t time.Time) int64 {
+	if d := t.Weekday(); d != time.Sunday {
+		return int64(d)
+	}
+	return 7
+}
+
+// calendarPeriodStart returns the start of the calendar period containing a
+// timestamp in the location of the timestamp, along with the start of the
+// following period.
+func calendarPeriodStart(t time.Time, period string, weekStart time.Weekday) (start, next time.Time) {
+	year, month, day := t.Date()
+	loc := t.Location()
+	switch period {
+	case "day":
+		start = time.Date(year, month, day, 0, 0, 0, 0, loc)
+		next = time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+	case "week":
+		day -= (int(t.Weekday()) - int(weekStart) + 7) % 7
+		start = time.Date(year, month, day, 0, 0, 0, 0, loc)
+		next = time.Date(year, month, day+7, 0, 0, 0, 0, loc)
+	case "month":
+		start = time.Date(year, month, 1, 0, 0, 0, 0, loc)
+		next = time.Date(year, month+1, 1, 0, 0, 0, 0, loc)
+	case "quarter":
+		month -= (month - 1) % 3
+		start = time.Date(year, month, 1, 0, 0, 0, 0, loc)
+		next = time.Date(year, month+3, 1, 0, 0, 0, 0, loc)
+	case "year":
+		start = time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+		next = time.Date(year+1, time.January, 1, 0, 0, 0, 0, loc)
+	}
+	return
+}
+
+func calendarPeriodCtor(end bool) bloblang.MethodConstructorV2 {
+	return func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+		period, err := args.GetString("period")
+		if err != nil {
+			return nil, err
+		}
+		switch period {
+		case "day", "week", "month", "quarter", "year":
+		default:
+			return nil, fmt.Errorf("unrecognised period: %v", period)
+		}
+		weekStartStr, err := args.GetString("week_start")
+		if err != nil {
+			return nil, err
+		}
+		weekStart, err := parseWeekday(weekStartStr)
+		if err != nil {
+			return nil, err
+		}
+		loc, err := calendarTZ(args)
+		if err != nil {
+			return nil, err
+		}
+		return calendarTimestampMethod(loc, func(t time.Time) (any, error) {
+			start, next := calendarPeriodStart(t, period, weekStart)
+			if end {
+				return next.Add(-time.Nanosecond), nil
+			}
+			return start, nil
+		}), nil
+	}
+}
+
+//------------------------------------------------------------------------------
+
+// businessCalendar determines whether a day is a business day, where weekends
+// and holidays are not business days.
+type businessCalendar struct {
+	// Holidays keyed by their date in the format 2006-01-02.
+	holidays map[string]struct{}
+}
+
+func newBusinessCalendar(v any) (*businessCalendar, error) {
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, value.NewTypeError(v, value.TArray)
+	}
+	c := &businessCalendar{holidays: make(map[string]struct{}, len(arr))}
+	for i, h := range arr {
+		if s, ok := h.(string); ok {
+			if d, err := time.Parse(calendarDateLayout, s); err == nil {
+				c.holidays[d.Format(calendarDateLayout)] = struct{}{}
+				continue
+			}
+		}
+		t, err := value.IGetTimestamp(h)
+		if err != nil {
+			return nil, fmt.Errorf("holidays element %v: %w", i, err)
+		}
+		c.holidays[t.Format(calendarDateLayout)] = struct{}{}
+	}
+	return c, nil
+}
+
+func (c *businessCalendar) isBusinessDay(t time.Time) bool {
+	if d := t.Weekday(); d == time.Saturday || d == time.Sunday {
+		return false
+	}
+	_, isHoliday := c.holidays[t.Format(calendarDateLayout)]
+	return !isHoliday
+}
+
+// addDays adds a number of business days to a timestamp, where the time of day
+// is retained.
+func (c *businessCalendar) addDays(t time.Time, days int64) time.Time {
+	step := 1
+	if days < 0 {
+		step, days = -1, -days
+	}
+	for days > 0 {
+		if days <= 5 {
+			t = t.AddDate(0, 0, step)
+			if c.isBusinessDay(t) {
+				days--
+			}
+			continue
+		}
+
+		// Any seven consecutive days contain exactly five week days, and so we
+		// skip whole weeks and then account for the holidays that were skipped
+		// on week days. At least one day is always left to be stepped through so
+		// that we never finish on a day that isn't a business day.
+		weeks := (days - 1) / 5
+		next := t.AddDate(0, 0, step*7*int(weeks))
+		days -= weeks * 5
+
+		from, to := t.Format(calendarDateLayout), next.Format(calendarDateLayout)
+		if step < 0 {
+			from, to = to, from
+		}
+		for h := range c.holidays {
+			if (h > from || (step < 0 && h == from)) && (h < to || (step > 0 && h == to)) {
+				if d, _ := time.Parse(calendarDateLayout, h); d.Weekday() != time.Saturday && d.Weekday() != time.Sunday {
+					days++
+				}
+			}
+		}
+		t = next
+	}
+	return t
+}
+
+//------------------------------------------------------------------------------
+
+// clockTime is a time of day measured as a duration since midnight.
+type clockTime time.Duration
+
+func parseClockTime(s string) (clockTime, bool) {
+	for _, layout := range []string{"15:04:05", "15:04"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return clockTime(t.Sub(time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC))), true
+		}
+	}
+	return 0, false
+}
+
+func clockTimeOf(t time.Time) clockTime {
+	h, m, s := t.Clock()
+	return clockTime(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second + time.Duration(t.Nanosecond()))
+}
+
+func tsBetweenCtor(args *bloblang.ParsedParams) (bloblang.Method, error) {
+	startV, err := args.Get("start")
+	if err != nil {
+		return nil, err
+	}
+	endV, err := args.Get("end")
+	if err != nil {
+		return nil, err
+	}
+	loc, err := calendarTZ(args)
+	if err != nil {
+		return nil, err
+	}
+
+	startStr, _ := startV.(string)
+	endStr, _ := endV.(string)
+	startClock, startIsClock := parseClockTime(startStr)
+	endClock, endIsClock := parseClockTime(endStr)
+	if startIsClock != endIsClock {
+		return nil, errors.New("start and end must both be times of day or both be timestamps")
+	}
+
+	if startIsClock {
+		return calendarTimestampMethod(loc, func(t time.Time) (any, error) {
+			c := clockTimeOf(t)
+			if startClock <= endClock {
+				return c >= startClock && c < endClock, nil
+			}
+			return c >= startClock || c < endClock, nil
+		}), nil
+	}
+
+	start, err := value.IGetTimestamp(startV)
+	if err != nil {
+		return nil, fmt.Errorf("start: %w", err)
+	}
+	end, err := value.IGetTimestamp(endV)
+	if err != nil {
+		return nil, fmt.Errorf("end: %w", err)
+	}
+	return bloblang.TimestampMethod(func(t time.Time) (any, error) {
+		return !t.Before(start) && t.Before(end), nil
+	}), nil
+}
+
+//------------------------------------------------------------------------------
+
+func init() {
+	tzParam := bloblang.NewStringParam("tz").Description(`An optional timezone in which to evaluate the timestamp, otherwise the timezone of the input string is used, or in the case of unix timestamps the local timezone is used. The argument is a location name corresponding to a file in the IANA Time Zone database, such as "America/New_York", or either "UTC" or "Local".`).Optional()
+
+	calendarPeriodSpec := func(description string) *bloblang.PluginSpec {
+		return bloblang.NewPluginSpec().
+			Beta().
+			Static().
+			Category(query.MethodCategoryTime).
+			Description(description).
+			Param(bloblang.NewStringParam("period").Description("The calendar period, one of `day`, `week`, `month`, `quarter` or `year`.")).
+			Param(tzParam).
+			Param(bloblang.NewStringParam("week_start").Description("The day on which weeks start.").Default("monday"))
+	}
+
+	bloblang.MustRegisterMethodV2("ts_start_of",
+		calendarPeriodSpec(`Returns the start of the calendar period that contains a timestamp. Periods follow the wall clock of the timezone, and so a day spanning a daylight saving transition may be shorter or longer than 24 hours. Timestamp values can either be a numerical unix time in seconds (with up to nanosecond precision via decimals), or a string in RFC 3339 format.`).
+			Example("",
+				`root.month = this.created_at.ts_start_of("month")`,
+				[2]string{
+					`{"created_at":"2024-08-14T05:54:23Z"}`,
+					`{"month":"2024-08-01T00:00:00Z"}`,
+				}).
+			Example("The start of a week is a Monday by default, which can be changed with the `week_start` argument.",
+				`root.week = this.created_at.ts_start_of(period: "week", tz: "America/New_York", week_start: "sunday")`,
+				[2]string{
+					`{"created_at":"2024-11-05T03:00:00Z"}`,
+					`{"week":"2024-11-03T00:00:00-04:00"}`,
+				}),
+		calendarPeriodCtor(false))
+
+	bloblang.MustRegisterMethodV2("ts_end_of",
+		calendarPeriodSpec(`Returns the last nanosecond of the calendar period that contains a timestamp. Periods follow the wall clock of the timezone, and so a day spanning a daylight saving transition may be shorter or longer than 24 hours. Timestamp values can either be a numerical unix time in seconds (with up to nanosecond precision via decimals), or a string in RFC 3339 format.`).
+			Example("",
+				`root.quarter_end = this.created_at.ts_end_of(period: "quarter", tz: "Europe/London")`,
+				[2]string{
+					`{"created_at":"2024-02-14T05:54:23Z"}`,
+					`{"quarter_end":"2024-03-31T23:59:59.999999999+01:00"}`,
+				}),
+		calendarPeriodCtor(true))
+
+	bloblang.MustRegisterMethodV2("ts_iso_week",
+		bloblang.NewPluginSpec().
+			Beta().
+			Static().
+			Category(query.MethodCategoryTime).
+			Description("Returns the ISO 8601 week number of a timestamp, from 1 to 53. Weeks start on a Monday and the first week of a year is the week containing its first Thursday, therefore days at the start of January may belong to the last week of the previous year. The year that a week belongs to can be obtained with `ts_strftime(\"%G\")`.").
+			Param(tzParam).
+			Example("",
+				`root.week = this.created_at.ts_iso_week()`,
+				[2]string{
+					`{"created_at":"2021-01-03T12:00:00Z"}`,
+					`{"week":53}`,
+				}),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			loc, err := calendarTZ(args)
+			if err != nil {
+				return nil, err
+			}
+			return calendarTimestampMethod(loc, func(t time.Time) (any, error) {
+				_, week := t.ISOWeek()
+				return int64(week), nil
+			}), nil
+		})
+
+	bloblang.MustRegisterMethodV2("ts_weekday",
+		bloblang.NewPluginSpec().
+			Beta().
+			Static().
+			Category(query.MethodCategoryTime).
+			Description("Returns the ISO 8601 day of the week of a timestamp, from 1 (Monday) to 7 (Sunday).").
+			Param(tzParam).
+			Example("",
+				`root.weekday = this.created_at.ts_weekday(tz: "Asia/Tokyo")`,
+				[2]string{
+					`{"created_at":"2024-08-17T20:00:00Z"}`,
+					`{"weekday":7}`,
+				}),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			loc, err := calendarTZ(args)
+			if err != nil {
+				return nil, err
+			}
+			return calendarTimestampMethod(loc, func(t time.Time) (any, error) {
+				return isoWeekday(t), nil
+			}), nil
+		})
+
+	holidaysParam := bloblang.NewAnyParam("holidays").Description("An optional array of holidays that are not business days, either as dates in the format `2006-01-02` or as timestamps.").Default([]any{})
+
+	bloblang.MustRegisterMethodV2("ts_is_business_day",
+		bloblang.NewPluginSpec().
+			Beta().
+			Static().
+			Category(query.MethodCategoryTime).
+			Description("Checks whether a timestamp falls on a business day, which is any Monday to Friday that isn't a holiday.").
+			Param(holidaysParam).
+			Param(tzParam).
+			Example("",
+				`root.is_business_day = this.created_at.ts_is_business_day(holidays: ["2024-12-25", "2024-12-26"])`,
+				[2]string{
+					`{"created_at":"2024-12-25T10:00:00Z"}`,
+					`{"is_business_day":false}`,
+				},
+				[2]string{
+					`{"created_at":"2024-12-27T10:00:00Z"}`,
+					`{"is_business_day":true}`,
+				}),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			holidays, err := args.Get("holidays")
+			if err != nil {
+				return nil, err
+			}
+			cal, err := newBusinessCalendar(holidays)
+			if err != nil {
+				return nil, err
+			}
+			loc, err := calendarTZ(args)
+			if err != nil {
+				return nil, err
+			}
+			return calendarTimestampMethod(loc, func(t time.Time) (any, error) {
+				return cal.isBusinessDay(t), nil
+			}), nil
+		})
+
+	bloblang.MustRegisterMethodV2("ts_add_business_days",
+		bloblang.NewPluginSpec().
+			Beta().
+			Static().
+			Category(query.MethodCategoryTime).
+			Description("Adds a number of business days to a timestamp, skipping weekends and holidays, while retaining the time of day in the wall clock of the timezone. A negative number of days moves the timestamp backwards.").
+			Param(bloblang.NewInt64Param("days").Description("The number of business days to add.")).
+			Param(holidaysParam).
+			Param(tzParam).
+			Example("",
+				`root.due_at = this.created_at.ts_add_business_days(days: 3, holidays: ["2024-12-25", "2024-12-26"])`,
+				[2]string{
+					`{"created_at":"2024-12-23T09:30:00Z"}`,
+					`{"due_at":"2024-12-30T09:30:00Z"}`,
+				}),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			days, err := args.GetInt64("days")
+			if err != nil {
+				return nil, err
+			}
+			holidays, err := args.Get("holidays")
+			if err != nil {
+				return nil, err
+			}
+			cal, err := newBusinessCalendar(holidays)
+			if err != nil {
+				return nil, err
+			}
+			loc, err := calendarTZ(args)
+			if err != nil {
+				return nil, err
+			}
+			return calendarTimestampMethod(loc, func(t time.Time) (any, error) {
+				return cal.addDays(t, days), nil
+			}), nil
+		})
+
+	bloblang.MustRegisterMethodV2("ts_between",
+		bloblang.NewPluginSpec().
+			Beta().
+			Static().
+			Category(query.MethodCategoryTime).
+			Description(`Checks whether a timestamp falls within a range, where the start of the range is inclusive and the end is exclusive. The range is either two timestamps, or two times of day in the format `+"`15:04`"+` or `+"`15:04:05`"+`, in which case the wall clock time of the timestamp is checked and the range therefore follows daylight saving transitions of the timezone. A range of times of day where the end is before the start spans midnight.`).
+			Param(bloblang.NewAnyParam("start").Description("The start of the range.")).
+			Param(bloblang.NewAnyParam("end").Description("The end of the range.")).
+			Param(tzParam).
+			Example("",
+				`root.in_hours = this.created_at.ts_between(start: "09:00", end: "17:30", tz: "Europe/London")`,
+				[2]string{
+					`{"created_at":"2024-07-01T08:30:00Z"}`,
+					`{"in_hours":true}`,
+				},
+				[2]string{
+					`{"created_at":"2024-12-02T08:30:00Z"}`,
+					`{"in_hours":false}`,
+				}).
+			Example("",
+				`root.in_window = this.created_at.ts_between(this.window.start, this.window.end)`,
+				[2]string{
+					`{"created_at":"2024-07-01T08:30:00Z","window":{"start":"2024-07-01T00:00:00Z","end":"2024-07-02T00:00:00Z"}}`,
+					`{"in_window":true}`,
+				}),
+		tsBetweenCtor)
+}
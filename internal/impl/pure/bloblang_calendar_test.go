@@ -0,0 +1,164 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+func TestBusinessCalendarAddDays(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	start := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+	var holidays []any
+	for range 40 {
+		holidays = append(holidays, start.AddDate(0, 0, rng.IntN(730)-365).Format(calendarDateLayout))
+	}
+	cal, err := newBusinessCalendar(holidays)
+	require.NoError(t, err)
+
+	naive := func(t time.Time, days int64) time.Time {
+		step := 1
+		if days < 0 {
+			step, days = -1, -days
+		}
+		for days > 0 {
+			t = t.AddDate(0, 0, step)
+			if cal.isBusinessDay(t) {
+				days--
+			}
+		}
+		return t
+	}
+
+	for range 500 {
+		from := start.AddDate(0, 0, rng.IntN(60)-30)
+		days := int64(rng.IntN(400) - 200)
+		require.Equal(t, naive(from, days), cal.addDays(from, days), "%v + %v", from, days)
+	}
+}
+
+func TestCalendarMethods(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mapping string
+		input   string
+		output  string
+		execErr string
+	}{
+		{
+			name:    "start of periods",
+			mapping: `root = ["day","week","month","quarter","year"].map_each(p -> this.ts.ts_start_of(p).ts_format())`,
+			input:   `{"ts":"2024-08-14T05:54:23.5+02:00"}`,
+			output:  `["2024-08-14T00:00:00+02:00","2024-08-12T00:00:00+02:00","2024-08-01T00:00:00+02:00","2024-07-01T00:00:00+02:00","2024-01-01T00:00:00+02:00"]`,
+		},
+		{
+			name:    "end of periods",
+			mapping: `root = ["day","week","month","quarter","year"].map_each(p -> this.ts.ts_end_of(period: p, tz: "UTC").ts_format())`,
+			input:   `{"ts":"2024-02-14T05:54:23Z"}`,
+			output:  `["2024-02-14T23:59:59.999999999Z","2024-02-18T23:59:59.999999999Z","2024-02-29T23:59:59.999999999Z","2024-03-31T23:59:59.999999999Z","2024-12-31T23:59:59.999999999Z"]`,
+		},
+		{
+			name:    "start of day spanning dst",
+			mapping: `root = this.ts.ts_end_of(period: "day", tz: "Europe/Berlin").ts_sub(this.ts.ts_start_of(period: "day", tz: "Europe/Berlin")) + 1`,
+			input:   `{"ts":"2024-03-31T12:00:00Z"}`,
+			output:  `82800000000000`,
+		},
+		{
+			name:    "start of week with week start",
+			mapping: `root = this.ts.ts_start_of(period: "week", week_start: "Saturday").ts_format()`,
+			input:   `{"ts":"2024-08-17T05:54:23Z"}`,
+			output:  `"2024-08-17T00:00:00Z"`,
+		},
+		{
+			name:    "iso week at year boundary",
+			mapping: `root = this.map_each(ts -> ts.ts_iso_week())`,
+			input:   `["2024-12-30T00:00:00Z","2027-01-01T00:00:00Z","2020-06-15T00:00:00Z"]`,
+			output:  `[1,53,25]`,
+		},
+		{
+			name:    "weekday",
+			mapping: `root = this.map_each(ts -> ts.ts_weekday())`,
+			input:   `["2024-08-12T00:00:00Z","2024-08-18T23:00:00Z"]`,
+			output:  `[1,7]`,
+		},
+		{
+			name:    "business day with timestamp holidays",
+			mapping: `root = this.ts.ts_is_business_day(holidays: [this.holiday], tz: "UTC")`,
+			input:   `{"ts":"2024-07-04T15:00:00Z","holiday":"2024-07-04T00:00:00Z"}`,
+			output:  `false`,
+		},
+		{
+			name:    "business day weekend in timezone",
+			mapping: `root = this.ts.ts_is_business_day(tz: "Pacific/Auckland")`,
+			input:   `{"ts":"2024-08-16T20:00:00Z"}`,
+			output:  `false`,
+		},
+		{
+			name:    "subtract business days",
+			mapping: `root = this.ts.ts_add_business_days(-1).ts_format()`,
+			input:   `{"ts":"2024-08-19T09:00:00Z"}`,
+			output:  `"2024-08-16T09:00:00Z"`,
+		},
+		{
+			name:    "add business days across dst keeps wall clock",
+			mapping: `root = this.ts.ts_add_business_days(days: 5, tz: "America/New_York").ts_format()`,
+			input:   `{"ts":"2024-03-08T14:00:00Z"}`,
+			output:  `"2024-03-15T09:00:00-04:00"`,
+		},
+		{
+			name:    "bad holidays",
+			mapping: `root = this.ts.ts_is_business_day(holidays: this.holidays)`,
+			input:   `{"ts":"2024-08-19T09:00:00Z","holidays":["2024-01-01","nope"]}`,
+			execErr: "holidays element 1",
+		},
+		{
+			name:    "between overnight",
+			mapping: `root = this.map_each(ts -> ts.ts_between("22:00", "06:00"))`,
+			input:   `["2024-08-19T23:00:00Z","2024-08-19T05:59:59Z","2024-08-19T06:00:00Z","2024-08-19T22:00:00Z"]`,
+			output:  `[true,true,false,true]`,
+		},
+		{
+			name:    "between timestamps",
+			mapping: `root = this.map_each(ts -> ts.ts_between(1700000000, "2023-11-15T00:00:00Z"))`,
+			input:   `["2023-11-14T22:13:20Z","2023-11-15T00:00:00Z","2023-11-14T22:13:19Z"]`,
+			output:  `[true,false,false]`,
+		},
+		{
+			name:    "between mixed",
+			mapping: `root = this.ts.ts_between(this.start, "10:00")`,
+			input:   `{"ts":"2024-08-19T09:00:00Z","start":"2024-08-19T00:00:00Z"}`,
+			execErr: "start and end must both be times of day or both be timestamps",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := execJSONMapping(t, test.mapping, test.input)
+			if test.execErr != "" {
+				require.ErrorContains(t, err, test.execErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.output, res)
+		})
+	}
+
+	for _, mapping := range []string{
+		`root = this.ts_start_of("fortnight")`,
+		`root = this.ts_start_of(period: "week", week_start: "someday")`,
+		`root = this.ts_end_of(period: "day", tz: "Nowhere/Special")`,
+		`root = this.ts_between("09:00", "25:00")`,
+		`root = this.ts_add_business_days(days: 1, holidays: "2024-01-01")`,
+	} {
+		_, err := bloblang.Parse(mapping)
+		require.Error(t, err, mapping)
+	}
+}
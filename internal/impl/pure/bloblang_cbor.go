@@ -0,0 +1,49 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"fmt"
+
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
+	"github.com/redpanda-data/benthos/v4/internal/cbor"
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+func init() {
+	bloblang.MustRegisterMethodV2("parse_cbor",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryParsing).
+			Description("Attempts to parse a byte array as a single CBOR value and returns the result. Map keys that are not strings are converted into strings. Date/time tags are parsed as timestamps, bignums as numbers, and other tags are represented by their tagged content.").
+			Example("", `root.doc = this.doc.decode("hex").parse_cbor()`,
+				[2]string{
+					`{"doc":"a163666f6f63626172"}`,
+					`{"doc":{"foo":"bar"}}`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			return bloblang.BytesMethod(func(data []byte) (any, error) {
+				v, err := cbor.Unmarshal(data)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse value as CBOR: %w", err)
+				}
+				return v, nil
+			}), nil
+		})
+
+	bloblang.MustRegisterMethodV2("format_cbor",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryParsing).
+			Description("Serializes a target value into a CBOR byte array. Numbers that hold integer values are encoded as integers and all other numbers, including decimals, as floats, objects are encoded with their keys in lexicographical order and timestamps as RFC 3339 date/time strings.").
+			Example("", `root.encoded = this.format_cbor().encode("hex")`,
+				[2]string{
+					`{"foo":"bar"}`,
+					`{"encoded":"a163666f6f63626172"}`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			return func(v any) (any, error) {
+				return cbor.Marshal(v)
+			}, nil
+		})
+}
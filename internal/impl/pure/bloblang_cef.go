@@ -0,0 +1,281 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
+	"github.com/redpanda-data/benthos/v4/internal/value"
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+// The keys of CEF header fields in the order that they appear, following the
+// version.
+var cefHeaderFields = []string{
+	"device_vendor",
+	"device_product",
+	"device_version",
+	"device_event_class_id",
+	"name",
+	"severity",
+}
+
+const (
+	cefFieldVersion    = "version"
+	cefFieldExtensions = "extensions"
+)
+
+func isCEFExtensionKeyChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') ||
+		(c >= 'A' && c <= 'Z') ||
+		(c >= '0' && c <= '9') ||
+		c == '_' || c == '.' || c == '-' || c == '[' || c == ']'
+}
+
+// cefNumberOrString returns a string as an integer when it is one.
+func cefNumberOrString(s string) any {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	return s
+}
+
+func unescapeCEFHeader(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && (s[i+1] == '\\' || s[i+1] == '|') {
+			i++
+		}
+		buf.WriteByte(s[i])
+	}
+	return buf.String()
+}
+
+func unescapeCEFExtension(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			switch s[i+1] {
+			case '\\', '=':
+				i++
+			case 'n':
+				i++
+				buf.WriteByte('\n')
+				continue
+			case 'r':
+				i++
+				buf.WriteByte('\r')
+				continue
+			}
+		}
+		buf.WriteByte(s[i])
+	}
+	return buf.String()
+}
+
+// parseCEFExtensions parses the space separated key=value pairs of a CEF
+// extension, where values may contain spaces and the end of a value is
+// determined by the start of the next key.
+func parseCEFExtensions(s string) map[string]any {
+	type keyPos struct {
+		key        string
+		start, end int
+	}
+	var keys []keyPos
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] != '=' {
+			continue
+		}
+		start := i
+		for start > 0 && isCEFExtensionKeyChar(s[start-1]) {
+			start--
+		}
+		if start == i || (start > 0 && s[start-1] != ' ') {
+			// Unescaped equals signs that don't follow a key are treated as
+			// part of the value.
+			continue
+		}
+		keys = append(keys, keyPos{key: s[start:i], start: start, end: i + 1})
+	}
+
+	res := make(map[string]any, len(keys))
+	for i, k := range keys {
+		valueEnd := len(s)
+		if i+1 < len(keys) {
+			valueEnd = keys[i+1].start
+		}
+		res[k.key] = unescapeCEFExtension(strings.TrimRight(s[k.end:valueEnd], " "))
+	}
+	return res
+}
+
+// parseCEF parses an ArcSight Common Event Format message. Any content
+// preceding the `CEF:` prefix, such as a syslog header, is ignored. When an
+// error occurs the fields parsed up until that point are returned along with
+// it.
+func parseCEF(s string) (map[string]any, error) {
+	res := map[string]any{}
+
+	idx := strings.Index(s, "CEF:")
+	if idx < 0 {
+		return res, errors.New("missing CEF: prefix")
+	}
+	s = s[idx+len("CEF:"):]
+
+	var fields []string
+	truncated := false
+	for !truncated && len(fields) < len(cefHeaderFields)+1 {
+		end := -1
+		for i := 0; i < len(s); i++ {
+			if s[i] == '\\' {
+				i++
+			} else if s[i] == '|' {
+				end = i
+				break
+			}
+		}
+		if end < 0 {
+			// The header is incomplete, but the remaining content is kept as
+			// the value of the next field.
+			if s != "" {
+				fields = append(fields, s)
+			}
+			truncated = true
+			break
+		}
+		fields = append(fields, s[:end])
+		s = s[end+1:]
+	}
+
+	for i, f := range fields {
+		if i == 0 {
+			res[cefFieldVersion] = cefNumberOrString(strings.TrimSpace(f))
+			continue
+		}
+		f = unescapeCEFHeader(f)
+		if cefHeaderFields[i-1] == "severity" {
+			res["severity"] = cefNumberOrString(f)
+		} else {
+			res[cefHeaderFields[i-1]] = f
+		}
+	}
+	if truncated {
+		return res, fmt.Errorf("expected %v header fields terminated by a pipe, found %v", len(cefHeaderFields)+1, len(fields))
+	}
+
+	res[cefFieldExtensions] = parseCEFExtensions(s)
+	return res, nil
+}
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+)
+
+func formatCEF(obj map[string]any) (string, error) {
+	var buf strings.Builder
+	buf.WriteString("CEF:")
+
+	if v, exists := obj[cefFieldVersion]; exists && v != nil {
+		buf.WriteString(value.IToString(v))
+	} else {
+		buf.WriteByte('0')
+	}
+
+	for _, k := range cefHeaderFields {
+		v, exists := obj[k]
+		if !exists || v == nil {
+			return "", fmt.Errorf("missing header field %v", k)
+		}
+		buf.WriteByte('|')
+		buf.WriteString(cefHeaderEscaper.Replace(value.IToString(v)))
+	}
+	buf.WriteByte('|')
+
+	extV, exists := obj[cefFieldExtensions]
+	if !exists || extV == nil {
+		return buf.String(), nil
+	}
+	ext, ok := extV.(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("field %v: %w", cefFieldExtensions, value.NewTypeError(extV, value.TObject))
+	}
+
+	keys := make([]string, 0, len(ext))
+	for k := range ext {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for i, k := range keys {
+		if k == "" || strings.IndexFunc(k, func(r rune) bool {
+			return r >= 0x80 || !isCEFExtensionKeyChar(byte(r))
+		}) >= 0 {
+			return "", fmt.Errorf("extension key %q contains invalid characters", k)
+		}
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(k)
+		buf.WriteByte('=')
+		if v := ext[k]; v != nil {
+			buf.WriteString(cefExtensionEscaper.Replace(value.IToString(v)))
+		}
+	}
+	return buf.String(), nil
+}
+
+func init() {
+	bloblang.MustRegisterMethodV2("parse_cef",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryParsing).
+			Description(`Attempts to parse a string in the ArcSight Common Event Format (CEF) into an object. Any content preceding the `+"`CEF:`"+` prefix, such as a syslog header, is ignored.
+
+The resulting object contains the header fields `+"`version`, `device_vendor`, `device_product`, `device_version`, `device_event_class_id`, `name` and `severity`"+`, where the version and severity are integers when possible, and an object `+"`extensions`"+` containing the extension key/value pairs as strings. Escape sequences within header fields and extension values are unescaped.`).
+			Example("", `root = content().parse_cef()`,
+				[2]string{
+					`CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 msg=Detected a \= sign spt=1232`,
+					`{"device_event_class_id":"100","device_product":"threatmanager","device_vendor":"Security","device_version":"1.0","extensions":{"dst":"2.1.2.2","msg":"Detected a = sign","spt":"1232","src":"10.0.0.1"},"name":"worm successfully stopped","severity":10,"version":0}`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			return bloblang.StringMethod(func(s string) (any, error) {
+				v, err := parseCEF(s)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse value as CEF: %w", err)
+				}
+				return v, nil
+			}), nil
+		})
+
+	bloblang.MustRegisterMethodV2("format_cef",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryParsing).
+			Description(`Formats an object in the structure produced by `+"`parse_cef`"+` as an ArcSight Common Event Format (CEF) message. The header fields `+"`device_vendor`, `device_product`, `device_version`, `device_event_class_id`, `name` and `severity`"+` are required, the `+"`version`"+` defaults to `+"`0`"+` and the keys of `+"`extensions`"+` are written in alphabetical order. Pipes and backslashes within header fields, and equals signs, backslashes and line breaks within extension values are escaped.`).
+			Example("", `root = this.format_cef()`,
+				[2]string{
+					`{"device_vendor":"Acme","device_product":"fire|wall","device_version":"2.3","device_event_class_id":"deny","name":"Connection denied","severity":7,"extensions":{"src":"10.0.0.1","reason":"a=b"}}`,
+					`CEF:0|Acme|fire\|wall|2.3|deny|Connection denied|7|reason=a\=b src=10.0.0.1`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			return bloblang.ObjectMethod(func(obj map[string]any) (any, error) {
+				return formatCEF(obj)
+			}), nil
+		})
+}
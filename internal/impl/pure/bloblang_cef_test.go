@@ -0,0 +1,126 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCEF(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       string
+		output      map[string]any
+		errContains string
+	}{
+		{
+			name:  "no extensions",
+			input: `CEF:0|Vendor|Product|1.0|100|Name|5|`,
+			output: map[string]any{
+				"version": int64(0), "device_vendor": "Vendor", "device_product": "Product", "device_version": "1.0",
+				"device_event_class_id": "100", "name": "Name", "severity": int64(5), "extensions": map[string]any{},
+			},
+		},
+		{
+			name:  "escaped header",
+			input: `CEF:1|Ven\|dor|Pro\\duct|1.0|100|Name|Very-High|`,
+			output: map[string]any{
+				"version": int64(1), "device_vendor": "Ven|dor", "device_product": `Pro\duct`, "device_version": "1.0",
+				"device_event_class_id": "100", "name": "Name", "severity": "Very-High",
+				"extensions": map[string]any{},
+			},
+		},
+		{
+			name:  "extension values with spaces and equals",
+			input: `<13>Jan 18 11:07:53 host CEF:0|V|P|1|sig|N|3|msg=a \= b\nc\\ request=http://x.com/?a=b&c=d cs1Label=foo bar cs1=`,
+			output: map[string]any{
+				"version": int64(0), "device_vendor": "V", "device_product": "P", "device_version": "1",
+				"device_event_class_id": "sig", "name": "N", "severity": int64(3),
+				"extensions": map[string]any{
+					"msg":      "a = b\nc\\",
+					"request":  "http://x.com/?a=b&c=d",
+					"cs1Label": "foo bar",
+					"cs1":      "",
+				},
+			},
+		},
+		{
+			name:        "no prefix",
+			input:       `0|V|P|1|sig|N|3|`,
+			output:      map[string]any{},
+			errContains: "missing CEF: prefix",
+		},
+		{
+			name:        "truncated header",
+			input:       `CEF:0|V|P|1|sig|N|3`,
+			output:      map[string]any{"version": int64(0), "device_vendor": "V", "device_product": "P", "device_version": "1", "device_event_class_id": "sig", "name": "N", "severity": int64(3)},
+			errContains: "expected 7 header fields terminated by a pipe, found 7",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := parseCEF(test.input)
+			if test.errContains != "" {
+				require.ErrorContains(t, err, test.errContains)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.output, res)
+		})
+	}
+}
+
+func TestFormatCEF(t *testing.T) {
+	input := map[string]any{
+		"version":               int64(1),
+		"device_vendor":         `Ven|dor`,
+		"device_product":        `Pro\duct`,
+		"device_version":        "1.0",
+		"device_event_class_id": int64(100),
+		"name":                  "Multi\nline",
+		"severity":              "High",
+		"extensions": map[string]any{
+			"msg":  "a = b\nc\\",
+			"cnt":  int64(5),
+			"none": nil,
+		},
+	}
+
+	res, err := formatCEF(input)
+	require.NoError(t, err)
+	assert.Equal(t, `CEF:1|Ven\|dor|Pro\\duct|1.0|100|Multi line|High|cnt=5 msg=a \= b\nc\\ none=`, res)
+
+	parsed, err := parseCEF(res)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"version":               int64(1),
+		"device_vendor":         `Ven|dor`,
+		"device_product":        `Pro\duct`,
+		"device_version":        "1.0",
+		"device_event_class_id": "100",
+		"name":                  "Multi line",
+		"severity":              "High",
+		"extensions": map[string]any{
+			"msg":  "a = b\nc\\",
+			"cnt":  "5",
+			"none": "",
+		},
+	}, parsed)
+
+	delete(input, "name")
+	_, err = formatCEF(input)
+	require.ErrorContains(t, err, "missing header field name")
+
+	input["name"] = "n"
+	input["extensions"] = map[string]any{"bad key": 1}
+	_, err = formatCEF(input)
+	require.ErrorContains(t, err, "contains invalid characters")
+
+	input["extensions"] = "nope"
+	_, err = formatCEF(input)
+	require.ErrorContains(t, err, "field extensions")
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
+	"github.com/redpanda-data/benthos/v4/internal/value"
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+func decimalRoundingModesDescription() string {
+	modes := make([]string, 0, len(value.DecimalRoundingModes))
+	for k := range value.DecimalRoundingModes {
+		modes = append(modes, k)
+	}
+	slices.Sort(modes)
+
+	var buf strings.Builder
+	for _, k := range modes {
+		fmt.Fprintf(&buf, "\n- `%v`: %v", k, value.DecimalRoundingModes[k])
+	}
+	return buf.String()
+}
+
+func decimalRoundingMode(args *bloblang.ParsedParams) (string, error) {
+	mode, err := args.GetString("rounding")
+	if err != nil {
+		return "", err
+	}
+	if _, exists := value.DecimalRoundingModes[mode]; !exists {
+		return "", fmt.Errorf("unrecognised rounding mode: %v", mode)
+	}
+	return mode, nil
+}
+
+func decimalScale(args *bloblang.ParsedParams) (int32, error) {
+	scale, err := args.GetInt64("scale")
+	if err != nil {
+		return 0, err
+	}
+	if scale > math.MaxInt16 || scale < math.MinInt16 {
+		return 0, fmt.Errorf("scale %v is out of range", scale)
+	}
+	return int32(scale), nil
+}
+
+func init() {
+	bloblang.MustRegisterMethodV2("decimal",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryNumbers).
+			Description(`
+Converts a number or a string into a decimal, which is a number of arbitrary precision. Adding, subtracting and multiplying decimals with other numbers is exact, dividing them produces a result with 34 significant digits, and decimals are serialised as JSON numbers without losing any digits.
+
+Floating point numbers are converted using their shortest decimal representation, so that `+"`0.1`"+` becomes exactly `+"`0.1`"+`. In order to avoid floating point numbers entirely use a string as the input, or parse JSON documents with `+"`parse_json(use_decimal: true)`"+`.`).
+			Example("", `root.total = this.price.decimal() * this.quantity`,
+				[2]string{`{"price":"19.99","quantity":3}`, `{"total":59.97}`},
+			).
+			Example("Decimals keep precision that floating point numbers would lose.", `root.sum = this.a.decimal() + this.b.decimal()`,
+				[2]string{`{"a":"9007199254740993.1","b":"0.2"}`, `{"sum":9007199254740993.3}`},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			return func(input any) (any, error) {
+				return value.IToDecimal(input)
+			}, nil
+		})
+
+	bloblang.MustRegisterMethodV2("round_decimal",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryNumbers).
+			Description(`
+Rounds a number to a decimal with a given number of digits after the decimal point, using one of the following rounding modes:
+`+decimalRoundingModesDescription()+`
+
+A negative scale rounds to a power of ten.`).
+			Param(bloblang.NewInt64Param("scale").Description("The number of digits after the decimal point.")).
+			Param(bloblang.NewStringParam("rounding").Description("The rounding mode to use.").Default("half_even")).
+			Example("", `root.amount = this.amount.round_decimal(2)`,
+				[2]string{`{"amount":"2.345"}`, `{"amount":2.34}`},
+				[2]string{`{"amount":"2.355"}`, `{"amount":2.36}`},
+			).
+			Example("", `root.amount = this.amount.round_decimal(scale: 0, rounding: "ceiling")`,
+				[2]string{`{"amount":-2.5}`, `{"amount":-2}`},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			scale, err := decimalScale(args)
+			if err != nil {
+				return nil, err
+			}
+			mode, err := decimalRoundingMode(args)
+			if err != nil {
+				return nil, err
+			}
+			return func(input any) (any, error) {
+				d, err := value.IToDecimal(input)
+				if err != nil {
+					return nil, err
+				}
+				return d.Round(scale, mode)
+			}, nil
+		})
+
+	bloblang.MustRegisterMethodV2("format_decimal",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryNumbers).
+			Description(`
+Formats a number as a string with exactly a given number of digits after the decimal point and without an exponent, rounding it with one of the following rounding modes when necessary:
+`+decimalRoundingModesDescription()).
+			Param(bloblang.NewInt64Param("scale").Description("The number of digits after the decimal point, which must not be negative.")).
+			Param(bloblang.NewStringParam("rounding").Description("The rounding mode to use.").Default("half_even")).
+			Example("", `root.price = this.price.format_decimal(2)`,
+				[2]string{`{"price":10}`, `{"price":"10.00"}`},
+				[2]string{`{"price":"3.14159"}`, `{"price":"3.14"}`},
+			).
+			Example("", `root.price = this.price.format_decimal(scale: 1, rounding: "half_up")`,
+				[2]string{`{"price":0.25}`, `{"price":"0.3"}`},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			scale, err := decimalScale(args)
+			if err != nil {
+				return nil, err
+			}
+			if scale < 0 {
+				return nil, fmt.Errorf("scale must not be negative, got %v", scale)
+			}
+			mode, err := decimalRoundingMode(args)
+			if err != nil {
+				return nil, err
+			}
+			return func(input any) (any, error) {
+				d, err := value.IToDecimal(input)
+				if err != nil {
+					return nil, err
+				}
+				if d, err = d.Round(scale, mode); err != nil {
+					return nil, err
+				}
+				return d.Text(), nil
+			}, nil
+		})
+}
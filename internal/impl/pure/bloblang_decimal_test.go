@@ -0,0 +1,135 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+func TestDecimalMethods(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mapping string
+		input   string
+		output  string
+		execErr string
+	}{
+		{
+			name:    "parse json preserves precision",
+			mapping: `root = this.doc.parse_json(use_decimal: true)`,
+			input:   `{"doc":"{\"a\":[1.10,12345678901234567890123.45],\"b\":{\"c\":-0.000000000000000000001}}"}`,
+			output:  `{"a":[1.10,12345678901234567890123.45],"b":{"c":-1E-21}}`,
+		},
+		{
+			name:    "exact arithmetic",
+			mapping: `root = this.doc.parse_json(use_decimal: true).map_each(ele -> ele * 3 - 0.1)`,
+			input:   `{"doc":"[0.1, 0.7, 100000000000000000000.1]"}`,
+			output:  `[0.2,2.0,300000000000000000000.2]`,
+		},
+		{
+			name:    "division",
+			mapping: `root = [this.a.decimal() / 8, this.a.decimal() / 3, this.a.decimal() % 3]`,
+			input:   `{"a":"10"}`,
+			output:  `[1.25,3.333333333333333333333333333333333,1]`,
+		},
+		{
+			name:    "comparison",
+			mapping: `root = [this.a.decimal() == 5, this.a.decimal() > 4.99, this.b.decimal() < this.c.decimal()]`,
+			input:   `{"a":"5.00","b":"9007199254740993","c":"9007199254740994"}`,
+			output:  `[true,true,true]`,
+		},
+		{
+			name:    "rounding methods",
+			mapping: `root = [this.a.decimal().round(), this.a.decimal().floor(), this.a.decimal().ceil(), this.a.decimal().abs()]`,
+			input:   `{"a":"-2.5"}`,
+			output:  `[-3,-3,-2,2.5]`,
+		},
+		{
+			name:    "sum mixed",
+			mapping: `root = [this.a.decimal(), 2, 0.5].sum()`,
+			input:   `{"a":"0.1"}`,
+			output:  `2.6`,
+		},
+		{
+			name:    "format and round",
+			mapping: `root = [this.a.format_decimal(3), this.a.round_decimal(1, "down"), this.a.format_decimal(0, "up")]`,
+			input:   `{"a":12.3456}`,
+			output:  `["12.346",12.3,"13"]`,
+		},
+		{
+			name:    "integer casts",
+			mapping: `root = [this.a.decimal().int64(), this.a.decimal().string()]`,
+			input:   `{"a":"123.000"}`,
+			output:  `[123,"123.000"]`,
+		},
+		{
+			name:    "decimals as yaml",
+			mapping: `root = {"a": this.a.decimal(), "b": this.b.decimal()}.format_yaml().string()`,
+			input:   `{"a":"12345678901234567890.10","b":"-3"}`,
+			output:  `"a: 12345678901234567890.10\nb: -3\n"`,
+		},
+		{
+			name:    "decimals as msgpack",
+			mapping: `root = [this.a.decimal(), this.b.decimal()].format_msgpack().parse_msgpack()`,
+			input:   `{"a":"1.50","b":"12"}`,
+			output:  `[1.5,12]`,
+		},
+		{
+			name:    "decimals as cbor",
+			mapping: `root = [this.a.decimal(), this.b.decimal()].format_cbor().parse_cbor()`,
+			input:   `{"a":"1.50","b":"12"}`,
+			output:  `[1.5,12]`,
+		},
+		{
+			name:    "unique decimals",
+			mapping: `root = [this.a.decimal(), this.b.decimal(), 1.5, this.c.decimal(), this.d.decimal(), "1.5"].unique()`,
+			input:   `{"a":"1.50","b":"1.5","c":"0.10000000000000000001","d":"0.100000000000000000010"}`,
+			output:  `[1.50,0.10000000000000000001,"1.5"]`,
+		},
+		{
+			name:    "divide by zero",
+			mapping: `root = this.a.decimal() / 0`,
+			input:   `{"a":"1"}`,
+			execErr: `decimal division: attempted to divide by zero`,
+		},
+		{
+			name:    "modulo by zero",
+			mapping: `root = this.a.decimal() % this.b`,
+			input:   `{"a":"1","b":0}`,
+			execErr: `decimal modulo: attempted to divide by zero`,
+		},
+		{
+			name:    "bad decimal",
+			mapping: `root = this.a.decimal()`,
+			input:   `{"a":"nope"}`,
+			execErr: `failed to parse "nope" as a decimal`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := execJSONMapping(t, test.mapping, test.input)
+			if test.execErr != "" {
+				require.ErrorContains(t, err, test.execErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.output, res)
+		})
+	}
+}
+
+func TestDecimalMethodsBadRounding(t *testing.T) {
+	_, err := bloblang.Parse(`root = this.format_decimal(2, "sideways")`)
+	require.ErrorContains(t, err, "unrecognised rounding mode: sideways")
+}
+
+func TestDecimalMethodsNegativeFormatScale(t *testing.T) {
+	_, err := bloblang.Parse(`root = this.format_decimal(-1)`)
+	require.ErrorContains(t, err, "scale must not be negative")
+}
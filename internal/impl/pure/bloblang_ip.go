@@ -0,0 +1,326 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
+	"github.com/redpanda-data/benthos/v4/internal/value"
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+// parseIPAddr parses an IPv4 or IPv6 address, where IPv4-mapped IPv6 addresses
+// are converted to IPv4.
+func parseIPAddr(s string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(s))
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("failed to parse IP address: %w", err)
+	}
+	return addr.Unmap(), nil
+}
+
+// parseIPPrefix parses a CIDR range, or an address which is treated as a range
+// containing only that address.
+func parseIPPrefix(s string) (netip.Prefix, error) {
+	s = strings.TrimSpace(s)
+	if !strings.Contains(s, "/") {
+		addr, err := parseIPAddr(s)
+		if err != nil {
+			return netip.Prefix{}, err
+		}
+		return netip.PrefixFrom(addr, addr.BitLen()), nil
+	}
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("failed to parse CIDR range: %w", err)
+	}
+	if addr := prefix.Addr(); addr.Is4In6() {
+		bits := prefix.Bits() - 96
+		if bits < 0 {
+			return netip.Prefix{}, fmt.Errorf("failed to parse CIDR range: IPv4-mapped prefix %v is shorter than 96 bits", s)
+		}
+		prefix = netip.PrefixFrom(addr.Unmap(), bits)
+	}
+	return prefix, nil
+}
+
+func ipVersion(addr netip.Addr) int64 {
+	if addr.Is4() {
+		return 4
+	}
+	return 6
+}
+
+// lastIPAddr returns the last address within a range.
+func lastIPAddr(prefix netip.Prefix) netip.Addr {
+	b := prefix.Masked().Addr().AsSlice()
+	for i := prefix.Bits(); i < len(b)*8; i++ {
+		b[i/8] |= 0x80 >> (i % 8)
+	}
+	addr, _ := netip.AddrFromSlice(b)
+	return addr
+}
+
+//------------------------------------------------------------------------------
+
+type ipTrieNode struct {
+	children [2]*ipTrieNode
+	terminal bool
+}
+
+// ipPrefixTrie is a binary trie of CIDR ranges that tests whether an address
+// is contained within any of them in time proportional to the address length.
+type ipPrefixTrie struct {
+	v4, v6 *ipTrieNode
+}
+
+func newIPPrefixTrie(prefixes []netip.Prefix) *ipPrefixTrie {
+	t := &ipPrefixTrie{v4: &ipTrieNode{}, v6: &ipTrieNode{}}
+	for _, p := range prefixes {
+		node := t.v6
+		if p.Addr().Is4() {
+			node = t.v4
+		}
+		b := p.Masked().Addr().AsSlice()
+		for i := 0; i < p.Bits() && !node.terminal; i++ {
+			bit := (b[i/8] >> (7 - i%8)) & 1
+			if node.children[bit] == nil {
+				node.children[bit] = &ipTrieNode{}
+			}
+			node = node.children[bit]
+		}
+		// Any longer ranges within this one are made redundant.
+		node.terminal = true
+		node.children = [2]*ipTrieNode{}
+	}
+	return t
+}
+
+func (t *ipPrefixTrie) contains(addr netip.Addr) bool {
+	node := t.v6
+	if addr.Is4() {
+		node = t.v4
+	}
+	b := addr.AsSlice()
+	for i := 0; node != nil; i++ {
+		if node.terminal {
+			return true
+		}
+		if i >= len(b)*8 {
+			return false
+		}
+		node = node.children[(b[i/8]>>(7-i%8))&1]
+	}
+	return false
+}
+
+//------------------------------------------------------------------------------
+
+func init() {
+	bloblang.MustRegisterMethodV2("is_ip",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryNetwork).
+			Description(`Checks whether a string is a valid IPv4 or IPv6 address, optionally of a specific version.`).
+			Param(bloblang.NewInt64Param("version").Description("When set to `4` or `6` only addresses of that version are considered valid.").Default(0)).
+			Example("", `root.valid = this.addresses.map_each(a -> a.is_ip())`,
+				[2]string{`{"addresses":["10.0.0.1","fe80::1%eth0","10.0.0.256","nope"]}`, `{"valid":[true,true,false,false]}`},
+			).
+			Example("", `root.valid = this.address.is_ip(version: 6)`,
+				[2]string{`{"address":"10.0.0.1"}`, `{"valid":false}`},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			version, err := args.GetInt64("version")
+			if err != nil {
+				return nil, err
+			}
+			if version != 0 && version != 4 && version != 6 {
+				return nil, fmt.Errorf("version must be 4 or 6, got %v", version)
+			}
+			return bloblang.StringMethod(func(s string) (any, error) {
+				addr, err := parseIPAddr(s)
+				if err != nil {
+					return false, nil
+				}
+				return version == 0 || ipVersion(addr) == version, nil
+			}), nil
+		})
+
+	bloblang.MustRegisterMethodV2("parse_ip",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryNetwork).
+			Description(`Parses an IPv4 or IPv6 address into an object containing the address in its canonical form, its version, any IPv6 zone and whether it belongs to any of the following classes of address:
+
+- `+"`is_private`"+`: Private ranges defined by RFC 1918 and RFC 4193.
+- `+"`is_loopback`"+`: Loopback addresses, such as `+"`127.0.0.1`"+` and `+"`::1`"+`.
+- `+"`is_multicast`"+`: Multicast addresses.
+- `+"`is_link_local`"+`: Link-local unicast and multicast addresses.
+- `+"`is_unspecified`"+`: The unspecified addresses `+"`0.0.0.0`"+` and `+"`::`"+`.
+- `+"`is_global_unicast`"+`: Global unicast addresses, which includes private addresses.
+
+IPv4-mapped IPv6 addresses are converted to IPv4 addresses.`).
+			Example("", `root = this.address.parse_ip()`,
+				[2]string{
+					`{"address":"192.168.0.10"}`,
+					`{"address":"192.168.0.10","is_global_unicast":true,"is_link_local":false,"is_loopback":false,"is_multicast":false,"is_private":true,"is_unspecified":false,"version":4}`,
+				},
+				[2]string{
+					`{"address":"FE80:0000::0001%eth0"}`,
+					`{"address":"fe80::1","is_global_unicast":false,"is_link_local":true,"is_loopback":false,"is_multicast":false,"is_private":false,"is_unspecified":false,"version":6,"zone":"eth0"}`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			return bloblang.StringMethod(func(s string) (any, error) {
+				addr, err := parseIPAddr(s)
+				if err != nil {
+					return nil, err
+				}
+				res := map[string]any{
+					"address":           addr.WithZone("").String(),
+					"version":           ipVersion(addr),
+					"is_private":        addr.IsPrivate(),
+					"is_loopback":       addr.IsLoopback(),
+					"is_multicast":      addr.IsMulticast(),
+					"is_link_local":     addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast(),
+					"is_unspecified":    addr.IsUnspecified(),
+					"is_global_unicast": addr.IsGlobalUnicast(),
+				}
+				if zone := addr.Zone(); zone != "" {
+					res["zone"] = zone
+				}
+				return res, nil
+			}), nil
+		})
+
+	bloblang.MustRegisterMethodV2("parse_cidr",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryNetwork).
+			Description(`Parses a CIDR range such as `+"`192.168.1.10/24`"+` into an object containing the address, the prefix length, the IP version, the network in CIDR notation, the network address and the last address of the range. IPv4 ranges also contain a broadcast address. An address without a prefix length is treated as a range containing only that address.`).
+			Example("", `root = this.range.parse_cidr()`,
+				[2]string{
+					`{"range":"192.168.1.10/20"}`,
+					`{"address":"192.168.1.10","broadcast_address":"192.168.15.255","last_address":"192.168.15.255","network":"192.168.0.0/20","network_address":"192.168.0.0","prefix_length":20,"version":4}`,
+				},
+				[2]string{
+					`{"range":"2001:db8:abcd:12::1/48"}`,
+					`{"address":"2001:db8:abcd:12::1","last_address":"2001:db8:abcd:ffff:ffff:ffff:ffff:ffff","network":"2001:db8:abcd::/48","network_address":"2001:db8:abcd::","prefix_length":48,"version":6}`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			return bloblang.StringMethod(func(s string) (any, error) {
+				prefix, err := parseIPPrefix(s)
+				if err != nil {
+					return nil, err
+				}
+				masked := prefix.Masked()
+				last := lastIPAddr(prefix)
+				res := map[string]any{
+					"address":         prefix.Addr().String(),
+					"prefix_length":   int64(prefix.Bits()),
+					"version":         ipVersion(prefix.Addr()),
+					"network":         masked.String(),
+					"network_address": masked.Addr().String(),
+					"last_address":    last.String(),
+				}
+				if prefix.Addr().Is4() {
+					res["broadcast_address"] = last.String()
+				}
+				return res, nil
+			}), nil
+		})
+
+	bloblang.MustRegisterMethodV2("ip_in_cidr",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryNetwork).
+			Description(`Checks whether an IPv4 or IPv6 address is within any of one or more CIDR ranges. Addresses without a prefix length are matched exactly. When the ranges are provided as a literal they are compiled once into a prefix trie, which makes checking against large lists of ranges efficient.`).
+			Param(bloblang.NewAnyParam("cidrs").Description("A CIDR range or an array of CIDR ranges.")).
+			Example("", `root.internal = this.address.ip_in_cidr(["10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7"])`,
+				[2]string{`{"address":"172.20.1.1"}`, `{"internal":true}`},
+				[2]string{`{"address":"8.8.8.8"}`, `{"internal":false}`},
+				[2]string{`{"address":"fd12:3456::1"}`, `{"internal":true}`},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			cidrsV, err := args.Get("cidrs")
+			if err != nil {
+				return nil, err
+			}
+			var cidrs []any
+			switch t := cidrsV.(type) {
+			case string:
+				cidrs = []any{t}
+			case []any:
+				cidrs = t
+			default:
+				return nil, fmt.Errorf("cidrs: %w", value.NewTypeError(cidrsV, value.TString, value.TArray))
+			}
+
+			prefixes := make([]netip.Prefix, len(cidrs))
+			for i, c := range cidrs {
+				cStr, ok := c.(string)
+				if !ok {
+					return nil, fmt.Errorf("cidrs element %v: %w", i, value.NewTypeError(c, value.TString))
+				}
+				if prefixes[i], err = parseIPPrefix(cStr); err != nil {
+					return nil, fmt.Errorf("cidrs element %v: %w", i, err)
+				}
+			}
+			trie := newIPPrefixTrie(prefixes)
+
+			return bloblang.StringMethod(func(s string) (any, error) {
+				addr, err := parseIPAddr(s)
+				if err != nil {
+					return nil, err
+				}
+				return trie.contains(addr.WithZone("")), nil
+			}), nil
+		})
+
+	bloblang.MustRegisterMethodV2("mask_ip",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryNetwork).
+			Description(`Anonymises an IPv4 or IPv6 address by zeroing all bits following a prefix length, with separate prefix lengths for each version.`).
+			Param(bloblang.NewInt64Param("ipv4_prefix_length").Description("The number of leading bits of IPv4 addresses to keep.").Default(24)).
+			Param(bloblang.NewInt64Param("ipv6_prefix_length").Description("The number of leading bits of IPv6 addresses to keep.").Default(48)).
+			Example("", `root.addresses = this.addresses.map_each(a -> a.mask_ip())`,
+				[2]string{
+					`{"addresses":["203.0.113.195","2001:db8:85a3:8d3:1319:8a2e:370:7348"]}`,
+					`{"addresses":["203.0.113.0","2001:db8:85a3::"]}`,
+				},
+			).
+			Example("", `root.address = this.address.mask_ip(ipv4_prefix_length: 16)`,
+				[2]string{`{"address":"203.0.113.195"}`, `{"address":"203.0.0.0"}`},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			v4Bits, err := args.GetInt64("ipv4_prefix_length")
+			if err != nil {
+				return nil, err
+			}
+			v6Bits, err := args.GetInt64("ipv6_prefix_length")
+			if err != nil {
+				return nil, err
+			}
+			if v4Bits < 0 || v4Bits > 32 {
+				return nil, fmt.Errorf("ipv4_prefix_length must be between 0 and 32, got %v", v4Bits)
+			}
+			if v6Bits < 0 || v6Bits > 128 {
+				return nil, fmt.Errorf("ipv6_prefix_length must be between 0 and 128, got %v", v6Bits)
+			}
+			return bloblang.StringMethod(func(s string) (any, error) {
+				addr, err := parseIPAddr(s)
+				if err != nil {
+					return nil, err
+				}
+				bits := v6Bits
+				if addr.Is4() {
+					bits = v4Bits
+				}
+				prefix, err := addr.WithZone("").Prefix(int(bits))
+				if err != nil {
+					return nil, err
+				}
+				return prefix.Addr().String(), nil
+			}), nil
+		})
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"math/rand/v2"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+func TestIPPrefixTrie(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+
+	randAddr := func(v4 bool) netip.Addr {
+		if v4 {
+			var b [4]byte
+			for i := range b {
+				b[i] = byte(rng.IntN(4))
+			}
+			return netip.AddrFrom4(b)
+		}
+		var b [16]byte
+		for i := range b {
+			b[i] = byte(rng.IntN(4))
+		}
+		return netip.AddrFrom16(b)
+	}
+
+	var prefixes []netip.Prefix
+	for range 200 {
+		v4 := rng.IntN(2) == 0
+		addr := randAddr(v4)
+		prefixes = append(prefixes, netip.PrefixFrom(addr, rng.IntN(addr.BitLen()+1-8)+8))
+	}
+	trie := newIPPrefixTrie(prefixes)
+
+	for range 5000 {
+		addr := randAddr(rng.IntN(2) == 0)
+		exp := false
+		for _, p := range prefixes {
+			if p.Contains(addr) {
+				exp = true
+				break
+			}
+		}
+		require.Equal(t, exp, trie.contains(addr), addr.String())
+	}
+
+	all := newIPPrefixTrie([]netip.Prefix{netip.MustParsePrefix("0.0.0.0/0")})
+	assert.True(t, all.contains(netip.MustParseAddr("1.2.3.4")))
+	assert.False(t, all.contains(netip.MustParseAddr("::1")))
+
+	none := newIPPrefixTrie(nil)
+	assert.False(t, none.contains(netip.MustParseAddr("1.2.3.4")))
+}
+
+func TestIPMethods(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mapping string
+		input   string
+		output  string
+		execErr string
+	}{
+		{
+			name:    "in cidr single range",
+			mapping: `root = this.ips.map_each(ip -> ip.ip_in_cidr("192.168.0.0/16"))`,
+			input:   `{"ips":["192.168.1.1","192.169.0.1","::ffff:192.168.4.4"]}`,
+			output:  `[true,false,true]`,
+		},
+		{
+			name:    "in cidr exact addresses and mapped ranges",
+			mapping: `root = this.ips.map_each(ip -> ip.ip_in_cidr(["10.0.0.1", "::ffff:172.16.0.0/108", "fe80::/10"]))`,
+			input:   `{"ips":["10.0.0.1","10.0.0.2","172.16.3.4","fe80::1%eth0"]}`,
+			output:  `[true,false,true,true]`,
+		},
+		{
+			name:    "in cidr dynamic ranges",
+			mapping: `root = this.ip.ip_in_cidr(this.ranges)`,
+			input:   `{"ip":"2001:db8::5","ranges":["2001:db8::/125"]}`,
+			output:  `true`,
+		},
+		{
+			name:    "in cidr bad range",
+			mapping: `root = this.ip.ip_in_cidr(this.ranges)`,
+			input:   `{"ip":"10.0.0.1","ranges":["10.0.0.0/33"]}`,
+			execErr: "cidrs element 0: failed to parse CIDR range",
+		},
+		{
+			name:    "in cidr bad address",
+			mapping: `root = this.ip.ip_in_cidr("10.0.0.0/8")`,
+			input:   `{"ip":"10.0.0"}`,
+			execErr: "failed to parse IP address",
+		},
+		{
+			name:    "parse cidr single address",
+			mapping: `root = this.range.parse_cidr()`,
+			input:   `{"range":"10.1.2.3"}`,
+			output:  `{"address":"10.1.2.3","broadcast_address":"10.1.2.3","last_address":"10.1.2.3","network":"10.1.2.3/32","network_address":"10.1.2.3","prefix_length":32,"version":4}`,
+		},
+		{
+			name:    "parse cidr zero prefix",
+			mapping: `root = this.range.parse_cidr()`,
+			input:   `{"range":"::/0"}`,
+			output:  `{"address":"::","last_address":"ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff","network":"::/0","network_address":"::","prefix_length":0,"version":6}`,
+		},
+		{
+			name:    "parse ip mapped",
+			mapping: `root = this.ip.parse_ip()`,
+			input:   `{"ip":"::ffff:127.0.0.1"}`,
+			output:  `{"address":"127.0.0.1","is_global_unicast":false,"is_link_local":false,"is_loopback":true,"is_multicast":false,"is_private":false,"is_unspecified":false,"version":4}`,
+		},
+		{
+			name:    "parse ip multicast",
+			mapping: `root = this.ip.parse_ip()`,
+			input:   `{"ip":"ff02::1"}`,
+			output:  `{"address":"ff02::1","is_global_unicast":false,"is_link_local":true,"is_loopback":false,"is_multicast":true,"is_private":false,"is_unspecified":false,"version":6}`,
+		},
+		{
+			name:    "is ip versions",
+			mapping: `root = [this.a.is_ip(4), this.a.is_ip(6), this.b.is_ip(4), this.b.is_ip(6)]`,
+			input:   `{"a":"1.2.3.4","b":"::1"}`,
+			output:  `[true,false,false,true]`,
+		},
+		{
+			name:    "mask ip",
+			mapping: `root = this.ips.map_each(ip -> ip.mask_ip(ipv4_prefix_length: 0, ipv6_prefix_length: 128))`,
+			input:   `{"ips":["1.2.3.4","2001:db8::1%eth0"]}`,
+			output:  `["0.0.0.0","2001:db8::1"]`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := execJSONMapping(t, test.mapping, test.input)
+			if test.execErr != "" {
+				require.ErrorContains(t, err, test.execErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.output, res)
+		})
+	}
+
+	for _, mapping := range []string{
+		`root = this.is_ip(5)`,
+		`root = this.mask_ip(ipv4_prefix_length: 33)`,
+		`root = this.mask_ip(ipv6_prefix_length: -1)`,
+		`root = this.ip_in_cidr(["10.0.0.0/8", "nope"])`,
+		`root = this.ip_in_cidr(5)`,
+	} {
+		_, err := bloblang.Parse(mapping)
+		require.Error(t, err, mapping)
+	}
+}
@@ -0,0 +1,436 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
+	"github.com/redpanda-data/benthos/v4/internal/value"
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+func init() {
+	bloblang.MustRegisterMethodV2("create_json_patch",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryObjectAndArray).
+			Description("Computes an https://datatracker.ietf.org/doc/html/rfc6902[RFC 6902 JSON Patch^] that transforms the target value into the value provided as an argument. The resulting patch can be applied with the `apply_json_patch` method. Object keys are compared in lexicographical order and arrays are compared element by element, where elements beyond the length of the shorter array are added or removed.").
+			Param(bloblang.NewAnyParam("value").Description("The value that the patch should produce.")).
+			Example("", `root = this.before.create_json_patch(this.after)`,
+				[2]string{
+					`{"before":{"id":"foo","tags":["a","b"],"count":1},"after":{"id":"foo","tags":["a"],"count":2,"new":true}}`,
+					`[{"op":"replace","path":"/count","value":2},{"op":"add","path":"/new","value":true},{"op":"remove","path":"/tags/1"}]`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			to, err := args.Get("value")
+			if err != nil {
+				return nil, err
+			}
+			return func(v any) (any, error) {
+				ops := []any{}
+				createJSONPatch("", v, to, &ops)
+				return ops, nil
+			}, nil
+		})
+
+	bloblang.MustRegisterMethodV2("apply_json_patch",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryObjectAndArray).
+			Description("Applies an https://datatracker.ietf.org/doc/html/rfc6902[RFC 6902 JSON Patch^] to the target value and returns the result. The operations `add`, `remove`, `replace`, `move`, `copy` and `test` are supported. If any operation fails, including a `test` operation that does not match, then the method fails with an error identifying the operation and none of the changes are returned.").
+			Param(bloblang.NewAnyParam("patch").Description("An array of JSON Patch operations.")).
+			Example("", `root = this.doc.apply_json_patch(this.patch)`,
+				[2]string{
+					`{"doc":{"id":"foo","tags":["a"]},"patch":[{"op":"add","path":"/tags/-","value":"b"},{"op":"move","from":"/id","path":"/name"}]}`,
+					`{"name":"foo","tags":["a","b"]}`,
+				},
+				[2]string{
+					`{"doc":{"id":"foo"},"patch":[{"op":"test","path":"/id","value":"bar"},{"op":"remove","path":"/id"}]}`,
+					`Error("failed assignment (line 1): operation 0 (test): value at path /id does not match")`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			patchV, err := args.Get("patch")
+			if err != nil {
+				return nil, err
+			}
+			ops, err := parseJSONPatch(patchV)
+			if err != nil {
+				return nil, err
+			}
+			return func(v any) (any, error) {
+				return applyJSONPatch(v, ops)
+			}, nil
+		})
+
+	bloblang.MustRegisterMethodV2("apply_merge_patch",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryObjectAndArray).
+			Description("Applies an https://datatracker.ietf.org/doc/html/rfc7396[RFC 7396 JSON Merge Patch^] to the target value and returns the result. Fields of the patch set to `null` are removed from the target, objects are merged recursively and any other values replace those of the target.").
+			Param(bloblang.NewAnyParam("patch").Description("The merge patch to apply.")).
+			Example("", `root = this.doc.apply_merge_patch(this.patch)`,
+				[2]string{
+					`{"doc":{"title":"Goodbye!","author":{"givenName":"John","familyName":"Doe"},"tags":["example","sample"]},"patch":{"title":"Hello!","author":{"familyName":null},"tags":["example"]}}`,
+					`{"author":{"givenName":"John"},"tags":["example"],"title":"Hello!"}`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			patch, err := args.Get("patch")
+			if err != nil {
+				return nil, err
+			}
+			return func(v any) (any, error) {
+				return applyMergePatch(value.IClone(v), patch), nil
+			}, nil
+		})
+
+	bloblang.MustRegisterMethodV2("diff",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryObjectAndArray).
+			Description("Compares the target value against the value provided as an argument and returns an object containing the xref:configuration:field_paths.adoc[field paths] that were `added`, `removed` and `changed` in the argument. Objects are compared key by key in lexicographical order and arrays are compared element by element.").
+			Param(bloblang.NewAnyParam("value").Description("The value to compare against.")).
+			Example("", `root = this.before.diff(this.after)`,
+				[2]string{
+					`{"before":{"id":"foo","user":{"name":"bar","age":20},"tags":["a","b"]},"after":{"id":"foo","user":{"name":"baz","email":"baz@example.com"},"tags":["a"]}}`,
+					`{"added":["user.email"],"changed":["user.name"],"removed":["tags.1","user.age"]}`,
+				},
+			).
+			Example("Check whether any fields have changed.", `root.changed = this.before.diff(this.after).values().flatten().length() > 0`,
+				[2]string{
+					`{"before":{"id":"foo"},"after":{"id":"foo"}}`,
+					`{"changed":false}`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			to, err := args.Get("value")
+			if err != nil {
+				return nil, err
+			}
+			return func(v any) (any, error) {
+				d := structuralDiff{added: []string{}, removed: []string{}, changed: []string{}}
+				d.compare(nil, v, to)
+				return map[string]any{
+					"added":   stringsToAnys(d.added),
+					"removed": stringsToAnys(d.removed),
+					"changed": stringsToAnys(d.changed),
+				}, nil
+			}, nil
+		})
+}
+
+func stringsToAnys(s []string) []any {
+	a := make([]any, len(s))
+	for i, v := range s {
+		a[i] = v
+	}
+	return a
+}
+
+//------------------------------------------------------------------------------
+
+func jsonPatchOp(op, path string, v any) map[string]any {
+	m := map[string]any{"op": op, "path": path}
+	if op != "remove" {
+		m["value"] = value.IClone(v)
+	}
+	return m
+}
+
+// unionKeysSorted returns the keys present in either of two objects in
+// lexicographical order.
+func unionKeysSorted(a, b map[string]any) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, exists := a[k]; !exists {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func createJSONPatch(path string, from, to any, ops *[]any) {
+	switch fromT := from.(type) {
+	case map[string]any:
+		toT, ok := to.(map[string]any)
+		if !ok {
+			break
+		}
+		for _, k := range unionKeysSorted(fromT, toT) {
+			childPath := path + "/" + escapeJSONPointerToken(k)
+			fromV, inFrom := fromT[k]
+			toV, inTo := toT[k]
+			switch {
+			case !inTo:
+				*ops = append(*ops, jsonPatchOp("remove", childPath, nil))
+			case !inFrom:
+				*ops = append(*ops, jsonPatchOp("add", childPath, toV))
+			default:
+				createJSONPatch(childPath, fromV, toV, ops)
+			}
+		}
+		return
+	case []any:
+		toT, ok := to.([]any)
+		if !ok {
+			break
+		}
+		common := min(len(fromT), len(toT))
+		for i := 0; i < common; i++ {
+			createJSONPatch(path+"/"+strconv.Itoa(i), fromT[i], toT[i], ops)
+		}
+		// Remove trailing elements from the end so that the indexes of
+		// preceding removals remain valid.
+		for i := len(fromT) - 1; i >= common; i-- {
+			*ops = append(*ops, jsonPatchOp("remove", path+"/"+strconv.Itoa(i), nil))
+		}
+		for i := common; i < len(toT); i++ {
+			*ops = append(*ops, jsonPatchOp("add", path+"/"+strconv.Itoa(i), toT[i]))
+		}
+		return
+	}
+	if !structurallyEqual(from, to) {
+		*ops = append(*ops, jsonPatchOp("replace", path, to))
+	}
+}
+
+// structurallyEqual is similar to value.ICompare but also distinguishes
+// between objects with missing keys and those with null values.
+func structurallyEqual(left, right any) bool {
+	switch lhs := left.(type) {
+	case map[string]any:
+		rhs, ok := right.(map[string]any)
+		if !ok || len(lhs) != len(rhs) {
+			return false
+		}
+		for k, lv := range lhs {
+			rv, exists := rhs[k]
+			if !exists || !structurallyEqual(lv, rv) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		rhs, ok := right.([]any)
+		if !ok || len(lhs) != len(rhs) {
+			return false
+		}
+		for i, lv := range lhs {
+			if !structurallyEqual(lv, rhs[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return value.ICompare(left, right)
+}
+
+type jsonPatchOperation struct {
+	op    string
+	path  []string
+	from  []string
+	value any
+
+	rawPath string
+}
+
+func parseJSONPatch(v any) ([]jsonPatchOperation, error) {
+	arr, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected patch to be an array, got %v", value.ITypeOf(v))
+	}
+	ops := make([]jsonPatchOperation, len(arr))
+	for i, opV := range arr {
+		op, err := parseJSONPatchOperation(opV)
+		if err != nil {
+			return nil, fmt.Errorf("operation %v: %w", i, err)
+		}
+		ops[i] = op
+	}
+	return ops, nil
+}
+
+func parseJSONPatchOperation(v any) (op jsonPatchOperation, err error) {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return op, fmt.Errorf("expected object, got %v", value.ITypeOf(v))
+	}
+
+	getPointer := func(field string) ([]string, string, error) {
+		pV, exists := obj[field]
+		if !exists {
+			return nil, "", fmt.Errorf("missing field %v", field)
+		}
+		pStr, ok := pV.(string)
+		if !ok {
+			return nil, "", fmt.Errorf("field %v: expected string, got %v", field, value.ITypeOf(pV))
+		}
+		tokens, err := parseJSONPointer(pStr)
+		if err != nil {
+			return nil, "", fmt.Errorf("field %v: %w", field, err)
+		}
+		return tokens, pStr, nil
+	}
+
+	if op.op, ok = obj["op"].(string); !ok {
+		return op, errors.New("missing string field op")
+	}
+	if op.path, op.rawPath, err = getPointer("path"); err != nil {
+		return
+	}
+
+	switch op.op {
+	case "add", "replace", "test":
+		var exists bool
+		if op.value, exists = obj["value"]; !exists {
+			return op, fmt.Errorf("%v: missing field value", op.op)
+		}
+	case "move", "copy":
+		if op.from, _, err = getPointer("from"); err != nil {
+			return op, fmt.Errorf("%v: %w", op.op, err)
+		}
+		if op.op == "move" && len(op.from) < len(op.path) && slicesHavePrefix(op.path, op.from) {
+			return op, errors.New("move: a value cannot be moved into one of its children")
+		}
+	case "remove":
+	default:
+		return op, fmt.Errorf("unrecognised op %q", op.op)
+	}
+	return op, nil
+}
+
+func slicesHavePrefix(s, prefix []string) bool {
+	if len(prefix) > len(s) {
+		return false
+	}
+	for i, p := range prefix {
+		if s[i] != p {
+			return false
+		}
+	}
+	return true
+}
+
+func applyJSONPatch(doc any, ops []jsonPatchOperation) (any, error) {
+	doc = value.IClone(doc)
+	for i, op := range ops {
+		var err error
+		switch op.op {
+		case "add":
+			doc, err = jsonPointerAdd(doc, op.path, value.IClone(op.value))
+		case "remove":
+			doc, _, err = jsonPointerRemove(doc, op.path)
+		case "replace":
+			doc, err = jsonPointerReplace(doc, op.path, value.IClone(op.value))
+		case "move":
+			var moved any
+			if doc, moved, err = jsonPointerRemove(doc, op.from); err == nil {
+				doc, err = jsonPointerAdd(doc, op.path, moved)
+			}
+		case "copy":
+			var copied any
+			if copied, err = jsonPointerGet(doc, op.from); err == nil {
+				doc, err = jsonPointerAdd(doc, op.path, value.IClone(copied))
+			}
+		case "test":
+			var current any
+			if current, err = jsonPointerGet(doc, op.path); err == nil && !structurallyEqual(current, op.value) {
+				err = fmt.Errorf("value at path %v does not match", op.rawPath)
+			}
+		}
+		if err != nil {
+			return nil, fmt.Errorf("operation %v (%v): %w", i, op.op, err)
+		}
+	}
+	return doc, nil
+}
+
+//------------------------------------------------------------------------------
+
+// applyMergePatch applies an RFC 7396 merge patch to a target, which may be
+// modified in place.
+func applyMergePatch(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return value.IClone(patch)
+	}
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = map[string]any{}
+	}
+	for k, v := range patchObj {
+		if v == nil {
+			delete(targetObj, k)
+			continue
+		}
+		targetObj[k] = applyMergePatch(targetObj[k], v)
+	}
+	return targetObj
+}
+
+//------------------------------------------------------------------------------
+
+type structuralDiff struct {
+	added   []string
+	removed []string
+	changed []string
+}
+
+func diffPath(path []string) string {
+	escaped := make([]string, len(path))
+	for i, p := range path {
+		escaped[i] = strings.ReplaceAll(strings.ReplaceAll(p, "~", "~0"), ".", "~1")
+	}
+	return strings.Join(escaped, ".")
+}
+
+func (d *structuralDiff) compare(path []string, from, to any) {
+	switch fromT := from.(type) {
+	case map[string]any:
+		toT, ok := to.(map[string]any)
+		if !ok {
+			break
+		}
+		for _, k := range unionKeysSorted(fromT, toT) {
+			childPath := append(path[:len(path):len(path)], k)
+			fromV, inFrom := fromT[k]
+			toV, inTo := toT[k]
+			switch {
+			case !inTo:
+				d.removed = append(d.removed, diffPath(childPath))
+			case !inFrom:
+				d.added = append(d.added, diffPath(childPath))
+			default:
+				d.compare(childPath, fromV, toV)
+			}
+		}
+		return
+	case []any:
+		toT, ok := to.([]any)
+		if !ok {
+			break
+		}
+		for i, fromV := range fromT {
+			childPath := append(path[:len(path):len(path)], strconv.Itoa(i))
+			if i < len(toT) {
+				d.compare(childPath, fromV, toT[i])
+			} else {
+				d.removed = append(d.removed, diffPath(childPath))
+			}
+		}
+		for i := len(fromT); i < len(toT); i++ {
+			d.added = append(d.added, diffPath(append(path[:len(path):len(path)], strconv.Itoa(i))))
+		}
+		return
+	}
+	if !structurallyEqual(from, to) {
+		d.changed = append(d.changed, diffPath(path))
+	}
+}
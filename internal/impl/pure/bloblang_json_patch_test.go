@@ -0,0 +1,357 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+func execJSONMapping(t *testing.T, mapping, input string) (string, error) {
+	t.Helper()
+
+	exec, err := bloblang.Parse(mapping)
+	require.NoError(t, err)
+
+	var inputV any
+	require.NoError(t, json.Unmarshal([]byte(input), &inputV))
+
+	res, err := exec.Query(inputV)
+	if err != nil {
+		return "", err
+	}
+	resBytes, err := json.Marshal(res)
+	require.NoError(t, err)
+	return string(resBytes), nil
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	testCases := []struct {
+		name    string
+		doc     string
+		patch   string
+		output  string
+		execErr string
+	}{
+		{
+			name:   "add object member",
+			doc:    `{"foo":"bar"}`,
+			patch:  `[{"op":"add","path":"/baz","value":"qux"}]`,
+			output: `{"baz":"qux","foo":"bar"}`,
+		},
+		{
+			name:   "add array element",
+			doc:    `{"foo":["bar","baz"]}`,
+			patch:  `[{"op":"add","path":"/foo/1","value":"qux"}]`,
+			output: `{"foo":["bar","qux","baz"]}`,
+		},
+		{
+			name:   "add to end of array",
+			doc:    `{"foo":["bar"]}`,
+			patch:  `[{"op":"add","path":"/foo/-","value":["abc","def"]}]`,
+			output: `{"foo":["bar",["abc","def"]]}`,
+		},
+		{
+			name:   "remove array element",
+			doc:    `{"foo":["bar","qux","baz"]}`,
+			patch:  `[{"op":"remove","path":"/foo/1"}]`,
+			output: `{"foo":["bar","baz"]}`,
+		},
+		{
+			name:   "replace value",
+			doc:    `{"baz":"qux","foo":"bar"}`,
+			patch:  `[{"op":"replace","path":"/baz","value":"boo"}]`,
+			output: `{"baz":"boo","foo":"bar"}`,
+		},
+		{
+			name:   "replace root",
+			doc:    `{"foo":"bar"}`,
+			patch:  `[{"op":"replace","path":"","value":[1]}]`,
+			output: `[1]`,
+		},
+		{
+			name:   "move value",
+			doc:    `{"foo":{"bar":"baz","waldo":"fred"},"qux":{"corge":"grault"}}`,
+			patch:  `[{"op":"move","from":"/foo/waldo","path":"/qux/thud"}]`,
+			output: `{"foo":{"bar":"baz"},"qux":{"corge":"grault","thud":"fred"}}`,
+		},
+		{
+			name:   "move array element",
+			doc:    `{"foo":["all","grass","cows","eat"]}`,
+			patch:  `[{"op":"move","from":"/foo/1","path":"/foo/3"}]`,
+			output: `{"foo":["all","cows","eat","grass"]}`,
+		},
+		{
+			name:   "copy value",
+			doc:    `{"foo":{"bar":[1]}}`,
+			patch:  `[{"op":"copy","from":"/foo/bar","path":"/baz"},{"op":"add","path":"/baz/-","value":2}]`,
+			output: `{"baz":[1,2],"foo":{"bar":[1]}}`,
+		},
+		{
+			name:   "test passes",
+			doc:    `{"baz":"qux","foo":["a",2,"c"]}`,
+			patch:  `[{"op":"test","path":"/baz","value":"qux"},{"op":"test","path":"/foo/1","value":2}]`,
+			output: `{"baz":"qux","foo":["a",2,"c"]}`,
+		},
+		{
+			name:   "escaped keys",
+			doc:    `{"a/b":{"m~n":1}}`,
+			patch:  `[{"op":"replace","path":"/a~1b/m~0n","value":2}]`,
+			output: `{"a/b":{"m~n":2}}`,
+		},
+		{
+			name:    "test fails",
+			doc:     `{"baz":"qux"}`,
+			patch:   `[{"op":"test","path":"/baz","value":"bar"}]`,
+			execErr: "operation 0 (test): value at path /baz does not match",
+		},
+		{
+			name:    "test null against missing key",
+			doc:     `{"a":{}}`,
+			patch:   `[{"op":"test","path":"/a","value":{"b":null}}]`,
+			execErr: "operation 0 (test): value at path /a does not match",
+		},
+		{
+			name:    "add to nonexistent parent",
+			doc:     `{"foo":"bar"}`,
+			patch:   `[{"op":"add","path":"/baz","value":1},{"op":"add","path":"/baz/bat/qux","value":1}]`,
+			execErr: `operation 1 (add): cannot reference "bat" within number value`,
+		},
+		{
+			name:    "remove missing key",
+			doc:     `{"foo":"bar"}`,
+			patch:   `[{"op":"remove","path":"/baz"}]`,
+			execErr: `operation 0 (remove): field "baz" does not exist`,
+		},
+		{
+			name:    "replace out of bounds",
+			doc:     `{"foo":[1]}`,
+			patch:   `[{"op":"replace","path":"/foo/1","value":2}]`,
+			execErr: `operation 0 (replace): array index 1 out of bounds`,
+		},
+		{
+			name:    "leading zero index",
+			doc:     `{"foo":[1,2]}`,
+			patch:   `[{"op":"remove","path":"/foo/01"}]`,
+			execErr: `operation 0 (remove): invalid array index "01"`,
+		},
+		{
+			name:    "move into child",
+			doc:     `{"foo":{"bar":1}}`,
+			patch:   `[{"op":"move","from":"/foo","path":"/foo/bar/baz"}]`,
+			execErr: `operation 0: move: a value cannot be moved into one of its children`,
+		},
+		{
+			name:    "unknown op",
+			doc:     `{}`,
+			patch:   `[{"op":"nope","path":"/foo"}]`,
+			execErr: `operation 0: unrecognised op "nope"`,
+		},
+		{
+			name:    "missing value",
+			doc:     `{}`,
+			patch:   `[{"op":"add","path":"/foo"}]`,
+			execErr: `operation 0: add: missing field value`,
+		},
+		{
+			name:    "bad pointer",
+			doc:     `{}`,
+			patch:   `[{"op":"remove","path":"foo"}]`,
+			execErr: `operation 0: field path: json pointer "foo" must begin with a slash`,
+		},
+		{
+			name:    "patch not an array",
+			doc:     `{}`,
+			patch:   `{"op":"remove","path":"/foo"}`,
+			execErr: `expected patch to be an array, got object`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := execJSONMapping(t, `root = this.doc.apply_json_patch(this.patch)`,
+				`{"doc":`+test.doc+`,"patch":`+test.patch+`}`)
+			if test.execErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.execErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, test.output, res)
+		})
+	}
+}
+
+func TestApplyJSONPatchDoesNotMutate(t *testing.T) {
+	exec, err := bloblang.Parse(`root = this.apply_json_patch([{"op":"remove","path":"/foo/0"},{"op":"add","path":"/bar/baz","value":1}])`)
+	require.NoError(t, err)
+
+	input := map[string]any{
+		"foo": []any{"a", "b"},
+		"bar": map[string]any{},
+	}
+	res, err := exec.Query(input)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{
+		"foo": []any{"b"},
+		"bar": map[string]any{"baz": int64(1)},
+	}, res)
+	assert.Equal(t, map[string]any{
+		"foo": []any{"a", "b"},
+		"bar": map[string]any{},
+	}, input)
+}
+
+func TestCreateJSONPatchRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name   string
+		from   string
+		to     string
+		output string
+	}{
+		{
+			name:   "identical",
+			from:   `{"a":[1,{"b":null}]}`,
+			to:     `{"a":[1,{"b":null}]}`,
+			output: `[]`,
+		},
+		{
+			name:   "root scalar",
+			from:   `"foo"`,
+			to:     `"bar"`,
+			output: `[{"op":"replace","path":"","value":"bar"}]`,
+		},
+		{
+			name:   "type change",
+			from:   `{"a":{"b":1}}`,
+			to:     `{"a":[1]}`,
+			output: `[{"op":"replace","path":"/a","value":[1]}]`,
+		},
+		{
+			name:   "null versus missing",
+			from:   `{"a":{}}`,
+			to:     `{"a":{"b":null}}`,
+			output: `[{"op":"add","path":"/a/b","value":null}]`,
+		},
+		{
+			name:   "array shrink",
+			from:   `[1,2,3,4]`,
+			to:     `[1,5]`,
+			output: `[{"op":"replace","path":"/1","value":5},{"op":"remove","path":"/3"},{"op":"remove","path":"/2"}]`,
+		},
+		{
+			name:   "array grow",
+			from:   `{"a":[]}`,
+			to:     `{"a":["x","y"]}`,
+			output: `[{"op":"add","path":"/a/0","value":"x"},{"op":"add","path":"/a/1","value":"y"}]`,
+		},
+		{
+			name:   "escaped keys",
+			from:   `{"a/b":1,"c~d":1}`,
+			to:     `{"a/b":2}`,
+			output: `[{"op":"replace","path":"/a~1b","value":2},{"op":"remove","path":"/c~0d"}]`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			input := `{"from":` + test.from + `,"to":` + test.to + `}`
+
+			res, err := execJSONMapping(t, `root = this.from.create_json_patch(this.to)`, input)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.output, res)
+
+			res, err = execJSONMapping(t, `root = this.from.apply_json_patch(this.from.create_json_patch(this.to))`, input)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.to, res)
+		})
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	// Test cases from https://datatracker.ietf.org/doc/html/rfc7396#appendix-A
+	testCases := []struct {
+		doc    string
+		patch  string
+		output string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.doc+" "+test.patch, func(t *testing.T) {
+			res, err := execJSONMapping(t, `root = this.doc.apply_merge_patch(this.patch)`,
+				`{"doc":`+test.doc+`,"patch":`+test.patch+`}`)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.output, res)
+		})
+	}
+}
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		name   string
+		from   string
+		to     string
+		output string
+	}{
+		{
+			name:   "identical",
+			from:   `{"a":[1,{"b":"c"}]}`,
+			to:     `{"a":[1,{"b":"c"}]}`,
+			output: `{"added":[],"removed":[],"changed":[]}`,
+		},
+		{
+			name:   "root scalar",
+			from:   `1`,
+			to:     `2`,
+			output: `{"added":[],"removed":[],"changed":[""]}`,
+		},
+		{
+			name:   "nested changes",
+			from:   `{"a":{"b":1,"c":2},"d":[1,2,3]}`,
+			to:     `{"a":{"b":1,"c":3,"e":4},"d":[1],"f":null}`,
+			output: `{"added":["a.e","f"],"removed":["d.1","d.2"],"changed":["a.c"]}`,
+		},
+		{
+			name:   "type change",
+			from:   `{"a":{"b":1}}`,
+			to:     `{"a":"b"}`,
+			output: `{"added":[],"removed":[],"changed":["a"]}`,
+		},
+		{
+			name:   "escaped keys",
+			from:   `{"a.b":{"c~d":1}}`,
+			to:     `{"a.b":{"c~d":2}}`,
+			output: `{"added":[],"removed":[],"changed":["a~1b.c~0d"]}`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := execJSONMapping(t, `root = this.from.diff(this.to)`,
+				`{"from":`+test.from+`,"to":`+test.to+`}`)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.output, res)
+		})
+	}
+}
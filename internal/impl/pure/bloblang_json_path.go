@@ -0,0 +1,48 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
+	"github.com/redpanda-data/benthos/v4/internal/jsonpath"
+	"github.com/redpanda-data/benthos/v4/internal/value"
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+func init() {
+	bloblang.MustRegisterMethodV2("json_path",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryObjectAndArray).
+			Description("Executes a https://datatracker.ietf.org/doc/html/rfc9535[JSONPath^] expression against a value and returns an array of the matched values, which is empty when nothing matches. Expressions support name, index, slice and wildcard selectors, recursive descent with `..` and filters such as `[?@.price < 10 && @.tags]`, including the filter functions `length`, `count`, `match` and `search`. Object fields are visited in lexicographical order of their keys. When the expression is a literal string it is compiled once when the mapping is parsed.").
+			Param(bloblang.NewStringParam("expression").Description("The JSONPath expression to execute.")).
+			Example("", `root.titles = this.json_path("$.store.book[?@.price < 10].title")`,
+				[2]string{
+					`{"store":{"book":[{"title":"Sayings of the Century","price":8.95},{"title":"Sword of Honour","price":12.99},{"title":"Moby Dick","price":8.99}]}}`,
+					`{"titles":["Sayings of the Century","Moby Dick"]}`,
+				},
+			).
+			Example("Use recursive descent to find fields at any depth, and `index` to select a single result.", `root.first_id = this.json_path("$..id").index(0)`,
+				[2]string{
+					`{"data":{"nested":[{"id":"foo"},{"id":"bar"}]}}`,
+					`{"first_id":"foo"}`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			expr, err := args.GetString("expression")
+			if err != nil {
+				return nil, err
+			}
+			path, err := jsonpath.Compile(expr)
+			if err != nil {
+				return nil, err
+			}
+			return func(v any) (any, error) {
+				matches := path.Query(v)
+				res := make([]any, len(matches))
+				for i, m := range matches {
+					res[i] = value.IClone(m)
+				}
+				return res, nil
+			}, nil
+		})
+}
@@ -0,0 +1,300 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
+	"github.com/redpanda-data/benthos/v4/internal/value"
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+func init() {
+	bloblang.MustRegisterMethodV2("get_pointer",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryObjectAndArray).
+			Description("Extract a field value, identified via an https://datatracker.ietf.org/doc/html/rfc6901[RFC 6901 JSON Pointer^], from an object or array. If the value does not exist then `null` is returned. When the pointer is a literal string it is parsed once when the mapping is parsed.").
+			Param(bloblang.NewStringParam("pointer").Description("A JSON Pointer, where an empty string refers to the whole value.")).
+			Example("", `root.count = this.get_pointer("/items/0/count")`,
+				[2]string{
+					`{"items":[{"count":10},{"count":20}]}`,
+					`{"count":10}`,
+				},
+			).
+			Example("Pointers can be provided dynamically, for example from metadata.", `root.selected = this.doc.get_pointer(this.selector)
+root.missing = this.doc.get_pointer("/nope")`,
+				[2]string{
+					`{"doc":{"a/b":{"c":"foo"}},"selector":"/a~1b/c"}`,
+					`{"missing":null,"selected":"foo"}`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			pointer, err := args.GetString("pointer")
+			if err != nil {
+				return nil, err
+			}
+			tokens, err := parseJSONPointer(pointer)
+			if err != nil {
+				return nil, err
+			}
+			return func(v any) (any, error) {
+				res, err := jsonPointerGet(v, tokens)
+				if err != nil {
+					return nil, nil
+				}
+				return res, nil
+			}, nil
+		})
+
+	bloblang.MustRegisterMethodV2("set_pointer",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryObjectAndArray).
+			Description("Returns a copy of an object or array with a value set at a location identified via an https://datatracker.ietf.org/doc/html/rfc6901[RFC 6901 JSON Pointer^]. Objects are created for any parent fields of the location that do not exist, and the token `-` appends a value to an array. When the pointer is a literal string it is parsed once when the mapping is parsed.").
+			Param(bloblang.NewStringParam("pointer").Description("A JSON Pointer, where an empty string refers to the whole value.")).
+			Param(bloblang.NewAnyParam("value").Description("The value to set.")).
+			Example("", `root = this.set_pointer("/items/0/count", 15).set_pointer("/tags/-", "new")`,
+				[2]string{
+					`{"items":[{"count":10}],"tags":["old"]}`,
+					`{"items":[{"count":15}],"tags":["old","new"]}`,
+				},
+			).
+			Example("", `root = this.set_pointer("/a/b/c", true)`,
+				[2]string{
+					`{"id":"foo"}`,
+					`{"a":{"b":{"c":true}},"id":"foo"}`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			pointer, err := args.GetString("pointer")
+			if err != nil {
+				return nil, err
+			}
+			tokens, err := parseJSONPointer(pointer)
+			if err != nil {
+				return nil, err
+			}
+			newValue, err := args.Get("value")
+			if err != nil {
+				return nil, err
+			}
+			return func(v any) (any, error) {
+				return jsonPointerSet(value.IClone(v), tokens, value.IClone(newValue))
+			}, nil
+		})
+}
+
+// escapeJSONPointerToken escapes a single reference token of a JSON Pointer
+// as per RFC 6901.
+func escapeJSONPointerToken(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
+// parseJSONPointer parses an RFC 6901 JSON Pointer into its unescaped
+// reference tokens, where the empty string refers to the whole document.
+func parseJSONPointer(p string) ([]string, error) {
+	if p == "" {
+		return nil, nil
+	}
+	if p[0] != '/' {
+		return nil, fmt.Errorf("json pointer %q must begin with a slash", p)
+	}
+	tokens := strings.Split(p[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// jsonPointerArrayIndex parses a reference token as an index of an array of a
+// given length. When allowEnd is true the index may refer to the position
+// after the last element, either explicitly or with the token `-`.
+func jsonPointerArrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if token == "-" {
+		if allowEnd {
+			return length, nil
+		}
+		return 0, errors.New("index - refers to a nonexistent array element")
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if i > length || (i == length && !allowEnd) {
+		return 0, fmt.Errorf("array index %v out of bounds", i)
+	}
+	return i, nil
+}
+
+// jsonPointerGet returns the value referenced by a parsed JSON Pointer.
+func jsonPointerGet(v any, tokens []string) (any, error) {
+	for _, t := range tokens {
+		switch node := v.(type) {
+		case map[string]any:
+			var exists bool
+			if v, exists = node[t]; !exists {
+				return nil, fmt.Errorf("field %q does not exist", t)
+			}
+		case []any:
+			i, err := jsonPointerArrayIndex(t, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			v = node[i]
+		default:
+			return nil, fmt.Errorf("cannot reference %q within %v value", t, value.ITypeOf(v))
+		}
+	}
+	return v, nil
+}
+
+// jsonPointerModify calls fn with the container of the last token of a parsed
+// JSON Pointer and replaces that container with the result, returning the new
+// root value. The tokens must not be empty.
+func jsonPointerModify(v any, tokens []string, fn func(container any, token string) (any, error)) (any, error) {
+	if len(tokens) == 1 {
+		return fn(v, tokens[0])
+	}
+	switch node := v.(type) {
+	case map[string]any:
+		child, exists := node[tokens[0]]
+		if !exists {
+			return nil, fmt.Errorf("field %q does not exist", tokens[0])
+		}
+		newChild, err := jsonPointerModify(child, tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[tokens[0]] = newChild
+		return node, nil
+	case []any:
+		i, err := jsonPointerArrayIndex(tokens[0], len(node), false)
+		if err != nil {
+			return nil, err
+		}
+		newChild, err := jsonPointerModify(node[i], tokens[1:], fn)
+		if err != nil {
+			return nil, err
+		}
+		node[i] = newChild
+		return node, nil
+	}
+	return nil, fmt.Errorf("cannot reference %q within %v value", tokens[0], value.ITypeOf(v))
+}
+
+func jsonPointerAdd(v any, tokens []string, newValue any) (any, error) {
+	if len(tokens) == 0 {
+		return newValue, nil
+	}
+	return jsonPointerModify(v, tokens, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			node[token] = newValue
+			return node, nil
+		case []any:
+			i, err := jsonPointerArrayIndex(token, len(node), true)
+			if err != nil {
+				return nil, err
+			}
+			node = append(node, nil)
+			copy(node[i+1:], node[i:])
+			node[i] = newValue
+			return node, nil
+		}
+		return nil, fmt.Errorf("cannot add %q to %v value", token, value.ITypeOf(container))
+	})
+}
+
+func jsonPointerReplace(v any, tokens []string, newValue any) (any, error) {
+	if len(tokens) == 0 {
+		return newValue, nil
+	}
+	return jsonPointerModify(v, tokens, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			if _, exists := node[token]; !exists {
+				return nil, fmt.Errorf("field %q does not exist", token)
+			}
+			node[token] = newValue
+			return node, nil
+		case []any:
+			i, err := jsonPointerArrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			node[i] = newValue
+			return node, nil
+		}
+		return nil, fmt.Errorf("cannot reference %q within %v value", token, value.ITypeOf(container))
+	})
+}
+
+func jsonPointerRemove(v any, tokens []string) (newRoot, removed any, err error) {
+	if len(tokens) == 0 {
+		return nil, nil, errors.New("cannot remove the root of the document")
+	}
+	newRoot, err = jsonPointerModify(v, tokens, func(container any, token string) (any, error) {
+		switch node := container.(type) {
+		case map[string]any:
+			var exists bool
+			if removed, exists = node[token]; !exists {
+				return nil, fmt.Errorf("field %q does not exist", token)
+			}
+			delete(node, token)
+			return node, nil
+		case []any:
+			i, err := jsonPointerArrayIndex(token, len(node), false)
+			if err != nil {
+				return nil, err
+			}
+			removed = node[i]
+			return append(node[:i], node[i+1:]...), nil
+		}
+		return nil, fmt.Errorf("cannot reference %q within %v value", token, value.ITypeOf(container))
+	})
+	return
+}
+
+// jsonPointerSet sets a value at the location of a parsed JSON Pointer,
+// creating objects for any parents that do not exist.
+func jsonPointerSet(v any, tokens []string, newValue any) (any, error) {
+	if len(tokens) == 0 {
+		return newValue, nil
+	}
+	switch node := v.(type) {
+	case nil:
+		child, err := jsonPointerSet(nil, tokens[1:], newValue)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{tokens[0]: child}, nil
+	case map[string]any:
+		child, err := jsonPointerSet(node[tokens[0]], tokens[1:], newValue)
+		if err != nil {
+			return nil, err
+		}
+		node[tokens[0]] = child
+		return node, nil
+	case []any:
+		i, err := jsonPointerArrayIndex(tokens[0], len(node), true)
+		if err != nil {
+			return nil, err
+		}
+		if i == len(node) {
+			node = append(node, nil)
+		}
+		child, err := jsonPointerSet(node[i], tokens[1:], newValue)
+		if err != nil {
+			return nil, err
+		}
+		node[i] = child
+		return node, nil
+	}
+	return nil, fmt.Errorf("cannot set %q within %v value", tokens[0], value.ITypeOf(v))
+}
@@ -0,0 +1,187 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+func TestGetPointer(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mapping  string
+		input    string
+		output   string
+		parseErr string
+	}{
+		{
+			name:    "root",
+			mapping: `root = this.get_pointer("")`,
+			input:   `{"a":1}`,
+			output:  `{"a":1}`,
+		},
+		{
+			name:    "nested array",
+			mapping: `root = this.get_pointer("/a/1/b")`,
+			input:   `{"a":[{"b":1},{"b":2}]}`,
+			output:  `2`,
+		},
+		{
+			name:    "escaped tokens",
+			mapping: `root = this.get_pointer("/a~1b/~0c")`,
+			input:   `{"a/b":{"~c":"foo"}}`,
+			output:  `"foo"`,
+		},
+		{
+			name:    "empty key",
+			mapping: `root = this.get_pointer("/")`,
+			input:   `{"":"foo"}`,
+			output:  `"foo"`,
+		},
+		{
+			name:    "missing field",
+			mapping: `root = this.get_pointer("/a/b")`,
+			input:   `{"a":{}}`,
+			output:  `null`,
+		},
+		{
+			name:    "index out of bounds",
+			mapping: `root = this.get_pointer("/a/5")`,
+			input:   `{"a":[1]}`,
+			output:  `null`,
+		},
+		{
+			name:    "dynamic pointer",
+			mapping: `root = this.doc.get_pointer(this.ptr)`,
+			input:   `{"doc":{"a":[1,2]},"ptr":"/a/1"}`,
+			output:  `2`,
+		},
+		{
+			name:     "invalid pointer",
+			mapping:  `root = this.get_pointer("a/b")`,
+			parseErr: `json pointer "a/b" must begin with a slash`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			if test.parseErr != "" {
+				_, err := bloblang.Parse(test.mapping)
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.parseErr)
+				return
+			}
+			res, err := execJSONMapping(t, test.mapping, test.input)
+			require.NoError(t, err)
+			assert.JSONEq(t, test.output, res)
+		})
+	}
+}
+
+func TestSetPointer(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mapping string
+		input   string
+		output  string
+		execErr string
+	}{
+		{
+			name:    "replace root",
+			mapping: `root = this.set_pointer("", "foo")`,
+			input:   `{"a":1}`,
+			output:  `"foo"`,
+		},
+		{
+			name:    "create parents",
+			mapping: `root = this.set_pointer("/a/b~1c/d", 1)`,
+			input:   `{"a":{"e":2}}`,
+			output:  `{"a":{"b/c":{"d":1},"e":2}}`,
+		},
+		{
+			name:    "replace array element",
+			mapping: `root = this.set_pointer("/a/0", "x")`,
+			input:   `{"a":["y","z"]}`,
+			output:  `{"a":["x","z"]}`,
+		},
+		{
+			name:    "append to array",
+			mapping: `root = this.set_pointer("/a/-", "x").set_pointer("/a/3", "w")`,
+			input:   `{"a":["y","z"]}`,
+			output:  `{"a":["y","z","x","w"]}`,
+		},
+		{
+			name:    "object in appended element",
+			mapping: `root = this.set_pointer("/a/-/b", "x")`,
+			input:   `{"a":[]}`,
+			output:  `{"a":[{"b":"x"}]}`,
+		},
+		{
+			name:    "dynamic pointer",
+			mapping: `root = this.doc.set_pointer(this.ptr, this.value)`,
+			input:   `{"doc":{},"ptr":"/foo","value":[1]}`,
+			output:  `{"foo":[1]}`,
+		},
+		{
+			name:    "index out of bounds",
+			mapping: `root = this.set_pointer("/a/5", 1)`,
+			input:   `{"a":[]}`,
+			execErr: `array index 5 out of bounds`,
+		},
+		{
+			name:    "scalar parent",
+			mapping: `root = this.set_pointer("/a/b", 1)`,
+			input:   `{"a":"foo"}`,
+			execErr: `cannot set "b" within string value`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := execJSONMapping(t, test.mapping, test.input)
+			if test.execErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.execErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, test.output, res)
+		})
+	}
+}
+
+func TestSetPointerDoesNotMutate(t *testing.T) {
+	exec, err := bloblang.Parse(`root = this.set_pointer("/a/b", 2)`)
+	require.NoError(t, err)
+
+	input := map[string]any{"a": map[string]any{"b": 1}}
+	res, err := exec.Query(input)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]any{"a": map[string]any{"b": int64(2)}}, res)
+	assert.Equal(t, map[string]any{"a": map[string]any{"b": 1}}, input)
+}
+
+func TestJSONPathMethod(t *testing.T) {
+	res, err := execJSONMapping(t, `root = this.doc.json_path(this.path)`,
+		`{"doc":{"a":[{"b":1},{"b":2},{"c":3}]},"path":"$.a[?@.b > 1 || @.c]"}`)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"b":2},{"c":3}]`, res)
+
+	res, err = execJSONMapping(t, `root = this.json_path("$.nope[*]")`, `{"a":1}`)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, res)
+
+	_, err = bloblang.Parse(`root = this.json_path("$.a[")`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "char 4: expected a selector")
+
+	_, err = execJSONMapping(t, `root = this.json_path(this.path)`, `{"path":"a.b"}`)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expected path to begin with $")
+}
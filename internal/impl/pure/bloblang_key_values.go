@@ -0,0 +1,370 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
+	"github.com/redpanda-data/benthos/v4/internal/value"
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+// keyValueParser parses strings consisting of key/value pairs such as
+// `foo=bar baz="buz qux"`.
+type keyValueParser struct {
+	pairSep  string
+	kvSep    string
+	quotes   string
+	trim     bool
+	bareKeys any
+}
+
+var logfmtParser = keyValueParser{
+	pairSep:  " ",
+	kvSep:    "=",
+	quotes:   `"`,
+	trim:     true,
+	bareKeys: true,
+}
+
+// pairSepAt returns the length of the pair separator at index i of s, or -1
+// if there isn't one. A separator consisting only of whitespace matches any run
+// of whitespace.
+func (p keyValueParser) pairSepAt(s string, i int) int {
+	if strings.TrimSpace(p.pairSep) != "" {
+		if strings.HasPrefix(s[i:], p.pairSep) {
+			return len(p.pairSep)
+		}
+		return -1
+	}
+	j := i
+	for j < len(s) {
+		r, size := utf8.DecodeRuneInString(s[j:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		j += size
+	}
+	if j == i {
+		return -1
+	}
+	return j - i
+}
+
+// skipSpace skips whitespace when trimming is enabled, stopping at the
+// beginning of a pair separator.
+func (p keyValueParser) skipSpace(s string, i int) int {
+	if !p.trim {
+		return i
+	}
+	for i < len(s) && p.pairSepAt(s, i) < 0 {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// readQuoted reads a quoted string beginning at i, where backslashes escape the
+// following character, and returns the unescaped contents along with the index
+// following the closing quote.
+func (p keyValueParser) readQuoted(s string, i int) (string, int, error) {
+	quote := s[i]
+	var buf strings.Builder
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			if j+1 < len(s) {
+				j++
+				switch s[j] {
+				case 'n':
+					buf.WriteByte('\n')
+				case 'r':
+					buf.WriteByte('\r')
+				case 't':
+					buf.WriteByte('\t')
+				default:
+					buf.WriteByte(s[j])
+				}
+				continue
+			}
+		case quote:
+			return buf.String(), j + 1, nil
+		}
+		buf.WriteByte(s[j])
+	}
+	return "", 0, fmt.Errorf("char %v: unterminated quoted string", i)
+}
+
+// readToken reads either a quoted string or an unquoted string that ends at a
+// pair separator, the key/value separator when stopAtKVSep is set, or the end
+// of the input.
+func (p keyValueParser) readToken(s string, i int, stopAtKVSep bool) (string, int, error) {
+	if i < len(s) && strings.IndexByte(p.quotes, s[i]) >= 0 {
+		return p.readQuoted(s, i)
+	}
+	end := i
+	for end < len(s) && p.pairSepAt(s, end) < 0 && (!stopAtKVSep || !strings.HasPrefix(s[end:], p.kvSep)) {
+		end++
+	}
+	token := s[i:end]
+	if p.trim {
+		token = strings.TrimRightFunc(token, unicode.IsSpace)
+	}
+	return token, end, nil
+}
+
+// parse returns the key/value pairs of a string. When an error occurs the pairs
+// parsed up until that point are returned along with it.
+func (p keyValueParser) parse(s string) (map[string]any, error) {
+	res := map[string]any{}
+	i := 0
+	for {
+		for {
+			if p.trim {
+				for i < len(s) {
+					r, size := utf8.DecodeRuneInString(s[i:])
+					if !unicode.IsSpace(r) {
+						break
+					}
+					i += size
+				}
+			}
+			n := p.pairSepAt(s, i)
+			if n <= 0 {
+				break
+			}
+			i += n
+		}
+		if i >= len(s) {
+			return res, nil
+		}
+
+		keyStart := i
+		key, next, err := p.readToken(s, i, true)
+		if err != nil {
+			return res, err
+		}
+		if key == "" {
+			return res, fmt.Errorf("char %v: expected a key", keyStart)
+		}
+		i = p.skipSpace(s, next)
+
+		if !strings.HasPrefix(s[i:], p.kvSep) {
+			if i < len(s) && p.pairSepAt(s, i) < 0 {
+				return res, fmt.Errorf("char %v: expected %q or %q after key", i, p.kvSep, p.pairSep)
+			}
+			res[key] = p.bareKeys
+			continue
+		}
+		i = p.skipSpace(s, i+len(p.kvSep))
+
+		var v string
+		if v, i, err = p.readToken(s, i, false); err != nil {
+			return res, err
+		}
+		res[key] = v
+
+		i = p.skipSpace(s, i)
+		if i < len(s) && p.pairSepAt(s, i) < 0 {
+			return res, fmt.Errorf("char %v: expected %q after value", i, p.pairSep)
+		}
+	}
+}
+
+func logfmtNeedsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == utf8.RuneError || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func logfmtValue(v any) (string, error) {
+	var s string
+	switch t := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		s = t
+	case []byte:
+		s = string(t)
+	case map[string]any, []any:
+		b, err := json.Marshal(t)
+		if err != nil {
+			return "", err
+		}
+		s = string(b)
+	default:
+		s = value.IToString(t)
+	}
+	if logfmtNeedsQuoting(s) {
+		return strconv.Quote(s), nil
+	}
+	return s, nil
+}
+
+// formatLogfmt writes the keys of an object as logfmt pairs, starting with the
+// keys listed in order followed by the remaining keys sorted alphabetically.
+func formatLogfmt(obj map[string]any, order []string) (string, error) {
+	keys := make([]string, 0, len(obj))
+	seen := make(map[string]struct{}, len(order))
+	for _, k := range order {
+		if _, exists := obj[k]; exists {
+			if _, dupe := seen[k]; !dupe {
+				keys = append(keys, k)
+				seen[k] = struct{}{}
+			}
+		}
+	}
+	remaining := make([]string, 0, len(obj))
+	for k := range obj {
+		if _, exists := seen[k]; !exists {
+			remaining = append(remaining, k)
+		}
+	}
+	sort.Strings(remaining)
+	keys = append(keys, remaining...)
+
+	var buf strings.Builder
+	for i, k := range keys {
+		if k == "" || strings.ContainsFunc(k, func(r rune) bool {
+			return r <= ' ' || r == '=' || r == '"' || !unicode.IsPrint(r)
+		}) {
+			return "", fmt.Errorf("key %q cannot be represented in logfmt", k)
+		}
+		v, err := logfmtValue(obj[k])
+		if err != nil {
+			return "", fmt.Errorf("key %v: %w", k, err)
+		}
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(k)
+		buf.WriteByte('=')
+		buf.WriteString(v)
+	}
+	return buf.String(), nil
+}
+
+func init() {
+	bloblang.MustRegisterMethodV2("parse_logfmt",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryParsing).
+			Description(`Attempts to parse a string in https://brandur.org/logfmt[logfmt^] format into an object of strings. Values may be quoted with double quotes, in which case backslash escape sequences are supported, and keys without a value are given the value `+"`true`"+`. When a key appears multiple times the last value is kept.`).
+			Example("", `root = content().parse_logfmt()`,
+				[2]string{
+					`time=2025-01-02T03:04:05Z level=info msg="request served" status=200 cached`,
+					`{"cached":true,"level":"info","msg":"request served","status":"200","time":"2025-01-02T03:04:05Z"}`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			return bloblang.StringMethod(func(s string) (any, error) {
+				v, err := logfmtParser.parse(s)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse value as logfmt: %w", err)
+				}
+				return v, nil
+			}), nil
+		})
+
+	bloblang.MustRegisterMethodV2("format_logfmt",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryParsing).
+			Description(`Formats an object as a https://brandur.org/logfmt[logfmt^] string. Values that are empty or contain spaces, quotes, equals signs or control characters are quoted, objects and arrays are written as JSON and null values are written as empty values. Keys are sorted alphabetically unless listed in `+"`order`"+`.`).
+			Param(bloblang.NewAnyParam("order").Description("An array of keys to write first and in the given order, when they exist.").Default([]any{})).
+			Example("", `root = this.format_logfmt(order: ["level", "msg"])`,
+				[2]string{
+					`{"status":200,"msg":"request served","level":"info","tags":["a","b"]}`,
+					`level=info msg="request served" status=200 tags="[\"a\",\"b\"]"`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			orderV, err := args.Get("order")
+			if err != nil {
+				return nil, err
+			}
+			orderArr, ok := orderV.([]any)
+			if !ok {
+				return nil, fmt.Errorf("order: %w", value.NewTypeError(orderV, value.TArray))
+			}
+			order := make([]string, len(orderArr))
+			for i, k := range orderArr {
+				if order[i], ok = k.(string); !ok {
+					return nil, fmt.Errorf("order element %v: %w", i, value.NewTypeError(k, value.TString))
+				}
+			}
+			return bloblang.ObjectMethod(func(obj map[string]any) (any, error) {
+				return formatLogfmt(obj, order)
+			}), nil
+		})
+
+	bloblang.MustRegisterMethodV2("parse_key_values",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryParsing).
+			Description(`Attempts to parse a string consisting of key/value pairs into an object of strings, where the separators between pairs and between keys and values are configurable. Keys and values may be wrapped in any of the characters of `+"`quotes`"+`, in which case backslash escape sequences are supported, and keys without a value are given an empty string. When a key appears multiple times the last value is kept.`).
+			Param(bloblang.NewStringParam("pair_separator").Description("The string that separates key/value pairs.").Default(" ")).
+			Param(bloblang.NewStringParam("key_value_separator").Description("The string that separates keys from their values.").Default("=")).
+			Param(bloblang.NewStringParam("quotes").Description("Characters that may be used to quote keys and values. Set this to an empty string in order to disable quoting.").Default(`"'`)).
+			Param(bloblang.NewBoolParam("trim").Description("Whether to remove whitespace surrounding keys, values and separators.").Default(true)).
+			Example("", `root = this.header.parse_key_values(pair_separator: ";", key_value_separator: ":")`,
+				[2]string{
+					`{"header":"user: \"Ash Ketchum\"; region: kanto; trainer"}`,
+					`{"region":"kanto","trainer":"","user":"Ash Ketchum"}`,
+				},
+			).
+			Example("", `root = this.query.parse_key_values(pair_separator: "&", quotes: "", trim: false)`,
+				[2]string{
+					`{"query":"a=1&b= 2&c"}`,
+					`{"a":"1","b":" 2","c":""}`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			p := keyValueParser{bareKeys: ""}
+			var err error
+			if p.pairSep, err = args.GetString("pair_separator"); err != nil {
+				return nil, err
+			}
+			if p.kvSep, err = args.GetString("key_value_separator"); err != nil {
+				return nil, err
+			}
+			if p.quotes, err = args.GetString("quotes"); err != nil {
+				return nil, err
+			}
+			if p.trim, err = args.GetBool("trim"); err != nil {
+				return nil, err
+			}
+			if p.pairSep == "" || p.kvSep == "" {
+				return nil, errors.New("separators must not be empty")
+			}
+			if p.pairSep == p.kvSep {
+				return nil, errors.New("pair_separator and key_value_separator must be different")
+			}
+			for _, r := range p.quotes {
+				if r >= utf8.RuneSelf {
+					return nil, fmt.Errorf("quote character %q is not supported, only ASCII characters can be used", r)
+				}
+			}
+			return bloblang.StringMethod(func(s string) (any, error) {
+				v, err := p.parse(s)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse value as key/value pairs: %w", err)
+				}
+				return v, nil
+			}), nil
+		})
+}
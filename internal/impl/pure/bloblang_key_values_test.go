@@ -0,0 +1,166 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+func TestParseLogfmt(t *testing.T) {
+	testCases := []struct {
+		input       string
+		output      map[string]any
+		errContains string
+	}{
+		{
+			input:  ``,
+			output: map[string]any{},
+		},
+		{
+			input:  `a=1 b=two`,
+			output: map[string]any{"a": "1", "b": "two"},
+		},
+		{
+			input:  `  a=1    b=   c="" d`,
+			output: map[string]any{"a": "1", "b": "", "c": "", "d": true},
+		},
+		{
+			input:  "a=\"line one\\nline \\\"two\\\"\"\tb=x=y",
+			output: map[string]any{"a": "line one\nline \"two\"", "b": "x=y"},
+		},
+		{
+			input:  `"quoted key"=1 a=1 a=2`,
+			output: map[string]any{"quoted key": "1", "a": "2"},
+		},
+		{
+			input:       `a="unterminated`,
+			errContains: "char 2: unterminated quoted string",
+		},
+		{
+			input:       `a="b"c`,
+			errContains: "char 5: expected",
+		},
+		{
+			input:       `=foo`,
+			errContains: "char 0: expected a key",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.input, func(t *testing.T) {
+			res, err := logfmtParser.parse(test.input)
+			if test.errContains != "" {
+				require.ErrorContains(t, err, test.errContains)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.output, res)
+		})
+	}
+}
+
+func TestFormatLogfmt(t *testing.T) {
+	res, err := formatLogfmt(map[string]any{
+		"msg":   "hello world",
+		"level": "info",
+		"empty": "",
+		"null":  nil,
+		"ok":    true,
+		"n":     1.5,
+		"obj":   map[string]any{"a": 1},
+		"ctrl":  "a\tb\\c=d",
+		"uni":   "café",
+	}, []string{"level", "msg", "missing", "level"})
+	require.NoError(t, err)
+	assert.Equal(t, `level=info msg="hello world" ctrl="a\tb\\c=d" empty="" n=1.5 null= obj="{\"a\":1}" ok=true uni=café`, res)
+
+	parsed, err := logfmtParser.parse(res)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"level": "info",
+		"msg":   "hello world",
+		"ctrl":  "a\tb\\c=d",
+		"empty": "",
+		"n":     "1.5",
+		"null":  "",
+		"obj":   `{"a":1}`,
+		"ok":    "true",
+		"uni":   "café",
+	}, parsed)
+
+	_, err = formatLogfmt(map[string]any{"bad key": 1}, nil)
+	require.ErrorContains(t, err, "cannot be represented in logfmt")
+}
+
+func TestParseKeyValues(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mapping string
+		input   string
+		output  string
+		execErr string
+	}{
+		{
+			name:    "defaults",
+			mapping: `root = this.v.parse_key_values()`,
+			input:   `{"v":"a=1 b='two three' c"}`,
+			output:  `{"a":"1","b":"two three","c":""}`,
+		},
+		{
+			name:    "custom separators with whitespace",
+			mapping: `root = this.v.parse_key_values(pair_separator: ",", key_value_separator: ":")`,
+			input:   `{"v":" a : 1 ,b:two words,, c:\"x,y\" "}`,
+			output:  `{"a":"1","b":"two words","c":"x,y"}`,
+		},
+		{
+			name:    "multi character separators",
+			mapping: `root = this.v.parse_key_values(pair_separator: "&&", key_value_separator: "=>")`,
+			input:   `{"v":"a=>1&&b=>x=y&&c=>"}`,
+			output:  `{"a":"1","b":"x=y","c":""}`,
+		},
+		{
+			name:    "no trimming",
+			mapping: `root = this.v.parse_key_values(pair_separator: ";", trim: false)`,
+			input:   `{"v":" a= 1;b=2 "}`,
+			output:  `{" a":" 1","b":"2 "}`,
+		},
+		{
+			name:    "no quoting",
+			mapping: `root = this.v.parse_key_values(quotes: "")`,
+			input:   `{"v":"a=\"b c=d"}`,
+			output:  `{"a":"\"b","c":"d"}`,
+		},
+		{
+			name:    "unterminated quote",
+			mapping: `root = this.v.parse_key_values()`,
+			input:   `{"v":"a='b"}`,
+			execErr: "failed to parse value as key/value pairs: char 2: unterminated quoted string",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := execJSONMapping(t, test.mapping, test.input)
+			if test.execErr != "" {
+				require.ErrorContains(t, err, test.execErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.output, res)
+		})
+	}
+
+	for _, mapping := range []string{
+		`root = this.parse_key_values(pair_separator: "")`,
+		`root = this.parse_key_values(pair_separator: "=")`,
+		`root = this.parse_key_values(quotes: "«")`,
+	} {
+		_, err := bloblang.Parse(mapping)
+		require.Error(t, err, mapping)
+	}
+}
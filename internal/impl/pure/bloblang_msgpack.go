@@ -0,0 +1,49 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"fmt"
+
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
+	"github.com/redpanda-data/benthos/v4/internal/msgpack"
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+func init() {
+	bloblang.MustRegisterMethodV2("parse_msgpack",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryParsing).
+			Description("Attempts to parse a byte array as a single MessagePack value and returns the result. Map keys that are not strings are converted into strings. Extension types other than timestamps are represented as objects with the fields `type` and `data`.").
+			Example("", `root.doc = this.doc.decode("hex").parse_msgpack()`,
+				[2]string{
+					`{"doc":"81a3666f6fa3626172"}`,
+					`{"doc":{"foo":"bar"}}`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			return bloblang.BytesMethod(func(data []byte) (any, error) {
+				v, err := msgpack.Unmarshal(data)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse value as MessagePack: %w", err)
+				}
+				return v, nil
+			}), nil
+		})
+
+	bloblang.MustRegisterMethodV2("format_msgpack",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryParsing).
+			Description("Serializes a target value into a MessagePack byte array. Numbers that hold integer values are encoded as integers and all other numbers, including decimals, as floats, objects are encoded with their keys in lexicographical order and timestamps with the timestamp extension type.").
+			Example("", `root.encoded = this.format_msgpack().encode("hex")`,
+				[2]string{
+					`{"foo":"bar"}`,
+					`{"encoded":"81a3666f6fa3626172"}`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			return func(v any) (any, error) {
+				return msgpack.Marshal(v)
+			}, nil
+		})
+}
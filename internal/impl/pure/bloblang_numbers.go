@@ -152,6 +152,8 @@ root.outs = this.ins.map_each(ele -> ele.abs())
 			return func(input any) (any, error) {
 				sanitInput := value.ISanitize(input)
 				switch v := sanitInput.(type) {
+				case value.Decimal:
+					return v.Abs(), nil
 				case float64:
 					return math.Abs(v), nil
 				case int64:
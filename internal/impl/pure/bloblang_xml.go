@@ -0,0 +1,487 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding/ianaindex"
+
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
+	"github.com/redpanda-data/benthos/v4/internal/value"
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+const (
+	xmlNamespacesStrip  = "strip"
+	xmlNamespacesPrefix = "prefix"
+	xmlNamespacesURI    = "uri"
+)
+
+func init() {
+	bloblang.MustRegisterMethodV2("parse_xml",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryParsing).
+			Description(`Attempts to parse a string as an XML document and returns a structured result, where the root element is the only key of the resulting object.
+
+Elements that contain only text are represented as strings. Elements with attributes or child elements are represented as objects, where attributes are keys prefixed with `+"`attribute_prefix`"+`, child elements are keys of their name and any text is stored under the key `+"`text_key`"+`. Repeated child elements of the same name are collected into an array, and elements at the paths listed in `+"`force_array`"+` are always collected into an array, even when they occur once. Leading and trailing whitespace of text is removed.`).
+			Param(bloblang.NewStringParam("attribute_prefix").Description("A prefix added to the keys of attributes.").Default("-")).
+			Param(bloblang.NewStringParam("text_key").Description("The key under which the text of elements with attributes or child elements is stored.").Default("#text")).
+			Param(bloblang.NewStringParam("cdata_key").Description("When set the contents of CDATA sections are stored under this key, separately from other text, in order for them to be preserved when the value is formatted with `format_xml`. When empty CDATA sections are treated as text.").Default("")).
+			Param(bloblang.NewAnyParam("force_array").Description("An array of dot separated paths of element names, starting with the name of the root element, where elements are always collected into an array.").Default([]any{})).
+			Param(bloblang.NewStringParam("namespaces").Description("How the namespaces of element and attribute names are handled. When `strip` only the local name is kept and namespace declarations are removed. When `prefix` names keep their namespace prefix, such as `soap:Body`, and namespace declarations are kept as attributes. When `uri` names are prefixed with the full namespace URI wrapped in braces, such as `{http://example.com/ns}Body`, and namespace declarations are removed.").Default(xmlNamespacesStrip)).
+			Param(bloblang.NewBoolParam("cast").Description("Whether to cast the text of elements and attribute values into numbers and booleans when they can be parsed as such.").Default(false)).
+			Example("", `root.doc = this.doc.parse_xml()`,
+				[2]string{
+					`{"doc":"<root><title id=\"1\">This is a title</title><content>This is some content</content></root>"}`,
+					`{"doc":{"root":{"content":"This is some content","title":{"#text":"This is a title","-id":"1"}}}}`,
+				},
+			).
+			Example("Elements can be forced into arrays and values can be cast into numbers and booleans.", `root = content().parse_xml(force_array: ["order.item"], cast: true)`,
+				[2]string{
+					`<order><item sku="a1"><qty>2</qty></item><paid>true</paid></order>`,
+					`{"order":{"item":[{"-sku":"a1","qty":2}],"paid":true}}`,
+				},
+			).
+			Example("Namespace prefixes can be preserved.", `root = content().parse_xml(namespaces: "prefix")`,
+				[2]string{
+					`<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Body>hello</soap:Body></soap:Envelope>`,
+					`{"soap:Envelope":{"-xmlns:soap":"http://www.w3.org/2003/05/soap-envelope","soap:Body":"hello"}}`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			var opts xmlParseOpts
+			var err error
+			if opts.attrPrefix, err = args.GetString("attribute_prefix"); err != nil {
+				return nil, err
+			}
+			if opts.textKey, err = args.GetString("text_key"); err != nil {
+				return nil, err
+			}
+			if opts.cdataKey, err = args.GetString("cdata_key"); err != nil {
+				return nil, err
+			}
+			if opts.namespaces, err = args.GetString("namespaces"); err != nil {
+				return nil, err
+			}
+			switch opts.namespaces {
+			case xmlNamespacesStrip, xmlNamespacesPrefix, xmlNamespacesURI:
+			default:
+				return nil, fmt.Errorf("unrecognised namespaces mode: %v, expected one of: %v, %v, %v", opts.namespaces, xmlNamespacesStrip, xmlNamespacesPrefix, xmlNamespacesURI)
+			}
+			if opts.cast, err = args.GetBool("cast"); err != nil {
+				return nil, err
+			}
+
+			forceArrayV, err := args.Get("force_array")
+			if err != nil {
+				return nil, err
+			}
+			forceArray, ok := forceArrayV.([]any)
+			if !ok {
+				return nil, fmt.Errorf("force_array: %w", value.NewTypeError(forceArrayV, value.TArray))
+			}
+			opts.forceArray = make(map[string]struct{}, len(forceArray))
+			for i, p := range forceArray {
+				pStr, ok := p.(string)
+				if !ok {
+					return nil, fmt.Errorf("force_array element %v: %w", i, value.NewTypeError(p, value.TString))
+				}
+				opts.forceArray[pStr] = struct{}{}
+			}
+
+			return bloblang.BytesMethod(func(data []byte) (any, error) {
+				v, err := parseXML(data, opts)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse value as XML: %w", err)
+				}
+				return v, nil
+			}), nil
+		})
+
+	bloblang.MustRegisterMethodV2("format_xml",
+		bloblang.NewPluginSpec().
+			Category(query.MethodCategoryParsing).
+			Description(`Serializes a target value into an XML byte array, following the same structure as the results of the `+"`parse_xml`"+` method.
+
+The target value must either be an object with a single key, which is used as the name of the root element, or `+"`root_tag`"+` must be specified. Keys prefixed with `+"`attribute_prefix`"+` become attributes, the value of `+"`text_key`"+` becomes the text of the element, the value of `+"`cdata_key`"+` becomes a CDATA section of the element, arrays become repeated elements and all other keys become child elements. Attributes and child elements are written in the lexicographical order of their keys.`).
+			Param(bloblang.NewStringParam("attribute_prefix").Description("The prefix of keys that are written as attributes.").Default("-")).
+			Param(bloblang.NewStringParam("text_key").Description("The key of values that are written as the text of an element.").Default("#text")).
+			Param(bloblang.NewStringParam("cdata_key").Description("When set values of this key are written as CDATA sections.").Default("")).
+			Param(bloblang.NewStringParam("root_tag").Description("An optional name of a root element to wrap the target value in.").Default("")).
+			Param(bloblang.NewStringParam("indent").Description("Indentation string. Each child element will begin on a new, indented line followed by one or more copies of indent according to the nesting.").Default("  ")).
+			Param(bloblang.NewBoolParam("no_indent").Description("Disable indentation and write the document on a single line.").Default(false)).
+			Param(bloblang.NewBoolParam("header").Description("Whether to begin the document with an XML declaration.").Default(false)).
+			Example("", `root = this.format_xml()`,
+				[2]string{
+					`{"root":{"title":{"#text":"This is a title","-id":"1"},"content":"This is some content"}}`,
+					`<root>
+  <content>This is some content</content>
+  <title id="1">This is a title</title>
+</root>`,
+				},
+			).
+			Example("Use `no_indent` for compact output, and `root_tag` to wrap a value in a root element.", `root = this.items.format_xml(root_tag: "items", no_indent: true).string()`,
+				[2]string{
+					`{"items":{"item":[{"-id":"1","#text":"a"},{"-id":"2","#text":"b"}]}}`,
+					`<items><item id="1">a</item><item id="2">b</item></items>`,
+				},
+			).
+			Example("Values can be written as CDATA sections.", `root = this.format_xml(cdata_key: "#cdata", no_indent: true)`,
+				[2]string{
+					`{"script":{"#cdata":"if (a < b) { go(); }","-type":"js"}}`,
+					`<script type="js"><![CDATA[if (a < b) { go(); }]]></script>`,
+				},
+			),
+		func(args *bloblang.ParsedParams) (bloblang.Method, error) {
+			var opts xmlFormatOpts
+			var err error
+			if opts.attrPrefix, err = args.GetString("attribute_prefix"); err != nil {
+				return nil, err
+			}
+			if opts.textKey, err = args.GetString("text_key"); err != nil {
+				return nil, err
+			}
+			if opts.cdataKey, err = args.GetString("cdata_key"); err != nil {
+				return nil, err
+			}
+			var rootTag string
+			if rootTag, err = args.GetString("root_tag"); err != nil {
+				return nil, err
+			}
+			if opts.indent, err = args.GetString("indent"); err != nil {
+				return nil, err
+			}
+			var noIndent, header bool
+			if noIndent, err = args.GetBool("no_indent"); err != nil {
+				return nil, err
+			}
+			if noIndent {
+				opts.indent = ""
+			}
+			if header, err = args.GetBool("header"); err != nil {
+				return nil, err
+			}
+			return func(v any) (any, error) {
+				if rootTag != "" {
+					v = map[string]any{rootTag: v}
+				}
+				obj, ok := v.(map[string]any)
+				if !ok || len(obj) != 1 {
+					return nil, errors.New("expected an object with a single key as the root element, or a root_tag to be specified")
+				}
+				var buf bytes.Buffer
+				if header {
+					buf.WriteString(strings.TrimSuffix(xml.Header, "\n"))
+					opts.newline(&buf, 0)
+				}
+				for k, e := range obj {
+					if err := formatXMLElement(&buf, k, e, 0, opts); err != nil {
+						return nil, err
+					}
+				}
+				return buf.Bytes(), nil
+			}, nil
+		})
+}
+
+//------------------------------------------------------------------------------
+
+type xmlParseOpts struct {
+	attrPrefix string
+	textKey    string
+	cdataKey   string
+	namespaces string
+	forceArray map[string]struct{}
+	cast       bool
+}
+
+var xmlCDataStart = []byte("<![CDATA[")
+
+type xmlParser struct {
+	opts xmlParseOpts
+	raw  []byte
+	dec  *xml.Decoder
+}
+
+func xmlCharsetReader(label string, input io.Reader) (io.Reader, error) {
+	enc, err := ianaindex.IANA.Encoding(label)
+	if err != nil {
+		return nil, err
+	}
+	if enc == nil {
+		return nil, fmt.Errorf("unsupported charset: %v", label)
+	}
+	return enc.NewDecoder().Reader(input), nil
+}
+
+func parseXML(data []byte, opts xmlParseOpts) (any, error) {
+	p := &xmlParser{
+		opts: opts,
+		raw:  data,
+		dec:  xml.NewDecoder(bytes.NewReader(data)),
+	}
+	p.dec.CharsetReader = xmlCharsetReader
+	for {
+		tok, err := p.token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("document does not contain a root element")
+			}
+			return nil, err
+		}
+		if start, ok := tok.(xml.StartElement); ok {
+			name := p.name(start.Name)
+			v, err := p.element(start, name)
+			if err != nil {
+				return nil, err
+			}
+			if _, forced := p.opts.forceArray[name]; forced {
+				v = []any{v}
+			}
+			return map[string]any{name: v}, nil
+		}
+	}
+}
+
+// token returns the next token of the document. Namespaces are only resolved
+// into URIs when they are not kept as prefixes.
+func (p *xmlParser) token() (xml.Token, error) {
+	if p.opts.namespaces == xmlNamespacesPrefix {
+		return p.dec.RawToken()
+	}
+	return p.dec.Token()
+}
+
+func (p *xmlParser) name(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	switch p.opts.namespaces {
+	case xmlNamespacesPrefix:
+		return n.Space + ":" + n.Local
+	case xmlNamespacesURI:
+		return "{" + n.Space + "}" + n.Local
+	}
+	return n.Local
+}
+
+func (p *xmlParser) castValue(s string) any {
+	if !p.opts.cast {
+		return s
+	}
+	switch s {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return s
+}
+
+func isXMLNamespaceDecl(n xml.Name) bool {
+	return (n.Space == "" && n.Local == "xmlns") || n.Space == "xmlns"
+}
+
+func (p *xmlParser) element(start xml.StartElement, path string) (any, error) {
+	obj := map[string]any{}
+	for _, a := range start.Attr {
+		if p.opts.namespaces != xmlNamespacesPrefix && isXMLNamespaceDecl(a.Name) {
+			continue
+		}
+		obj[p.opts.attrPrefix+p.name(a.Name)] = p.castValue(a.Value)
+	}
+
+	var text, cdata strings.Builder
+	var hasCData bool
+	for {
+		offset := p.dec.InputOffset()
+		tok, err := p.token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("element <%v> is not closed", path)
+			}
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := p.name(t.Name)
+			childPath := path + "." + name
+			child, err := p.element(t, childPath)
+			if err != nil {
+				return nil, err
+			}
+			_, forced := p.opts.forceArray[childPath]
+			switch existing := obj[name].(type) {
+			case nil:
+				if forced {
+					obj[name] = []any{child}
+				} else {
+					obj[name] = child
+				}
+			case []any:
+				obj[name] = append(existing, child)
+			default:
+				obj[name] = []any{existing, child}
+			}
+		case xml.CharData:
+			if p.opts.cdataKey != "" && bytes.HasPrefix(p.raw[offset:], xmlCDataStart) {
+				hasCData = true
+				cdata.Write(t)
+			} else {
+				text.Write(t)
+			}
+		case xml.EndElement:
+			if p.opts.namespaces == xmlNamespacesPrefix && p.name(t.Name) != p.name(start.Name) {
+				return nil, fmt.Errorf("element <%v> closed by </%v>", p.name(start.Name), p.name(t.Name))
+			}
+			textStr := strings.TrimSpace(text.String())
+			if len(obj) == 0 && !hasCData {
+				return p.castValue(textStr), nil
+			}
+			if textStr != "" {
+				obj[p.opts.textKey] = p.castValue(textStr)
+			}
+			if hasCData {
+				obj[p.opts.cdataKey] = cdata.String()
+			}
+			return obj, nil
+		}
+	}
+}
+
+//------------------------------------------------------------------------------
+
+type xmlFormatOpts struct {
+	attrPrefix string
+	textKey    string
+	cdataKey   string
+	indent     string
+}
+
+func (o xmlFormatOpts) newline(buf *bytes.Buffer, depth int) {
+	if o.indent == "" {
+		return
+	}
+	buf.WriteByte('\n')
+	for range depth {
+		buf.WriteString(o.indent)
+	}
+}
+
+func writeXMLCData(buf *bytes.Buffer, s string) {
+	// A CDATA section cannot contain its own terminator, and therefore it is
+	// split across multiple sections.
+	buf.WriteString("<![CDATA[")
+	buf.WriteString(strings.ReplaceAll(s, "]]>", "]]]]><![CDATA[>"))
+	buf.WriteString("]]>")
+}
+
+func formatXMLElement(buf *bytes.Buffer, name string, v any, depth int, opts xmlFormatOpts) error {
+	if arr, ok := v.([]any); ok {
+		for i, e := range arr {
+			if i > 0 {
+				opts.newline(buf, depth)
+			}
+			if err := formatXMLElement(buf, name, e, depth, opts); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if name == "" || strings.ContainsAny(name, " \t\n<>&\"'/=") {
+		return fmt.Errorf("invalid XML element name: %q", name)
+	}
+
+	buf.WriteByte('<')
+	buf.WriteString(name)
+
+	obj, isObj := v.(map[string]any)
+	if !isObj {
+		if v == nil {
+			buf.WriteString("/>")
+			return nil
+		}
+		buf.WriteByte('>')
+		if err := xml.EscapeText(buf, []byte(value.IToString(v))); err != nil {
+			return err
+		}
+		buf.WriteString("</")
+		buf.WriteString(name)
+		buf.WriteByte('>')
+		return nil
+	}
+
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var children []string
+	for _, k := range keys {
+		attrName, isAttr := strings.CutPrefix(k, opts.attrPrefix)
+		switch {
+		case k == opts.textKey, opts.cdataKey != "" && k == opts.cdataKey:
+		case opts.attrPrefix != "" && isAttr:
+			if obj[k] == nil {
+				continue
+			}
+			buf.WriteByte(' ')
+			buf.WriteString(attrName)
+			buf.WriteString(`="`)
+			if err := xml.EscapeText(buf, []byte(value.IToString(obj[k]))); err != nil {
+				return err
+			}
+			buf.WriteByte('"')
+		default:
+			children = append(children, k)
+		}
+	}
+
+	text, hasText := obj[opts.textKey]
+	cdata, hasCData := obj[opts.cdataKey]
+	hasCData = hasCData && opts.cdataKey != ""
+	if len(children) == 0 && !hasText && !hasCData {
+		buf.WriteString("/>")
+		return nil
+	}
+	buf.WriteByte('>')
+
+	if hasText && text != nil {
+		if err := xml.EscapeText(buf, []byte(value.IToString(text))); err != nil {
+			return err
+		}
+	}
+	if hasCData && cdata != nil {
+		writeXMLCData(buf, value.IToString(cdata))
+	}
+	for _, k := range children {
+		opts.newline(buf, depth+1)
+		if err := formatXMLElement(buf, k, obj[k], depth+1, opts); err != nil {
+			return err
+		}
+	}
+	if len(children) > 0 {
+		opts.newline(buf, depth)
+	}
+
+	buf.WriteString("</")
+	buf.WriteString(name)
+	buf.WriteByte('>')
+	return nil
+}
@@ -0,0 +1,250 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/public/bloblang"
+)
+
+func TestParseXMLMethod(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mapping string
+		input   string
+		output  any
+		execErr string
+	}{
+		{
+			name:    "repeated elements and mixed content",
+			mapping: `root = this.parse_xml()`,
+			input: `<?xml version="1.0"?>
+<catalog>
+  <!-- comment -->
+  <book id="1">
+    <title>First</title>
+  </book>
+  <book id="2">second <i>text</i></book>
+  <empty/>
+</catalog>`,
+			output: map[string]any{
+				"catalog": map[string]any{
+					"book": []any{
+						map[string]any{"-id": "1", "title": "First"},
+						map[string]any{"-id": "2", "#text": "second", "i": "text"},
+					},
+					"empty": "",
+				},
+			},
+		},
+		{
+			name:    "custom keys",
+			mapping: `root = this.parse_xml(attribute_prefix: "@", text_key: "value")`,
+			input:   `<a b="c">d</a>`,
+			output: map[string]any{
+				"a": map[string]any{"@b": "c", "value": "d"},
+			},
+		},
+		{
+			name:    "force array",
+			mapping: `root = this.parse_xml(force_array: ["a", "a.b.c"])`,
+			input:   `<a><b><c>1</c></b><d>2</d></a>`,
+			output: map[string]any{
+				"a": []any{map[string]any{
+					"b": map[string]any{"c": []any{"1"}},
+					"d": "2",
+				}},
+			},
+		},
+		{
+			name:    "cast values",
+			mapping: `root = this.parse_xml(cast: true)`,
+			input:   `<a n="5"><f>1.5</f><b>false</b><s>nope</s></a>`,
+			output: map[string]any{
+				"a": map[string]any{"-n": int64(5), "f": 1.5, "b": false, "s": "nope"},
+			},
+		},
+		{
+			name:    "cdata as text",
+			mapping: `root = this.parse_xml()`,
+			input:   `<a><![CDATA[<b>]]></a>`,
+			output:  map[string]any{"a": "<b>"},
+		},
+		{
+			name:    "cdata preserved",
+			mapping: `root = this.parse_xml(cdata_key: "#cdata")`,
+			input:   `<a>text <![CDATA[ <b> ]]></a>`,
+			output: map[string]any{
+				"a": map[string]any{"#text": "text", "#cdata": " <b> "},
+			},
+		},
+		{
+			name:    "namespaces stripped",
+			mapping: `root = this.parse_xml()`,
+			input:   `<s:Envelope xmlns:s="http://example.com/s" xmlns="http://example.com/d"><s:Body s:id="1"><Item>a</Item></s:Body></s:Envelope>`,
+			output: map[string]any{
+				"Envelope": map[string]any{
+					"Body": map[string]any{"-id": "1", "Item": "a"},
+				},
+			},
+		},
+		{
+			name:    "namespaces as prefixes",
+			mapping: `root = this.parse_xml(namespaces: "prefix")`,
+			input:   `<s:Envelope xmlns:s="http://example.com/s" xmlns="http://example.com/d"><s:Body s:id="1"><Item>a</Item></s:Body></s:Envelope>`,
+			output: map[string]any{
+				"s:Envelope": map[string]any{
+					"-xmlns:s": "http://example.com/s",
+					"-xmlns":   "http://example.com/d",
+					"s:Body":   map[string]any{"-s:id": "1", "Item": "a"},
+				},
+			},
+		},
+		{
+			name:    "namespaces as uris",
+			mapping: `root = this.parse_xml(namespaces: "uri")`,
+			input:   `<s:Envelope xmlns:s="http://example.com/s" xmlns="http://example.com/d"><s:Body><Item>a</Item></s:Body></s:Envelope>`,
+			output: map[string]any{
+				"{http://example.com/s}Envelope": map[string]any{
+					"{http://example.com/s}Body": map[string]any{
+						"{http://example.com/d}Item": "a",
+					},
+				},
+			},
+		},
+		{
+			name:    "declared charset",
+			mapping: `root = this.parse_xml()`,
+			input:   "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><a>caf\xe9</a>",
+			output:  map[string]any{"a": "café"},
+		},
+		{
+			name:    "unclosed element",
+			mapping: `root = this.parse_xml()`,
+			input:   `<a><b>c</b>`,
+			execErr: "failed to parse value as XML",
+		},
+		{
+			name:    "mismatched prefixed element",
+			mapping: `root = this.parse_xml(namespaces: "prefix")`,
+			input:   `<a:b></a:c>`,
+			execErr: "element <a:b> closed by </a:c>",
+		},
+		{
+			name:    "no root element",
+			mapping: `root = this.parse_xml()`,
+			input:   `<!-- nothing -->`,
+			execErr: "document does not contain a root element",
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			exec, err := bloblang.Parse(test.mapping)
+			require.NoError(t, err)
+
+			res, err := exec.Query([]byte(test.input))
+			if test.execErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, test.output, res)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.execErr)
+			}
+		})
+	}
+}
+
+func TestFormatXMLMethod(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mapping string
+		input   any
+		output  string
+		execErr string
+	}{
+		{
+			name:    "pretty",
+			mapping: `root = this.format_xml().string()`,
+			input: map[string]any{
+				"catalog": map[string]any{
+					"-version": 2,
+					"book": []any{
+						map[string]any{"-id": "1", "title": "First & Last"},
+						map[string]any{"-id": "2", "#text": "second"},
+					},
+					"empty": nil,
+				},
+			},
+			output: `<catalog version="2">
+  <book id="1">
+    <title>First &amp; Last</title>
+  </book>
+  <book id="2">second</book>
+  <empty/>
+</catalog>`,
+		},
+		{
+			name:    "compact with header",
+			mapping: `root = this.format_xml(no_indent: true, header: true).string()`,
+			input:   map[string]any{"a": map[string]any{"b": []any{1, 2}, "-c": `"quoted"`}},
+			output:  `<?xml version="1.0" encoding="UTF-8"?><a c="&#34;quoted&#34;"><b>1</b><b>2</b></a>`,
+		},
+		{
+			name:    "custom indent and root tag",
+			mapping: `root = this.format_xml(root_tag: "doc", indent: "\t").string()`,
+			input:   map[string]any{"a": map[string]any{"b": "c"}},
+			output:  "<doc>\n\t<a>\n\t\t<b>c</b>\n\t</a>\n</doc>",
+		},
+		{
+			name:    "cdata",
+			mapping: `root = this.format_xml(cdata_key: "#cdata", no_indent: true).string()`,
+			input:   map[string]any{"a": map[string]any{"#cdata": "x]]>y"}},
+			output:  `<a><![CDATA[x]]]]><![CDATA[>y]]></a>`,
+		},
+		{
+			name:    "round trip",
+			mapping: `root = this.format_xml(cdata_key: "#cdata").parse_xml(cdata_key: "#cdata", force_array: ["a.b"]).format_xml(cdata_key: "#cdata", no_indent: true).string()`,
+			input: map[string]any{
+				"a": map[string]any{
+					"-x": "y",
+					"b":  []any{map[string]any{"#cdata": "<c>"}},
+					"d":  "e",
+				},
+			},
+			output: `<a x="y"><b><![CDATA[<c>]]></b><d>e</d></a>`,
+		},
+		{
+			name:    "multiple root keys",
+			mapping: `root = this.format_xml()`,
+			input:   map[string]any{"a": "b", "c": "d"},
+			execErr: "expected an object with a single key as the root element",
+		},
+		{
+			name:    "invalid element name",
+			mapping: `root = this.format_xml()`,
+			input:   map[string]any{"a": map[string]any{"b c": "d"}},
+			execErr: `invalid XML element name: "b c"`,
+		},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.name, func(t *testing.T) {
+			exec, err := bloblang.Parse(test.mapping)
+			require.NoError(t, err)
+
+			res, err := exec.Query(test.input)
+			if test.execErr == "" {
+				require.NoError(t, err)
+				assert.Equal(t, test.output, res)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.execErr)
+			}
+		})
+	}
+}
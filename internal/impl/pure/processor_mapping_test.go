@@ -197,6 +197,35 @@ func TestMappingCreateJSONError(t *testing.T) {
 	assert.Equal(t, `failed assignment (line 1): invalid character 'h' in literal true (expecting 'r')`, err.Error())
 }
 
+func TestMappingExecutionBudget(t *testing.T) {
+	tCtx := t.Context()
+
+	msg := message.Batch{
+		message.NewPart([]byte(`{"count":10}`)),
+		message.NewPart([]byte(`{"count":1000}`)),
+	}
+
+	exec, err := bloblang.NewEnvironment().
+		WithExecutionBudget(bloblang.ExecutionBudget{MaxOperations: 100}).
+		Parse(`root = range(0, this.count).map_each(n -> n * 2).sum()`)
+	require.NoError(t, err)
+
+	proc := newMapping(exec, nil)
+
+	outBatches, err := proc.ProcessBatch(processor.TestBatchProcContext(tCtx, nil, msg), msg)
+	require.NoError(t, err)
+	require.Len(t, outBatches, 1)
+	require.Len(t, outBatches[0], 2)
+
+	assert.Equal(t, `90`, string(outBatches[0][0].AsBytes()))
+	require.NoError(t, outBatches[0][0].ErrorGet())
+
+	assert.Equal(t, `{"count":1000}`, string(outBatches[0][1].AsBytes()))
+	err = outBatches[0][1].ErrorGet()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, bloblang.ErrBudgetExceeded)
+}
+
 func BenchmarkMappingBasic(b *testing.B) {
 	blobl, err := bloblang.Parse(`
 root = this
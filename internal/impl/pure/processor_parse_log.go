@@ -27,6 +27,8 @@ const (
 	plpFieldWithRFC3339  = "allow_rfc3339"
 	plpFieldWithYear     = "default_year"
 	plpFieldWithTimezone = "default_timezone"
+	plpFieldNginxFormat  = "nginx_log_format"
+	plpFieldW3CFields    = "w3c_fields"
 )
 
 func parseLogSpec() *service.ConfigSpec {
@@ -70,9 +72,59 @@ Attempts to parse a log following the https://tools.ietf.org/html/rfc3164[Syslog
 - `+"`procid`"+` (string)
 - `+"`appname`"+` (string)
 - `+"`msgid`"+` (string)
+
+=== `+"`logfmt`"+`
+
+Attempts to parse a log in https://brandur.org/logfmt[logfmt^] format, resulting in an object of string values where keys without a value are given the value `+"`true`"+`. This is the same as the `+"xref:guides:bloblang/methods.adoc#parse_logfmt[`parse_logfmt`]"+` Bloblang method.
+
+=== `+"`cef`"+`
+
+Attempts to parse a log in the ArcSight Common Event Format (CEF), ignoring any content preceding the `+"`CEF:`"+` prefix such as a syslog header. The resulting structured document may contain any of the following fields:
+
+- `+"`version`"+` (int)
+- `+"`device_vendor`"+` (string)
+- `+"`device_product`"+` (string)
+- `+"`device_version`"+` (string)
+- `+"`device_event_class_id`"+` (string)
+- `+"`name`"+` (string)
+- `+"`severity`"+` (int, or string when not numeric)
+- `+"`extensions`"+` (object)
+
+=== `+"`apache_common`"+`
+
+Attempts to parse a log in the Apache common log format, which is equivalent to the `+"`nginx`"+` format with the `+"`nginx_log_format`"+` `+"`"+apacheCommonLogFormat+"`"+`.
+
+=== `+"`apache_combined`"+`
+
+Attempts to parse a log in the Apache combined log format, which is equivalent to the `+"`nginx`"+` format with the `+"`nginx_log_format`"+` `+"`"+apacheCombinedLogFormat+"`"+`.
+
+=== `+"`nginx`"+`
+
+Attempts to parse an access log written with the nginx `+"`log_format`"+` configured with the field `+"`nginx_log_format`"+`, which defaults to the nginx `+"`combined`"+` format. Each variable of the format becomes a field of the resulting document with the leading `+"`$`"+` removed, and variables with the value `+"`-`"+` are omitted. Variables must be separated by literal text.
+
+The values of `+"`$time_local`"+` and `+"`$time_iso8601`"+` are parsed and written in RFC3339 format, and the values of variables that are numeric, such as `+"`$status`"+`, `+"`$body_bytes_sent`"+` and `+"`$request_time`"+`, are parsed into numbers. When a `+"`$request`"+` variable is present it is also split into the fields `+"`request_method`"+`, `+"`request_uri`"+` and `+"`server_protocol`"+`.
+
+=== `+"`w3c_extended`"+`
+
+Attempts to parse a log in the W3C extended log file format, as written by Microsoft IIS. The names of fields are taken from the most recent `+"`#Fields`"+` directive, or the field `+"`w3c_fields`"+` until a directive is seen, and directive lines are removed from the pipeline. Fields with the value `+"`-`"+` are omitted, the `+"`date`"+` and `+"`time`"+` fields are combined into a single RFC3339 `+"`timestamp`"+` field, and the values of numeric fields, such as `+"`sc-status`"+` and `+"`time-taken`"+`, are parsed into numbers.
+
+=== `+"`gelf`"+`
+
+Attempts to parse a Graylog Extended Log Format (GELF) message, which may be compressed with gzip or zlib. Chunked messages are not supported. The resulting structured document may contain any of the following fields:
+
+- `+"`version`"+` (string)
+- `+"`host`"+` (string)
+- `+"`short_message`"+` (string)
+- `+"`full_message`"+` (string)
+- `+"`timestamp`"+` (string, RFC3339)
+- `+"`level`"+` (int)
+- `+"`facility`"+` (string)
+- `+"`line`"+` (int)
+- `+"`file`"+` (string)
+- `+"`additional_fields`"+` (object, with the leading underscore of each key removed)
 `).
 		Fields(
-			service.NewStringEnumField(plpFieldFormat, "syslog_rfc5424", "syslog_rfc3164").
+			service.NewStringEnumField(plpFieldFormat, "syslog_rfc5424", "syslog_rfc3164", "logfmt", "cef", "apache_common", "apache_combined", "nginx", "w3c_extended", "gelf").
 				Description("A common log <<formats, format>> to parse."),
 			service.NewBoolField(plpFieldBestEffort).
 				Description("Still returns partially parsed messages even if an error occurs.").
@@ -90,6 +142,14 @@ Attempts to parse a log following the https://tools.ietf.org/html/rfc3164[Syslog
 				Description("Sets the strategy to decide the timezone for rfc3164 timestamps. Applicable to format `syslog_rfc3164`. This value should follow the https://golang.org/pkg/time/#LoadLocation[time.LoadLocation^] format.").
 				Advanced().
 				Default("UTC"),
+			service.NewStringField(plpFieldNginxFormat).
+				Description("The `log_format` of an nginx access log, where variables such as `$remote_addr` or `${remote_addr}` become fields of the parsed document. Applicable to format `nginx`.").
+				Advanced().
+				Default(nginxCombinedLogFormat),
+			service.NewStringField(plpFieldW3CFields).
+				Description("A space separated list of field names to use for W3C extended logs until a `#Fields` directive is seen, which is useful when consuming logs from the middle of a file. Applicable to format `w3c_extended`.").
+				Advanced().
+				Default(""),
 			service.NewStringField(plpFieldCodec).Deprecated(),
 		)
 }
@@ -101,6 +161,8 @@ type parseLogConfig struct {
 	WithRFC3339  bool
 	WithYear     string
 	WithTimezone string
+	NginxFormat  string
+	W3CFields    string
 }
 
 func init() {
@@ -126,6 +188,12 @@ func init() {
 			if c.WithTimezone, err = conf.FieldString(plpFieldWithTimezone); err != nil {
 				return nil, err
 			}
+			if c.NginxFormat, err = conf.FieldString(plpFieldNginxFormat); err != nil {
+				return nil, err
+			}
+			if c.W3CFields, err = conf.FieldString(plpFieldW3CFields); err != nil {
+				return nil, err
+			}
 
 			mgr := interop.UnwrapManagement(res)
 			p, err := newParseLog(c, mgr)
@@ -136,6 +204,8 @@ func init() {
 		})
 }
 
+// parserFormat parses a log into structured data. A nil result without an error
+// indicates that the log should be dropped.
 type parserFormat func(body []byte) (map[string]any, error)
 
 func parserRFC5424(bestEffort bool) parserFormat {
@@ -269,14 +339,41 @@ func parserRFC3164(bestEffort, wrfc3339 bool, year, tz string) (parserFormat, er
 	}, nil
 }
 
-func getParseFormat(parser string, bestEffort, rfc3339 bool, defYear, defTZ string) (parserFormat, error) {
-	switch parser {
+// partialParser wraps a parse function that returns the fields parsed up until
+// an error, which are returned without the error when parsing with best effort
+// and at least one field was parsed.
+func partialParser(bestEffort bool, fn func(s string) (map[string]any, error)) parserFormat {
+	return func(body []byte) (map[string]any, error) {
+		res, err := fn(string(body))
+		if err != nil && (!bestEffort || len(res) == 0) {
+			return nil, err
+		}
+		return res, nil
+	}
+}
+
+func getParseFormat(conf parseLogConfig) (parserFormat, error) {
+	switch conf.Format {
 	case "syslog_rfc5424":
-		return parserRFC5424(bestEffort), nil
+		return parserRFC5424(conf.BestEffort), nil
 	case "syslog_rfc3164":
-		return parserRFC3164(bestEffort, rfc3339, defYear, defTZ)
+		return parserRFC3164(conf.BestEffort, conf.WithRFC3339, conf.WithYear, conf.WithTimezone)
+	case "logfmt":
+		return partialParser(conf.BestEffort, logfmtParser.parse), nil
+	case "cef":
+		return partialParser(conf.BestEffort, parseCEF), nil
+	case "apache_common":
+		return accessLogParser(conf.BestEffort, apacheCommonLogFormat)
+	case "apache_combined":
+		return accessLogParser(conf.BestEffort, apacheCombinedLogFormat)
+	case "nginx":
+		return accessLogParser(conf.BestEffort, conf.NginxFormat)
+	case "w3c_extended":
+		return newW3CParser(conf.BestEffort, conf.W3CFields).parse, nil
+	case "gelf":
+		return partialParser(conf.BestEffort, parseGELF), nil
 	}
-	return nil, fmt.Errorf("format not recognised: %s", parser)
+	return nil, fmt.Errorf("format not recognised: %s", conf.Format)
 }
 
 //------------------------------------------------------------------------------
@@ -293,8 +390,7 @@ func newParseLog(conf parseLogConfig, mgr bundle.NewManagement) (processor.AutoO
 		log:       mgr.Logger(),
 	}
 	var err error
-	if s.format, err = getParseFormat(conf.Format, conf.BestEffort, conf.WithRFC3339,
-		conf.WithYear, conf.WithTimezone); err != nil {
+	if s.format, err = getParseFormat(conf); err != nil {
 		return nil, err
 	}
 	return s, nil
@@ -306,6 +402,11 @@ func (s *parseLogProc) Process(ctx context.Context, msg *message.Part) ([]*messa
 		s.log.Debug("Failed to parse message as %s: %v", s.formatStr, err)
 		return nil, err
 	}
+	if dataMap == nil {
+		// Lines that only describe the log, such as W3C directives, are
+		// dropped.
+		return nil, nil
+	}
 
 	msg.SetStructuredMut(dataMap)
 	return []*message.Part{msg}, nil
@@ -0,0 +1,363 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	nginxCombinedLogFormat  = `$remote_addr - $remote_user [$time_local] "$request" $status $body_bytes_sent "$http_referer" "$http_user_agent"`
+	apacheCommonLogFormat   = `$remote_addr $remote_logname $remote_user [$time_local] "$request" $status $body_bytes_sent`
+	apacheCombinedLogFormat = apacheCommonLogFormat + ` "$http_referer" "$http_user_agent"`
+
+	accessLogTimeLocal = "02/Jan/2006:15:04:05 -0700"
+)
+
+// Variables of access logs that are parsed into numbers.
+var accessLogNumericVars = map[string]struct{}{
+	"body_bytes_sent":        {},
+	"bytes_sent":             {},
+	"connection":             {},
+	"connection_requests":    {},
+	"content_length":         {},
+	"msec":                   {},
+	"pid":                    {},
+	"remote_port":            {},
+	"request_length":         {},
+	"request_time":           {},
+	"server_port":            {},
+	"status":                 {},
+	"upstream_connect_time":  {},
+	"upstream_header_time":   {},
+	"upstream_response_time": {},
+	"upstream_status":        {},
+}
+
+// parseLogNumber returns a string as an integer or float when it can be parsed
+// as one, and otherwise returns the string unchanged.
+func parseLogNumber(s string) any {
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return f
+	}
+	return s
+}
+
+type accessLogToken struct {
+	literal  string
+	variable string
+}
+
+// compileAccessLogFormat compiles an nginx log_format string into a sequence of
+// literals and variables.
+func compileAccessLogFormat(format string) ([]accessLogToken, error) {
+	var tokens []accessLogToken
+	var literal strings.Builder
+
+	isVarChar := func(c byte) bool {
+		return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+	}
+
+	for i := 0; i < len(format); i++ {
+		if format[i] != '$' {
+			literal.WriteByte(format[i])
+			continue
+		}
+
+		var name string
+		if i+1 < len(format) && format[i+1] == '{' {
+			end := strings.IndexByte(format[i+2:], '}')
+			if end < 0 {
+				return nil, fmt.Errorf("char %v: unterminated variable name", i)
+			}
+			name = format[i+2 : i+2+end]
+			i += end + 2
+		} else {
+			end := i + 1
+			for end < len(format) && isVarChar(format[end]) {
+				end++
+			}
+			name = format[i+1 : end]
+			i = end - 1
+		}
+		if name == "" {
+			return nil, fmt.Errorf("char %v: expected a variable name", i)
+		}
+
+		if literal.Len() > 0 {
+			tokens = append(tokens, accessLogToken{literal: literal.String()})
+			literal.Reset()
+		} else if len(tokens) > 0 && tokens[len(tokens)-1].variable != "" {
+			return nil, fmt.Errorf("variables $%v and $%v must be separated by literal text", tokens[len(tokens)-1].variable, name)
+		}
+		tokens = append(tokens, accessLogToken{variable: name})
+	}
+	if literal.Len() > 0 {
+		tokens = append(tokens, accessLogToken{literal: literal.String()})
+	}
+	if len(tokens) == 0 {
+		return nil, errors.New("log format must not be empty")
+	}
+	return tokens, nil
+}
+
+// indexUnescaped returns the index of the first occurrence of substr in s that
+// isn't preceded by a backslash escape.
+func indexUnescaped(s, substr string) int {
+	for i := 0; i < len(s); i++ {
+		if strings.HasPrefix(s[i:], substr) {
+			return i
+		}
+		if s[i] == '\\' {
+			i++
+		}
+	}
+	return -1
+}
+
+// unescapeAccessLogValue removes the escape sequences written by Apache and
+// nginx, such as `\"` and `\x22`.
+func unescapeAccessLogValue(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var buf strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			buf.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'x':
+			if i+2 < len(s) {
+				if b, err := strconv.ParseUint(s[i+1:i+3], 16, 8); err == nil {
+					buf.WriteByte(byte(b))
+					i += 2
+					continue
+				}
+			}
+			buf.WriteString(`\x`)
+		case 'n':
+			buf.WriteByte('\n')
+		case 't':
+			buf.WriteByte('\t')
+		default:
+			buf.WriteByte(s[i])
+		}
+	}
+	return buf.String()
+}
+
+func setAccessLogVar(res map[string]any, name, raw string) error {
+	if raw == "-" {
+		return nil
+	}
+	v := unescapeAccessLogValue(raw)
+
+	switch name {
+	case "time_local":
+		t, err := time.Parse(accessLogTimeLocal, v)
+		if err != nil {
+			return fmt.Errorf("failed to parse $%v: %w", name, err)
+		}
+		res[name] = t.Format(time.RFC3339Nano)
+		return nil
+	case "time_iso8601":
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return fmt.Errorf("failed to parse $%v: %w", name, err)
+		}
+		res[name] = t.Format(time.RFC3339Nano)
+		return nil
+	case "request":
+		if parts := strings.Split(v, " "); len(parts) == 3 {
+			res["request_method"] = parts[0]
+			res["request_uri"] = parts[1]
+			res["server_protocol"] = parts[2]
+		}
+	}
+
+	if _, isNum := accessLogNumericVars[name]; isNum {
+		res[name] = parseLogNumber(v)
+		return nil
+	}
+	res[name] = v
+	return nil
+}
+
+// parseAccessLog extracts the variables of a compiled log format from a line.
+// When an error occurs the variables parsed up until that point are returned
+// along with it.
+func parseAccessLog(tokens []accessLogToken, line string) (map[string]any, error) {
+	line = strings.TrimRight(line, "\r\n")
+
+	res := map[string]any{}
+	pos := 0
+	for i, tok := range tokens {
+		if tok.variable == "" {
+			if !strings.HasPrefix(line[pos:], tok.literal) {
+				return res, fmt.Errorf("char %v: expected %q", pos, tok.literal)
+			}
+			pos += len(tok.literal)
+			continue
+		}
+
+		end := len(line)
+		if i+1 < len(tokens) {
+			idx := indexUnescaped(line[pos:], tokens[i+1].literal)
+			if idx < 0 {
+				return res, fmt.Errorf("char %v: expected %q following $%v", pos, tokens[i+1].literal, tok.variable)
+			}
+			end = pos + idx
+		}
+		if err := setAccessLogVar(res, tok.variable, line[pos:end]); err != nil {
+			return res, err
+		}
+		pos = end
+	}
+	if pos < len(line) {
+		return res, fmt.Errorf("char %v: unexpected content following the log format", pos)
+	}
+	return res, nil
+}
+
+func accessLogParser(bestEffort bool, format string) (parserFormat, error) {
+	tokens, err := compileAccessLogFormat(format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile log format: %w", err)
+	}
+	return partialParser(bestEffort, func(s string) (map[string]any, error) {
+		return parseAccessLog(tokens, s)
+	}), nil
+}
+
+//------------------------------------------------------------------------------
+
+// Fields of W3C extended logs that are parsed into numbers.
+var w3cNumericFields = map[string]struct{}{
+	"c-port":          {},
+	"cs-bytes":        {},
+	"s-port":          {},
+	"sc-bytes":        {},
+	"sc-status":       {},
+	"sc-substatus":    {},
+	"sc-win32-status": {},
+	"time-taken":      {},
+}
+
+// w3cParser parses W3C extended logs, where the fields of each line are
+// described by the most recent #Fields directive.
+type w3cParser struct {
+	bestEffort bool
+
+	mut    sync.Mutex
+	fields []string
+}
+
+func newW3CParser(bestEffort bool, defaultFields string) *w3cParser {
+	return &w3cParser{
+		bestEffort: bestEffort,
+		fields:     strings.Fields(defaultFields),
+	}
+}
+
+// splitW3CLine splits a line on whitespace, where values may be wrapped in
+// double quotes in order to contain whitespace.
+func splitW3CLine(line string) ([]string, error) {
+	var values []string
+	for i := 0; i < len(line); {
+		if line[i] == ' ' || line[i] == '\t' {
+			i++
+			continue
+		}
+		if line[i] == '"' {
+			end := strings.IndexByte(line[i+1:], '"')
+			if end < 0 {
+				return values, fmt.Errorf("char %v: unterminated quoted value", i)
+			}
+			values = append(values, line[i+1:i+1+end])
+			i += end + 2
+			continue
+		}
+		end := strings.IndexAny(line[i:], " \t")
+		if end < 0 {
+			end = len(line) - i
+		}
+		values = append(values, line[i:i+end])
+		i += end
+	}
+	return values, nil
+}
+
+func (w *w3cParser) parseLine(line string) (map[string]any, error) {
+	line = strings.TrimRight(line, "\r\n")
+	if strings.HasPrefix(line, "#") {
+		if fields, isFields := strings.CutPrefix(line, "#Fields:"); isFields {
+			w.mut.Lock()
+			w.fields = strings.Fields(fields)
+			w.mut.Unlock()
+		}
+		return nil, nil
+	}
+
+	w.mut.Lock()
+	fields := w.fields
+	w.mut.Unlock()
+	if len(fields) == 0 {
+		return map[string]any{}, errors.New("no #Fields directive has been seen and no default fields are configured")
+	}
+
+	values, splitErr := splitW3CLine(line)
+
+	res := make(map[string]any, len(fields))
+	for i, v := range values {
+		if i >= len(fields) {
+			break
+		}
+		if v == "-" {
+			continue
+		}
+		if _, isNum := w3cNumericFields[fields[i]]; isNum {
+			res[fields[i]] = parseLogNumber(v)
+		} else {
+			res[fields[i]] = v
+		}
+	}
+
+	date, hasDate := res["date"].(string)
+	clock, hasTime := res["time"].(string)
+	if hasDate && hasTime {
+		t, err := time.Parse(time.DateTime, date+" "+clock)
+		if err != nil {
+			return res, fmt.Errorf("failed to parse date and time: %w", err)
+		}
+		delete(res, "date")
+		delete(res, "time")
+		res["timestamp"] = t.Format(time.RFC3339Nano)
+	}
+
+	if splitErr != nil {
+		return res, splitErr
+	}
+	if len(values) != len(fields) {
+		return res, fmt.Errorf("expected %v fields, found %v", len(fields), len(values))
+	}
+	return res, nil
+}
+
+func (w *w3cParser) parse(body []byte) (map[string]any, error) {
+	res, err := w.parseLine(string(body))
+	if err != nil && (!w.bestEffort || len(res) == 0) {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+)
+
+// The maximum size of a decompressed GELF message.
+const gelfMaxDecompressedSize = 32 * 1024 * 1024
+
+func decompressGELF(b []byte) ([]byte, error) {
+	var r io.ReadCloser
+	var err error
+	switch {
+	case len(b) >= 2 && b[0] == 0x1e && b[1] == 0x0f:
+		return nil, errors.New("chunked GELF messages are not supported")
+	case len(b) >= 2 && b[0] == 0x1f && b[1] == 0x8b:
+		r, err = gzip.NewReader(bytes.NewReader(b))
+	case len(b) >= 2 && b[0] == 0x78 && (uint16(b[0])<<8|uint16(b[1]))%31 == 0:
+		r, err = zlib.NewReader(bytes.NewReader(b))
+	default:
+		return b, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress message: %w", err)
+	}
+	defer r.Close()
+
+	out, err := io.ReadAll(io.LimitReader(r, gelfMaxDecompressedSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress message: %w", err)
+	}
+	if len(out) > gelfMaxDecompressedSize {
+		return nil, errors.New("decompressed message exceeds the maximum size")
+	}
+	return out, nil
+}
+
+// gelfInt returns a JSON number as an integer when it has no fractional part.
+func gelfInt(v any) any {
+	if f, ok := v.(float64); ok && f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+		return int64(f)
+	}
+	return v
+}
+
+// parseGELF parses a Graylog Extended Log Format message. When an error occurs
+// the fields parsed up until that point are returned along with it.
+func parseGELF(s string) (map[string]any, error) {
+	body, err := decompressGELF([]byte(s))
+	if err != nil {
+		return map[string]any{}, err
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return map[string]any{}, fmt.Errorf("failed to parse message as JSON: %w", err)
+	}
+
+	res := make(map[string]any, len(doc))
+	var additional map[string]any
+	for k, v := range doc {
+		switch k {
+		case "timestamp":
+			f, ok := v.(float64)
+			if !ok {
+				err = fmt.Errorf("expected timestamp to be a number, found %T", v)
+				continue
+			}
+			sec, frac := math.Modf(f)
+			res[k] = time.Unix(int64(sec), int64(math.Round(frac*1e6))*1e3).UTC().Format(time.RFC3339Nano)
+		case "level", "line":
+			res[k] = gelfInt(v)
+		case "_id":
+			// Reserved by the specification and therefore ignored.
+		default:
+			if name, isAdditional := strings.CutPrefix(k, "_"); isAdditional {
+				if additional == nil {
+					additional = map[string]any{}
+				}
+				additional[name] = v
+				continue
+			}
+			res[k] = v
+		}
+	}
+	if additional != nil {
+		res["additional_fields"] = additional
+	}
+	if err != nil {
+		return res, err
+	}
+
+	for _, k := range []string{"version", "host", "short_message"} {
+		if _, exists := res[k]; !exists {
+			return res, fmt.Errorf("missing required field %v", k)
+		}
+	}
+	return res, nil
+}
@@ -3,10 +3,15 @@
 package pure_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/redpanda-data/benthos/v4/internal/component/testutil"
@@ -46,6 +51,48 @@ func TestParseLogCases(t *testing.T) {
 			input:   `<28>Dec  2 16:49:23 host app[23410]: Test`,
 			output:  fmt.Sprintf(`{"appname":"app","facility":3,"hostname":"host","message":"Test","priority":28,"procid":"23410","severity":4,"timestamp":"%v-12-02T16:49:23Z"}`, time.Now().Year()),
 		},
+		{
+			name:    "valid logfmt input",
+			format:  "logfmt",
+			bestEff: false,
+			input:   `level=warn msg="disk almost full" used=93%`,
+			output:  `{"level":"warn","msg":"disk almost full","used":"93%"}`,
+		},
+		{
+			name:    "partial logfmt input with best effort",
+			format:  "logfmt",
+			bestEff: true,
+			input:   `level=warn msg="disk almost full`,
+			output:  `{"level":"warn"}`,
+		},
+		{
+			name:    "partial logfmt input without best effort",
+			format:  "logfmt",
+			bestEff: false,
+			input:   `level=warn msg="disk almost full`,
+			output:  `level=warn msg="disk almost full`,
+		},
+		{
+			name:    "valid cef input with syslog header",
+			format:  "cef",
+			bestEff: true,
+			input:   `Sep 19 08:26:10 host CEF:0|Vendor|Product|1.0|42|Login failed|High|suser=admin msg=Too many attempts`,
+			output:  `{"device_event_class_id":"42","device_product":"Product","device_vendor":"Vendor","device_version":"1.0","extensions":{"msg":"Too many attempts","suser":"admin"},"name":"Login failed","severity":"High","version":0}`,
+		},
+		{
+			name:    "truncated cef input with best effort",
+			format:  "cef",
+			bestEff: true,
+			input:   `CEF:1|Vendor|Product`,
+			output:  `{"device_product":"Product","device_vendor":"Vendor","version":1}`,
+		},
+		{
+			name:    "invalid cef input",
+			format:  "cef",
+			bestEff: true,
+			input:   `not a cef message`,
+			output:  `not a cef message`,
+		},
 	}
 
 	for _, test := range tests {
@@ -129,3 +176,217 @@ parse_log:
 		})
 	}
 }
+
+func TestParseLogWebFormats(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		inputs  []string
+		outputs []string
+		errs    []bool
+	}{
+		{
+			name: "apache common",
+			config: `
+format: apache_common
+`,
+			inputs: []string{
+				`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326`,
+				`127.0.0.1 - - [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.1" 304 -`,
+			},
+			outputs: []string{
+				`{"body_bytes_sent":2326,"remote_addr":"127.0.0.1","remote_user":"frank","request":"GET /apache_pb.gif HTTP/1.0","request_method":"GET","request_uri":"/apache_pb.gif","server_protocol":"HTTP/1.0","status":200,"time_local":"2000-10-10T13:55:36-07:00"}`,
+				`{"remote_addr":"127.0.0.1","request":"GET / HTTP/1.1","request_method":"GET","request_uri":"/","server_protocol":"HTTP/1.1","status":304,"time_local":"2000-10-10T13:55:36-07:00"}`,
+			},
+		},
+		{
+			name: "apache combined with escaped quotes",
+			config: `
+format: apache_combined
+`,
+			inputs: []string{
+				`10.1.2.3 - - [01/Feb/2025:00:00:01 +0000] "GET /search?q=\"x\" HTTP/1.1" 200 15 "-" "curl/8.0 \"test\""`,
+			},
+			outputs: []string{
+				`{"body_bytes_sent":15,"http_user_agent":"curl/8.0 \"test\"","remote_addr":"10.1.2.3","request":"GET /search?q=\"x\" HTTP/1.1","request_method":"GET","request_uri":"/search?q=\"x\"","server_protocol":"HTTP/1.1","status":200,"time_local":"2025-02-01T00:00:01Z"}`,
+			},
+		},
+		{
+			name: "nginx custom format",
+			config: `
+format: nginx
+nginx_log_format: '$remote_addr [$time_iso8601] "$request" $status ${request_time}s upstream=$upstream_response_time "$http_x_forwarded_for"'
+`,
+			inputs: []string{
+				`192.168.0.1 [2025-03-04T05:06:07+00:00] "POST /api HTTP/2.0" 201 0.042s upstream=0.040 "\x22quoted\x22"`,
+				`192.168.0.1 [2025-03-04T05:06:07+00:00] "POST /api HTTP/2.0" 201 0.042s upstream=0.040, 0.002 "-"`,
+			},
+			outputs: []string{
+				`{"http_x_forwarded_for":"\"quoted\"","remote_addr":"192.168.0.1","request":"POST /api HTTP/2.0","request_method":"POST","request_time":0.042,"request_uri":"/api","server_protocol":"HTTP/2.0","status":201,"time_iso8601":"2025-03-04T05:06:07Z","upstream_response_time":0.04}`,
+				`{"remote_addr":"192.168.0.1","request":"POST /api HTTP/2.0","request_method":"POST","request_time":0.042,"request_uri":"/api","server_protocol":"HTTP/2.0","status":201,"time_iso8601":"2025-03-04T05:06:07Z","upstream_response_time":"0.040, 0.002"}`,
+			},
+		},
+		{
+			name: "nginx default format best effort",
+			config: `
+format: nginx
+best_effort: true
+`,
+			inputs: []string{
+				`1.2.3.4 - - [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.1" 200`,
+			},
+			outputs: []string{
+				`{"remote_addr":"1.2.3.4","request":"GET / HTTP/1.1","request_method":"GET","request_uri":"/","server_protocol":"HTTP/1.1","time_local":"2000-10-10T13:55:36-07:00"}`,
+			},
+		},
+		{
+			name: "nginx default format without best effort",
+			config: `
+format: nginx
+best_effort: false
+`,
+			inputs: []string{
+				`1.2.3.4 - - [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.1" 200`,
+				`1.2.3.4 - - [not a time] "GET / HTTP/1.1" 200 5 "-" "-"`,
+			},
+			outputs: []string{
+				`1.2.3.4 - - [10/Oct/2000:13:55:36 -0700] "GET / HTTP/1.1" 200`,
+				`1.2.3.4 - - [not a time] "GET / HTTP/1.1" 200 5 "-" "-"`,
+			},
+			errs: []bool{true, true},
+		},
+		{
+			name: "w3c extended with directives",
+			config: `
+format: w3c_extended
+`,
+			inputs: []string{
+				`#Software: Microsoft Internet Information Services 10.0`,
+				`#Fields: date time s-ip cs-method cs-uri-stem cs-uri-query s-port cs-username c-ip cs(User-Agent) sc-status time-taken`,
+				`2025-01-02 03:04:05 10.0.0.1 GET /index.html - 443 - 10.0.0.2 Mozilla/5.0+(Windows) 200 15`,
+				`#Fields: date time cs-method sc-status`,
+				`2025-01-02 03:04:06.5 GET 404`,
+			},
+			outputs: []string{
+				`{"c-ip":"10.0.0.2","cs(User-Agent)":"Mozilla/5.0+(Windows)","cs-method":"GET","cs-uri-stem":"/index.html","s-ip":"10.0.0.1","s-port":443,"sc-status":200,"time-taken":15,"timestamp":"2025-01-02T03:04:05Z"}`,
+				`{"cs-method":"GET","sc-status":404,"timestamp":"2025-01-02T03:04:06.5Z"}`,
+			},
+		},
+		{
+			name: "w3c extended with default fields",
+			config: `
+format: w3c_extended
+best_effort: false
+w3c_fields: cs-method cs-uri-stem sc-status
+`,
+			inputs: []string{
+				`GET "/a b" 200`,
+				`GET /a`,
+			},
+			outputs: []string{
+				`{"cs-method":"GET","cs-uri-stem":"/a b","sc-status":200}`,
+				`GET /a`,
+			},
+			errs: []bool{false, true},
+		},
+		{
+			name: "w3c extended without fields",
+			config: `
+format: w3c_extended
+`,
+			inputs:  []string{`GET /a 200`},
+			outputs: []string{`GET /a 200`},
+			errs:    []bool{true},
+		},
+		{
+			name: "gelf",
+			config: `
+format: gelf
+`,
+			inputs: []string{
+				`{"version":"1.1","host":"example.org","short_message":"A short message","full_message":"Backtrace here\n\nmore stuff","timestamp":1385053862.3072,"level":1,"_user_id":9001,"_some_info":"foo","_id":"ignored"}`,
+				`{"version":"1.1","short_message":"no host","level":3}`,
+				`[]`,
+			},
+			outputs: []string{
+				`{"additional_fields":{"some_info":"foo","user_id":9001},"full_message":"Backtrace here\n\nmore stuff","host":"example.org","level":1,"short_message":"A short message","timestamp":"2013-11-21T17:11:02.3072Z","version":"1.1"}`,
+				`{"level":3,"short_message":"no host","version":"1.1"}`,
+				`[]`,
+			},
+			errs: []bool{false, false, true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf, err := testutil.ProcessorFromYAML("parse_log:" + strings.ReplaceAll(test.config, "\n", "\n  "))
+			require.NoError(t, err)
+
+			proc, err := mock.NewManager().NewProcessor(conf)
+			require.NoError(t, err)
+
+			var inputs [][]byte
+			for _, in := range test.inputs {
+				inputs = append(inputs, []byte(in))
+			}
+			msgsOut, res := proc.ProcessBatch(t.Context(), message.QuickBatch(inputs))
+			require.NoError(t, res)
+			require.Len(t, msgsOut, 1)
+			require.Equal(t, len(test.outputs), msgsOut[0].Len())
+
+			for i, exp := range test.outputs {
+				part := msgsOut[0].Get(i)
+				assert.Equal(t, exp, string(part.AsBytes()), i)
+				expErr := test.errs != nil && test.errs[i]
+				assert.Equal(t, expErr, part.ErrorGet() != nil, "%v: %v", i, part.ErrorGet())
+			}
+		})
+	}
+}
+
+func TestParseLogGELFCompressed(t *testing.T) {
+	conf, err := testutil.ProcessorFromYAML(`
+parse_log:
+  format: gelf
+`)
+	require.NoError(t, err)
+
+	proc, err := mock.NewManager().NewProcessor(conf)
+	require.NoError(t, err)
+
+	doc := `{"version":"1.1","host":"h","short_message":"m"}`
+
+	var gzipped, zlibbed bytes.Buffer
+	gw := gzip.NewWriter(&gzipped)
+	_, _ = gw.Write([]byte(doc))
+	require.NoError(t, gw.Close())
+	zw := zlib.NewWriter(&zlibbed)
+	_, _ = zw.Write([]byte(doc))
+	require.NoError(t, zw.Close())
+
+	msgsOut, res := proc.ProcessBatch(t.Context(), message.QuickBatch([][]byte{
+		gzipped.Bytes(), zlibbed.Bytes(), {0x1e, 0x0f, 0x00},
+	}))
+	require.NoError(t, res)
+	require.Len(t, msgsOut, 1)
+	require.Equal(t, 3, msgsOut[0].Len())
+
+	assert.Equal(t, `{"host":"h","short_message":"m","version":"1.1"}`, string(msgsOut[0].Get(0).AsBytes()))
+	assert.Equal(t, `{"host":"h","short_message":"m","version":"1.1"}`, string(msgsOut[0].Get(1).AsBytes()))
+	require.Error(t, msgsOut[0].Get(2).ErrorGet())
+	assert.Contains(t, msgsOut[0].Get(2).ErrorGet().Error(), "chunked GELF messages are not supported")
+}
+
+func TestParseLogBadNginxFormat(t *testing.T) {
+	for _, format := range []string{`$a$b`, `${a`, ``, `$`} {
+		conf, err := testutil.ProcessorFromYAML(fmt.Sprintf(`
+parse_log:
+  format: nginx
+  nginx_log_format: '%v'
+`, format))
+		require.NoError(t, err)
+
+		_, err = mock.NewManager().NewProcessor(conf)
+		require.Error(t, err, format)
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/redpanda-data/benthos/v4/internal/cbor"
+	"github.com/redpanda-data/benthos/v4/internal/msgpack"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+const (
+	bdsFieldFraming = "framing"
+	bdsFieldDecode  = "decode"
+)
+
+const (
+	bdsFramingConcatenated = "concatenated"
+	bdsFramingUint32BE     = "uint32_be"
+	bdsFramingUint32LE     = "uint32_le"
+	bdsFramingUvarint      = "uvarint"
+)
+
+// binaryDocumentStream is implemented by the decoders of the binary document
+// formats, which read successive values from a stream.
+type binaryDocumentStream interface {
+	Decode() (any, error)
+	DecodeRaw() ([]byte, error)
+}
+
+type binaryDocumentFormat struct {
+	scanner   string
+	name      string
+	newStream func(r io.Reader) binaryDocumentStream
+	unmarshal func(data []byte) (any, error)
+}
+
+func binaryDocumentScannerSpec(format string) *service.ConfigSpec {
+	return service.NewConfigSpec().
+		Beta().
+		Version("4.58.0").
+		Summary(fmt.Sprintf("Consumes a stream of one or more %v documents.", format)).
+		Description(fmt.Sprintf("Documents can either be concatenated one after the other, or each prefixed with its length in bytes. Each document is either parsed into a structured message, or emitted as a message containing its raw %v encoded bytes.", format)).
+		Fields(
+			service.NewStringAnnotatedEnumField(bdsFieldFraming, map[string]string{
+				bdsFramingConcatenated: "Documents are written one after the other without any delimiter.",
+				bdsFramingUint32BE:     "Each document is prefixed with its length as a big-endian unsigned 32 bit integer.",
+				bdsFramingUint32LE:     "Each document is prefixed with its length as a little-endian unsigned 32 bit integer.",
+				bdsFramingUvarint:      "Each document is prefixed with its length as an unsigned varint, as used by protobuf.",
+			}).
+				Description("The way in which documents are delimited within the stream.").
+				Default(bdsFramingConcatenated),
+			service.NewBoolField(bdsFieldDecode).
+				Description(fmt.Sprintf("Whether to parse each document into a structured message. When `false` each document is validated and emitted with its raw %v encoded bytes as the message contents.", format)).
+				Default(true),
+		)
+}
+
+func init() {
+	for _, f := range []binaryDocumentFormat{
+		{
+			scanner: "msgpack_documents",
+			name:    "MessagePack",
+			newStream: func(r io.Reader) binaryDocumentStream {
+				return msgpack.NewDecoder(r)
+			},
+			unmarshal: msgpack.Unmarshal,
+		},
+		{
+			scanner: "cbor_documents",
+			name:    "CBOR",
+			newStream: func(r io.Reader) binaryDocumentStream {
+				return cbor.NewDecoder(r)
+			},
+			unmarshal: cbor.Unmarshal,
+		},
+	} {
+		service.MustRegisterBatchScannerCreator(f.scanner, binaryDocumentScannerSpec(f.name),
+			func(conf *service.ParsedConfig, mgr *service.Resources) (service.BatchScannerCreator, error) {
+				return binaryDocumentScannerCreatorFromParsed(f, conf)
+			})
+	}
+}
+
+func binaryDocumentScannerCreatorFromParsed(format binaryDocumentFormat, conf *service.ParsedConfig) (c *binaryDocumentScannerCreator, err error) {
+	c = &binaryDocumentScannerCreator{format: format}
+	if c.framing, err = conf.FieldString(bdsFieldFraming); err != nil {
+		return
+	}
+	if c.decode, err = conf.FieldBool(bdsFieldDecode); err != nil {
+		return
+	}
+	return
+}
+
+type binaryDocumentScannerCreator struct {
+	format  binaryDocumentFormat
+	framing string
+	decode  bool
+}
+
+func (c *binaryDocumentScannerCreator) Create(rdr io.ReadCloser, aFn service.AckFunc, details *service.ScannerSourceDetails) (service.BatchScanner, error) {
+	s := &binaryDocumentScanner{
+		format:  c.format,
+		framing: c.framing,
+		decode:  c.decode,
+		r:       rdr,
+	}
+	if c.framing == bdsFramingConcatenated {
+		s.stream = c.format.newStream(rdr)
+	} else {
+		s.br = bufio.NewReader(rdr)
+	}
+	return service.AutoAggregateBatchScannerAcks(s, aFn), nil
+}
+
+func (c *binaryDocumentScannerCreator) Close(context.Context) error {
+	return nil
+}
+
+type binaryDocumentScanner struct {
+	format  binaryDocumentFormat
+	framing string
+	decode  bool
+
+	stream binaryDocumentStream
+	br     *bufio.Reader
+	r      io.ReadCloser
+}
+
+func (s *binaryDocumentScanner) NextBatch(ctx context.Context) (service.MessageBatch, error) {
+	if s.r == nil {
+		return nil, io.EOF
+	}
+
+	msg, err := s.next()
+	if err != nil {
+		_ = s.r.Close()
+		s.r = nil
+		return nil, err
+	}
+	return service.MessageBatch{msg}, nil
+}
+
+func (s *binaryDocumentScanner) next() (*service.Message, error) {
+	if s.stream != nil {
+		if !s.decode {
+			raw, err := s.stream.DecodeRaw()
+			if err != nil {
+				return nil, err
+			}
+			return service.NewMessage(raw), nil
+		}
+		v, err := s.stream.Decode()
+		if err != nil {
+			return nil, err
+		}
+		msg := service.NewMessage(nil)
+		msg.SetStructuredMut(v)
+		return msg, nil
+	}
+
+	frame, err := s.readFrame()
+	if err != nil {
+		return nil, err
+	}
+	v, err := s.format.unmarshal(frame)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %v document: %w", s.format.name, err)
+	}
+	if !s.decode {
+		return service.NewMessage(frame), nil
+	}
+	msg := service.NewMessage(nil)
+	msg.SetStructuredMut(v)
+	return msg, nil
+}
+
+// readFrame reads the next length prefixed document from the stream. The
+// document is read incrementally so that allocations are bound by the size of
+// the data rather than the declared length.
+func (s *binaryDocumentScanner) readFrame() ([]byte, error) {
+	var length uint64
+	switch s.framing {
+	case bdsFramingUvarint:
+		var err error
+		if length, err = binary.ReadUvarint(s.br); err != nil {
+			return nil, err
+		}
+	default:
+		var lBytes [4]byte
+		if _, err := io.ReadFull(s.br, lBytes[:]); err != nil {
+			return nil, err
+		}
+		if s.framing == bdsFramingUint32LE {
+			length = uint64(binary.LittleEndian.Uint32(lBytes[:]))
+		} else {
+			length = uint64(binary.BigEndian.Uint32(lBytes[:]))
+		}
+	}
+
+	if length > math.MaxInt64 {
+		return nil, fmt.Errorf("document length %v exceeds the maximum supported", length)
+	}
+
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, s.br, int64(length)); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (s *binaryDocumentScanner) Close(ctx context.Context) error {
+	if s.r == nil {
+		return nil
+	}
+	return s.r.Close()
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package pure_test
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/internal/component/scanner/testutil"
+	"github.com/redpanda-data/benthos/v4/public/service"
+)
+
+func binaryDocumentScannerFromYAML(t *testing.T, conf string) *service.OwnedScannerCreator {
+	t.Helper()
+
+	confSpec := service.NewConfigSpec().Field(service.NewScannerField("test"))
+	pConf, err := confSpec.ParseYAML(conf, nil)
+	require.NoError(t, err)
+
+	rdr, err := pConf.FieldScanner("test")
+	require.NoError(t, err)
+	return rdr
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func frameDocuments(framing string, docs ...[]byte) (data []byte) {
+	for _, d := range docs {
+		switch framing {
+		case "uint32_be":
+			data = binary.BigEndian.AppendUint32(data, uint32(len(d)))
+		case "uint32_le":
+			data = binary.LittleEndian.AppendUint32(data, uint32(len(d)))
+		case "uvarint":
+			data = binary.AppendUvarint(data, uint64(len(d)))
+		}
+		data = append(data, d...)
+	}
+	return
+}
+
+func TestBinaryDocumentScanners(t *testing.T) {
+	formats := map[string][][]byte{
+		// {"a":"a0"}, {"a":"a1"}, ["a2",3]
+		"msgpack_documents": {
+			mustDecodeHex(t, "81a161a26130"),
+			mustDecodeHex(t, "81a161a26131"),
+			mustDecodeHex(t, "92a2613203"),
+		},
+		"cbor_documents": {
+			mustDecodeHex(t, "a16161626130"),
+			mustDecodeHex(t, "bf6161626131ff"),
+			mustDecodeHex(t, "8262613203"),
+		},
+	}
+
+	for scanner, docs := range formats {
+		for _, framing := range []string{"concatenated", "uint32_be", "uint32_le", "uvarint"} {
+			t.Run(scanner+" "+framing, func(t *testing.T) {
+				rdr := binaryDocumentScannerFromYAML(t, `
+test:
+  `+scanner+`:
+    framing: `+framing+`
+`)
+				testutil.ScannerTestSuite(t, rdr, nil, frameDocuments(framing, docs...),
+					`{"a":"a0"}`,
+					`{"a":"a1"}`,
+					`["a2",3]`,
+				)
+			})
+
+			t.Run(scanner+" "+framing+" raw", func(t *testing.T) {
+				rdr := binaryDocumentScannerFromYAML(t, `
+test:
+  `+scanner+`:
+    framing: `+framing+`
+    decode: false
+`)
+				testutil.ScannerTestSuite(t, rdr, nil, frameDocuments(framing, docs...),
+					string(docs[0]),
+					string(docs[1]),
+					string(docs[2]),
+				)
+			})
+		}
+	}
+}
+
+func TestBinaryDocumentScannerBadData(t *testing.T) {
+	tests := []struct {
+		name   string
+		conf   string
+		data   []byte
+		errStr string
+	}{
+		{
+			name: "msgpack truncated",
+			conf: `
+test:
+  msgpack_documents: {}
+`,
+			data:   mustDecodeHex(t, "81a161a2613081a161"),
+			errStr: "unexpected EOF",
+		},
+		{
+			name: "msgpack invalid format",
+			conf: `
+test:
+  msgpack_documents: {}
+`,
+			data:   mustDecodeHex(t, "81a161a26130c1"),
+			errStr: "invalid format byte",
+		},
+		{
+			name: "cbor frame with trailing data",
+			conf: `
+test:
+  cbor_documents:
+    framing: uint32_be
+`,
+			data:   frameDocuments("uint32_be", mustDecodeHex(t, "a16161626130"), mustDecodeHex(t, "0101")),
+			errStr: "unexpected data after data item",
+		},
+		{
+			name: "cbor truncated frame",
+			conf: `
+test:
+  cbor_documents:
+    framing: uvarint
+`,
+			data:   append(frameDocuments("uvarint", mustDecodeHex(t, "a16161626130")), 0x05, 0x01),
+			errStr: "unexpected EOF",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rdr := binaryDocumentScannerFromYAML(t, test.conf)
+
+			var ack error
+			scanner, err := rdr.Create(io.NopCloser(strings.NewReader(string(test.data))), func(ctx context.Context, err error) error {
+				ack = err
+				return nil
+			}, &service.ScannerSourceDetails{})
+			require.NoError(t, err)
+
+			resBatch, aFn, err := scanner.NextBatch(t.Context())
+			require.NoError(t, err)
+			require.NoError(t, aFn(t.Context(), nil))
+			require.Len(t, resBatch, 1)
+			mBytes, err := resBatch[0].AsBytes()
+			require.NoError(t, err)
+			assert.Equal(t, `{"a":"a0"}`, string(mBytes))
+
+			_, _, err = scanner.NextBatch(t.Context())
+			assert.Error(t, err)
+
+			_, _, err = scanner.NextBatch(t.Context())
+			assert.ErrorIs(t, err, io.EOF)
+
+			assert.ErrorContains(t, ack, test.errStr)
+		})
+	}
+}
@@ -0,0 +1,871 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+// Package jsonpath implements JSONPath query expressions, as described in
+// RFC 9535, that are evaluated against generic structured values.
+package jsonpath
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/redpanda-data/benthos/v4/internal/value"
+)
+
+// Path is a compiled JSONPath expression.
+type Path struct {
+	raw      string
+	segments []segment
+}
+
+// Compile parses a JSONPath expression such as `$.store.book[?@.price < 10]`.
+// The supported syntax includes child and descendant segments, name, wildcard,
+// index, slice and filter selectors, and the filter functions `length`,
+// `count`, `match` and `search`.
+func Compile(expr string) (*Path, error) {
+	p := &parser{s: expr}
+	p.skipSpace()
+	if !p.consume("$") {
+		return nil, p.errorf("expected path to begin with $")
+	}
+	segments, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+	return &Path{raw: expr, segments: segments}, nil
+}
+
+// String returns the expression that the path was compiled from.
+func (p *Path) String() string {
+	return p.raw
+}
+
+// Query returns the values selected by the path from a root value. Object
+// members are visited in lexicographical order of their keys. The returned
+// values are not copies and must not be mutated.
+func (p *Path) Query(root any) []any {
+	return evalSegments(p.segments, root, root)
+}
+
+//------------------------------------------------------------------------------
+
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+type selector interface {
+	selectFrom(node, root any, out []any) []any
+}
+
+func evalSegments(segments []segment, node, root any) []any {
+	nodes := []any{node}
+	for _, seg := range segments {
+		var next []any
+		for _, n := range nodes {
+			if seg.descendant {
+				next = descend(seg.selectors, n, root, next)
+			} else {
+				for _, sel := range seg.selectors {
+					next = sel.selectFrom(n, root, next)
+				}
+			}
+		}
+		if nodes = next; len(nodes) == 0 {
+			break
+		}
+	}
+	return nodes
+}
+
+// descend applies selectors to a node and each of its descendants in
+// pre-order.
+func descend(selectors []selector, node, root any, out []any) []any {
+	for _, sel := range selectors {
+		out = sel.selectFrom(node, root, out)
+	}
+	for _, child := range children(node) {
+		out = descend(selectors, child, root, out)
+	}
+	return out
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func children(node any) []any {
+	switch t := node.(type) {
+	case map[string]any:
+		c := make([]any, 0, len(t))
+		for _, k := range sortedKeys(t) {
+			c = append(c, t[k])
+		}
+		return c
+	case []any:
+		return t
+	}
+	return nil
+}
+
+type nameSelector string
+
+func (s nameSelector) selectFrom(node, root any, out []any) []any {
+	if m, ok := node.(map[string]any); ok {
+		if v, exists := m[string(s)]; exists {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+type wildcardSelector struct{}
+
+func (wildcardSelector) selectFrom(node, root any, out []any) []any {
+	return append(out, children(node)...)
+}
+
+type indexSelector int
+
+func (s indexSelector) selectFrom(node, root any, out []any) []any {
+	arr, ok := node.([]any)
+	if !ok {
+		return out
+	}
+	i := int(s)
+	if i < 0 {
+		i += len(arr)
+	}
+	if i >= 0 && i < len(arr) {
+		out = append(out, arr[i])
+	}
+	return out
+}
+
+type sliceSelector struct {
+	start, end *int
+	step       int
+}
+
+func (s sliceSelector) selectFrom(node, root any, out []any) []any {
+	arr, ok := node.([]any)
+	if !ok || s.step == 0 {
+		return out
+	}
+	n := len(arr)
+	normalize := func(i int) int {
+		if i < 0 {
+			return i + n
+		}
+		return i
+	}
+	if s.step > 0 {
+		lower, upper := 0, n
+		if s.start != nil {
+			lower = min(max(normalize(*s.start), 0), n)
+		}
+		if s.end != nil {
+			upper = min(max(normalize(*s.end), 0), n)
+		}
+		for i := lower; i < upper; i += s.step {
+			out = append(out, arr[i])
+		}
+		return out
+	}
+	upper, lower := n-1, -1
+	if s.start != nil {
+		upper = min(max(normalize(*s.start), -1), n-1)
+	}
+	if s.end != nil {
+		lower = min(max(normalize(*s.end), -1), n-1)
+	}
+	for i := upper; i > lower; i += s.step {
+		out = append(out, arr[i])
+	}
+	return out
+}
+
+type filterSelector struct {
+	expr filterExpr
+}
+
+func (s filterSelector) selectFrom(node, root any, out []any) []any {
+	for _, child := range children(node) {
+		if s.expr.test(child, root) {
+			out = append(out, child)
+		}
+	}
+	return out
+}
+
+//------------------------------------------------------------------------------
+
+type filterExpr interface {
+	test(current, root any) bool
+}
+
+type orExpr []filterExpr
+
+func (e orExpr) test(current, root any) bool {
+	for _, c := range e {
+		if c.test(current, root) {
+			return true
+		}
+	}
+	return false
+}
+
+type andExpr []filterExpr
+
+func (e andExpr) test(current, root any) bool {
+	for _, c := range e {
+		if !c.test(current, root) {
+			return false
+		}
+	}
+	return true
+}
+
+type notExpr struct {
+	expr filterExpr
+}
+
+func (e notExpr) test(current, root any) bool {
+	return !e.expr.test(current, root)
+}
+
+type existsExpr struct {
+	query queryOperand
+}
+
+func (e existsExpr) test(current, root any) bool {
+	return len(e.query.nodes(current, root)) > 0
+}
+
+type compareExpr struct {
+	op          string
+	left, right operand
+}
+
+func (e compareExpr) test(current, root any) bool {
+	l, lOk := e.left.value(current, root)
+	r, rOk := e.right.value(current, root)
+	switch e.op {
+	case "==":
+		return compareEqual(l, lOk, r, rOk)
+	case "!=":
+		return !compareEqual(l, lOk, r, rOk)
+	case "<":
+		return lOk && rOk && compareLess(l, r)
+	case "<=":
+		return compareEqual(l, lOk, r, rOk) || (lOk && rOk && compareLess(l, r))
+	case ">":
+		return lOk && rOk && compareLess(r, l)
+	case ">=":
+		return compareEqual(l, lOk, r, rOk) || (lOk && rOk && compareLess(r, l))
+	}
+	return false
+}
+
+// compareEqual returns whether two operand values are equal, where a missing
+// value is only equal to another missing value.
+func compareEqual(l any, lOk bool, r any, rOk bool) bool {
+	if !lOk || !rOk {
+		return lOk == rOk
+	}
+	return deepEqual(l, r)
+}
+
+func deepEqual(l, r any) bool {
+	switch lt := l.(type) {
+	case map[string]any:
+		rt, ok := r.(map[string]any)
+		if !ok || len(lt) != len(rt) {
+			return false
+		}
+		for k, lv := range lt {
+			rv, exists := rt[k]
+			if !exists || !deepEqual(lv, rv) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		rt, ok := r.([]any)
+		if !ok || len(lt) != len(rt) {
+			return false
+		}
+		for i, lv := range lt {
+			if !deepEqual(lv, rt[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return value.ICompare(l, r)
+}
+
+func compareLess(l, r any) bool {
+	if lNum, err := value.IGetNumber(l); err == nil {
+		rNum, err := value.IGetNumber(r)
+		return err == nil && lNum < rNum
+	}
+	if lStr, ok := l.(string); ok {
+		rStr, ok := r.(string)
+		return ok && lStr < rStr
+	}
+	return false
+}
+
+type regexpExpr struct {
+	fullMatch bool
+	subject   operand
+	pattern   operand
+	compiled  *regexp.Regexp
+}
+
+func (e regexpExpr) test(current, root any) bool {
+	subjectV, ok := e.subject.value(current, root)
+	if !ok {
+		return false
+	}
+	subject, ok := subjectV.(string)
+	if !ok {
+		return false
+	}
+	re := e.compiled
+	if re == nil {
+		patternV, ok := e.pattern.value(current, root)
+		if !ok {
+			return false
+		}
+		pattern, ok := patternV.(string)
+		if !ok {
+			return false
+		}
+		var err error
+		if re, err = compileFilterRegexp(pattern, e.fullMatch); err != nil {
+			return false
+		}
+	}
+	return re.MatchString(subject)
+}
+
+func compileFilterRegexp(pattern string, fullMatch bool) (*regexp.Regexp, error) {
+	if fullMatch {
+		pattern = `\A(?:` + pattern + `)\z`
+	}
+	return regexp.Compile(pattern)
+}
+
+//------------------------------------------------------------------------------
+
+type operand interface {
+	// value returns the value of the operand, or false if the operand does not
+	// produce a value.
+	value(current, root any) (any, bool)
+}
+
+type literalOperand struct {
+	v any
+}
+
+func (o literalOperand) value(current, root any) (any, bool) {
+	return o.v, true
+}
+
+type queryOperand struct {
+	relative bool
+	segments []segment
+}
+
+func (o queryOperand) nodes(current, root any) []any {
+	if o.relative {
+		return evalSegments(o.segments, current, root)
+	}
+	return evalSegments(o.segments, root, root)
+}
+
+func (o queryOperand) value(current, root any) (any, bool) {
+	nodes := o.nodes(current, root)
+	if len(nodes) != 1 {
+		return nil, false
+	}
+	return nodes[0], true
+}
+
+type lengthOperand struct {
+	arg operand
+}
+
+func (o lengthOperand) value(current, root any) (any, bool) {
+	v, ok := o.arg.value(current, root)
+	if !ok {
+		return nil, false
+	}
+	switch t := v.(type) {
+	case string:
+		return float64(utf8.RuneCountInString(t)), true
+	case []any:
+		return float64(len(t)), true
+	case map[string]any:
+		return float64(len(t)), true
+	}
+	return nil, false
+}
+
+type countOperand struct {
+	query queryOperand
+}
+
+func (o countOperand) value(current, root any) (any, bool) {
+	return float64(len(o.query.nodes(current, root))), true
+}
+
+//------------------------------------------------------------------------------
+
+type parser struct {
+	s   string
+	pos int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("char %v: %v", p.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\n\r", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *parser) peek(prefix string) bool {
+	return strings.HasPrefix(p.s[p.pos:], prefix)
+}
+
+func (p *parser) consume(prefix string) bool {
+	if p.peek(prefix) {
+		p.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(prefix string) error {
+	p.skipSpace()
+	if !p.consume(prefix) {
+		if p.pos >= len(p.s) {
+			return p.errorf("expected %q but reached end of expression", prefix)
+		}
+		return p.errorf("expected %q", prefix)
+	}
+	return nil
+}
+
+func (p *parser) parseSegments() (segments []segment, err error) {
+	for {
+		// Whitespace is permitted between segments, but not within them.
+		start := p.pos
+		p.skipSpace()
+		var seg segment
+		switch {
+		case p.consume(".."):
+			seg.descendant = true
+			if p.peek("[") {
+				p.pos++
+				if seg.selectors, err = p.parseBracketed(); err != nil {
+					return nil, err
+				}
+			} else if seg.selectors, err = p.parseDotSelector(); err != nil {
+				return nil, err
+			}
+		case p.consume("."):
+			if seg.selectors, err = p.parseDotSelector(); err != nil {
+				return nil, err
+			}
+		case p.consume("["):
+			if seg.selectors, err = p.parseBracketed(); err != nil {
+				return nil, err
+			}
+		default:
+			p.pos = start
+			return segments, nil
+		}
+		segments = append(segments, seg)
+	}
+}
+
+func isNameFirst(r rune) bool {
+	return r == '_' || r >= utf8.RuneSelf || unicode.IsLetter(r)
+}
+
+func isNameChar(r rune) bool {
+	return isNameFirst(r) || r == '-' || (r >= '0' && r <= '9')
+}
+
+func (p *parser) parseDotSelector() ([]selector, error) {
+	if p.consume("*") {
+		return []selector{wildcardSelector{}}, nil
+	}
+	start := p.pos
+	for p.pos < len(p.s) {
+		r, size := utf8.DecodeRuneInString(p.s[p.pos:])
+		if (p.pos == start && !isNameFirst(r)) || !isNameChar(r) {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		return nil, p.errorf("expected a member name or wildcard")
+	}
+	return []selector{nameSelector(p.s[start:p.pos])}, nil
+}
+
+func (p *parser) parseBracketed() (selectors []selector, err error) {
+	for {
+		p.skipSpace()
+		var sel selector
+		if sel, err = p.parseSelector(); err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, sel)
+
+		p.skipSpace()
+		if p.consume("]") {
+			return selectors, nil
+		}
+		if err = p.expect(","); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) parseSelector() (selector, error) {
+	switch {
+	case p.consume("*"):
+		return wildcardSelector{}, nil
+	case p.peek("'"), p.peek(`"`):
+		str, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return nameSelector(str), nil
+	case p.consume("?"):
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return filterSelector{expr: expr}, nil
+	}
+
+	// Either an index or a slice.
+	var bounds [3]*int
+	for i := range bounds {
+		p.skipSpace()
+		if p.peek("-") || (p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9') {
+			n, err := p.parseInt()
+			if err != nil {
+				return nil, err
+			}
+			bounds[i] = &n
+			p.skipSpace()
+		}
+		if i == 2 || !p.consume(":") {
+			if i == 0 {
+				if bounds[0] == nil {
+					return nil, p.errorf("expected a selector")
+				}
+				return indexSelector(*bounds[0]), nil
+			}
+			break
+		}
+	}
+	s := sliceSelector{start: bounds[0], end: bounds[1], step: 1}
+	if bounds[2] != nil {
+		s.step = *bounds[2]
+	}
+	return s, nil
+}
+
+func (p *parser) parseInt() (int, error) {
+	start := p.pos
+	p.consume("-")
+	for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+		p.pos++
+	}
+	n, err := strconv.Atoi(p.s[start:p.pos])
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("invalid integer %q", p.s[start:p.pos])
+	}
+	return n, nil
+}
+
+func (p *parser) parseString() (string, error) {
+	quote := p.s[p.pos]
+	start := p.pos
+	p.pos++
+
+	var sb strings.Builder
+	for p.pos < len(p.s) {
+		c := p.s[p.pos]
+		switch {
+		case c == quote:
+			p.pos++
+			return sb.String(), nil
+		case c == '\\':
+			p.pos++
+			if p.pos >= len(p.s) {
+				return "", p.errorf("unterminated string")
+			}
+			esc := p.s[p.pos]
+			p.pos++
+			switch esc {
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case '/', '\\', '\'', '"':
+				sb.WriteByte(esc)
+			case 'u':
+				if p.pos+4 > len(p.s) {
+					return "", p.errorf("invalid unicode escape")
+				}
+				r, err := strconv.ParseUint(p.s[p.pos:p.pos+4], 16, 32)
+				if err != nil {
+					return "", p.errorf("invalid unicode escape")
+				}
+				p.pos += 4
+				sb.WriteRune(rune(r))
+			default:
+				return "", p.errorf("invalid escape character %q", esc)
+			}
+		default:
+			sb.WriteByte(c)
+			p.pos++
+		}
+	}
+	p.pos = start
+	return "", p.errorf("unterminated string")
+}
+
+//------------------------------------------------------------------------------
+
+func (p *parser) parseOr() (filterExpr, error) {
+	var exprs orExpr
+	for {
+		e, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+		p.skipSpace()
+		if !p.consume("||") {
+			break
+		}
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return exprs, nil
+}
+
+func (p *parser) parseAnd() (filterExpr, error) {
+	var exprs andExpr
+	for {
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+		p.skipSpace()
+		if !p.consume("&&") {
+			break
+		}
+	}
+	if len(exprs) == 1 {
+		return exprs[0], nil
+	}
+	return exprs, nil
+}
+
+func (p *parser) parseUnary() (filterExpr, error) {
+	p.skipSpace()
+	if p.consume("!") {
+		if p.peek("=") {
+			return nil, p.errorf("unexpected =")
+		}
+		e, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr: e}, nil
+	}
+	if p.consume("(") {
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	if p.peek("match(") || p.peek("search(") {
+		return p.parseRegexpFunction()
+	}
+
+	start := p.pos
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if !p.consume(op) {
+			continue
+		}
+		right, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return compareExpr{op: op, left: left, right: right}, nil
+	}
+
+	q, isQuery := left.(queryOperand)
+	if !isQuery {
+		p.pos = start
+		return nil, p.errorf("expected a comparison or a query")
+	}
+	return existsExpr{query: q}, nil
+}
+
+func (p *parser) parseRegexpFunction() (filterExpr, error) {
+	e := regexpExpr{fullMatch: p.consume("match(")}
+	if !e.fullMatch {
+		p.consume("search(")
+	}
+
+	var err error
+	if e.subject, err = p.parseOperand(); err != nil {
+		return nil, err
+	}
+	if err = p.expect(","); err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	patternStart := p.pos
+	if e.pattern, err = p.parseOperand(); err != nil {
+		return nil, err
+	}
+	if lit, isLit := e.pattern.(literalOperand); isLit {
+		pattern, isStr := lit.v.(string)
+		if !isStr {
+			p.pos = patternStart
+			return nil, p.errorf("expected a string pattern")
+		}
+		if e.compiled, err = compileFilterRegexp(pattern, e.fullMatch); err != nil {
+			p.pos = patternStart
+			return nil, p.errorf("invalid pattern: %v", err)
+		}
+	}
+	if err = p.expect(")"); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (p *parser) parseQuery(relative bool) (queryOperand, error) {
+	segments, err := p.parseSegments()
+	if err != nil {
+		return queryOperand{}, err
+	}
+	return queryOperand{relative: relative, segments: segments}, nil
+}
+
+func (p *parser) parseOperand() (operand, error) {
+	p.skipSpace()
+	switch {
+	case p.consume("@"):
+		return p.parseQuery(true)
+	case p.consume("$"):
+		return p.parseQuery(false)
+	case p.peek("'"), p.peek(`"`):
+		str, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		return literalOperand{v: str}, nil
+	case p.consume("true"):
+		return literalOperand{v: true}, nil
+	case p.consume("false"):
+		return literalOperand{v: false}, nil
+	case p.consume("null"):
+		return literalOperand{v: nil}, nil
+	case p.consume("length("):
+		arg, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return lengthOperand{arg: arg}, nil
+	case p.consume("count("):
+		p.skipSpace()
+		start := p.pos
+		arg, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		q, isQuery := arg.(queryOperand)
+		if !isQuery {
+			p.pos = start
+			return nil, p.errorf("expected a query argument")
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return countOperand{query: q}, nil
+	}
+	return p.parseNumber()
+}
+
+func (p *parser) parseNumber() (operand, error) {
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("+-.0123456789eE", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	if p.pos == start {
+		if p.pos >= len(p.s) {
+			return nil, p.errorf("expected an operand but reached end of expression")
+		}
+		return nil, p.errorf("expected an operand")
+	}
+	f, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("invalid number")
+	}
+	return literalOperand{v: f}, nil
+}
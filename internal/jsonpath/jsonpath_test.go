@@ -0,0 +1,109 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package jsonpath
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const storeDoc = `{
+  "store": {
+    "book": [
+      {"category": "reference", "author": "Nigel Rees", "title": "Sayings of the Century", "price": 8.95},
+      {"category": "fiction", "author": "Evelyn Waugh", "title": "Sword of Honour", "price": 12.99},
+      {"category": "fiction", "author": "Herman Melville", "title": "Moby Dick", "isbn": "0-553-21311-3", "price": 8.99},
+      {"category": "fiction", "author": "J. R. R. Tolkien", "title": "The Lord of the Rings", "isbn": "0-395-19395-8", "price": 22.99}
+    ],
+    "bicycle": {"color": "red", "price": 399}
+  },
+  "tags": ["a", "b", "c", "d", "e"],
+  "weird keys": {"a.b": 1, "it's": 2}
+}`
+
+func TestQuery(t *testing.T) {
+	var doc any
+	require.NoError(t, json.Unmarshal([]byte(storeDoc), &doc))
+
+	tests := []struct {
+		path string
+		exp  string
+	}{
+		{path: `$`, exp: `[` + storeDoc + `]`},
+		{path: `$.store.book[*].author`, exp: `["Nigel Rees","Evelyn Waugh","Herman Melville","J. R. R. Tolkien"]`},
+		{path: `$..author`, exp: `["Nigel Rees","Evelyn Waugh","Herman Melville","J. R. R. Tolkien"]`},
+		{path: `$.store.bicycle.*`, exp: `["red",399]`},
+		{path: `$.store..price`, exp: `[399,8.95,12.99,8.99,22.99]`},
+		{path: `$..book[2].title`, exp: `["Moby Dick"]`},
+		{path: `$..book[-1].title`, exp: `["The Lord of the Rings"]`},
+		{path: `$..book[0,1].title`, exp: `["Sayings of the Century","Sword of Honour"]`},
+		{path: `$..book[:2].title`, exp: `["Sayings of the Century","Sword of Honour"]`},
+		{path: `$..book[?@.isbn].title`, exp: `["Moby Dick","The Lord of the Rings"]`},
+		{path: `$..book[?(@.price<10)].title`, exp: `["Sayings of the Century","Moby Dick"]`},
+		{path: `$..book[?@.price < 10 && @.category == 'fiction'].title`, exp: `["Moby Dick"]`},
+		{path: `$..book[?@.price > 20 || !@.isbn].title`, exp: `["Sayings of the Century","Sword of Honour","The Lord of the Rings"]`},
+		{path: `$..book[?@.price > $.store.bicycle.price].title`, exp: `[]`},
+		{path: `$..book[?match(@.author, 'H.*')].title`, exp: `["Moby Dick"]`},
+		{path: `$..book[?search(@.author, 'R\\.')].title`, exp: `["The Lord of the Rings"]`},
+		{path: `$..book[?length(@.title) < 10].title`, exp: `["Moby Dick"]`},
+		{path: `$.store.book[?count(@.*) == 5].title`, exp: `["Moby Dick","The Lord of the Rings"]`},
+		{path: `$.store.book[?@.missing == null].title`, exp: `[]`},
+		{path: `$.store.book[?@.missing != 'x'].price`, exp: `[8.95,12.99,8.99,22.99]`},
+		{path: `$.tags[1:4]`, exp: `["b","c","d"]`},
+		{path: `$.tags[::2]`, exp: `["a","c","e"]`},
+		{path: `$.tags[::-1]`, exp: `["e","d","c","b","a"]`},
+		{path: `$.tags[-2:]`, exp: `["d","e"]`},
+		{path: `$.tags[3:1:-1]`, exp: `["d","c"]`},
+		{path: `$.tags[10]`, exp: `[]`},
+		{path: `$.tags[?@ == 'c' || @ == "e"]`, exp: `["c","e"]`},
+		{path: `$['weird keys']['a.b', "it's"]`, exp: `[1,2]`},
+		{path: `$["weird keys"]['it\'s']`, exp: `[2]`},
+		{path: `$.nope.nope`, exp: `[]`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			p, err := Compile(test.path)
+			require.NoError(t, err)
+
+			res := p.Query(doc)
+			if res == nil {
+				res = []any{}
+			}
+			resBytes, err := json.Marshal(res)
+			require.NoError(t, err)
+
+			var exp []any
+			require.NoError(t, json.Unmarshal([]byte(test.exp), &exp))
+			expBytes, err := json.Marshal(exp)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(expBytes), string(resBytes))
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := map[string]string{
+		`foo`:                 "char 0: expected path to begin with $",
+		`$.`:                  "char 2: expected a member name or wildcard",
+		`$.foo[`:              "char 6: expected a selector",
+		`$.foo[0`:             `char 7: expected "," but reached end of expression`,
+		`$['foo`:              "char 2: unterminated string",
+		`$[?@.a ==]`:          "char 9: expected an operand",
+		`$[?'a']`:             "char 3: expected a comparison or a query",
+		`$[?match(@.a, '(')]`: "char 14: invalid pattern",
+		`$.foo bar`:           `char 6: unexpected "bar"`,
+	}
+
+	for input, exp := range tests {
+		t.Run(input, func(t *testing.T) {
+			_, err := Compile(input)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), exp)
+		})
+	}
+}
@@ -3,6 +3,10 @@
 package manager
 
 import (
+	"errors"
+	"time"
+
+	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
 	"github.com/redpanda-data/benthos/v4/internal/component/cache"
 	"github.com/redpanda-data/benthos/v4/internal/component/input"
 	"github.com/redpanda-data/benthos/v4/internal/component/output"
@@ -17,8 +21,33 @@ const (
 	fieldResourceOutputs    = "output_resources"
 	fieldResourceCaches     = "cache_resources"
 	fieldResourceRateLimits = "rate_limit_resources"
+	fieldBloblang           = "bloblang"
+
+	fieldBloblangMaxOperations     = "max_operations"
+	fieldBloblangMaxAllocatedBytes = "max_allocated_bytes"
+	fieldBloblangMaxRecursionDepth = "max_recursion_depth"
+	fieldBloblangTimeout           = "timeout"
 )
 
+// BloblangConfig contains fields that limit the resources consumed by each
+// execution of the Bloblang mappings within a Benthos config.
+type BloblangConfig struct {
+	MaxOperations     int64         `yaml:"max_operations"`
+	MaxAllocatedBytes int64         `yaml:"max_allocated_bytes"`
+	MaxRecursionDepth int           `yaml:"max_recursion_depth"`
+	Timeout           time.Duration `yaml:"timeout"`
+}
+
+// Budget returns the execution budget described by the config.
+func (b BloblangConfig) Budget() query.Budget {
+	return query.Budget{
+		MaxOperations:     b.MaxOperations,
+		MaxAllocatedBytes: b.MaxAllocatedBytes,
+		MaxRecursionDepth: b.MaxRecursionDepth,
+		Timeout:           b.Timeout,
+	}
+}
+
 // ResourceConfig contains fields for specifying resource components at the root
 // of a Benthos config.
 type ResourceConfig struct {
@@ -27,6 +56,7 @@ type ResourceConfig struct {
 	ResourceOutputs    []output.Config    `yaml:"output_resources,omitempty"`
 	ResourceCaches     []cache.Config     `yaml:"cache_resources,omitempty"`
 	ResourceRateLimits []ratelimit.Config `yaml:"rate_limit_resources,omitempty"`
+	Bloblang           BloblangConfig     `yaml:"bloblang"`
 }
 
 // NewResourceConfig creates a ResourceConfig with default values.
@@ -41,13 +71,17 @@ func NewResourceConfig() ResourceConfig {
 }
 
 // AddFrom takes another Config and adds all of its resources to itself. If
-// there are any resource name collisions an error is returned.
+// there are any resource name collisions an error is returned. The Bloblang
+// config of the other Config replaces its own when it limits any resources.
 func (r *ResourceConfig) AddFrom(extra *ResourceConfig) error {
 	r.ResourceInputs = append(r.ResourceInputs, extra.ResourceInputs...)
 	r.ResourceProcessors = append(r.ResourceProcessors, extra.ResourceProcessors...)
 	r.ResourceOutputs = append(r.ResourceOutputs, extra.ResourceOutputs...)
 	r.ResourceCaches = append(r.ResourceCaches, extra.ResourceCaches...)
 	r.ResourceRateLimits = append(r.ResourceRateLimits, extra.ResourceRateLimits...)
+	if !extra.Bloblang.Budget().IsZero() {
+		r.Bloblang = extra.Bloblang
+	}
 	return nil
 }
 
@@ -137,5 +171,41 @@ func FromParsed(prov docs.Provider, pConf *docs.ParsedConfig) (conf ResourceConf
 		}
 		conf.ResourceRateLimits = append(conf.ResourceRateLimits, c)
 	}
+
+	if pConf.Contains(fieldBloblang) {
+		if conf.Bloblang, err = bloblangConfigFromParsed(pConf.Namespace(fieldBloblang)); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func bloblangConfigFromParsed(pConf *docs.ParsedConfig) (conf BloblangConfig, err error) {
+	var n int
+	if pConf.Contains(fieldBloblangMaxOperations) {
+		if n, err = pConf.FieldInt(fieldBloblangMaxOperations); err != nil {
+			return
+		}
+		conf.MaxOperations = int64(n)
+	}
+	if pConf.Contains(fieldBloblangMaxAllocatedBytes) {
+		if n, err = pConf.FieldInt(fieldBloblangMaxAllocatedBytes); err != nil {
+			return
+		}
+		conf.MaxAllocatedBytes = int64(n)
+	}
+	if pConf.Contains(fieldBloblangMaxRecursionDepth) {
+		if conf.MaxRecursionDepth, err = pConf.FieldInt(fieldBloblangMaxRecursionDepth); err != nil {
+			return
+		}
+	}
+	if pConf.Contains(fieldBloblangTimeout) {
+		if conf.Timeout, err = pConf.FieldDuration(fieldBloblangTimeout); err != nil {
+			return
+		}
+	}
+	if conf.MaxOperations < 0 || conf.MaxAllocatedBytes < 0 || conf.MaxRecursionDepth < 0 || conf.Timeout < 0 {
+		err = errors.New("bloblang limits must not be negative")
+	}
 	return
 }
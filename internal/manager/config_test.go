@@ -4,6 +4,7 @@ package manager_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -68,6 +69,32 @@ rate_limit_resources:
 				assert.Equal(t, "local", v.ResourceRateLimits[0].Type)
 			},
 		},
+		{
+			name: "bloblang limits",
+			input: `
+bloblang:
+  max_operations: 100
+  max_allocated_bytes: 2048
+  max_recursion_depth: 5
+  timeout: 50ms
+`,
+			validateFn: func(t testing.TB, v manager.ResourceConfig) {
+				assert.Equal(t, manager.BloblangConfig{
+					MaxOperations:     100,
+					MaxAllocatedBytes: 2048,
+					MaxRecursionDepth: 5,
+					Timeout:           time.Millisecond * 50,
+				}, v.Bloblang)
+			},
+		},
+		{
+			name: "negative bloblang limits",
+			input: `
+bloblang:
+  max_operations: -1
+`,
+			errContains: "must not be negative",
+		},
 	}
 
 	for _, test := range tests {
@@ -46,5 +46,14 @@ func Spec() docs.FieldSpecs {
 		docs.FieldRateLimit(
 			"rate_limit_resources", "A list of rate limit resources, each must have a unique label.",
 		).Array().LinterFunc(lintResource).HasDefault([]any{}).Advanced(),
+
+		docs.FieldObject(
+			fieldBloblang, "Limits on the resources that each execution of a Bloblang mapping within the config is allowed to consume, which applies to all Bloblang fields including those of the `mapping` and `mutation` processors. Executions that exceed a limit fail with an error that cannot be caught from within the mapping. A zero value for any field means that the resource is not limited.",
+		).WithChildren(
+			docs.FieldInt(fieldBloblangMaxOperations, "The maximum number of functions, methods, operators and iterations executed.").HasDefault(0),
+			docs.FieldInt(fieldBloblangMaxAllocatedBytes, "The maximum number of bytes that values created during an execution are estimated to occupy.").HasDefault(0),
+			docs.FieldInt(fieldBloblangMaxRecursionDepth, "The maximum number of nested map executions.").HasDefault(0),
+			docs.FieldString(fieldBloblangTimeout, "The maximum wall-clock time of an execution.", "100ms", "1s").HasDefault("0s"),
+		).Advanced(),
 	}
 }
@@ -225,6 +225,10 @@ func New(conf ResourceConfig, opts ...OptFunc) (*Type, error) {
 		opt(t)
 	}
 
+	if budget := conf.Bloblang.Budget(); !budget.IsZero() {
+		t.bloblEnv = t.bloblEnv.WithExecutionBudget(budget)
+	}
+
 	seen := map[string]struct{}{}
 
 	checkLabel := func(typeStr, label string) error {
@@ -585,3 +585,27 @@ func TestManagerGenericGetOrSet(t *testing.T) {
 	assert.True(t, loaded)
 	assert.Equal(t, "foo", v)
 }
+
+func TestManagerBloblangBudget(t *testing.T) {
+	conf, err := testutil.ManagerFromYAML(`
+processor_resources:
+  - label: foo
+    mapping: 'root = range(0, 100).map_each(n -> n * 2).sum()'
+bloblang:
+  max_operations: 20
+`)
+	require.NoError(t, err)
+
+	mgr, err := manager.New(conf)
+	require.NoError(t, err)
+
+	var res []message.Batch
+	var pErr error
+	require.NoError(t, mgr.AccessProcessor(t.Context(), "foo", func(p processor.V1) {
+		res, pErr = p.ProcessBatch(t.Context(), message.QuickBatch([][]byte{[]byte(`{}`)}))
+	}))
+	require.NoError(t, pErr)
+	require.Len(t, res, 1)
+	require.Len(t, res[0], 1)
+	assert.ErrorContains(t, res[0][0].ErrorGet(), "operations limit of 20 reached")
+}
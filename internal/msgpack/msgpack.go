@@ -0,0 +1,489 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+// Package msgpack implements the encoding and decoding of MessagePack values
+// into and from the generic value types used by Bloblang and messages.
+package msgpack
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/redpanda-data/benthos/v4/internal/bytesutil"
+	"github.com/redpanda-data/benthos/v4/internal/value"
+)
+
+// The extension type reserved for timestamps.
+const extTimestamp = -1
+
+// The maximum nesting of arrays and maps allowed when decoding, which protects
+// against exhausting the stack with malicious input.
+const maxDepth = 10000
+
+// ErrMaxDepth is returned when decoding a value that is nested too deeply.
+var ErrMaxDepth = errors.New("exceeded maximum nesting depth")
+
+// Decoder reads and decodes successive MessagePack values from a stream.
+type Decoder struct {
+	r   *bufio.Reader
+	raw *bytes.Buffer
+}
+
+// NewDecoder returns a decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+// Decode reads the next value from the stream. Maps are decoded as
+// map[string]any, where keys that are not strings are converted into strings,
+// integers are decoded as int64 (or uint64 when they exceed the range of an
+// int64), floats as float64, binary as []byte and timestamps as time.Time.
+// Other extension types are decoded as an object with the fields `type` and
+// `data`.
+//
+// Returns io.EOF if the stream ended before the start of a value, and
+// io.ErrUnexpectedEOF if it ended within a value.
+func (d *Decoder) Decode() (any, error) {
+	if _, err := d.r.Peek(1); err != nil {
+		return nil, err
+	}
+	v, err := d.decode(0)
+	if errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+	return v, err
+}
+
+// DecodeRaw reads the next value from the stream and returns its encoded bytes
+// after validating it.
+func (d *Decoder) DecodeRaw() ([]byte, error) {
+	d.raw = &bytes.Buffer{}
+	defer func() {
+		d.raw = nil
+	}()
+	if _, err := d.Decode(); err != nil {
+		return nil, err
+	}
+	return d.raw.Bytes(), nil
+}
+
+// Unmarshal decodes a single MessagePack value, returning an error if the data
+// contains anything after the value.
+func Unmarshal(data []byte) (any, error) {
+	d := NewDecoder(bytes.NewReader(data))
+	v, err := d.Decode()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if _, err := d.r.Peek(1); err == nil {
+		return nil, errors.New("unexpected data after value")
+	}
+	return v, nil
+}
+
+func (d *Decoder) readByte() (byte, error) {
+	b, err := d.r.ReadByte()
+	if err == nil && d.raw != nil {
+		d.raw.WriteByte(b)
+	}
+	return b, err
+}
+
+func (d *Decoder) readN(n uint64) ([]byte, error) {
+	b, err := bytesutil.ReadN(d.r, n)
+	if err != nil {
+		return nil, err
+	}
+	if d.raw != nil {
+		d.raw.Write(b)
+	}
+	return b, nil
+}
+
+func (d *Decoder) readUint(size int) (uint64, error) {
+	b, err := d.readN(uint64(size))
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+func (d *Decoder) readInt(size int) (int64, error) {
+	u, err := d.readUint(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return int64(int8(u)), nil
+	case 2:
+		return int64(int16(u)), nil
+	case 4:
+		return int64(int32(u)), nil
+	}
+	return int64(u), nil
+}
+
+func (d *Decoder) decode(depth int) (any, error) {
+	if depth > maxDepth {
+		return nil, ErrMaxDepth
+	}
+
+	b, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case b <= 0x7f:
+		return int64(b), nil
+	case b >= 0xe0:
+		return int64(int8(b)), nil
+	case b&0xf0 == 0x80:
+		return d.decodeMap(uint64(b&0x0f), depth)
+	case b&0xf0 == 0x90:
+		return d.decodeArray(uint64(b&0x0f), depth)
+	case b&0xe0 == 0xa0:
+		return d.decodeString(uint64(b & 0x1f))
+	}
+
+	switch b {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := d.readUint(1 << (b - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		return d.readN(n)
+	case 0xc7, 0xc8, 0xc9:
+		n, err := d.readUint(1 << (b - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeExt(n)
+	case 0xca:
+		u, err := d.readUint(4)
+		if err != nil {
+			return nil, err
+		}
+		return float64(math.Float32frombits(uint32(u))), nil
+	case 0xcb:
+		u, err := d.readUint(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(u), nil
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		u, err := d.readUint(1 << (b - 0xcc))
+		if err != nil {
+			return nil, err
+		}
+		if u > math.MaxInt64 {
+			return u, nil
+		}
+		return int64(u), nil
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		return d.readInt(1 << (b - 0xd0))
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return d.decodeExt(1 << (b - 0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := d.readUint(1 << (b - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeString(n)
+	case 0xdc, 0xdd:
+		n, err := d.readUint(2 << (b - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeArray(n, depth)
+	case 0xde, 0xdf:
+		n, err := d.readUint(2 << (b - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return d.decodeMap(n, depth)
+	}
+	return nil, fmt.Errorf("invalid format byte 0x%02x", b)
+}
+
+func (d *Decoder) decodeString(n uint64) (any, error) {
+	b, err := d.readN(n)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (d *Decoder) decodeArray(n uint64, depth int) (any, error) {
+	arr := make([]any, 0, min(n, bytesutil.MaxPrealloc))
+	for i := uint64(0); i < n; i++ {
+		v, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		arr = append(arr, v)
+	}
+	return arr, nil
+}
+
+func (d *Decoder) decodeMap(n uint64, depth int) (any, error) {
+	obj := make(map[string]any, min(n, bytesutil.MaxPrealloc))
+	for i := uint64(0); i < n; i++ {
+		k, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		v, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		obj[keyString(k)] = v
+	}
+	return obj, nil
+}
+
+func keyString(k any) string {
+	switch t := k.(type) {
+	case string:
+		return t
+	case []byte:
+		return string(t)
+	case int64:
+		return strconv.FormatInt(t, 10)
+	case uint64:
+		return strconv.FormatUint(t, 10)
+	case float64:
+		return strconv.FormatFloat(t, 'g', -1, 64)
+	case nil:
+		return "null"
+	}
+	b, _ := json.Marshal(k)
+	return string(b)
+}
+
+func (d *Decoder) decodeExt(n uint64) (any, error) {
+	t, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	data, err := d.readN(n)
+	if err != nil {
+		return nil, err
+	}
+	if int8(t) != extTimestamp {
+		return map[string]any{
+			"type": int64(int8(t)),
+			"data": data,
+		}, nil
+	}
+	switch len(data) {
+	case 4:
+		return time.Unix(int64(binary.BigEndian.Uint32(data)), 0).UTC(), nil
+	case 8:
+		v := binary.BigEndian.Uint64(data)
+		return time.Unix(int64(v&0x3ffffffff), int64(v>>34)).UTC(), nil
+	case 12:
+		nsec := binary.BigEndian.Uint32(data[:4])
+		sec := int64(binary.BigEndian.Uint64(data[4:]))
+		return time.Unix(sec, int64(nsec)).UTC(), nil
+	}
+	return nil, fmt.Errorf("invalid timestamp extension length %v", len(data))
+}
+
+//------------------------------------------------------------------------------
+
+// Marshal encodes a value as MessagePack. Floats that hold integer values are
+// encoded as integers, as numbers parsed from JSON documents are otherwise
+// always floats. Objects are encoded with their keys in lexicographical order.
+func Marshal(v any) ([]byte, error) {
+	return appendValue(nil, v, 0)
+}
+
+func appendUint(b []byte, u uint64) []byte {
+	switch {
+	case u <= 0x7f:
+		return append(b, byte(u))
+	case u <= math.MaxUint8:
+		return append(b, 0xcc, byte(u))
+	case u <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(u))
+	case u <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(u))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xcf), u)
+}
+
+func appendInt(b []byte, i int64) []byte {
+	switch {
+	case i >= 0:
+		return appendUint(b, uint64(i))
+	case i >= -32:
+		return append(b, byte(i))
+	case i >= math.MinInt8:
+		return append(b, 0xd0, byte(i))
+	case i >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(i))
+	case i >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(i))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(i))
+}
+
+func appendFloat(b []byte, f float64) []byte {
+	if f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 {
+		return appendInt(b, int64(f))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xcb), math.Float64bits(f))
+}
+
+// appendHeader appends the header of a string, binary, array or map of a
+// given length, where the fix format (if any) and the format bytes of each
+// width are provided.
+func appendHeader(b []byte, n int, fix, fixMax, f8, f16, f32 byte) []byte {
+	switch {
+	case fix != 0 && n <= int(fixMax):
+		return append(b, fix|byte(n))
+	case f8 != 0 && n <= math.MaxUint8:
+		return append(b, f8, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, f16), uint16(n))
+	}
+	return binary.BigEndian.AppendUint32(append(b, f32), uint32(n))
+}
+
+func appendString(b []byte, s string) []byte {
+	return append(appendHeader(b, len(s), 0xa0, 0x1f, 0xd9, 0xda, 0xdb), s...)
+}
+
+func appendTime(b []byte, t time.Time) []byte {
+	sec, nsec := t.Unix(), int64(t.Nanosecond())
+	if sec>>34 == 0 {
+		data := uint64(nsec)<<34 | uint64(sec)
+		if data&0xffffffff00000000 == 0 {
+			return binary.BigEndian.AppendUint32(append(b, 0xd6, 0xff), uint32(data))
+		}
+		return binary.BigEndian.AppendUint64(append(b, 0xd7, 0xff), data)
+	}
+	b = append(b, 0xc7, 12, 0xff)
+	b = binary.BigEndian.AppendUint32(b, uint32(nsec))
+	return binary.BigEndian.AppendUint64(b, uint64(sec))
+}
+
+func appendValue(b []byte, v any, depth int) ([]byte, error) {
+	if depth > maxDepth {
+		return nil, ErrMaxDepth
+	}
+	switch t := v.(type) {
+	case nil:
+		return append(b, 0xc0), nil
+	case bool:
+		if t {
+			return append(b, 0xc3), nil
+		}
+		return append(b, 0xc2), nil
+	case int:
+		return appendInt(b, int64(t)), nil
+	case int8:
+		return appendInt(b, int64(t)), nil
+	case int16:
+		return appendInt(b, int64(t)), nil
+	case int32:
+		return appendInt(b, int64(t)), nil
+	case int64:
+		return appendInt(b, t), nil
+	case uint:
+		return appendUint(b, uint64(t)), nil
+	case uint8:
+		return appendUint(b, uint64(t)), nil
+	case uint16:
+		return appendUint(b, uint64(t)), nil
+	case uint32:
+		return appendUint(b, uint64(t)), nil
+	case uint64:
+		return appendUint(b, t), nil
+	case float32:
+		return appendFloat(b, float64(t)), nil
+	case float64:
+		return appendFloat(b, t), nil
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			return appendInt(b, i), nil
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return appendFloat(b, f), nil
+	case value.Decimal:
+		if t.IsInteger() {
+			if i, err := t.Int64(); err == nil {
+				return appendInt(b, i), nil
+			}
+			if u, err := t.Uint64(); err == nil {
+				return appendUint(b, u), nil
+			}
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return appendFloat(b, f), nil
+	case string:
+		return appendString(b, t), nil
+	case []byte:
+		return append(appendHeader(b, len(t), 0, 0, 0xc4, 0xc5, 0xc6), t...), nil
+	case time.Time:
+		return appendTime(b, t), nil
+	case []any:
+		b = appendHeader(b, len(t), 0x90, 0x0f, 0, 0xdc, 0xdd)
+		var err error
+		for _, e := range t {
+			if b, err = appendValue(b, e, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	case map[string]any:
+		b = appendHeader(b, len(t), 0x80, 0x0f, 0, 0xde, 0xdf)
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var err error
+		for _, k := range keys {
+			b = appendString(b, k)
+			if b, err = appendValue(b, t[k], depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return b, nil
+	}
+	return nil, fmt.Errorf("unsupported value type %T", v)
+}
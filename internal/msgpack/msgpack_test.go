@@ -0,0 +1,192 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package msgpack
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/redpanda-data/benthos/v4/internal/value"
+)
+
+func TestUnmarshal(t *testing.T) {
+	tests := map[string]any{
+		"00":                             int64(0),
+		"7f":                             int64(127),
+		"ff":                             int64(-1),
+		"e0":                             int64(-32),
+		"cc80":                           int64(128),
+		"cdffff":                         int64(65535),
+		"ceffffffff":                     int64(4294967295),
+		"cfffffffffffffffff":             uint64(18446744073709551615),
+		"d080":                           int64(-128),
+		"d18000":                         int64(-32768),
+		"d280000000":                     int64(-2147483648),
+		"d38000000000000000":             int64(-9223372036854775808),
+		"ca3fc00000":                     1.5,
+		"cb3ff199999999999a":             1.1,
+		"c0":                             nil,
+		"c2":                             false,
+		"c3":                             true,
+		"a161":                           "a",
+		"d90161":                         "a",
+		"da000161":                       "a",
+		"db0000000161":                   "a",
+		"c40201ff":                       []byte{0x01, 0xff},
+		"c5000201ff":                     []byte{0x01, 0xff},
+		"c60000000201ff":                 []byte{0x01, 0xff},
+		"93010203":                       []any{int64(1), int64(2), int64(3)},
+		"dc0001c0":                       []any{nil},
+		"dd00000001c0":                   []any{nil},
+		"82a1610102c3":                   map[string]any{"a": int64(1), "2": true},
+		"de0001a161c0":                   map[string]any{"a": nil},
+		"df00000001a161c0":               map[string]any{"a": nil},
+		"d6ff514b67b0":                   time.Date(2013, 3, 21, 20, 4, 0, 0, time.UTC),
+		"d7ff77359400514b67b0":           time.Date(2013, 3, 21, 20, 4, 0, 500000000, time.UTC),
+		"c70cff00000001fffffffffffffffe": time.Unix(-2, 1).UTC(),
+		"d40105":                         map[string]any{"type": int64(1), "data": []byte{0x05}},
+	}
+
+	for input, exp := range tests {
+		t.Run(input, func(t *testing.T) {
+			b, err := hex.DecodeString(input)
+			require.NoError(t, err)
+
+			v, err := Unmarshal(b)
+			require.NoError(t, err)
+			assert.Equal(t, exp, v)
+		})
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	tests := map[string]string{
+		"":             "unexpected EOF",
+		"cd00":         "unexpected EOF",
+		"a261":         "unexpected EOF",
+		"0000":         "unexpected data after value",
+		"c1":           "invalid format byte 0xc1",
+		"d5ff0000":     "invalid timestamp extension length 2",
+		"c6ffffffff00": "unexpected EOF",
+		"dfffffffff":   "unexpected EOF",
+		"c40561":       "unexpected EOF",
+	}
+
+	for input, exp := range tests {
+		t.Run(input, func(t *testing.T) {
+			b, err := hex.DecodeString(input)
+			require.NoError(t, err)
+
+			_, err = Unmarshal(b)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), exp)
+		})
+	}
+
+	_, err := Unmarshal(bytes.Repeat([]byte{0x91}, maxDepth+2))
+	require.ErrorIs(t, err, ErrMaxDepth)
+}
+
+func mustDecimal(t testing.TB, s string) value.Decimal {
+	t.Helper()
+	d, err := value.NewDecimalFromString(s)
+	require.NoError(t, err)
+	return d
+}
+
+func TestMarshal(t *testing.T) {
+	tests := []struct {
+		input any
+		exp   string
+	}{
+		{input: 0, exp: "00"},
+		{input: -1, exp: "ff"},
+		{input: -33, exp: "d0df"},
+		{input: 256, exp: "cd0100"},
+		{input: int64(-9223372036854775808), exp: "d38000000000000000"},
+		{input: uint64(18446744073709551615), exp: "cfffffffffffffffff"},
+		{input: float64(5), exp: "05"},
+		{input: 1.1, exp: "cb3ff199999999999a"},
+		{input: true, exp: "c3"},
+		{input: nil, exp: "c0"},
+		{input: "a", exp: "a161"},
+		{input: strings.Repeat("b", 32), exp: "d920" + strings.Repeat("62", 32)},
+		{input: []byte{1}, exp: "c40101"},
+		{input: []any{1, "a"}, exp: "9201a161"},
+		{input: map[string]any{"b": 2, "a": 1}, exp: "82a16101a16202"},
+		{input: time.Unix(1363896240, 0), exp: "d6ff514b67b0"},
+		{input: time.Unix(1363896240, 500000000), exp: "d7ff77359400514b67b0"},
+		{input: mustDecimal(t, "12.000"), exp: "0c"},
+		{input: mustDecimal(t, "18446744073709551615"), exp: "cfffffffffffffffff"},
+		{input: mustDecimal(t, "1.1"), exp: "cb3ff199999999999a"},
+	}
+
+	for _, test := range tests {
+		b, err := Marshal(test.input)
+		require.NoError(t, err)
+		assert.Equal(t, test.exp, hex.EncodeToString(b), "%v", test.input)
+	}
+
+	_, err := Marshal(struct{}{})
+	require.Error(t, err)
+}
+
+func TestRoundTrip(t *testing.T) {
+	input := map[string]any{
+		"str":   "hello world",
+		"long":  strings.Repeat("a", 70000),
+		"bytes": bytes.Repeat([]byte{0xff}, 300),
+		"arr":   make([]any, 20),
+		"int":   int64(-300000),
+		"float": 0.333,
+		"obj":   map[string]any{"nested": []any{true, false}},
+		"time":  time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC),
+		"old":   time.Date(1900, 1, 2, 3, 4, 5, 6, time.UTC),
+	}
+
+	b, err := Marshal(input)
+	require.NoError(t, err)
+
+	v, err := Unmarshal(b)
+	require.NoError(t, err)
+	assert.Equal(t, input, v)
+}
+
+func TestDecoderStream(t *testing.T) {
+	var stream []byte
+	for _, v := range []any{"a", int64(2), map[string]any{"c": []any{}}} {
+		b, err := Marshal(v)
+		require.NoError(t, err)
+		stream = append(stream, b...)
+	}
+
+	d := NewDecoder(bytes.NewReader(stream))
+
+	v, err := d.Decode()
+	require.NoError(t, err)
+	assert.Equal(t, "a", v)
+
+	raw, err := d.DecodeRaw()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x02}, raw)
+
+	raw, err = d.DecodeRaw()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x81, 0xa1, 0x63, 0x90}, raw)
+
+	_, err = d.Decode()
+	require.ErrorIs(t, err, io.EOF)
+
+	d = NewDecoder(bytes.NewReader(stream[:len(stream)-1]))
+	_, _ = d.Decode()
+	_, _ = d.Decode()
+	_, err = d.Decode()
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
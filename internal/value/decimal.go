@@ -0,0 +1,350 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package value
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/cockroachdb/apd/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// DecimalDivisionPrecision is the number of significant digits that the
+// quotient of a decimal division is rounded to, matching that of an IEEE 754
+// decimal128 value.
+const DecimalDivisionPrecision = 34
+
+// DecimalRoundingModes lists the names of the supported decimal rounding modes
+// along with a description of each.
+var DecimalRoundingModes = map[string]string{
+	"half_even": "Round to the nearest value, and towards the value with an even last digit when halfway between them.",
+	"half_up":   "Round to the nearest value, and away from zero when halfway between them.",
+	"half_down": "Round to the nearest value, and towards zero when halfway between them.",
+	"up":        "Round away from zero.",
+	"down":      "Round towards zero, truncating any excess digits.",
+	"ceiling":   "Round towards positive infinity.",
+	"floor":     "Round towards negative infinity.",
+}
+
+// Decimal is an arbitrary-precision decimal number. Arithmetic between
+// decimals is exact with the exception of division, and a decimal is
+// serialised as a JSON or YAML number without any loss of precision.
+//
+// The zero value is the number zero.
+type Decimal struct {
+	// Treated as immutable once the Decimal has been constructed.
+	d *apd.Decimal
+}
+
+// Decimals with positive exponents up to this size are formatted without
+// scientific notation.
+const maxPlainDecimalExponent = 100
+
+var errDecimalNotFinite = errors.New("decimal values must be finite")
+
+func newDecimal(d *apd.Decimal) (Decimal, error) {
+	if d.Form != apd.Finite {
+		return Decimal{}, errDecimalNotFinite
+	}
+	return Decimal{d: d}, nil
+}
+
+func (d Decimal) apd() *apd.Decimal {
+	if d.d == nil {
+		return &apd.Decimal{}
+	}
+	return d.d
+}
+
+// NewDecimalFromString parses a decimal number such as `-12.3400` or `1.5e3`.
+// The number of digits after the decimal point is preserved.
+func NewDecimalFromString(s string) (Decimal, error) {
+	d, _, err := apd.NewFromString(s)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("failed to parse %q as a decimal", s)
+	}
+	if d.Form != apd.Finite {
+		return Decimal{}, fmt.Errorf("failed to parse %q as a decimal: %w", s, errDecimalNotFinite)
+	}
+	return Decimal{d: d}, nil
+}
+
+// NewDecimalFromInt64 returns a decimal with the value of an integer.
+func NewDecimalFromInt64(i int64) Decimal {
+	return Decimal{d: apd.New(i, 0)}
+}
+
+// NewDecimalFromUint64 returns a decimal with the value of an unsigned
+// integer.
+func NewDecimalFromUint64(u uint64) Decimal {
+	d := &apd.Decimal{}
+	d.Coeff.SetUint64(u)
+	return Decimal{d: d}
+}
+
+// NewDecimalFromFloat64 returns a decimal with the shortest decimal
+// representation of a float, such that 0.1 becomes exactly 0.1.
+func NewDecimalFromFloat64(f float64) (Decimal, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return Decimal{}, errDecimalNotFinite
+	}
+	return NewDecimalFromString(strconv.FormatFloat(f, 'g', -1, 64))
+}
+
+// IGetDecimal takes a boxed numerical value and returns it as a decimal.
+func IGetDecimal(v any) (Decimal, error) {
+	switch t := v.(type) {
+	case Decimal:
+		return t, nil
+	case json.Number:
+		return NewDecimalFromString(t.String())
+	case float32:
+		return NewDecimalFromString(strconv.FormatFloat(float64(t), 'g', -1, 32))
+	case float64:
+		return NewDecimalFromFloat64(t)
+	case uint, uint8, uint16, uint32, uint64:
+		u, err := IToUint(v)
+		if err != nil {
+			return Decimal{}, err
+		}
+		return NewDecimalFromUint64(u), nil
+	case int, int8, int16, int32, int64:
+		i, err := IToInt(v)
+		if err != nil {
+			return Decimal{}, err
+		}
+		return NewDecimalFromInt64(i), nil
+	}
+	return Decimal{}, NewTypeError(v, TNumber)
+}
+
+// IToDecimal takes a boxed value and attempts to convert it into a decimal,
+// either from a number or by parsing a string.
+func IToDecimal(v any) (Decimal, error) {
+	switch t := v.(type) {
+	case string:
+		return NewDecimalFromString(t)
+	case []byte:
+		return NewDecimalFromString(string(t))
+	}
+	return IGetDecimal(v)
+}
+
+// String returns the decimal formatted as a number, using scientific notation
+// only for very large or small exponents.
+func (d Decimal) String() string {
+	x := d.apd()
+	if x.Exponent > 0 && x.Exponent <= maxPlainDecimalExponent {
+		return x.Text('f')
+	}
+	return x.String()
+}
+
+// MarshalJSON writes the decimal as a JSON number with full precision.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// MarshalYAML writes the decimal as a YAML number with full precision.
+func (d Decimal) MarshalYAML() (any, error) {
+	return &yaml.Node{Kind: yaml.ScalarNode, Value: d.String()}, nil
+}
+
+// Float64 returns the closest float to the decimal.
+func (d Decimal) Float64() (float64, error) {
+	return d.apd().Float64()
+}
+
+// IsInteger returns whether the decimal has no fractional part.
+func (d Decimal) IsInteger() bool {
+	var frac apd.Decimal
+	d.apd().Modf(nil, &frac)
+	return frac.IsZero()
+}
+
+// Int64 returns the decimal as an integer, returning an error if it has a
+// fractional part or does not fit.
+func (d Decimal) Int64() (int64, error) {
+	if !d.IsInteger() {
+		return 0, errors.New("decimal value contains decimals and therefore cannot be cast as a signed integer, if you intend to round the value then call `.round()` explicitly before this cast")
+	}
+	i, err := d.Round(0, "down")
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(i.Text(), 10, 64)
+	if err != nil {
+		return 0, errors.New("decimal value is out of range of a signed integer")
+	}
+	return v, nil
+}
+
+// Uint64 returns the decimal as an unsigned integer, returning an error if it
+// has a fractional part or does not fit.
+func (d Decimal) Uint64() (uint64, error) {
+	if d.Sign() < 0 {
+		return 0, errors.New("decimal value is negative and cannot be cast as an unsigned integer")
+	}
+	if !d.IsInteger() {
+		return 0, errors.New("decimal value contains decimals and therefore cannot be cast as an unsigned integer, if you intend to round the value then call `.round()` explicitly before this cast")
+	}
+	i, err := d.Round(0, "down")
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseUint(i.Text(), 10, 64)
+	if err != nil {
+		return 0, errors.New("decimal value is out of range of an unsigned integer")
+	}
+	return v, nil
+}
+
+// Sign returns -1 if the decimal is negative, 0 if it is zero and 1 if it is
+// positive.
+func (d Decimal) Sign() int {
+	return d.apd().Sign()
+}
+
+// Cmp compares two decimals, returning -1 if d is less than o, 0 if they are
+// equal and 1 if d is greater than o.
+func (d Decimal) Cmp(o Decimal) int {
+	return d.apd().Cmp(o.apd())
+}
+
+type decimalOp func(c *apd.Context, d, x, y *apd.Decimal) (apd.Condition, error)
+
+func (d Decimal) apply(c *apd.Context, o Decimal, op decimalOp) (Decimal, error) {
+	res := &apd.Decimal{}
+	if _, err := op(c, res, d.apd(), o.apd()); err != nil {
+		return Decimal{}, err
+	}
+	return newDecimal(res)
+}
+
+// Add returns the exact sum of two decimals.
+func (d Decimal) Add(o Decimal) (Decimal, error) {
+	return d.apply(&apd.BaseContext, o, (*apd.Context).Add)
+}
+
+// Sub returns the exact difference of two decimals.
+func (d Decimal) Sub(o Decimal) (Decimal, error) {
+	return d.apply(&apd.BaseContext, o, (*apd.Context).Sub)
+}
+
+// Mul returns the exact product of two decimals.
+func (d Decimal) Mul(o Decimal) (Decimal, error) {
+	return d.apply(&apd.BaseContext, o, (*apd.Context).Mul)
+}
+
+var divisionContext = apd.BaseContext.WithPrecision(DecimalDivisionPrecision)
+
+func init() {
+	divisionContext.Rounding = apd.RoundHalfEven
+}
+
+// Quo returns the quotient of two decimals, rounded to
+// DecimalDivisionPrecision significant digits.
+func (d Decimal) Quo(o Decimal) (Decimal, error) {
+	if o.Sign() == 0 {
+		return Decimal{}, errors.New("attempted to divide by zero")
+	}
+	res := &apd.Decimal{}
+	cond, err := divisionContext.Quo(res, d.apd(), o.apd())
+	if err != nil {
+		return Decimal{}, err
+	}
+	if !cond.Inexact() {
+		// Exact quotients are padded with zeros up to the division precision,
+		// which are removed down to the exponent of the operands.
+		target := min(d.apd().Exponent-o.apd().Exponent, 0)
+		res.Reduce(res)
+		if res.Exponent > target {
+			res.Coeff.Mul(&res.Coeff, new(apd.BigInt).Exp(apd.NewBigInt(10), apd.NewBigInt(int64(res.Exponent-target)), nil))
+			res.Exponent = target
+		}
+	}
+	return newDecimal(res)
+}
+
+// Rem returns the remainder of dividing two decimals, which has the sign of
+// the dividend.
+func (d Decimal) Rem(o Decimal) (Decimal, error) {
+	if o.Sign() == 0 {
+		return Decimal{}, errors.New("attempted to divide by zero")
+	}
+	return d.apply(divisionContext, o, (*apd.Context).Rem)
+}
+
+// Neg returns the negation of the decimal.
+func (d Decimal) Neg() Decimal {
+	res := &apd.Decimal{}
+	res.Neg(d.apd())
+	return Decimal{d: res}
+}
+
+// Abs returns the absolute value of the decimal.
+func (d Decimal) Abs() Decimal {
+	res := &apd.Decimal{}
+	res.Abs(d.apd())
+	return Decimal{d: res}
+}
+
+// Round returns the decimal rounded to a number of digits after the decimal
+// point using one of the modes listed in DecimalRoundingModes. The result has
+// exactly that number of digits after the decimal point, and a negative scale
+// rounds to a power of ten.
+func (d Decimal) Round(scale int32, mode string) (Decimal, error) {
+	if _, exists := DecimalRoundingModes[mode]; !exists {
+		return Decimal{}, fmt.Errorf("unrecognised rounding mode: %v", mode)
+	}
+	x := d.apd()
+
+	// The precision must be large enough to hold every digit of the result.
+	digits := max(x.NumDigits()+int64(x.Exponent)+int64(scale), 1)
+	if digits > math.MaxUint32-1 {
+		return Decimal{}, errors.New("decimal scale is too large")
+	}
+	c := apd.BaseContext.WithPrecision(uint32(digits) + 1)
+	c.Rounding = apd.Rounder(mode)
+
+	res := &apd.Decimal{}
+	if _, err := c.Quantize(res, x, -scale); err != nil {
+		return Decimal{}, fmt.Errorf("failed to round decimal: %w", err)
+	}
+	return newDecimal(res)
+}
+
+// Canonical returns the decimal formatted such that decimals of equal value
+// are formatted identically regardless of any trailing zeros.
+func (d Decimal) Canonical() string {
+	res := &apd.Decimal{}
+	res.Reduce(d.apd())
+	return res.String()
+}
+
+// Text returns the decimal formatted without an exponent.
+func (d Decimal) Text() string {
+	return d.apd().Text('f')
+}
+
+// DecimalOperands returns both values as decimals when at least one of them is
+// a decimal and the other is a number.
+func DecimalOperands(left, right any) (l, r Decimal, ok bool) {
+	_, lIsDec := left.(Decimal)
+	_, rIsDec := right.(Decimal)
+	if !lIsDec && !rIsDec {
+		return
+	}
+	var err error
+	if l, err = IGetDecimal(left); err != nil {
+		return
+	}
+	if r, err = IGetDecimal(right); err != nil {
+		return
+	}
+	return l, r, true
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 Redpanda Data, Inc.
+
+package value
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func mustDecimal(t testing.TB, s string) Decimal {
+	t.Helper()
+	d, err := NewDecimalFromString(s)
+	require.NoError(t, err)
+	return d
+}
+
+func TestDecimalParse(t *testing.T) {
+	for _, test := range []struct {
+		in  string
+		out string
+	}{
+		{in: "0", out: "0"},
+		{in: "-12.3400", out: "-12.3400"},
+		{in: "1.5e3", out: "1500"},
+		{in: "1e-3", out: "0.001"},
+		{in: "123456789012345678901234567890.123456789", out: "123456789012345678901234567890.123456789"},
+		{in: "1e200", out: "1E+200"},
+	} {
+		d, err := NewDecimalFromString(test.in)
+		require.NoError(t, err, test.in)
+		assert.Equal(t, test.out, d.String(), test.in)
+	}
+
+	for _, in := range []string{"", "nope", "NaN", "Infinity", "-inf"} {
+		_, err := NewDecimalFromString(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestDecimalConversions(t *testing.T) {
+	for _, test := range []struct {
+		in  any
+		out string
+	}{
+		{in: 0.1, out: "0.1"},
+		{in: float32(0.1), out: "0.1"},
+		{in: int64(-5), out: "-5"},
+		{in: uint64(18446744073709551615), out: "18446744073709551615"},
+		{in: json.Number("10.50"), out: "10.50"},
+		{in: "3.14", out: "3.14"},
+		{in: []byte("2.718"), out: "2.718"},
+	} {
+		d, err := IToDecimal(test.in)
+		require.NoError(t, err, test.in)
+		assert.Equal(t, test.out, d.String(), test.in)
+	}
+
+	_, err := IGetDecimal("3.14")
+	require.Error(t, err)
+
+	i, err := mustDecimal(t, "42.00").Int64()
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), i)
+
+	_, err = mustDecimal(t, "42.5").Int64()
+	require.ErrorContains(t, err, "contains decimals")
+
+	_, err = mustDecimal(t, "9223372036854775808").Int64()
+	require.ErrorContains(t, err, "out of range")
+
+	u, err := mustDecimal(t, "18446744073709551615").Uint64()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(18446744073709551615), u)
+
+	_, err = mustDecimal(t, "-1").Uint64()
+	require.ErrorContains(t, err, "negative")
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	a, b := mustDecimal(t, "0.1"), mustDecimal(t, "0.2")
+
+	sum, err := a.Add(b)
+	require.NoError(t, err)
+	assert.Equal(t, "0.3", sum.String())
+
+	diff, err := a.Sub(b)
+	require.NoError(t, err)
+	assert.Equal(t, "-0.1", diff.String())
+
+	prod, err := mustDecimal(t, "1.10").Mul(mustDecimal(t, "3"))
+	require.NoError(t, err)
+	assert.Equal(t, "3.30", prod.String())
+
+	quo, err := mustDecimal(t, "10").Quo(mustDecimal(t, "4"))
+	require.NoError(t, err)
+	assert.Equal(t, "2.5", quo.String())
+
+	quo, err = mustDecimal(t, "1").Quo(mustDecimal(t, "3"))
+	require.NoError(t, err)
+	assert.Equal(t, "0.3333333333333333333333333333333333", quo.String())
+
+	_, err = a.Quo(Decimal{})
+	require.Error(t, err)
+
+	rem, err := mustDecimal(t, "-7.5").Rem(mustDecimal(t, "2"))
+	require.NoError(t, err)
+	assert.Equal(t, "-1.5", rem.String())
+
+	assert.Equal(t, "-0.1", a.Neg().String())
+	assert.Equal(t, "0.1", a.Neg().Abs().String())
+	assert.Equal(t, -1, a.Cmp(b))
+	assert.Equal(t, 0, mustDecimal(t, "1.0").Cmp(mustDecimal(t, "1")))
+}
+
+func TestDecimalRound(t *testing.T) {
+	for _, test := range []struct {
+		in    string
+		scale int32
+		mode  string
+		out   string
+	}{
+		{in: "2.345", scale: 2, mode: "half_even", out: "2.34"},
+		{in: "2.355", scale: 2, mode: "half_even", out: "2.36"},
+		{in: "2.345", scale: 2, mode: "half_up", out: "2.35"},
+		{in: "2.345", scale: 2, mode: "half_down", out: "2.34"},
+		{in: "2.341", scale: 2, mode: "up", out: "2.35"},
+		{in: "2.349", scale: 2, mode: "down", out: "2.34"},
+		{in: "-2.341", scale: 2, mode: "ceiling", out: "-2.34"},
+		{in: "-2.341", scale: 2, mode: "floor", out: "-2.35"},
+		{in: "5", scale: 3, mode: "half_even", out: "5.000"},
+		{in: "1234", scale: -2, mode: "half_up", out: "1200"},
+		{in: "0.004", scale: 2, mode: "half_up", out: "0.00"},
+	} {
+		d, err := mustDecimal(t, test.in).Round(test.scale, test.mode)
+		require.NoError(t, err, test.in)
+		assert.Equal(t, test.out, d.String(), "%v %v %v", test.in, test.scale, test.mode)
+	}
+
+	_, err := mustDecimal(t, "1").Round(0, "sideways")
+	require.ErrorContains(t, err, "unrecognised rounding mode")
+}
+
+func TestDecimalJSON(t *testing.T) {
+	b, err := json.Marshal(map[string]any{
+		"a": mustDecimal(t, "12345678901234567890.123456789"),
+		"b": mustDecimal(t, "1.50"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":12345678901234567890.123456789,"b":1.50}`, string(b))
+}
+
+func TestDecimalYAML(t *testing.T) {
+	b, err := yaml.Marshal(map[string]any{
+		"a": mustDecimal(t, "12345678901234567890.123456789"),
+		"b": mustDecimal(t, "1.50"),
+		"c": mustDecimal(t, "-1E-120"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "a: 12345678901234567890.123456789\nb: 1.50\nc: -1E-120\n", string(b))
+
+	var v map[string]any
+	require.NoError(t, yaml.Unmarshal(b, &v))
+	assert.Equal(t, map[string]any{"a": 12345678901234567890.123456789, "b": 1.5, "c": -1e-120}, v)
+}
+
+func TestDecimalCanonical(t *testing.T) {
+	assert.Equal(t, mustDecimal(t, "1.5").Canonical(), mustDecimal(t, "1.500").Canonical())
+	assert.Equal(t, mustDecimal(t, "100").Canonical(), mustDecimal(t, "1e2").Canonical())
+	assert.NotEqual(t, mustDecimal(t, "1.5").Canonical(), mustDecimal(t, "1.05").Canonical())
+}
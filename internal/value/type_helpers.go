@@ -44,7 +44,7 @@ func ITypeOf(i any) Type {
 		return TString
 	case []byte:
 		return TBytes
-	case int, int8, int16, int32, int64, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+	case int, int8, int16, int32, int64, uint8, uint16, uint32, uint64, float32, float64, json.Number, Decimal:
 		return TNumber
 	case bool:
 		return TBool
@@ -109,6 +109,8 @@ func IGetNumber(v any) (float64, error) {
 		return t, nil
 	case json.Number:
 		return t.Float64()
+	case Decimal:
+		return t.Float64()
 	}
 	return 0, NewTypeError(v, TNumber)
 }
@@ -144,6 +146,9 @@ func IGetFloat32(v any) (float32, error) {
 	case json.Number:
 		v, e := t.Float64()
 		return float32(v), e
+	case Decimal:
+		v, e := t.Float64()
+		return float32(v), e
 	}
 	return 0, NewTypeError(v, TNumber)
 }
@@ -185,6 +190,12 @@ func IGetInt(v any) (int64, error) {
 			return int64(f), nil
 		}
 		return 0, err
+	case Decimal:
+		if t.IsInteger() {
+			return t.Int64()
+		}
+		f, err := t.Float64()
+		return int64(f), err
 	}
 	return 0, NewTypeError(v, TNumber)
 }
@@ -193,7 +204,7 @@ func IGetInt(v any) (int64, error) {
 // (uint64) from it.
 func IGetUInt(v any) (uint64, error) {
 	switch v.(type) {
-	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number:
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, json.Number, Decimal:
 		// We're passing through here because it handles out of bounds issues.
 		return IToUint(v)
 	}
@@ -231,6 +242,8 @@ func IGetBool(v any) (bool, error) {
 		return t != 0, nil
 	case json.Number:
 		return t.String() != "0", nil
+	case Decimal:
+		return t.Sign() != 0, nil
 	}
 	return false, NewTypeError(v, TBool)
 }
@@ -279,6 +292,14 @@ func IGetTimestamp(v any) (time.Time, error) {
 		fint := math.Trunc(t)
 		fdec := t - fint
 		return time.Unix(int64(fint), int64(fdec*1e9)), nil
+	case Decimal:
+		f, err := t.Float64()
+		if err != nil {
+			return time.Time{}, err
+		}
+		fint := math.Trunc(f)
+		fdec := f - fint
+		return time.Unix(int64(fint), int64(fdec*1e9)), nil
 	case json.Number:
 		if i, err := t.Int64(); err == nil {
 			return time.Unix(i, 0), nil
@@ -312,7 +333,7 @@ func IIsNull(i any) bool {
 
 // RestrictForComparison takes a boxed value of any type sanitizes it and,
 // additionally, it attempts to perform the following conversions:
-// - int64, uint64 and json.Number to float64.
+// - int64, uint64, json.Number and Decimal to float64.
 // - []byte to string.
 func RestrictForComparison(v any) any {
 	v = ISanitize(v)
@@ -325,6 +346,10 @@ func RestrictForComparison(v any) any {
 		if f, err := IGetNumber(t); err == nil {
 			return f
 		}
+	case Decimal:
+		if f, err := t.Float64(); err == nil {
+			return f
+		}
 	case []byte:
 		return string(t)
 	}
@@ -332,11 +357,11 @@ func RestrictForComparison(v any) any {
 }
 
 // ISanitize takes a boxed value of any type and attempts to convert it into one
-// of the following types: string, []byte, int64, uint64, float64, bool,
-// []interface{}, map[string]interface{}, Delete, Nothing.
+// of the following types: string, []byte, int64, uint64, float64, Decimal,
+// bool, []interface{}, map[string]interface{}, Delete, Nothing.
 func ISanitize(i any) any {
 	switch t := i.(type) {
-	case string, []byte, int64, uint64, float64, bool, []any, map[string]any, Delete, Nothing:
+	case string, []byte, int64, uint64, float64, Decimal, bool, []any, map[string]any, Delete, Nothing:
 		return i
 	case json.RawMessage:
 		return []byte(t)
@@ -383,6 +408,8 @@ func IToBytes(i any) []byte {
 		return t
 	case json.Number:
 		return []byte(t.String())
+	case Decimal:
+		return []byte(t.String())
 	case int64:
 		return strconv.AppendInt(nil, t, 10)
 	case uint64:
@@ -419,6 +446,8 @@ func IToString(i any) string {
 		return strconv.FormatFloat(t, 'g', -1, 64)
 	case json.Number:
 		return t.String()
+	case Decimal:
+		return t.String()
 	case bool:
 		if t {
 			return "true"
@@ -469,6 +498,8 @@ func IToFloat64(v any) (float64, error) {
 		return float64(t), nil
 	case json.Number:
 		return t.Float64()
+	case Decimal:
+		return t.Float64()
 	case []byte:
 		return strconv.ParseFloat(string(t), 64)
 	case string:
@@ -509,6 +540,9 @@ func IToFloat32(v any) (float32, error) {
 			return 0, err
 		}
 		return float32(f64), nil
+	case Decimal:
+		f64, err := t.Float64()
+		return float32(f64), err
 	case []byte:
 		f64, err := strconv.ParseFloat(string(t), 32)
 		if err != nil {
@@ -590,6 +624,8 @@ func IToInt(v any) (int64, error) {
 		return int64(t), nil
 	case json.Number:
 		return t.Int64()
+	case Decimal:
+		return t.Int64()
 	case []byte:
 		return strconv.ParseInt(string(t), 0, 64)
 	case string:
@@ -710,6 +746,8 @@ func IToUint(v any) (uint64, error) {
 			return 0, errors.New("signed integer value is negative and cannot be cast as an unsigned integer")
 		}
 		return uint64(i), nil
+	case Decimal:
+		return t.Uint64()
 	case []byte:
 		return strconv.ParseUint(string(t), 0, 64)
 	case string:
@@ -798,6 +836,8 @@ func IToBool(v any) (bool, error) {
 		return t != 0, nil
 	case json.Number:
 		return t.String() != "0", nil
+	case Decimal:
+		return t.Sign() != 0, nil
 	case []byte:
 		if v, err := strconv.ParseBool(string(t)); err == nil {
 			return v, nil
@@ -840,6 +880,9 @@ func ICompare(left, right any) bool {
 	if left == nil && right == nil {
 		return true
 	}
+	if lDec, rDec, ok := DecimalOperands(left, right); ok {
+		return lDec.Cmp(rDec) == 0
+	}
 	switch lhs := RestrictForComparison(left).(type) {
 	case string:
 		rhs, err := IGetString(right)
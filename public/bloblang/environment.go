@@ -3,6 +3,8 @@
 package bloblang
 
 import (
+	"time"
+
 	"github.com/redpanda-data/benthos/v4/internal/bloblang"
 	"github.com/redpanda-data/benthos/v4/internal/bloblang/parser"
 	"github.com/redpanda-data/benthos/v4/internal/bloblang/query"
@@ -338,6 +340,48 @@ func (e *Environment) WithMaxMapRecursion(n int) *Environment {
 	}
 }
 
+// ExecutionBudget describes limits on the resources that a single execution of
+// a mapping is allowed to consume, which is useful when executing mappings
+// from untrusted sources. A zero value for any field means that the resource
+// is not limited.
+type ExecutionBudget struct {
+	// MaxOperations is the maximum number of functions, methods, operators and
+	// iterations executed.
+	MaxOperations int64
+
+	// MaxAllocatedBytes is the maximum number of bytes that values created
+	// during the execution are estimated to occupy.
+	MaxAllocatedBytes int64
+
+	// MaxRecursionDepth is the maximum number of nested map executions.
+	MaxRecursionDepth int
+
+	// Timeout is the maximum wall-clock time of the execution.
+	Timeout time.Duration
+}
+
+// ErrBudgetExceeded is matched (with errors.Is) by errors returned when the
+// execution of a mapping exceeds the ExecutionBudget of its environment.
+var ErrBudgetExceeded = query.ErrBudgetExceeded
+
+// WithExecutionBudget returns a copy of the environment where each execution
+// of mappings parsed from it is limited by a budget. Executions that exceed
+// the budget fail with an error that matches ErrBudgetExceeded, and this
+// cannot be caught from within the mapping.
+//
+// The budget also applies to mapping processors when the environment is used
+// to parse their mappings.
+func (e *Environment) WithExecutionBudget(b ExecutionBudget) *Environment {
+	return &Environment{
+		env: e.env.WithExecutionBudget(query.Budget{
+			MaxOperations:     b.MaxOperations,
+			MaxAllocatedBytes: b.MaxAllocatedBytes,
+			MaxRecursionDepth: b.MaxRecursionDepth,
+			Timeout:           b.Timeout,
+		}),
+	}
+}
+
 // OnlyPure removes any methods and functions that have been registered but are
 // marked as impure. Impure in this context means the method/function is able to
 // mutate global state or access machine state (read environment variables,
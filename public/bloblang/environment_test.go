@@ -4,6 +4,7 @@ package bloblang
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -108,3 +109,81 @@ func TestEnvironmentDisabledImports(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "imports are disabled in this context")
 }
+
+func TestEnvironmentExecutionBudget(t *testing.T) {
+	tests := map[string]struct {
+		budget  ExecutionBudget
+		mapping string
+		input   any
+		output  any
+		errMsg  string
+	}{
+		"within budget": {
+			budget: ExecutionBudget{
+				MaxOperations:     1000,
+				MaxAllocatedBytes: 1 << 20,
+				MaxRecursionDepth: 10,
+				Timeout:           time.Minute,
+			},
+			mapping: `root = this.map_each(n -> n * 2).sum()`,
+			input:   []any{1, 2, 3},
+			output:  float64(12),
+		},
+		"operations exceeded": {
+			budget:  ExecutionBudget{MaxOperations: 100},
+			mapping: `root = range(0, 1000).map_each(n -> n + 1)`,
+			errMsg:  "execution budget exceeded: operations limit of 100 reached",
+		},
+		"allocated bytes exceeded": {
+			budget:  ExecutionBudget{MaxAllocatedBytes: 1 << 20},
+			mapping: `root = range(0, 100000000)`,
+			errMsg:  "execution budget exceeded: allocated bytes limit of 1048576 reached",
+		},
+		"allocated bytes exceeded by strings": {
+			budget: ExecutionBudget{MaxAllocatedBytes: 1000},
+			mapping: `map double {
+  root = if this.length() < 1000000 { (this + this).apply("double") } else { this }
+}
+root = "ab".apply("double")`,
+			errMsg: "execution budget exceeded: allocated bytes limit of 1000 reached",
+		},
+		"recursion depth exceeded": {
+			budget: ExecutionBudget{MaxRecursionDepth: 5},
+			mapping: `map countdown {
+  root = if this > 0 { (this - 1).apply("countdown") } else { "done" }
+}
+root = this.apply("countdown")`,
+			input:  10,
+			errMsg: "execution budget exceeded: recursion depth limit of 5 reached",
+		},
+		"timeout exceeded": {
+			budget:  ExecutionBudget{Timeout: time.Nanosecond},
+			mapping: `root = range(0, 10000).map_each(n -> n.string().length())`,
+			errMsg:  "execution budget exceeded: timeout limit of 1ns reached",
+		},
+		"exceeded budget cannot be caught": {
+			budget:  ExecutionBudget{MaxOperations: 100},
+			mapping: `root = range(0, 1000).map_each(n -> n + 1).catch([])`,
+			errMsg:  "execution budget exceeded: operations limit of 100 reached",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			exe, err := NewEnvironment().WithExecutionBudget(test.budget).Parse(test.mapping)
+			require.NoError(t, err)
+
+			for i := 0; i < 2; i++ {
+				res, err := exe.Query(test.input)
+				if test.errMsg != "" {
+					require.Error(t, err)
+					assert.ErrorIs(t, err, ErrBudgetExceeded)
+					assert.Contains(t, err.Error(), test.errMsg)
+					continue
+				}
+				require.NoError(t, err)
+				assert.Equal(t, test.output, res)
+			}
+		})
+	}
+}
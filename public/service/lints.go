@@ -74,6 +74,10 @@ const (
 
 	// LintDeprecated means a field is deprecated and should not be used.
 	LintDeprecated LintType = iota
+
+	// LintUnformattedBloblang means the field contains a Bloblang mapping that
+	// is valid but not canonically formatted.
+	LintUnformattedBloblang LintType = iota
 )
 
 func convertDocsLintType(d docs.LintType) LintType {
@@ -112,6 +116,8 @@ func convertDocsLintType(d docs.LintType) LintType {
 		return LintExpectedScalar
 	case docs.LintDeprecated:
 		return LintDeprecated
+	case docs.LintUnformattedBloblang:
+		return LintUnformattedBloblang
 	}
 	return LintCustom
 }
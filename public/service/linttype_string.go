@@ -25,11 +25,12 @@ func _() {
 	_ = x[LintExpectedObject-14]
 	_ = x[LintExpectedScalar-15]
 	_ = x[LintDeprecated-16]
+	_ = x[LintUnformattedBloblang-17]
 }
 
-const _LintType_name = "LintCustomLintFailedReadLintMissingEnvVarLintInvalidOptionLintBadLabelLintMissingLabelLintDuplicateLabelLintBadBloblangLintShouldOmitLintComponentMissingLintComponentNotFoundLintUnknownLintMissingLintExpectedArrayLintExpectedObjectLintExpectedScalarLintDeprecated"
+const _LintType_name = "LintCustomLintFailedReadLintMissingEnvVarLintInvalidOptionLintBadLabelLintMissingLabelLintDuplicateLabelLintBadBloblangLintShouldOmitLintComponentMissingLintComponentNotFoundLintUnknownLintMissingLintExpectedArrayLintExpectedObjectLintExpectedScalarLintDeprecatedLintUnformattedBloblang"
 
-var _LintType_index = [...]uint16{0, 10, 24, 41, 58, 70, 86, 104, 119, 133, 153, 174, 185, 196, 213, 231, 249, 263}
+var _LintType_index = [...]uint16{0, 10, 24, 41, 58, 70, 86, 104, 119, 133, 153, 174, 185, 196, 213, 231, 249, 263, 286}
 
 func (i LintType) String() string {
 	if i < 0 || i >= LintType(len(_LintType_index)-1) {